@@ -37,6 +37,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("error: failed to create workflow: %v\n", err)
 	}
+	if err := w.Validate(); err != nil {
+		log.Fatalf("error: failed to validate workflow: %v\n", err)
+	}
 	if err := w.Run(io.Discard, true, false, true, "ARTIFACTS"); err != nil {
 		log.Fatalf("error: failed to run workflow: %v\n", err)
 	}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodes
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name     string
+	Binaries []string
+	Nodes    string
+	Wait     time.Duration
+}
+
+// NewCommand returns a new cobra.Command for replacing binaries on running nodes
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "nodes",
+		Short: "Replaces the kubeadm, kubelet or kubectl binaries on running nodes",
+		Long: "Copies kubeadm, kubelet or kubectl binaries from the host into running nodes, replacing the existing ones,\n" +
+			"and restarts the kubelet if replaced, e.g. for testing a fix on an existing cluster without building a new image.\n" +
+			"Binaries are identified by file name, e.g. _output/bin/kubeadm",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Name, "name",
+		constants.DefaultClusterName,
+		"cluster name",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.Binaries, "with-binary",
+		nil,
+		"path to a kubeadm, kubelet or kubectl binary to be copied into the nodes; can be repeated",
+	)
+	cmd.Flags().StringVar(
+		&flags.Nodes, "nodes",
+		"@all",
+		"the nodes where to replace the binaries; it can be a comma separated list of node names, node selectors like @cp* or @w*, and label:<selector>",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Wait,
+		"wait", time.Duration(1*time.Minute),
+		"wait for the kubelet to be healthy after restarting it",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole) error {
+	// get a kinder cluster manager
+	o, err := manager.NewClusterManager(flags.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", flags.Name)
+	}
+
+	if err := o.PatchBinaries(flags.Nodes, flags.Binaries, flags.Wait); err != nil {
+		return errors.Wrap(err, "failed to replace binaries")
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/kubeadm/kinder/cmd/kinder/build/baseimage"
+	"k8s.io/kubeadm/kinder/cmd/kinder/build/nodeimage"
 	"k8s.io/kubeadm/kinder/cmd/kinder/build/nodevariant"
 )
 
@@ -28,17 +29,20 @@ func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "build",
-		Short: "Build one of [base-image, node-image-variant]",
+		Short: "Build one of [base-image, node-image, node-image-variant]",
 		Long: "The 'build' command is used for creating images necessary for a Kubernetes cluster created with kinder.\n" +
-			"It has two primary subcommands:\n" +
+			"It has three primary subcommands:\n" +
 			"1. 'base-image': This command is used to build the base image for nodes in the cluster.\n" +
 			"The base image includes all the necessary dependencies and configurations required for Kubernetes nodes.\n" +
 			"2. 'node-image-variant': This command is used to build different variants of the node images based\n" +
 			"on the base image. These variants may include different Kubernetes versions, CNI plugins,\n" +
-			"or any other variations as needed for testing different Kubernetes features and behaviors.\n",
+			"or any other variations as needed for testing different Kubernetes features and behaviors.\n" +
+			"3. 'node-image': This command is used to build a node image from a local Kubernetes source tree,\n" +
+			"including uncommitted changes, e.g. for testing kubeadm changes end to end.\n",
 	}
 	// add subcommands
 	cmd.AddCommand(baseimage.NewCommand())
+	cmd.AddCommand(nodeimage.NewCommand())
 	cmd.AddCommand(nodevariant.NewCommand())
 	return cmd
 }
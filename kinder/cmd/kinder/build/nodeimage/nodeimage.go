@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeimage
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/build/alter"
+	"k8s.io/kubeadm/kinder/pkg/build/kube"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Image                   string
+	BaseImage               string
+	KubeRoot                string
+	Arch                    string
+	PrePullAdditionalImages bool
+	SmokeTest               bool
+}
+
+// NewCommand returns a new cobra.Command for building a node image from a Kubernetes source tree
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:    cobra.NoArgs,
+		Use:     "node-image",
+		Aliases: []string{"node", "ni"},
+		Short:   "build a node image from a local Kubernetes source tree",
+		Long: "build kubeadm, kubelet, kubectl and the control plane images from a local Kubernetes source tree,\n" +
+			"including uncommitted changes, and add them to a node image to be used for the kubeadm init workflow",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Image, "image",
+		constants.DefaultNodeImage,
+		"name:tag of the resulting image to be built",
+	)
+	cmd.Flags().StringVar(
+		&flags.BaseImage, "base-image",
+		constants.DefaultBaseImage,
+		"name:tag of the source image; this can be a kindest/base image or kindest/node image",
+	)
+	cmd.Flags().StringVar(
+		&flags.KubeRoot, "kube-root",
+		"",
+		"Path to the Kubernetes source directory (if empty, the path is autodetected)",
+	)
+	cmd.Flags().StringVar(
+		&flags.Arch, "arch",
+		runtime.GOARCH,
+		"architecture of the Kubernetes binaries and images to be built",
+	)
+	cmd.Flags().BoolVar(
+		&flags.PrePullAdditionalImages, "with-kubeadm-additional-images",
+		true,
+		"pre-pull kubeadm additional required images such as etcd, coredns and pause, etc",
+	)
+	cmd.Flags().BoolVar(
+		&flags.SmokeTest, "smoke-test",
+		false,
+		"after the build, boot a node from the image and verify systemd, the container runtime, the Kubernetes binaries and the images in the node, failing the build if any check fails",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
+	kubeRoot := flags.KubeRoot
+	if kubeRoot == "" {
+		var err error
+		if kubeRoot, err = kube.FindKubeRoot(); err != nil {
+			return err
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "kinder-node-image-")
+	if err != nil {
+		return errors.Wrap(err, "error creating the folder for the build output")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := kube.Build(kubeRoot, flags.Arch, dir); err != nil {
+		return errors.Wrapf(err, "error building Kubernetes from %s", kubeRoot)
+	}
+
+	ctx, err := alter.NewContext(
+		alter.WithBaseImage(flags.BaseImage),
+		alter.WithImage(flags.Image),
+		alter.WithArch(flags.Arch),
+		alter.WithInitArtifacts(dir),
+		alter.WithPrePullAdditionalImages(flags.PrePullAdditionalImages),
+		alter.WithSmokeTest(flags.SmokeTest),
+	)
+	if err != nil {
+		return errors.Wrap(err, "error creating alter context")
+	}
+	if err := ctx.Alter(); err != nil {
+		return errors.Wrap(err, "error altering node image")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodevariant
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+// variant is a node image variant; in batch mode, variants are read from the batch file
+type variant struct {
+	Image            string   `json:"image"`
+	InitArtifacts    string   `json:"initArtifacts,omitempty"`
+	UpgradeArtifacts []string `json:"upgradeArtifacts,omitempty"`
+}
+
+// fileSuffix returns a suffix for the files of the variant, e.g. kindest_node_v1.33.0 for kindest/node:v1.33.0
+func (v variant) fileSuffix() string {
+	return regexp.MustCompile("[/:@]").ReplaceAllString(v.Image, "_")
+}
+
+// readBatchFile reads the list of variants from a YAML or JSON file, e.g.
+//
+//   - image: kindest/node:v1.32.0-upgrade
+//     initArtifacts: v1.32.0
+//     upgradeArtifacts: [v1.33.0]
+func readBatchFile(path string) ([]variant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the batch file %s", path)
+	}
+	var variants []variant
+	if err := yaml.UnmarshalStrict(data, &variants); err != nil {
+		return nil, errors.Wrapf(err, "error reading the batch file %s", path)
+	}
+	if len(variants) == 0 {
+		return nil, errors.Errorf("the batch file %s does not define any variant", path)
+	}
+	images := map[string]bool{}
+	for i, v := range variants {
+		if v.Image == "" {
+			return nil, errors.Errorf("the variant %d in %s has no image", i+1, path)
+		}
+		if images[v.Image] {
+			return nil, errors.Errorf("the image %s is defined twice in %s", v.Image, path)
+		}
+		images[v.Image] = true
+	}
+	return variants, nil
+}
+
+// buildVariants builds all the variants, sharing the base image, that is pulled once, and the bits downloaded from URLs,
+// that are stored in the local cache; a variant failing does not stop the others, so all the failures are reported
+func buildVariants(flags *flagpole, variants []variant) error {
+	// labels are resolved once, so all the variants use the same builds, e.g. for ci/latest
+	resolved := map[string]string{}
+	for i := range variants {
+		v := &variants[i]
+		var err error
+		if v.InitArtifacts, err = pinLabel(v.InitArtifacts, resolved); err != nil {
+			return err
+		}
+		for j := range v.UpgradeArtifacts {
+			if v.UpgradeArtifacts[j], err = pinLabel(v.UpgradeArtifacts[j], resolved); err != nil {
+				return err
+			}
+		}
+	}
+
+	var failed []string
+	for i, v := range variants {
+		log.Infof("Building variant %d of %d: %s ...", i+1, len(variants), v.Image)
+		if err := buildVariant(flags, v); err != nil {
+			log.Errorf("error building %s: %v", v.Image, err)
+			failed = append(failed, v.Image)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to build %d of %d variants: %s", len(failed), len(variants), strings.Join(failed, ", "))
+	}
+	log.Infof("Built %d variants", len(variants))
+	return nil
+}
+
+// pinLabel returns the ci/release build a label points to, e.g. ci/v1.34.0-alpha.1.23+0123456789abcd for ci/latest;
+// other sources, including labels in GCS buckets, are returned as they are
+func pinLabel(src string, resolved map[string]string) (string, error) {
+	if src == "" || !extract.IsLabel(src) {
+		return src, nil
+	}
+	var prefix string
+	switch {
+	case strings.HasPrefix(src, "ci/"):
+		prefix = "ci/"
+	case strings.HasPrefix(src, "release/"):
+		prefix = "release/"
+	case strings.HasPrefix(src, "gs://"):
+		return src, nil
+	}
+	if v, ok := resolved[src]; ok {
+		return v, nil
+	}
+	version, err := extract.ResolveLabel(src)
+	if err != nil {
+		return "", errors.Wrapf(err, "error resolving %s", src)
+	}
+	log.Infof("Using %s%s for %s", prefix, version, src)
+	resolved[src] = prefix + version
+	return resolved[src], nil
+}
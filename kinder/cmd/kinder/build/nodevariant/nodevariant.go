@@ -17,24 +17,70 @@ limitations under the License.
 package nodevariant
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"k8s.io/kubeadm/kinder/pkg/build/alter"
 	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/cri/host"
+	"k8s.io/kubeadm/kinder/pkg/extract"
 )
 
+// sourceDateEpochEnv is the env variable with the time recorded in reproducible builds,
+// see https://reproducible-builds.org/specs/source-date-epoch/
+const sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
 type flagpole struct {
 	Image                   string
 	BaseImage               string
 	InitArtifacts           string
 	ImageTars               []string
 	ImageNamePrefix         string
-	UpgradeArtifacts        string
+	UpgradeArtifacts        []string
 	Kubeadm                 string
 	Kubelet                 string
+	Packages                string
+	Containerd              string
+	Runc                    string
+	CRIO                    string
+	Etcd                    string
+	UpgradeEtcd             string
+	SandboxImage            string
 	PrePullAdditionalImages bool
+	PrePullAddonImages      bool
+	ImagesFromFile          string
 	Path                    []string
+	SystemdDir              string
+	KubeletConfig           []string
+	Arch                    []string
+	Push                    bool
+	Sign                    bool
+	SignKey                 string
+	Optimize                bool
+	SBOM                    string
+	SBOMFormat              string
+	Reproducible            bool
+	SourceDateEpoch         int64
+	SmokeTest               bool
+	CacheFrom               string
+	CacheTo                 string
+	Checksums               string
+	VerifySignatures        bool
+	SignatureIdentity       string
+	SignatureIssuer         string
+	HTTPProxy               string
+	HTTPSProxy              string
+	NoProxy                 string
+	CACertificates          []string
+	BatchFile               string
 }
 
 // NewCommand returns a new cobra.Command for building the node image
@@ -58,7 +104,7 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(
 		&flags.BaseImage, "base-image",
 		constants.DefaultBaseImage,
-		"name:tag of the source image; this can be a kindest/base image or kindest/node image",
+		"name:tag of the source image; this can be a kindest/base image, a kindest/node image or a custom image built from a kindest/base image",
 	)
 	cmd.Flags().StringVar(
 		&flags.InitArtifacts, "with-init-artifacts",
@@ -75,10 +121,10 @@ func NewCommand() *cobra.Command {
 		"",
 		"add a name prefix to images tars included in the image",
 	)
-	cmd.Flags().StringVar(
+	cmd.Flags().StringSliceVar(
 		&flags.UpgradeArtifacts, "with-upgrade-artifacts",
-		"",
-		"version/build-label/path to a folder with Kubernetes binaries & image tarballs to be used for testing the kubeadm-upgrade workflow",
+		nil,
+		"version/build-label/path to a folder with Kubernetes binaries & image tarballs to be used for testing the kubeadm-upgrade workflow; can be repeated for upgrades across many versions",
 	)
 	cmd.Flags().StringVar(
 		&flags.Kubeadm, "with-kubeadm",
@@ -90,34 +136,303 @@ func NewCommand() *cobra.Command {
 		"",
 		"override the kubeadm binary existing in the image with the given version/build-label/file or folder containing the kubelet binary",
 	)
+	cmd.Flags().StringVar(
+		&flags.Packages, "with-packages",
+		"",
+		"install kubeadm, kubelet and kubectl from the deb/rpm packages of pkgs.k8s.io for the given minor version, e.g. v1.33, or version, e.g. v1.33.1",
+	)
+	cmd.Flags().StringVar(
+		&flags.Containerd, "with-containerd",
+		"",
+		"override the containerd binaries existing in the image with the given containerd version, e.g. v2.0.0, or with a containerd release tarball",
+	)
+	cmd.Flags().StringVar(
+		&flags.Runc, "with-runc",
+		"",
+		"override the runc binary existing in the image with the given runc version, e.g. v1.2.0, or with a runc binary",
+	)
+	cmd.Flags().StringVar(
+		&flags.CRIO, "with-crio",
+		"",
+		"replace containerd with CRI-O as the container runtime of the image, using the given CRI-O version, e.g. v1.31.0, or a CRI-O release bundle",
+	)
+	cmd.Flags().StringVar(
+		&flags.Etcd, "with-etcd",
+		"",
+		"embed the given etcd image, or tag of the etcd image used by kubeadm, e.g. 3.5.21-0, and use it instead of the kubeadm default when creating the cluster",
+	)
+	cmd.Flags().StringVar(
+		&flags.UpgradeEtcd, "with-upgrade-etcd",
+		"",
+		"embed the given etcd image, or tag of the etcd image used by kubeadm, for the upgrade artifacts, and use it instead of the kubeadm default when upgrading",
+	)
+	cmd.Flags().StringVar(
+		&flags.SandboxImage, "with-sandbox-image",
+		"",
+		"embed the given pause image and use it as the sandbox image of the container runtime, instead of the pause image recommended by kubeadm",
+	)
 	cmd.Flags().BoolVar(
 		&flags.PrePullAdditionalImages, "with-kubeadm-additional-images",
 		true,
 		"pre-pull kubeadm additional required images such as etcd, coredns and pause, etc",
 	)
+	cmd.Flags().BoolVar(
+		&flags.PrePullAddonImages, "with-addon-images",
+		false,
+		"pre-pull the images of the CNI plugin and of the addons installed by kinder, e.g. the local-path provisioner, and the coredns and pause images matching the Kubernetes version of the image",
+	)
+	cmd.Flags().StringVar(
+		&flags.ImagesFromFile, "with-images-from-file",
+		"",
+		"path to a file with a list of images, one per line, to be pulled for the architecture of the image and pre-loaded into the image",
+	)
 	cmd.Flags().StringSliceVar(
 		&flags.Path, "with-path",
 		nil,
 		"sourcePath:destPath pairs; copies file/dir at sourcePath on the host to destPath inside the image, destPath has to be absolute",
 	)
+	cmd.Flags().StringVar(
+		&flags.SystemdDir, "extra-systemd-dir",
+		"",
+		"path to a folder with systemd units and drop-ins, e.g. kubelet.service.d/10-custom.conf, to be installed in /etc/systemd/system inside the image; units with an [Install] section are enabled",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.KubeletConfig, "with-kubelet-config",
+		nil,
+		"path to a file with a KubeletConfiguration fragment to be baked into the kubelet drop-in configuration folder inside the image; can be repeated, and fragments are applied in order on top of the kubelet configuration generated by kubeadm",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.Arch, "arch",
+		[]string{runtime.GOARCH},
+		"architectures of the image, e.g. amd64,arm64; when more than one architecture is set, an image tagged with the architecture suffix is built for each of them",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Push, "push",
+		false,
+		"push the resulting image; when more than one architecture is set, a manifest list referencing the image of each architecture is pushed too",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Sign, "sign",
+		false,
+		"sign the pushed image with cosign, using the keyless flow unless --sign-key is set; requires --push",
+	)
+	cmd.Flags().StringVar(
+		&flags.SignKey, "sign-key",
+		"",
+		"path or KMS URI of the key for signing the image",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Optimize, "optimize",
+		false,
+		"reduce the size of the image by removing package manager caches and squashing the image into a single layer, and print a size breakdown per bits source",
+	)
+	cmd.Flags().StringVar(
+		&flags.SBOM, "sbom",
+		"",
+		"path of the SBOM of the image, listing the Kubernetes binaries, the container images and the OS packages in the image; when more than one architecture is set, the architecture is added to the file name",
+	)
+	cmd.Flags().StringVar(
+		&flags.SBOMFormat, "sbom-format",
+		alter.SBOMFormatSPDX,
+		fmt.Sprintf("format of the SBOM, one of %v", alter.SBOMFormats),
+	)
+	cmd.Flags().BoolVar(
+		&flags.Reproducible, "reproducible",
+		false,
+		"build the image in reproducible mode, pinning the time of the files changed in the image and the creation time of the image, so builds from identical inputs produce identical content; labels, e.g. ci/latest, are rejected as sources",
+	)
+	cmd.Flags().Int64Var(
+		&flags.SourceDateEpoch, "source-date-epoch",
+		0,
+		"time, in seconds since the epoch, recorded in images built in reproducible mode; defaults to SOURCE_DATE_EPOCH if set, or 0",
+	)
+	cmd.Flags().BoolVar(
+		&flags.SmokeTest, "smoke-test",
+		false,
+		"after the build, boot a node from the image and verify systemd, the container runtime, the Kubernetes binaries and the images in the node, failing the build if any check fails",
+	)
+	cmd.Flags().StringVar(
+		&flags.CacheFrom, "cache-from",
+		"",
+		"build cache to load the image from, if it has an image built from the same inputs; type=local,src=DIR for a local folder, or type=registry,ref=REPOSITORY for an image repository",
+	)
+	cmd.Flags().StringVar(
+		&flags.CacheTo, "cache-to",
+		"",
+		"build cache to save the image into; type=local,dest=DIR for a local folder, or type=registry,ref=REPOSITORY for an image repository",
+	)
+	cmd.Flags().StringVar(
+		&flags.Checksums, "checksums",
+		"",
+		"path or URL to a file with the sha256 checksums, in the format of sha256sum, of the bits downloaded from URLs; files can be referenced by name or by URL",
+	)
+	cmd.Flags().BoolVar(
+		&flags.VerifySignatures, "verify-signatures",
+		false,
+		"verify the sigstore signatures of the bits downloaded from URLs with cosign; signatures are read from the .sig and .cert files next to each file",
+	)
+	cmd.Flags().StringVar(
+		&flags.SignatureIdentity, "signature-identity",
+		extract.DefaultSignatureIdentity,
+		"identity expected in the certificate of the signatures",
+	)
+	cmd.Flags().StringVar(
+		&flags.SignatureIssuer, "signature-issuer",
+		extract.DefaultSignatureIssuer,
+		"OIDC issuer expected in the certificate of the signatures",
+	)
+	cmd.Flags().StringVar(
+		&flags.HTTPProxy, "http-proxy",
+		"",
+		"proxy for the http downloads of the build, on the host and in the image, e.g. of bits and packages; defaults to HTTP_PROXY",
+	)
+	cmd.Flags().StringVar(
+		&flags.HTTPSProxy, "https-proxy",
+		"",
+		"proxy for the https downloads of the build, on the host and in the image, e.g. of bits and packages; defaults to HTTPS_PROXY",
+	)
+	cmd.Flags().StringVar(
+		&flags.NoProxy, "no-proxy",
+		"",
+		"comma separated list of hosts that are not reached through the proxy; defaults to NO_PROXY",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.CACertificates, "ca-cert",
+		nil,
+		"path to a file with additional CA certificates, in PEM format, trusted during the build, e.g. on networks with TLS interception; the certificates are not added to the image",
+	)
+	cmd.Flags().StringVar(
+		&flags.BatchFile, "batch-file",
+		"",
+		"path to a YAML file with a list of variants, each with image, initArtifacts and upgradeArtifacts, to be built in one invocation with the other flags; labels are resolved once for all the variants",
+	)
 	return cmd
 }
 
 func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
+	if len(flags.Arch) == 0 {
+		return errors.New("at least one architecture must be set with the --arch flag")
+	}
+	if flags.Sign && !flags.Push {
+		return errors.New("signing the image requires the --push flag, because signatures are stored in the registry")
+	}
+	if env := os.Getenv(sourceDateEpochEnv); env != "" && !cmd.Flags().Changed("source-date-epoch") {
+		epoch, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s", sourceDateEpochEnv)
+		}
+		flags.SourceDateEpoch = epoch
+	}
+
+	if flags.BatchFile == "" {
+		return buildVariant(flags, variant{Image: flags.Image, InitArtifacts: flags.InitArtifacts, UpgradeArtifacts: flags.UpgradeArtifacts})
+	}
+	for _, f := range []string{"image", "with-init-artifacts", "with-upgrade-artifacts"} {
+		if cmd.Flags().Changed(f) {
+			return errors.Errorf("--%s can't be used with --batch-file, because it is set for each variant in the file", f)
+		}
+	}
+	variants, err := readBatchFile(flags.BatchFile)
+	if err != nil {
+		return err
+	}
+	return buildVariants(flags, variants)
+}
+
+// buildVariant builds a variant for each architecture, and then pushes and signs it if requested
+func buildVariant(flags *flagpole, v variant) error {
+	if len(flags.Arch) == 1 {
+		if err := alterImage(flags, v, v.Image, flags.Arch[0]); err != nil {
+			return err
+		}
+		if !flags.Push {
+			return nil
+		}
+		if err := alter.PushImage(v.Image); err != nil {
+			return err
+		}
+		return signImage(flags, v.Image)
+	}
+
+	// in case of many architectures, an image is built for each architecture, sequentially, because
+	// the base image for the different architectures is pulled with the same name
+	var archImages []string
+	for _, arch := range flags.Arch {
+		image := alter.ArchImage(v.Image, arch)
+		if err := alterImage(flags, v, image, arch); err != nil {
+			return errors.Wrapf(err, "error building the image for %s", arch)
+		}
+		archImages = append(archImages, image)
+	}
+	if !flags.Push {
+		log.Warnf("built %v; a manifest list for %s is created only with the --push flag", archImages, v.Image)
+		return nil
+	}
+	if err := alter.PushManifestList(v.Image, archImages); err != nil {
+		return err
+	}
+	return signImage(flags, v.Image)
+}
+
+func signImage(flags *flagpole, image string) error {
+	if !flags.Sign {
+		return nil
+	}
+	return host.SignImage(image, flags.SignKey)
+}
+
+func alterImage(flags *flagpole, v variant, image, arch string) error {
+	sbom := flags.SBOM
+	if sbom != "" && (len(flags.Arch) > 1 || flags.BatchFile != "") {
+		// the SBOM of each image is written into a different file, e.g. with the arch in the file name
+		var suffix []string
+		if flags.BatchFile != "" {
+			suffix = append(suffix, v.fileSuffix())
+		}
+		if len(flags.Arch) > 1 {
+			suffix = append(suffix, arch)
+		}
+		ext := filepath.Ext(sbom)
+		sbom = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(sbom, ext), strings.Join(suffix, "-"), ext)
+	}
+
 	ctx, err := alter.NewContext(
 		// base build options
 		alter.WithBaseImage(flags.BaseImage),
-		alter.WithImage(flags.Image),
+		alter.WithImage(image),
+		alter.WithArch(arch),
 		// bits to be added to the image
-		alter.WithInitArtifacts(flags.InitArtifacts),
+		alter.WithInitArtifacts(v.InitArtifacts),
 		alter.WithKubeadm(flags.Kubeadm),
 		alter.WithKubelet(flags.Kubelet),
+		alter.WithPackages(flags.Packages),
+		alter.WithContainerd(flags.Containerd),
+		alter.WithRunc(flags.Runc),
+		alter.WithCRIO(flags.CRIO),
 		alter.WithImageTars(flags.ImageTars),
-		alter.WithUpgradeArtifacts(flags.UpgradeArtifacts),
+		alter.WithUpgradeArtifacts(v.UpgradeArtifacts),
 		alter.WithPrePullAdditionalImages(flags.PrePullAdditionalImages),
+		alter.WithPrePullAddonImages(flags.PrePullAddonImages),
+		alter.WithImagesFromFile(flags.ImagesFromFile),
+		alter.WithEtcd(flags.Etcd),
+		alter.WithUpgradeEtcd(flags.UpgradeEtcd),
+		alter.WithSandboxImage(flags.SandboxImage),
 		// bits options
 		alter.WithImageNamePrefix(flags.ImageNamePrefix),
 		alter.WithPath(flags.Path),
+		alter.WithSystemdDir(flags.SystemdDir),
+		alter.WithKubeletConfig(flags.KubeletConfig),
+		alter.WithOptimize(flags.Optimize),
+		alter.WithSBOM(sbom, flags.SBOMFormat),
+		alter.WithReproducible(flags.Reproducible, flags.SourceDateEpoch),
+		alter.WithSmokeTest(flags.SmokeTest),
+		alter.WithBuildCache(flags.CacheFrom, flags.CacheTo),
+		// verification of the bits
+		alter.WithChecksums(flags.Checksums),
+		alter.WithSignatures(flags.VerifySignatures, flags.SignatureIdentity, flags.SignatureIssuer),
+		// network options
+		alter.WithProxy(extract.ProxyConfig{HTTPProxy: flags.HTTPProxy, HTTPSProxy: flags.HTTPSProxy, NoProxy: flags.NoProxy}),
+		alter.WithCACertificates(flags.CACertificates),
 	)
 	if err != nil {
 		return errors.Wrap(err, "error creating alter context")
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/cmd/kinder/cache/prune"
+)
+
+// NewCommand returns a new cobra.Command for managing the local cache of downloaded bits
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cache",
+		Short: "Manages the local cache of the binaries and image tarballs downloaded by kinder",
+		Long: "When the cache is enabled by setting KINDER_CACHE=true, binaries and image tarballs downloaded via http,\n" +
+			"e.g. from ci/release builds, are stored in a local cache, so following builds and workflows use them\n" +
+			"without downloading them again.\n" +
+			"The cache is stored in $KINDER_CACHE_DIR, defaulting to ~/.cache/kinder/bits, and it grows until pruned",
+	}
+	cmd.AddCommand(prune.NewCommand())
+	return cmd
+}
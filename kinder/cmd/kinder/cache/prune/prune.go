@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+type flagpole struct {
+	Dir       string
+	OlderThan time.Duration
+	All       bool
+}
+
+// NewCommand returns a new cobra.Command for pruning the local cache of downloaded bits
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "prune",
+		Short: "Removes the files not used recently from the local cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			olderThan := flags.OlderThan
+			if flags.All {
+				olderThan = 0
+			}
+			return extract.PruneCache(os.Stdout, flags.Dir, olderThan)
+		},
+	}
+
+	dir, _ := extract.DefaultCacheDir()
+	cmd.Flags().StringVar(
+		&flags.Dir,
+		"cache-dir", dir,
+		"folder of the local cache",
+	)
+	cmd.Flags().DurationVar(
+		&flags.OlderThan,
+		"older-than", 7*24*time.Hour,
+		"remove the files not used in the given duration",
+	)
+	cmd.Flags().BoolVar(
+		&flags.All,
+		"all", false,
+		"remove all the files",
+	)
+	return cmd
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package completion implements the `completion` command, and the dynamic completion of
+// cluster names, node names and action names
+package completion
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+// nodeSelectors are the kinder node selectors, see status.Cluster.SelectNodes
+var nodeSelectors = []string{"@all", "@cp*", "@cp1", "@cpN", "@w*", "@lb", "@etcd"}
+
+// nodeSelectionFlags are the flags accepting a comma separated list of node selectors
+var nodeSelectionFlags = []string{"only-node", "skip-node", "node-selector", "nodes"}
+
+// NewCommand returns a new cobra.Command for generating the shell completion scripts; the root command
+// should disable the default completion command of cobra
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "completion",
+		Short: "Generates the completion script for one of [bash, zsh, fish]",
+		Long: "Generates the completion script for one of [bash, zsh, fish]; besides commands and flags, the scripts complete\n" +
+			"cluster names, node names and selectors, and action names, by querying the existing clusters.\n\n" +
+			"For loading completions in the current shell:\n" +
+			"  bash: source <(kinder completion bash)\n" +
+			"  zsh:  source <(kinder completion zsh)\n" +
+			"  fish: kinder completion fish | source",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "bash",
+		Short: "Generates the completion script for bash",
+		Long:  "Generates the completion script for bash, that requires the bash-completion package",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Root().GenBashCompletionV2(os.Stdout, true)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "zsh",
+		Short: "Generates the completion script for zsh",
+		Long:  "Generates the completion script for zsh; for loading completions in each session, add the script to a folder in $fpath",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Root().GenZshCompletion(os.Stdout)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "fish",
+		Short: "Generates the completion script for fish",
+		Long:  "Generates the completion script for fish; for loading completions in each session, write it to ~/.config/fish/completions/kinder.fish",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Root().GenFishCompletion(os.Stdout, true)
+		},
+	})
+	return cmd
+}
+
+// Register adds the dynamic completion of cluster names, node names and action names to the kinder commands
+func Register(root *cobra.Command) {
+	walk(root, func(cmd *cobra.Command) {
+		if f := cmd.Flags().Lookup("name"); f != nil && strings.Contains(f.Usage, "cluster name") {
+			_ = cmd.RegisterFlagCompletionFunc("name", clusterNames)
+		}
+		for _, name := range nodeSelectionFlags {
+			if cmd.Flags().Lookup(name) != nil {
+				_ = cmd.RegisterFlagCompletionFunc(name, nodeSelectionList)
+			}
+		}
+
+		switch cmd.CommandPath() {
+		case "kinder do":
+			cmd.ValidArgsFunction = firstArg(actionNames)
+		case "kinder exec", "kinder port-forward":
+			cmd.ValidArgsFunction = firstArg(nodeSelectionList)
+		case "kinder cp":
+			cmd.ValidArgsFunction = nodePaths
+		case "kinder shell", "kinder skew", "kinder status":
+			cmd.ValidArgsFunction = firstArg(clusterNames)
+		}
+	})
+}
+
+func walk(cmd *cobra.Command, f func(*cobra.Command)) {
+	f(cmd)
+	for _, c := range cmd.Commands() {
+		walk(c, f)
+	}
+}
+
+// firstArg returns a completion function completing only the first positional argument
+func firstArg(f func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return f(cmd, args, toComplete)
+	}
+}
+
+func clusterNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	clusters, err := status.ListClusters()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return clusters, cobra.ShellCompDirectiveNoFileComp
+}
+
+func actionNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	names := []string{}
+	for _, a := range actions.Catalog() {
+		names = append(names, a.Name+"\t"+a.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// nodes returns the node selectors and the names of the nodes, without the cluster name prefix, of the
+// cluster set with --name
+func nodes(cmd *cobra.Command) []string {
+	name := constants.DefaultClusterName
+	if f := cmd.Flags().Lookup("name"); f != nil {
+		name = f.Value.String()
+	}
+	names := append([]string{}, nodeSelectors...)
+	if c, err := status.FromDocker(name); err == nil {
+		for _, n := range c.AllNodes() {
+			names = append(names, strings.TrimPrefix(n.Name(), name+"-"))
+		}
+	}
+	return names
+}
+
+// nodeSelectionList completes the last term of a comma separated list of node selectors
+func nodeSelectionList(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	head := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		head = toComplete[:i+1]
+	}
+	completions := []string{}
+	for _, n := range nodes(cmd) {
+		completions = append(completions, head+n)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// nodePaths completes the node part of [node:]path arguments; local paths are completed by the shell
+func nodePaths(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 1 || strings.Contains(toComplete, ":") {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	completions := []string{}
+	for _, n := range nodes(cmd) {
+		if strings.HasPrefix(n, toComplete) {
+			completions = append(completions, n+":")
+		}
+	}
+	// when the argument doesn't look like a node, it is a local path
+	if len(completions) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
@@ -44,9 +44,12 @@ func NewCommand() *cobra.Command {
 			"    @cpN 	the secondary control plane nodes \n" +
 			"    @w* 	all the worker nodes\n" +
 			"    @lb 	the external load balancer\n" +
-			"    @etcd 	the external etcd",
-		Short: "Copy files/folders between a node and the local filesystem",
-		Long:  "kinder cp is a \"topology aware\" wrapper on docker cp",
+			"    @etcd 	the external etcd\n" +
+			"  SRC_PATH can be a file, a folder or a glob pattern, e.g. /var/log/*.log",
+		Short: "Copy files/folders between nodes and the local filesystem",
+		Long: "kinder cp is a \"topology aware\" wrapper on docker cp.\n" +
+			"When copying from many nodes, files are copied into a subfolder of DEST_PATH for each node, e.g. DEST_PATH/kind-worker/;\n" +
+			"when SRC_PATH matches many files, DEST_PATH is a folder, that is created if missing",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runE(flags, cmd, args)
 		},
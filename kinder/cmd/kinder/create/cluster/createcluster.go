@@ -38,6 +38,11 @@ type flagpole struct {
 	ExternalEtcd         bool
 	ExternalLoadBalancer bool
 	Volumes              []string
+	Labels               []string
+	VerifySignature      bool
+	SignatureKey         string
+	SignatureIdentity    string
+	SignatureIssuer      string
 }
 
 // NewCommand returns a new cobra.Command for cluster creation
@@ -93,6 +98,32 @@ func NewCommand() *cobra.Command {
 		"volume", nil,
 		"mount a volume on node containers",
 	)
+	cmd.Flags().StringSliceVar(
+		&flags.Labels,
+		"label", nil,
+		"add a label in the key=value format to the node containers, e.g. for selecting clusters in kinder delete cluster",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.VerifySignature,
+		"verify-signature", false,
+		"verify the cosign signature of the node image in its registry before using it",
+	)
+	cmd.Flags().StringVar(
+		&flags.SignatureKey,
+		"signature-key", "",
+		"path or KMS URI of the public key for verifying the signature of the node image",
+	)
+	cmd.Flags().StringVar(
+		&flags.SignatureIdentity,
+		"signature-identity", "",
+		"identity expected in the certificate of keyless signatures, when --signature-key is not set",
+	)
+	cmd.Flags().StringVar(
+		&flags.SignatureIssuer,
+		"signature-issuer", "",
+		"OIDC issuer expected in the certificate of keyless signatures, when --signature-key is not set",
+	)
 
 	cmd.MarkFlagRequired("image")
 
@@ -116,6 +147,8 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
 		manager.ExternalEtcd(flags.ExternalEtcd),
 		manager.Retain(flags.Retain),
 		manager.Volumes(flags.Volumes),
+		manager.Labels(flags.Labels),
+		manager.VerifyImageSignature(flags.VerifySignature, flags.SignatureKey, flags.SignatureIdentity, flags.SignatureIssuer),
 	); err != nil {
 		return errors.Wrap(err, "failed to create cluster")
 	}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster implements the `cluster` command for deleting one or more clusters
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name       string
+	Kubeconfig string
+	All        bool
+	Selectors  []string
+	OlderThan  time.Duration
+	DryRun     bool
+}
+
+// NewCommand returns a new cobra.Command for deleting a cluster, or all the clusters matching a set of filters
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Deletes a cluster, or all the clusters matching the given filters",
+		Long: "Deletes the kind cluster by --name, or with --all, --selector or --older-than all the clusters matching\n" +
+			"the given filters, e.g. for garbage-collecting the clusters leaked by CI jobs.\n\n" +
+			"This is an idempotent operation, meaning it may be called multiple times without failing (like \"rm -f\");\n" +
+			"when deleting many clusters, errors are reported after trying to delete all of them",
+		Example: "  # delete the clusters created by CI jobs more than 6 hours ago\n" +
+			"  kinder delete cluster --selector ci-job --older-than 6h",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags, cmd)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name", "n",
+		constants.DefaultClusterName,
+		"cluster name; it can't be used with --all, --selector or --older-than",
+	)
+	cmd.Flags().StringVar(
+		&flags.Kubeconfig,
+		"kubeconfig", "",
+		"sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config",
+	)
+	cmd.Flags().BoolVarP(
+		&flags.All,
+		"all", "A",
+		false,
+		"delete all the clusters, or all the clusters matching --selector and --older-than",
+	)
+	cmd.Flags().StringSliceVarP(
+		&flags.Selectors,
+		"selector", "l",
+		nil,
+		"delete only the clusters with nodes labeled as key or key=value, e.g. set by kinder create cluster --label; "+
+			"many selectors must all match",
+	)
+	cmd.Flags().DurationVar(
+		&flags.OlderThan,
+		"older-than", 0,
+		"delete only the clusters created more than the given duration ago, e.g. 24h",
+	)
+	cmd.Flags().BoolVar(
+		&flags.DryRun,
+		"dry-run", false,
+		"only print the clusters that would be deleted",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, cmd *cobra.Command) error {
+	bulk := flags.All || len(flags.Selectors) > 0 || flags.OlderThan > 0
+	if flags.OlderThan < 0 {
+		return errors.New("flag --older-than should not be a negative duration")
+	}
+
+	var names []string
+	if bulk {
+		if cmd.Flags().Changed("name") {
+			return errors.New("flag --name can't be used with --all, --selector or --older-than")
+		}
+		var err error
+		if names, err = manager.SelectClusters(flags.Selectors, flags.OlderThan); err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Println("No clusters matching the given filters")
+			return nil
+		}
+	} else {
+		// as in kind, the default cluster name can be overridden via env
+		if name := os.Getenv("KIND_CLUSTER_NAME"); name != "" && !cmd.Flags().Changed("name") {
+			flags.Name = name
+		}
+		names = []string{flags.Name}
+	}
+
+	if flags.DryRun {
+		fmt.Printf("Clusters that would be deleted: %s\n", strings.Join(names, ", "))
+		return nil
+	}
+
+	var failed []string
+	for _, name := range names {
+		fmt.Printf("Deleting cluster %q ...\n", name)
+		if err := manager.DeleteCluster(name, flags.Kubeconfig); err != nil {
+			log.Error(err)
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to delete clusters %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
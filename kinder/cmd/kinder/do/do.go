@@ -17,34 +17,62 @@ limitations under the License.
 package do
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	K8sVersion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
 	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
 	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+	"k8s.io/kubeadm/kinder/pkg/kubeadm"
 )
 
 type flagpole struct {
 	Name                  string
 	UsePhases             bool
+	InitPhases            []string
+	SkipInitPhases        []string
 	UpgradeVersion        string
 	CopyCerts             string
 	Discovery             string
 	OnlyNode              string
+	SkipNode              string
 	DryRun                bool
 	VLevel                int
 	PatchesDir            string
 	Wait                  time.Duration
 	IgnorePreflightErrors string
+	KubeadmExtraFlags     string
+	VerifyEtcdMembers     bool
 	KubeadmConfigVersion  string
 	FeatureGate           string
 	EncryptionAlgorithm   string
+	CloudProvider         string
+	ExternalEtcdEndpoints []string
+	ExternalEtcdCAFile    string
+	ExternalEtcdCertFile  string
+	ExternalEtcdKeyFile   string
+	ResultsFile           string
+	ArtifactsDir          string
+	List                  bool
+	Output                string
+	ChaosService          string
+	ChaosDuration         time.Duration
+	PartitionMode         string
+	RollbackExpect        string
+	VerifySpec            string
+	NodeSelector          string
+	Command               string
+	Parallel              bool
 }
 
 // NewCommand returns a new cobra.Command for exec
@@ -53,7 +81,7 @@ func NewCommand() *cobra.Command {
 		Discovery: string(actions.TokenDiscovery),
 	}
 	cmd := &cobra.Command{
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		Use: "do [flags] ACTION\n\n" +
 			"Args:\n" +
 			fmt.Sprintf("  ACTION is one of %s", actions.KnownActions()),
@@ -71,17 +99,30 @@ func NewCommand() *cobra.Command {
 	)
 	cmd.Flags().StringVar(&flags.OnlyNode,
 		"only-node",
-		"", "exec the action only on the selected node",
+		"", "exec the action only on the selected nodes; comma separated list of node names, "+
+			"node selectors like @cp* or @w*, or label:<Kubernetes label selector> as the last term",
+	)
+	cmd.Flags().StringVar(&flags.SkipNode,
+		"skip-node",
+		"", "exec the action skipping the selected nodes; same syntax of --only-node, applied after it",
 	)
 	cmd.Flags().BoolVar(
 		&flags.DryRun,
 		"dry-run", false,
-		"only prints workflow commands, without executing them",
+		"only prints the commands, the config files and the node targets of the action, without changing the cluster",
 	)
 	cmd.Flags().BoolVar(
 		&flags.UsePhases, "use-phases",
 		false, "use the kubeadm phases subcommands instead of the kubeadm top-level commands",
 	)
+	cmd.Flags().StringSliceVar(
+		&flags.InitPhases, "init-phases",
+		nil, fmt.Sprintf("ordered list of kubeadm init phases to run when using --use-phases; use any of %s", actions.KnownInitPhases()),
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.SkipInitPhases, "skip-init-phases",
+		nil, "list of kubeadm init phases to skip when using --use-phases",
+	)
 	cmd.Flags().StringVar(
 		&flags.UpgradeVersion,
 		"upgrade-version", "",
@@ -117,12 +158,23 @@ func NewCommand() *cobra.Command {
 		"ignore-preflight-errors", constants.KubeadmIgnorePreflightErrors,
 		"list of kubeadm preflight errors to skip",
 	)
+	cmd.Flags().StringVar(
+		&flags.KubeadmExtraFlags,
+		"kubeadm-extra-flags", flags.KubeadmExtraFlags,
+		"space separated list of flags appended to the kubeadm init, join, upgrade and reset command lines, e.g. \"--v=5 --dry-run\"; "+
+			"quoted values are not supported and it can't be used with --use-phases",
+	)
+	cmd.Flags().BoolVar(
+		&flags.VerifyEtcdMembers, "verify-etcd-members",
+		false, "verify the etcd members after each control-plane join, like the etcd-members-verify action does",
+	)
 	cmd.Flags().StringVar(
 		&flags.KubeadmConfigVersion,
 		"kubeadm-config-version", flags.KubeadmConfigVersion,
-		"the kubeadm config version to be used for init, join and upgrade. "+
+		fmt.Sprintf("the kubeadm config version to be used for init, join and upgrade; use one of %s. ", kubeadm.KnownConfigVersions())+
 			"If not set, kubeadm will automatically choose the kubeadm config version "+
-			"according to the Kubernetes version in use",
+			"according to the Kubernetes version in use. The kubeadm-config-migrate action uses this "+
+			"as the version to migrate from (v1beta3 if not set)",
 	)
 	cmd.Flags().StringVar(
 		&flags.FeatureGate,
@@ -134,10 +186,104 @@ func NewCommand() *cobra.Command {
 		"kubeadm-encryption-algorithm", "",
 		"the encryption algorithm used by kubeadm for private keys in the cluster",
 	)
+	cmd.Flags().StringVar(
+		&flags.CloudProvider,
+		"cloud-provider", "",
+		fmt.Sprintf("the kubelet --cloud-provider flag to be used for init and join; only %q is supported", actions.CloudProviderExternal),
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.ExternalEtcdEndpoints,
+		"external-etcd-endpoints", nil,
+		"the endpoints of an external etcd cluster to be used for init; if not set, the external etcd node in the cluster is used, if any",
+	)
+	cmd.Flags().StringVar(
+		&flags.ExternalEtcdCAFile,
+		"external-etcd-ca-file", "",
+		"the path on the control-plane nodes of the CA certificate for the external etcd endpoints",
+	)
+	cmd.Flags().StringVar(
+		&flags.ExternalEtcdCertFile,
+		"external-etcd-cert-file", "",
+		"the path on the control-plane nodes of the client certificate for the external etcd endpoints",
+	)
+	cmd.Flags().StringVar(
+		&flags.ExternalEtcdKeyFile,
+		"external-etcd-key-file", "",
+		"the path on the control-plane nodes of the client key for the external etcd endpoints",
+	)
+	cmd.Flags().StringVar(
+		&flags.ChaosService,
+		"chaos-service", string(actions.ChaosServiceKubelet),
+		fmt.Sprintf("the service to be stopped by the chaos-stop-service action; use one of %s", actions.KnownChaosServices()),
+	)
+	cmd.Flags().DurationVar(
+		&flags.ChaosDuration,
+		"chaos-duration", time.Duration(30*time.Second),
+		"for how long chaos actions keep the failure condition before restoring the node",
+	)
+	cmd.Flags().StringVar(
+		&flags.PartitionMode,
+		"partition-mode", string(actions.PartitionFromControlPlane),
+		fmt.Sprintf("how nodes are partitioned by the chaos-network-partition action; use one of %s", actions.KnownPartitionModes()),
+	)
+	cmd.Flags().StringVar(
+		&flags.RollbackExpect,
+		"rollback-expect", string(actions.RollbackSupported),
+		fmt.Sprintf("the expected outcome of kubeadm upgrade node for the kubeadm-rollback-node action; use one of %s", actions.KnownRollbackExpectations()),
+	)
+	cmd.Flags().StringVar(
+		&flags.VerifySpec,
+		"verify-spec", "",
+		"a YAML file with the expected cluster state to be asserted by the verify action",
+	)
+	cmd.Flags().StringVar(
+		&flags.NodeSelector,
+		"node-selector", "@all",
+		"comma separated list of nodes targeted by the run-on-nodes action; each entry can be a node selector "+
+			"like @all, @cp*, @cp1, @cpn, @w*, @lb, @etcd, a node name or label:<Kubernetes label selector> as the last entry",
+	)
+	cmd.Flags().StringVar(
+		&flags.Command,
+		"command", "",
+		"the shell command executed by the run-on-nodes action",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Parallel,
+		"parallel", false,
+		"execute the run-on-nodes command on all the selected nodes in parallel",
+	)
+	cmd.Flags().BoolVar(
+		&flags.List,
+		"list", false,
+		"list the available actions with their description, supported flags and node roles",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Output,
+		"output", "o", "text",
+		"output format for --list; use one of [text, json]",
+	)
+	cmd.Flags().StringVar(
+		&flags.ArtifactsDir,
+		"artifacts-dir", os.Getenv("ARTIFACTS"),
+		"the folder where nodes files and command outputs declared by the action are collected when the action completes or fails; "+
+			"defaults to the ARTIFACTS env variable, if set",
+	)
+	cmd.Flags().StringVar(
+		&flags.ResultsFile,
+		"results-file", "",
+		"write a JSON document with the result of the action and of each command executed on nodes to this file; use - for stdout",
+	)
 	return cmd
 }
 
 func runE(flags *flagpole, cmd *cobra.Command, args []string) (err error) {
+	if flags.List {
+		return listActions(flags.Output)
+	}
+	if len(args) != 1 {
+		return errors.Errorf("an ACTION is required; use one of %s", actions.KnownActions())
+	}
+
 	// validate UpgradeVersion flag
 	var upgradeVersion *K8sVersion.Version
 	if flags.UpgradeVersion != "" {
@@ -152,35 +298,86 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	if flags.KubeadmConfigVersion != "" {
+		if err := kubeadm.ValidateConfigVersion(flags.KubeadmConfigVersion); err != nil {
+			return err
+		}
+	}
+
 	copyCerts := actions.CopyCertsMode(strings.ToLower(flags.CopyCerts))
 	if err := actions.ValidateCopyCertsMode(copyCerts); err != nil {
 		return err
 	}
 
+	if err := actions.ValidateCloudProvider(strings.ToLower(flags.CloudProvider)); err != nil {
+		return err
+	}
+
+	externalEtcd := actions.ExternalEtcdOptions{
+		Endpoints: flags.ExternalEtcdEndpoints,
+		CAFile:    flags.ExternalEtcdCAFile,
+		CertFile:  flags.ExternalEtcdCertFile,
+		KeyFile:   flags.ExternalEtcdKeyFile,
+	}
+	if err := externalEtcd.Validate(); err != nil {
+		return err
+	}
+
+	chaosService := actions.ChaosService(strings.ToLower(flags.ChaosService))
+	if err := actions.ValidateChaosService(chaosService); err != nil {
+		return err
+	}
+
+	partitionMode := actions.PartitionMode(strings.ToLower(flags.PartitionMode))
+	if err := actions.ValidatePartitionMode(partitionMode); err != nil {
+		return err
+	}
+
+	rollbackExpect := actions.RollbackExpectation(strings.ToLower(flags.RollbackExpect))
+	if err := actions.ValidateRollbackExpectation(rollbackExpect); err != nil {
+		return err
+	}
+
 	// get a kinder cluster manager
 	o, err := manager.NewClusterManager(flags.Name)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", flags.Name)
 	}
 
-	// eventually, instruct the cluster manager to run only commands on one node
+	// eventually, instruct the cluster manager to run only commands on a subset of nodes
 	if flags.OnlyNode != "" {
 		if err := o.OnlyNode(flags.OnlyNode); err != nil {
 			return err
 		}
 	}
+	if flags.SkipNode != "" {
+		if err := o.SkipNode(flags.SkipNode); err != nil {
+			return err
+		}
+	}
 
 	// eventually, instruct the cluster manager to dry run commands (without actually running them)
 	if flags.DryRun {
 		o.DryRun()
 
 		flags.Wait = 0
+		flags.ArtifactsDir = ""
+	}
+
+	// eventually, instruct the cluster manager to record the result of each command
+	var recorder *exec.Recorder
+	if flags.ResultsFile != "" {
+		recorder = exec.NewRecorder()
+		o.RecordCommands(recorder)
 	}
 
 	// executed the requested action
 	action := args[0]
+	start := time.Now()
 	err = o.DoAction(action,
 		actions.UsePhases(flags.UsePhases),
+		actions.InitPhases(flags.InitPhases),
+		actions.SkipInitPhases(flags.SkipInitPhases),
 		actions.CopyCerts(copyCerts),
 		actions.Discovery(discovery),
 		actions.Wait(flags.Wait),
@@ -188,13 +385,95 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) (err error) {
 		actions.VLevel(flags.VLevel),
 		actions.PatchesDir(flags.PatchesDir),
 		actions.IgnorePreflightErrors(flags.IgnorePreflightErrors),
+		actions.KubeadmExtraFlags(strings.Fields(flags.KubeadmExtraFlags)),
+		actions.VerifyEtcdMembers(flags.VerifyEtcdMembers),
 		actions.KubeadmConfigVersion(flags.KubeadmConfigVersion),
 		actions.FeatureGate(flags.FeatureGate),
 		actions.EncryptionAlgorithm(flags.EncryptionAlgorithm),
+		actions.CloudProvider(strings.ToLower(flags.CloudProvider)),
+		actions.ExternalEtcd(externalEtcd),
+		actions.ChaosServiceToStop(chaosService),
+		actions.ChaosDuration(flags.ChaosDuration),
+		actions.Partition(partitionMode),
+		actions.RollbackExpect(rollbackExpect),
+		actions.VerifySpecFile(flags.VerifySpec),
+		actions.NodeSelector(flags.NodeSelector),
+		actions.Command(flags.Command),
+		actions.Parallel(flags.Parallel),
+		actions.ArtifactsDir(flags.ArtifactsDir),
 	)
+
+	if recorder != nil {
+		if werr := writeResults(flags.ResultsFile, flags.Name, action, start, recorder, err); werr != nil {
+			log.Warnf("failed to write the action results: %v", werr)
+		}
+	}
+
 	if err != nil {
 		return errors.Wrapf(err, "failed to exec action %s", action)
 	}
 
 	return nil
 }
+
+// actionResult is a machine-readable summary of an action execution
+type actionResult struct {
+	Cluster   string               `json:"cluster"`
+	Action    string               `json:"action"`
+	StartTime string               `json:"startTime"`
+	Duration  float64              `json:"durationSeconds"`
+	Succeeded bool                 `json:"succeeded"`
+	Error     string               `json:"error,omitempty"`
+	Commands  []exec.CommandResult `json:"commands"`
+}
+
+// writeResults writes the action result document to file, or to stdout if file is "-"
+func writeResults(file, cluster, action string, start time.Time, recorder *exec.Recorder, actionErr error) error {
+	result := actionResult{
+		Cluster:   cluster,
+		Action:    action,
+		StartTime: start.UTC().Format(time.RFC3339),
+		Duration:  time.Since(start).Seconds(),
+		Succeeded: actionErr == nil,
+		Commands:  recorder.Results(),
+	}
+	if actionErr != nil {
+		result.Error = actionErr.Error()
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the action results")
+	}
+	data = append(data, '\n')
+
+	if file == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return errors.Wrapf(os.WriteFile(file, data, 0644), "failed to write %s", file)
+}
+
+// listActions prints the catalog of the available actions
+func listActions(output string) error {
+	catalog := actions.Catalog()
+
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(catalog, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the action catalog")
+		}
+		fmt.Println(string(data))
+	case "text":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ACTION\tROLES\tDESCRIPTION")
+		for _, a := range catalog {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", a.Name, strings.Join(a.Roles, ","), a.Description)
+		}
+		return w.Flush()
+	default:
+		return errors.Errorf("invalid output format %q; use one of [text, json]", output)
+	}
+	return nil
+}
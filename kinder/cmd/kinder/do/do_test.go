@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"testing"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+)
+
+func TestCatalogFlagsAreDefined(t *testing.T) {
+	cmd := NewCommand()
+	for _, a := range actions.Catalog() {
+		if a.Description == "" {
+			t.Errorf("action %s does not have a description", a.Name)
+		}
+		if len(a.Roles) == 0 {
+			t.Errorf("action %s does not have roles", a.Name)
+		}
+		for _, f := range a.Flags {
+			if cmd.Flags().Lookup(f) == nil {
+				t.Errorf("action %s lists flag %q, that is not defined by kinder do", a.Name, f)
+			}
+		}
+	}
+}
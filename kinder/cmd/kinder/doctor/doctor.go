@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package doctor implements the `doctor` command
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/doctor"
+)
+
+type flagpole struct {
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for checking the host
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "doctor",
+		Short: "Checks the host for the requirements of kinder and for known problems",
+		Long: "Checks the host for the requirements of kinder and for settings known to make clusters fail, like the version\n" +
+			"of docker or podman, cgroup v2 and the delegation of controllers to rootless engines, the inotify limits,\n" +
+			"the kernel modules, ip6tables and the free disk space, and prints how to fix the problems found.\n" +
+			"The command fails if any check fails; warnings are for problems affecting only some clusters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Output, "output", "o",
+		"text",
+		"output format; use one of [text, json]",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole) error {
+	if flags.Output != "text" && flags.Output != "json" {
+		return errors.Errorf("invalid output format %q; use one of [text, json]", flags.Output)
+	}
+
+	results := doctor.Run()
+	failures := 0
+	if flags.Output == "json" {
+		for _, r := range results {
+			if r.Status == doctor.Failure {
+				failures++
+			}
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		failures = doctor.Print(os.Stdout, results)
+	}
+
+	if failures > 0 {
+		return errors.Errorf("%d checks failed", failures)
+	}
+	return nil
+}
@@ -25,7 +25,8 @@ import (
 )
 
 type flagpole struct {
-	Name string
+	Name     string
+	Parallel bool
 }
 
 // NewCommand returns a new cobra.Command for exec
@@ -43,9 +44,15 @@ func NewCommand() *cobra.Command {
 			"    @cpN 	the secondary control plane nodes \n" +
 			"    @w* 	all the worker nodes\n" +
 			"    @lb 	the external load balancer\n" +
-			"    @etcd 	the external etcd",
+			"    @etcd 	the external etcd\n" +
+			"    role:<role> 	the nodes with a role, e.g. role:external-etcd\n" +
+			"    label:<label selector> 	the nodes whose Node objects match a label selector\n" +
+			"  or a comma separated list of node names and node selectors; a label: term must be the last one,\n" +
+			"  because the label selector extends to the end of the list, e.g. @cp1,label:a=b,c=d",
 		Short: "Executes command on one or more nodes in the local Kubernetes cluster",
-		Long:  "Exec is a \"topology aware\" wrapper on docker exec, allowing to run command on one or more nodes in the local Kubernetes cluster\n",
+		Long: "Exec is a \"topology aware\" wrapper on docker exec, allowing to run command on one or more nodes in the local Kubernetes cluster.\n" +
+			"With --parallel, the command is executed on all the nodes at the same time, the output of each node is prefixed\n" +
+			"with the node name, and the command fails if it fails on any node\n",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runE(flags, cmd, args)
 		},
@@ -55,6 +62,11 @@ func NewCommand() *cobra.Command {
 		"name", constants.DefaultClusterName,
 		"cluster name",
 	)
+	cmd.Flags().BoolVar(
+		&flags.Parallel,
+		"parallel", false,
+		"execute the command on all the nodes at the same time",
+	)
 	return cmd
 }
 
@@ -66,7 +78,7 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
 	}
 
 	// execute the command on selected target nodes
-	err = o.ExecCommand(args[0], args[1:])
+	err = o.ExecCommand(args[0], args[1:], flags.Parallel)
 	if err != nil {
 		return errors.Wrap(err, "failed to exec command")
 	}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logs implements the `logs` command for exporting a logs bundle of a cluster
+package logs
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name  string
+	Since time.Duration
+}
+
+// NewCommand returns a new cobra.Command for exporting the logs of a cluster
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "logs [output-dir]",
+		Short: "Exports a logs bundle of the kind cluster by --name",
+		Long: "Exports a logs bundle of the kind cluster by --name into output-dir, or into a temporary folder.\n" +
+			"The bundle has a folder for each node under nodes/, with the journal of the kubelet and of containerd,\n" +
+			"/etc/kubernetes without private keys, the pod logs and the container status, a cluster/ folder with the kubeadm\n" +
+			"ConfigMaps, the etcd health and the dumps of the API objects, and an index.json file listing all the files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags, args)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Name, "name",
+		constants.DefaultClusterName,
+		"cluster name",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Since, "since",
+		0,
+		"export only the journal entries more recent than the given duration, e.g. 1h; the whole journal if 0",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, args []string) error {
+	o, err := manager.NewClusterManager(flags.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", flags.Name)
+	}
+
+	var dir string
+	if len(args) > 0 {
+		dir = args[0]
+	} else if dir, err = os.MkdirTemp("", "kinder-logs-"); err != nil {
+		return err
+	}
+
+	var since time.Time
+	if flags.Since > 0 {
+		since = time.Now().Add(-flags.Since)
+	}
+	return actions.ExportLogs(o.Cluster, dir, since)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodeimage implements the `node-image` command for exporting node images
+package nodeimage
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/build/alter"
+)
+
+type flagpole struct {
+	Image  string
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for exporting a node image into an archive
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "node-image",
+		Short: "Exports a node image into an archive with the manifest of its bits",
+		Long: "Exports a node image into an archive, that contains the image and the manifest of the bits added by\n" +
+			"kinder build node-variant, with versions, sources and checksums; the archive can be imported with\n" +
+			"kinder import node-image, e.g. for moving images between machines or caching them in CI without a registry.\n" +
+			"The archive is compressed if the output ends with .gz or .tgz",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.Output == "" {
+				return errors.New("the --output flag is required")
+			}
+			return alter.ExportImage(flags.Image, flags.Output)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Image,
+		"image", alter.DefaultImage,
+		"name:tag of the node image to export",
+	)
+	cmd.Flags().StringVar(
+		&flags.Output,
+		"output", "",
+		"path of the archive",
+	)
+	return cmd
+}
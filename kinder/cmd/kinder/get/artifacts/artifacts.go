@@ -38,6 +38,17 @@ type flagpole struct {
 	OnlyKubelet  bool
 	OnlyBinaries bool
 	OnlyImages   bool
+	Arch         string
+
+	Checksums         string
+	VerifySignatures  bool
+	SignatureIdentity string
+	SignatureIssuer   string
+
+	HTTPProxy      string
+	HTTPSProxy     string
+	NoProxy        string
+	CACertificates []string
 }
 
 // NewCommand returns a new cobra.Command for exec
@@ -54,6 +65,8 @@ func NewCommand() *cobra.Command {
 			"    ci/VERSION       where VERSION is a semantic version\n" +
 			"    VERSION          as shortcut to release/VERSION if build metadata are empty, else to ci/VERSION\n" +
 			"    URL              an http or http server where release artifacts are available\n" +
+			"    gs://BUCKET/PATH/LABEL_OR_VERSION  a label or a version in a GCS bucket with the same layout of the ci builds\n" +
+			"    oci://REGISTRY/REPOSITORY:TAG      an OCI artifact with a layer for each file, e.g. pushed with oras push\n" +
 			"    PATH             a local folder (file:// schema can be use to disambiguate release/ or ci/ folder)\n" +
 			"  DESTINATION_PATH should be a local path; if missing the current path will be used",
 		Aliases: []string{"build-artifacts", "release-artifacts", "ci-artifacts"},
@@ -80,6 +93,42 @@ func NewCommand() *cobra.Command {
 		onlyImagesFLagName, false,
 		"Gets only the kube-apiserver, kube-scheduler, kube-controller-manager and kube-proxy image tarballs (instead of all artifacts)",
 	)
+	cmd.Flags().StringVar(&flags.Arch,
+		"arch", "amd64",
+		"Architecture of the artifacts to get from ci/release builds, e.g. arm64",
+	)
+	cmd.Flags().StringVar(&flags.Checksums,
+		"checksums", "",
+		"Path or URL to a file with the sha256 checksums, in the format of sha256sum, of the artifacts downloaded from URLs",
+	)
+	cmd.Flags().BoolVar(&flags.VerifySignatures,
+		"verify-signatures", false,
+		"Verifies the sigstore signatures of the artifacts downloaded from URLs with cosign",
+	)
+	cmd.Flags().StringVar(&flags.SignatureIdentity,
+		"signature-identity", extract.DefaultSignatureIdentity,
+		"Identity expected in the certificate of the signatures",
+	)
+	cmd.Flags().StringVar(&flags.SignatureIssuer,
+		"signature-issuer", extract.DefaultSignatureIssuer,
+		"OIDC issuer expected in the certificate of the signatures",
+	)
+	cmd.Flags().StringVar(&flags.HTTPProxy,
+		"http-proxy", "",
+		"Proxy for the http downloads; defaults to HTTP_PROXY",
+	)
+	cmd.Flags().StringVar(&flags.HTTPSProxy,
+		"https-proxy", "",
+		"Proxy for the https downloads; defaults to HTTPS_PROXY",
+	)
+	cmd.Flags().StringVar(&flags.NoProxy,
+		"no-proxy", "",
+		"Comma separated list of hosts that are not reached through the proxy; defaults to NO_PROXY",
+	)
+	cmd.Flags().StringSliceVar(&flags.CACertificates,
+		"ca-cert", nil,
+		"Path to a file with additional CA certificates, in PEM format, trusted for the downloads",
+	)
 
 	return cmd
 }
@@ -98,12 +147,22 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
 		dst = args[1]
 	}
 
+	proxy := extract.ProxyConfig{HTTPProxy: flags.HTTPProxy, HTTPSProxy: flags.HTTPSProxy, NoProxy: flags.NoProxy}
+	if len(flags.CACertificates) > 0 || proxy != (extract.ProxyConfig{}) {
+		if err := extract.ConfigureHTTP(proxy, flags.CACertificates); err != nil {
+			return err
+		}
+	}
+
 	// Build an artifact extractor customized with the command options
 	e := extract.NewExtractor(src, dst,
 		extract.OnlyKubeadm(flags.OnlyKubeadm),
 		extract.OnlyKubelet(flags.OnlyKubelet),
 		extract.OnlyKubernetesBinaries(flags.OnlyBinaries),
 		extract.OnlyKubernetesImages(flags.OnlyImages),
+		extract.WithArch(flags.Arch),
+		extract.WithChecksums(flags.Checksums),
+		extract.WithSignatures(flags.VerifySignatures, flags.SignatureIdentity, flags.SignatureIssuer),
 	)
 
 	// Extracts the artifacts from the source
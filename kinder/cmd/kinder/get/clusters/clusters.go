@@ -1,5 +1,5 @@
 /*
-Copyright 2019 The Kubernetes Authors.
+Copyright 2026 The Kubernetes Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -21,30 +21,64 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/kubeadm/kinder/cmd/kinder/get/output"
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
 )
 
+type flagpole struct {
+	Output string
+}
+
+// cluster is the schema of the structured output
+type cluster struct {
+	Name  string   `json:"name"`
+	Nodes []string `json:"nodes"`
+}
+
 // NewCommand returns a new cobra.Command for getting the list of clusters
 func NewCommand() *cobra.Command {
+	flags := &flagpole{}
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "clusters",
 		Short: "Lists existing kind clusters by their name",
-		Long:  "Lists existing kind clusters by their name",
+		Long:  "Lists existing kind clusters by their name, or with their nodes with -o json|yaml",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runE(cmd, args)
+			return runE(flags)
 		},
 	}
+	output.AddFlag(cmd, &flags.Output)
 	return cmd
 }
 
-func runE(cmd *cobra.Command, args []string) error {
-	clusters, err := status.ListClusters()
+func runE(flags *flagpole) error {
+	if err := output.Validate(flags.Output); err != nil {
+		return err
+	}
+	names, err := status.ListClusters()
 	if err != nil {
 		return err
 	}
-	for _, cluster := range clusters {
-		fmt.Println(cluster)
+
+	items := []cluster{}
+	if flags.Output == output.JSON || flags.Output == output.YAML {
+		for _, name := range names {
+			c, err := status.FromDocker(name)
+			if err != nil {
+				return err
+			}
+			item := cluster{Name: name, Nodes: []string{}}
+			for _, n := range c.AllNodes() {
+				item.Nodes = append(item.Nodes, n.Name())
+			}
+			items = append(items, item)
+		}
 	}
-	return nil
+
+	return output.Print(flags.Output, items, names, func() error {
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	})
 }
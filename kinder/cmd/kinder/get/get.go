@@ -21,6 +21,8 @@ import (
 
 	"k8s.io/kubeadm/kinder/cmd/kinder/get/artifacts"
 	"k8s.io/kubeadm/kinder/cmd/kinder/get/clusters"
+	"k8s.io/kubeadm/kinder/cmd/kinder/get/images"
+	"k8s.io/kubeadm/kinder/cmd/kinder/get/kubeconfig"
 	"k8s.io/kubeadm/kinder/cmd/kinder/get/kubeconfigpath"
 	"k8s.io/kubeadm/kinder/cmd/kinder/get/nodes"
 )
@@ -30,8 +32,9 @@ func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "get",
-		Short: "Gets one of [clusters, nodes, kubeconfig-path, artifacts]",
-		Long:  "Gets one of [clusters, nodes, kubeconfig-path, artifacts]",
+		Short: "Gets one of [clusters, nodes, images, kubeconfig, kubeconfig-path, artifacts]",
+		Long: "Gets one of [clusters, nodes, images, kubeconfig, kubeconfig-path, artifacts];\n" +
+			"clusters, nodes and images support -o json|yaml|name for automation",
 	}
 
 	cmd.AddCommand(clusters.NewCommand())
@@ -42,5 +45,7 @@ func NewCommand() *cobra.Command {
 
 	// add kinder only commands
 	cmd.AddCommand(artifacts.NewCommand())
+	cmd.AddCommand(images.NewCommand())
+	cmd.AddCommand(kubeconfig.NewCommand())
 	return cmd
 }
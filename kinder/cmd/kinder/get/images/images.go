@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package images implements the `images` command
+package images
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/cmd/kinder/get/output"
+	"k8s.io/kubeadm/kinder/pkg/build/alter"
+)
+
+type flagpole struct {
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for getting the list of node images
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "images",
+		Short: "Lists the node images built by kinder",
+		Long: "Lists the node images built by kinder on the host, with their base image, creation time and size;\n" +
+			"dangling images, e.g. left over by rebuilding an image with the same name, are listed by ID",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags)
+		},
+	}
+	output.AddFlag(cmd, &flags.Output)
+	return cmd
+}
+
+func runE(flags *flagpole) error {
+	if err := output.Validate(flags.Output); err != nil {
+		return err
+	}
+	images, err := alter.ListNodeImages()
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for _, i := range images {
+		if i.Name != "" {
+			names = append(names, i.Name)
+		} else {
+			names = append(names, i.ID)
+		}
+	}
+
+	return output.Print(flags.Output, images, names, func() error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "IMAGE\tID\tBASE IMAGE\tCREATED\tSIZE")
+		for n, i := range images {
+			fmt.Fprintf(w, "%s\t%.19s\t%s\t%s\t%.1f MB\n", names[n], i.ID, i.BaseImage, i.Created.Local().Format("2006-01-02 15:04"), float64(i.SizeBytes)/(1<<20))
+		}
+		return w.Flush()
+	})
+}
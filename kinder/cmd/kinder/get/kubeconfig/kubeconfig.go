@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name       string
+	Merge      bool
+	Kubeconfig string
+	Context    string
+}
+
+// NewCommand returns a new cobra.Command for getting the kubeconfig of a cluster
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "kubeconfig",
+		Short: "Prints the admin kubeconfig of the kind cluster by --name, or merges it into a kubeconfig file",
+		Long: "Prints the admin kubeconfig of the kind cluster by --name, with the API server endpoint reachable from the host;\n" +
+			"with --merge, the kubeconfig is merged as a context into the default kubeconfig file, e.g. ~/.kube/config,\n" +
+			"and the context is set as current context",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Name, "name",
+		constants.DefaultClusterName,
+		"cluster name",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Merge, "merge",
+		false,
+		"merge the kubeconfig into the kubeconfig file instead of printing it",
+	)
+	cmd.Flags().StringVar(
+		&flags.Kubeconfig, "kubeconfig",
+		"",
+		"kubeconfig file to merge into; defaults to the first file in KUBECONFIG, or ~/.kube/config",
+	)
+	cmd.Flags().StringVar(
+		&flags.Context, "context",
+		"",
+		"name of the context, of the cluster and of the user in the kubeconfig; defaults to kinder-<cluster name>",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole) error {
+	o, err := manager.NewClusterManager(flags.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", flags.Name)
+	}
+
+	context := flags.Context
+	if context == "" {
+		context = actions.KubeConfigContextName(flags.Name)
+	}
+	config, err := actions.KubeConfig(o.Cluster, context)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the kubeconfig of %s", flags.Name)
+	}
+
+	if !flags.Merge {
+		data, err := clientcmd.Write(*config)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	path := flags.Kubeconfig
+	if path == "" {
+		path = clientcmd.NewDefaultPathOptions().GetDefaultFilename()
+	}
+	if err := actions.MergeKubeConfig(config, path); err != nil {
+		return err
+	}
+	fmt.Printf("merged the kubeconfig of %s into %s as context %q, that is now the current context\n", flags.Name, path, context)
+	return nil
+}
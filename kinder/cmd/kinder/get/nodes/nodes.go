@@ -1,5 +1,5 @@
 /*
-Copyright 2019 The Kubernetes Authors.
+Copyright 2026 The Kubernetes Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,15 +18,29 @@ package nodes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/kubeadm/kinder/cmd/kinder/get/output"
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
 	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/cri/host"
 )
 
 type flagpole struct {
-	Name string
+	Name   string
+	Output string
+}
+
+// node is the schema of the structured output
+type node struct {
+	Name    string `json:"name"`
+	Cluster string `json:"cluster"`
+	Role    string `json:"role"`
+	Image   string `json:"image"`
+	// State is the state of the node container, e.g. running or exited
+	State string `json:"state"`
 }
 
 // NewCommand returns a new cobra.Command for getting the list of nodes in a cluster
@@ -37,7 +51,7 @@ func NewCommand() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Use:   "nodes",
 		Short: "Lists existing nodes in kind clusters by their name",
-		Long:  "Lists existing nodes in kind clusters by their name",
+		Long:  "Lists existing nodes in kind clusters by their name, or with their role, image and state with -o json|yaml",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runE(flags, cmd, args)
 		},
@@ -47,17 +61,43 @@ func NewCommand() *cobra.Command {
 		&flags.Name,
 		"name", constants.DefaultClusterName, "cluster name",
 	)
+	output.AddFlag(cmd, &flags.Output)
 	return cmd
 }
 
 func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
+	if err := output.Validate(flags.Output); err != nil {
+		return err
+	}
 	cluster, err := status.FromDocker(flags.Name)
 	if err != nil {
 		return err
 	}
 
-	for _, node := range cluster.AllNodes() {
-		fmt.Println(node.Name())
+	names := []string{}
+	items := []node{}
+	for _, n := range cluster.AllNodes() {
+		names = append(names, n.Name())
+		if flags.Output != output.JSON && flags.Output != output.YAML {
+			continue
+		}
+		item := node{Name: n.Name(), Cluster: flags.Name, Role: n.Role()}
+		lines, err := host.InspectContainer(n.Name(), "{{.Config.Image}} {{.State.Status}}")
+		if err != nil {
+			return err
+		}
+		if len(lines) == 1 {
+			if fields := strings.Fields(lines[0]); len(fields) == 2 {
+				item.Image, item.State = fields[0], fields[1]
+			}
+		}
+		items = append(items, item)
 	}
-	return nil
+
+	return output.Print(flags.Output, items, names, func() error {
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	})
 }
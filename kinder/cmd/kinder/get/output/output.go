@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package output implements the structured output of the get commands
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Text is the human readable output, that is the default
+	Text = "text"
+	// JSON prints the items as a JSON list
+	JSON = "json"
+	// YAML prints the items as a YAML list
+	YAML = "yaml"
+	// Name prints the name of each item, one per line
+	Name = "name"
+)
+
+// AddFlag adds the -o/--output flag to a get command
+func AddFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVarP(
+		format, "output", "o",
+		Text,
+		"output format; use one of [text, json, yaml, name]",
+	)
+}
+
+// Validate returns an error if the output format is not supported; it should be called before collecting
+// the items, so invalid flags fail fast
+func Validate(format string) error {
+	switch format {
+	case Text, JSON, YAML, Name:
+		return nil
+	}
+	return errors.Errorf("invalid output format %q; use one of [text, json, yaml, name]", format)
+}
+
+// Print prints the items, that should be a slice of structs with json tags, in the given format;
+// names are printed with the name format, and text is called for the text format
+func Print(format string, items any, names []string, text func() error) error {
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the output")
+		}
+		fmt.Println(string(data))
+	case YAML:
+		data, err := yaml.Marshal(items)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the output")
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	case Name:
+		for _, n := range names {
+			fmt.Println(n)
+		}
+	case Text:
+		return text()
+	default:
+		return Validate(format)
+	}
+	return nil
+}
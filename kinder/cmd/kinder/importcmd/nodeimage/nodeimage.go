@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodeimage implements the `node-image` command for importing node images
+package nodeimage
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/build/alter"
+)
+
+type flagpole struct {
+	Input string
+	Tag   string
+}
+
+// NewCommand returns a new cobra.Command for importing a node image from an archive
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "node-image",
+		Short: "Imports a node image from an archive created by kinder export node-image",
+		Long: "Imports a node image from an archive created by kinder export node-image, after checking the checksum\n" +
+			"of the image in the archive, and prints the manifest of its bits",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.Input == "" {
+				return errors.New("the --input flag is required")
+			}
+			return alter.ImportImage(os.Stdout, flags.Input, flags.Tag)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Input,
+		"input", "",
+		"path of the archive",
+	)
+	cmd.Flags().StringVar(
+		&flags.Tag,
+		"tag", "",
+		"additional name:tag for the imported image",
+	)
+	return cmd
+}
@@ -23,14 +23,29 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/kubeadm/kinder/cmd/kinder/alter"
 	"k8s.io/kubeadm/kinder/cmd/kinder/build"
+	"k8s.io/kubeadm/kinder/cmd/kinder/cache"
+	"k8s.io/kubeadm/kinder/cmd/kinder/completion"
 	"k8s.io/kubeadm/kinder/cmd/kinder/cp"
 	"k8s.io/kubeadm/kinder/cmd/kinder/create"
+	deletecluster "k8s.io/kubeadm/kinder/cmd/kinder/delete/cluster"
 	"k8s.io/kubeadm/kinder/cmd/kinder/do"
+	"k8s.io/kubeadm/kinder/cmd/kinder/doctor"
 	"k8s.io/kubeadm/kinder/cmd/kinder/exec"
+	exportlogs "k8s.io/kubeadm/kinder/cmd/kinder/export/logs"
+	exportnodeimage "k8s.io/kubeadm/kinder/cmd/kinder/export/nodeimage"
 	"k8s.io/kubeadm/kinder/cmd/kinder/get"
+	"k8s.io/kubeadm/kinder/cmd/kinder/importcmd"
+	"k8s.io/kubeadm/kinder/cmd/kinder/portforward"
+	"k8s.io/kubeadm/kinder/cmd/kinder/prune"
+	"k8s.io/kubeadm/kinder/cmd/kinder/shell"
+	"k8s.io/kubeadm/kinder/cmd/kinder/skew"
+	"k8s.io/kubeadm/kinder/cmd/kinder/status"
 	"k8s.io/kubeadm/kinder/cmd/kinder/test"
+	"k8s.io/kubeadm/kinder/cmd/kinder/top"
 	"k8s.io/kubeadm/kinder/cmd/kinder/version"
+	"k8s.io/kubeadm/kinder/cmd/kinder/wait"
 	"k8s.io/kubeadm/kinder/pkg/constants"
 	kindcmd "sigs.k8s.io/kind/pkg/cmd"
 	kinddelete "sigs.k8s.io/kind/pkg/cmd/kind/delete"
@@ -64,6 +79,8 @@ func NewCommand() *cobra.Command {
 		},
 		SilenceUsage: true,
 		Version:      constants.KinderVersion,
+		// replaced by kinder completion, that adds the completion of cluster, node and action names
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
 	}
 	cmd.PersistentFlags().StringVar(
 		&flags.LogLevel,
@@ -75,22 +92,53 @@ func NewCommand() *cobra.Command {
 	logger := kindcmd.NewLogger()
 	ioStreams := kindcmd.StandardIOStreams()
 
-	// add kind top level subcommands re-used without changes
-	cmd.AddCommand(kinddelete.NewCommand(logger, ioStreams))
-	cmd.AddCommand(kindexport.NewCommand(logger, ioStreams))
+	// add kind commands extended in kinder
+	deleteCmd := kinddelete.NewCommand(logger, ioStreams)
+	// kind delete cluster is replaced by a command that can delete many clusters selected by label or by age
+	for _, c := range deleteCmd.Commands() {
+		if c.Name() == "cluster" {
+			deleteCmd.RemoveCommand(c)
+		}
+	}
+	deleteCmd.AddCommand(deletecluster.NewCommand())
+	cmd.AddCommand(deleteCmd)
+
+	exportCmd := kindexport.NewCommand(logger, ioStreams)
+	// kind export logs is replaced by a bundle with a layout designed for kubeadm CI triage
+	for _, c := range exportCmd.Commands() {
+		if c.Name() == "logs" {
+			exportCmd.RemoveCommand(c)
+		}
+	}
+	exportCmd.AddCommand(exportlogs.NewCommand())
+	exportCmd.AddCommand(exportnodeimage.NewCommand())
+	cmd.AddCommand(exportCmd)
 
 	// add kind commands customized in kind
+	cmd.AddCommand(alter.NewCommand())
 	cmd.AddCommand(build.NewCommand())
 	cmd.AddCommand(create.NewCommand())
 	cmd.AddCommand(version.NewCommand())
 	cmd.AddCommand(get.NewCommand())
 
 	// add kinder only commands
+	cmd.AddCommand(cache.NewCommand())
+	cmd.AddCommand(completion.NewCommand())
 	cmd.AddCommand(cp.NewCommand())
 	cmd.AddCommand(do.NewCommand())
+	cmd.AddCommand(doctor.NewCommand())
 	cmd.AddCommand(exec.NewCommand())
+	cmd.AddCommand(importcmd.NewCommand())
+	cmd.AddCommand(portforward.NewCommand())
+	cmd.AddCommand(prune.NewCommand())
+	cmd.AddCommand(shell.NewCommand())
+	cmd.AddCommand(skew.NewCommand())
+	cmd.AddCommand(status.NewCommand())
 	cmd.AddCommand(test.NewCommand())
+	cmd.AddCommand(top.NewCommand())
+	cmd.AddCommand(wait.NewCommand())
 
+	completion.Register(cmd)
 	return cmd
 }
 
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package portforward implements the `port-forward` command
+package portforward
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name    string
+	Address string
+}
+
+// NewCommand returns a new cobra.Command for forwarding ports from the host to a node
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args: cobra.MinimumNArgs(2),
+		Use: "port-forward [flags] NODE_NAME|NODE_SELECTOR [HOST_PORT:]CONTAINER_PORT...\n\n" +
+			"Args:\n" +
+			"  NODE_NAME is the container name without the cluster name prefix\n" +
+			"  NODE_SELECTOR is one of the selectors of kinder exec, matching exactly one node\n" +
+			"  HOST_PORT is the port on the host, the same as CONTAINER_PORT if not set, a free port if empty",
+		Short: "Forwards one or more ports on the host to a node in the local Kubernetes cluster",
+		Long: "Forwards one or more ports on the host to a node, until interrupted; also the ports listening only on the\n" +
+			"loopback interface of the node can be reached, e.g. the etcd metrics on port 2381.\n" +
+			"Connections are proxied with socat via docker exec, so socat must be installed in the node",
+		Example: "  # reach the etcd metrics on the bootstrap control-plane node at http://127.0.0.1:2381/metrics\n" +
+			"  kinder port-forward @cp1 2381\n\n" +
+			"  # reach the kubelet API of the first worker node on a free port\n" +
+			"  kinder port-forward worker-1 :10250",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags, args)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Name,
+		"name", constants.DefaultClusterName,
+		"cluster name",
+	)
+	cmd.Flags().StringVar(
+		&flags.Address,
+		"address", "127.0.0.1",
+		"address to listen on the host",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, args []string) error {
+	// get a kinder cluster manager
+	o, err := manager.NewClusterManager(flags.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", flags.Name)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := o.PortForward(ctx, args[0], flags.Address, args[1:]); err != nil {
+		return errors.Wrap(err, "failed to forward ports")
+	}
+	return nil
+}
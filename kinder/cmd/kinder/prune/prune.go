@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prune implements the `prune` command
+package prune
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+)
+
+type flagpole struct {
+	OlderThan time.Duration
+	DryRun    bool
+}
+
+// NewCommand returns a new cobra.Command for removing the resources leaked by interrupted runs
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "prune",
+		Short: "Removes the docker resources leaked by interrupted kinder runs",
+		Long: "Removes the docker resources leaked by interrupted kinder runs, identified by the kind and kinder labels:\n" +
+			"the node containers of clusters without a control-plane node or never started, the temporary containers\n" +
+			"used e.g. for altering images, the volumes no longer used, and the untagged node images.\n" +
+			"Only the resources created more than --older-than ago are removed, so runs in progress are not affected",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags)
+		},
+	}
+	cmd.Flags().DurationVar(
+		&flags.OlderThan,
+		"older-than", time.Hour,
+		"remove only the resources created more than the given duration ago",
+	)
+	cmd.Flags().BoolVar(
+		&flags.DryRun,
+		"dry-run", false,
+		"only print the resources that would be removed",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole) error {
+	if flags.OlderThan < 0 {
+		return errors.New("flag --older-than should not be a negative duration")
+	}
+
+	resources, err := manager.FindLeakedResources(flags.OlderThan)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the leaked resources")
+	}
+	if len(resources) == 0 {
+		fmt.Println("No leaked resources found")
+		return nil
+	}
+	manager.PrintLeakedResources(os.Stdout, resources)
+	if flags.DryRun {
+		return nil
+	}
+
+	fmt.Println()
+	if err := manager.RemoveLeakedResources(resources); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %d resources\n", len(resources))
+	return nil
+}
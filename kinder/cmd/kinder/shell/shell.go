@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shell implements the `shell` command
+package shell
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Nodes string
+	Mode  string
+}
+
+// NewCommand returns a new cobra.Command for opening an interactive session on many nodes
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "shell [cluster name]",
+		Short: "Opens an interactive session on the nodes of a kind cluster",
+		Long: "Opens an interactive session on the nodes of a kind cluster, by default kind, selected with --nodes; see\n" +
+			"kinder exec for the node selectors. With --mode:\n" +
+			"  broadcast   each command typed is executed on all the nodes at the same time, with the output prefixed by the\n" +
+			"              node name; each node keeps its own bash, so e.g. cd and variables are preserved across commands\n" +
+			"  panes       a tmux window with a pane for each node is opened, with the input sent to all the panes\n" +
+			"  sequential  a shell is opened on each node, one after the other",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := constants.DefaultClusterName
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runE(flags, name)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Nodes,
+		"nodes", "@all",
+		"the nodes of the session, as a comma separated list of node names and node selectors",
+	)
+	cmd.Flags().StringVar(
+		&flags.Mode,
+		"mode", manager.ShellBroadcastMode,
+		fmt.Sprintf("the mode of the session; use one of [%s]", strings.Join(manager.ShellModes, ", ")),
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, name string) error {
+	// get a kinder cluster manager
+	o, err := manager.NewClusterManager(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", name)
+	}
+
+	return o.Shell(flags.Nodes, flags.Mode)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package skew implements the `skew` command
+package skew
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for reporting the version skew of a cluster
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "skew [cluster name]",
+		Short: "Reports the versions of the components of a kind cluster and their skew",
+		Long: "Reports the versions of kubeadm, kubelet, kube-apiserver, kube-controller-manager, kube-scheduler and etcd on\n" +
+			"each node, and the version of CoreDNS, of a kind cluster, by default kind; the command fails if the versions\n" +
+			"are not within the Kubernetes and kubeadm version skew policies, so it can be used as a verification in workflows",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := constants.DefaultClusterName
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runE(flags, name)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Output, "output", "o",
+		"text",
+		"output format; use one of [text, json]",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, name string) error {
+	if flags.Output != "text" && flags.Output != "json" {
+		return errors.Errorf("invalid output format %q; use one of [text, json]", flags.Output)
+	}
+
+	known, err := status.IsKnown(name)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return errors.Errorf("a cluster with the name %q does not exists", name)
+	}
+	c, err := status.FromDocker(name)
+	if err != nil {
+		return err
+	}
+
+	r := actions.GetSkewReport(c)
+	if flags.Output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	} else {
+		r.Print(os.Stdout)
+	}
+
+	if len(r.Violations) > 0 {
+		return errors.Errorf("the versions of cluster %s are not within the supported skew", name)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status implements the `status` command
+package status
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for getting the status of a cluster
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "status [cluster name]",
+		Short: "Prints the status of a kind cluster",
+		Long: "Prints the status of the node containers, the readiness of the Kubernetes nodes, the versions of the components,\n" +
+			"the health of the etcd members and the expiration of the certificates of a kind cluster, by default kind;\n" +
+			"the command fails if the cluster is not healthy, so it can be used as an assertion in workflows",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := constants.DefaultClusterName
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runE(flags, name)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Output, "output", "o",
+		"text",
+		"output format; use one of [text, json]",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, name string) error {
+	if flags.Output != "text" && flags.Output != "json" {
+		return errors.Errorf("invalid output format %q; use one of [text, json]", flags.Output)
+	}
+
+	known, err := status.IsKnown(name)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return errors.Errorf("a cluster with the name %q does not exists", name)
+	}
+	// the status is read also for clusters that can't be managed, e.g. with stopped nodes
+	c, err := status.FromDocker(name)
+	if err != nil {
+		return err
+	}
+
+	s := actions.GetClusterStatus(c)
+	if flags.Output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+	} else {
+		s.Print(os.Stdout)
+	}
+
+	if !s.Healthy {
+		return errors.Errorf("cluster %s is not healthy", name)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/test/workflow"
+)
+
+type flagpole struct {
+	Dir      string
+	Workflow string
+	Limit    int
+}
+
+// NewCommand returns a new cobra.Command for listing the recorded workflow runs
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "history",
+		Short: "Lists the test workflow runs recorded on this host",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return workflow.PrintHistory(os.Stdout, flags.Dir, flags.Workflow, flags.Limit)
+		},
+	}
+
+	dir, _ := workflow.DefaultHistoryDir()
+	cmd.Flags().StringVar(
+		&flags.Dir,
+		"history-dir", dir,
+		"folder where workflow runs are recorded",
+	)
+	cmd.Flags().StringVar(
+		&flags.Workflow,
+		"workflow", "",
+		"list only the runs of the workflow with the given name, e.g. regular-1.33",
+	)
+	cmd.Flags().IntVar(
+		&flags.Limit,
+		"limit", 20,
+		"maximum number of runs to list, starting from the newest; 0 lists all the runs",
+	)
+	return cmd
+}
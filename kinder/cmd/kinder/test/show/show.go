@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/test/workflow"
+)
+
+type flagpole struct {
+	Dir string
+}
+
+// NewCommand returns a new cobra.Command for showing a recorded workflow run
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args: cobra.ExactArgs(1),
+		Use: "show RUN\n\n" +
+			"Args:\n" +
+			"  RUN is the id of a workflow run, as listed by kinder test history, or latest for the newest run\n",
+		Short: "Shows a test workflow run recorded on this host, comparing task timings and failures with the other runs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return workflow.ShowRun(os.Stdout, flags.Dir, args[0])
+		},
+	}
+
+	dir, _ := workflow.DefaultHistoryDir()
+	cmd.Flags().StringVar(
+		&flags.Dir,
+		"history-dir", dir,
+		"folder where workflow runs are recorded",
+	)
+	return cmd
+}
@@ -21,6 +21,8 @@ import (
 
 	"k8s.io/kubeadm/kinder/cmd/kinder/test/e2e"
 	"k8s.io/kubeadm/kinder/cmd/kinder/test/e2ekubeadm"
+	"k8s.io/kubeadm/kinder/cmd/kinder/test/history"
+	"k8s.io/kubeadm/kinder/cmd/kinder/test/show"
 	"k8s.io/kubeadm/kinder/cmd/kinder/test/workflow"
 )
 
@@ -35,5 +37,7 @@ func NewCommand() *cobra.Command {
 	cmd.AddCommand(e2e.NewCommand())
 	cmd.AddCommand(e2ekubeadm.NewCommand())
 	cmd.AddCommand(workflow.NewCommand())
+	cmd.AddCommand(history.NewCommand())
+	cmd.AddCommand(show.NewCommand())
 	return cmd
 }
@@ -17,8 +17,13 @@ limitations under the License.
 package workflow
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"k8s.io/kubeadm/kinder/pkg/test/workflow"
@@ -26,20 +31,36 @@ import (
 
 type flagpole struct {
 	DryRun      bool
+	Plan        bool
 	Verbose     bool
 	ExitOnError bool
+	ResumeFrom  string
+	Validate    bool
+	Step        bool
+	Quiet       bool
+	NoColor     bool
+	Timeout     time.Duration
+	Multiplier  float64
+	Concurrency int
+	Webhook     string
+	NoPreflight bool
+	HistoryDir  string
+	NoHistory   bool
 }
 
 // NewCommand returns a new cobra.Command for e2e-kubeadm
 func NewCommand() *cobra.Command {
 	flags := &flagpole{}
 	cmd := &cobra.Command{
-		Use: "workflow [flags] CONFIG [ARTIFACTS]\n\n" +
+		Use: "workflow [flags] CONFIG... [ARTIFACTS]\n\n" +
 			"Args:\n" +
-			"  CONFIG is the path of a workflow config file\n" +
-			"  ARTIFACTS is the path to the directory where to store ARTIFACTS\n",
+			"  CONFIG is the path of a workflow config file, an http(s) URL like https://host/workflow.yaml[#sha256:DIGEST]\n" +
+			"  or an OCI artifact reference like oci://registry/repository[:tag][@sha256:DIGEST][#file];\n" +
+			"  many CONFIG, also as globs like ci/workflows/regular-*.yaml, can be executed in one invocation\n" +
+			"  ARTIFACTS is the path to the directory where to store ARTIFACTS; when running many workflows,\n" +
+			"  each workflow gets its own subfolder\n",
 		Short: "Runs test workflow",
-		Args:  cobra.RangeArgs(1, 2),
+		Args:  cobra.MinimumNArgs(1),
 		// TODO: add a long description
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runE(flags, cmd, args)
@@ -51,32 +72,198 @@ func NewCommand() *cobra.Command {
 		"dry-run", false,
 		"only prints workflow commands, without executing them",
 	)
+	cmd.Flags().BoolVar(
+		&flags.Plan,
+		"plan", false,
+		"only prints the workflow plan, with vars, commands, timeouts and target clusters of each task, without executing it",
+	)
 	cmd.Flags().BoolVar(
 		&flags.Verbose,
 		"verbose", false,
-		"redirect command output to stdout",
+		"redirect command output to stdout, prefixing each line with the task name and the time elapsed since the task started",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Quiet,
+		"quiet", false,
+		"only prints failed tasks and the final summary",
+	)
+	cmd.Flags().BoolVar(
+		&flags.NoColor,
+		"no-color", os.Getenv("NO_COLOR") != "",
+		"disable colors in the output; colors are disabled also when the NO_COLOR environment variable is set",
 	)
 	cmd.Flags().BoolVar(
 		&flags.ExitOnError,
 		"exit-on-task-error", false,
 		"exit after first task failed",
 	)
+	cmd.Flags().StringVar(
+		&flags.ResumeFrom,
+		"resume-from", "",
+		"resume the workflow run recorded in ARTIFACTS from the given task, skipping the tasks before it",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Timeout,
+		"timeout", 0,
+		"maximum duration of the whole workflow; once reached, the running task is stopped and only forced tasks, e.g. cleanup tasks, are executed",
+	)
+	cmd.Flags().Float64Var(
+		&flags.Multiplier,
+		"task-timeout-multiplier", 1,
+		"multiplies the timeout of all the tasks, e.g. for running the workflow in slow environments",
+	)
+	cmd.Flags().IntVar(
+		&flags.Concurrency,
+		"concurrency", 1,
+		"maximum number of workflows executed at the same time, when running many workflows",
+	)
+	cmd.Flags().StringVar(
+		&flags.Webhook,
+		"webhook", "",
+		"URL to POST JSON events to when the workflow and each task start and complete, e.g. for dashboards or chat notifications",
+	)
+	cmd.Flags().BoolVar(
+		&flags.NoPreflight,
+		"skip-preflight", false,
+		"skip the checks for commands, clusters, images and the ARTIFACTS folder executed before running any task",
+	)
+	historyDir, _ := workflow.DefaultHistoryDir()
+	cmd.Flags().StringVar(
+		&flags.HistoryDir,
+		"history-dir", historyDir,
+		"folder where workflow runs are recorded, for kinder test history and kinder test show",
+	)
+	cmd.Flags().BoolVar(
+		&flags.NoHistory,
+		"no-history", false,
+		"do not record the workflow run",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Step,
+		"step", false,
+		"pause before each task, showing the resolved command and asking whether to run it, skip it or abort the workflow",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Validate,
+		"validate-only", false,
+		"only validates the workflow, checking unknown fields, undefined vars, duplicated task names and invalid timeouts, without executing it",
+	)
 	return cmd
 }
 
 func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
 
-	// retrieve config and artifacts from arguments
-	config := args[0]
-	artifacts := ""
-	if len(args) > 1 {
-		artifacts = args[1]
+	if flags.Quiet && (flags.Verbose || flags.Step) {
+		return errors.New("--quiet can't be combined with --verbose or --step")
 	}
 
-	w, err := workflow.NewWorkflow(config)
+	// retrieve configs and artifacts from arguments
+	configs, artifacts, err := parseArgs(args)
 	if err != nil {
 		return err
 	}
+	if len(configs) > 1 {
+		if flags.Step || flags.ResumeFrom != "" {
+			return errors.New("--step and --resume-from can't be used when running many workflows")
+		}
+		if flags.Verbose && flags.Concurrency > 1 {
+			return errors.New("--verbose can't be used when running many workflows at the same time")
+		}
+	}
 
-	return w.Run(os.Stdout, flags.DryRun, flags.Verbose, flags.ExitOnError, artifacts)
+	var workflows []*workflow.Workflow
+	for _, config := range configs {
+		w, err := workflow.NewWorkflow(config)
+		if err != nil {
+			return err
+		}
+
+		if flags.Multiplier != 1 {
+			if err := w.ScaleTaskTimeouts(flags.Multiplier); err != nil {
+				return err
+			}
+		}
+
+		workflows = append(workflows, w)
+	}
+
+	if flags.Validate {
+		var invalid []string
+		for i, w := range workflows {
+			if err := w.Validate(); err != nil {
+				fmt.Printf("%s: %v\n", configs[i], err)
+				invalid = append(invalid, configs[i])
+				continue
+			}
+			fmt.Printf("%s is valid\n", configs[i])
+		}
+		if len(invalid) > 0 {
+			return errors.Errorf("invalid workflows %s", strings.Join(invalid, ", "))
+		}
+		return nil
+	}
+
+	if flags.Plan {
+		for i, w := range workflows {
+			if len(workflows) > 1 {
+				fmt.Printf("### workflow %s\n\n", configs[i])
+			}
+			if err := w.Plan(os.Stdout, artifacts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	options := []workflow.RunOption{
+		workflow.Quiet(flags.Quiet),
+		workflow.NoColor(flags.NoColor),
+		workflow.Timeout(flags.Timeout),
+		workflow.Webhook(flags.Webhook),
+		workflow.SkipPreflight(flags.NoPreflight),
+	}
+	if !flags.NoHistory {
+		options = append(options, workflow.History(flags.HistoryDir))
+	}
+	if len(workflows) > 1 {
+		return workflow.RunAll(os.Stdout, workflows, flags.Concurrency, flags.DryRun, flags.Verbose, flags.ExitOnError, artifacts, options...)
+	}
+	options = append(options, workflow.Step(flags.Step), workflow.ResumeFrom(flags.ResumeFrom))
+	return workflows[0].Run(os.Stdout, flags.DryRun, flags.Verbose, flags.ExitOnError, artifacts, options...)
+}
+
+// parseArgs returns the workflow configs and the artifacts folder, if any; the last argument is considered
+// the artifacts folder only if it does not look like a workflow config. Globs are expanded
+func parseArgs(args []string) (configs []string, artifacts string, err error) {
+	if len(args) > 1 && !isConfig(args[len(args)-1]) {
+		args, artifacts = args[:len(args)-1], args[len(args)-1]
+	}
+
+	for _, a := range args {
+		if isRemote(a) || !strings.ContainsAny(a, "*?[") {
+			configs = append(configs, a)
+			continue
+		}
+		matches, err := filepath.Glob(a)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "invalid pattern %s", a)
+		}
+		if len(matches) == 0 {
+			return nil, "", errors.Errorf("no workflow files match %s", a)
+		}
+		configs = append(configs, matches...)
+	}
+	return configs, artifacts, nil
+}
+
+func isRemote(arg string) bool {
+	return strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "oci://")
+}
+
+func isConfig(arg string) bool {
+	if isRemote(arg) || strings.ContainsAny(arg, "*?[") {
+		return true
+	}
+	ext := filepath.Ext(strings.SplitN(arg, "#", 2)[0])
+	return ext == ".yaml" || ext == ".yml"
 }
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package top implements the `top` command
+package top
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name     string
+	Interval time.Duration
+	NoStream bool
+}
+
+// NewCommand returns a new cobra.Command for showing the resource usage of the node containers
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "top",
+		Short: "Shows the CPU, memory and disk usage of the node containers of the kind cluster by --name",
+		Long: "Shows the CPU, memory and disk usage of the node containers of the kind cluster by --name, refreshed every --interval,\n" +
+			"with the totals and the capacity of the host; a warning is printed when the node containers use most of the CPUs\n" +
+			"or of the memory of the host, that is often the cause of flakes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Name, "name",
+		constants.DefaultClusterName,
+		"cluster name",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Interval, "interval",
+		5*time.Second,
+		"interval between refreshes",
+	)
+	cmd.Flags().BoolVar(
+		&flags.NoStream, "no-stream",
+		false,
+		"print the usage once instead of refreshing it",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole) error {
+	known, err := status.IsKnown(flags.Name)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return errors.Errorf("a cluster with the name %q does not exists", flags.Name)
+	}
+	c, err := status.FromDocker(flags.Name)
+	if err != nil {
+		return err
+	}
+
+	host, err := actions.GetHostCapacity()
+	if err != nil {
+		log.Warnf("the usage is shown without the capacity of the host: %v", err)
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for {
+		usage, err := actions.GetNodesUsage(c)
+		if err != nil {
+			return err
+		}
+		if !flags.NoStream {
+			// clears the screen, like top
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("kinder top - cluster %s - %s\n\n", flags.Name, time.Now().Format("15:04:05"))
+		}
+		actions.PrintNodesUsage(os.Stdout, usage, host)
+		if flags.NoStream {
+			return nil
+		}
+
+		select {
+		case <-interrupt:
+			return nil
+		case <-time.After(flags.Interval):
+		}
+	}
+}
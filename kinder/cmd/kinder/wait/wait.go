@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wait implements the `wait` command
+package wait
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+type flagpole struct {
+	Name      string
+	For       []string
+	Namespace string
+	Timeout   time.Duration
+}
+
+// NewCommand returns a new cobra.Command for waiting for cluster conditions
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Use:   "wait --for=CONDITION [RESOURCE...]",
+		Short: "Waits for conditions of the kind cluster by --name to hold",
+		Long: "Waits for conditions of the kind cluster by --name to hold, or fails after --timeout. CONDITION can be:\n" +
+			"  nodes-ready                       all the Kubernetes nodes are Ready\n" +
+			"  pods-ready                        all the pods in all the namespaces are Ready, or completed\n" +
+			"  condition=<type>[=<status>]       the RESOURCEs, e.g. deployment/coredns, have the condition with the given status,\n" +
+			"                                    by default True; RESOURCEs that don't exist yet are waited for\n" +
+			"--for can be repeated, and the command waits for all the conditions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(flags, args)
+		},
+	}
+	cmd.Flags().StringVar(
+		&flags.Name, "name",
+		constants.DefaultClusterName,
+		"cluster name",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.For, "for",
+		nil,
+		"condition to wait for; use one of [nodes-ready, pods-ready, condition=<type>[=<status>]]",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Namespace, "namespace", "n",
+		"",
+		"namespace of the RESOURCEs",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Timeout, "timeout",
+		5*time.Minute,
+		"how long to wait for the conditions",
+	)
+	return cmd
+}
+
+func runE(flags *flagpole, args []string) error {
+	o, err := manager.NewClusterManager(flags.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a kinder cluster manager for %s", flags.Name)
+	}
+	return actions.WaitForConditions(o.Cluster, flags.For, args, flags.Namespace, flags.Timeout)
+}
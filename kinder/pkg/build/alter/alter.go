@@ -21,8 +21,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	goruntime "runtime"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -31,10 +34,12 @@ import (
 	"k8s.io/kubeadm/kinder/pkg/build/bits"
 	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions/assets"
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
 	"k8s.io/kubeadm/kinder/pkg/cri/host"
 	"k8s.io/kubeadm/kinder/pkg/cri/nodes"
 	"k8s.io/kubeadm/kinder/pkg/exec"
 	"k8s.io/kubeadm/kinder/pkg/extract"
+	"k8s.io/kubeadm/kinder/pkg/kubeadm"
 	kindfs "sigs.k8s.io/kind/pkg/fs"
 )
 
@@ -44,6 +49,14 @@ const DefaultBaseImage = "kindest/node:latest"
 // DefaultImage is the default name:tag for the alter image
 const DefaultImage = DefaultBaseImage
 
+// BaseImageLabel is the label of the altered images with the base image they are built from,
+// e.g. for telling apart images built from different base images
+const BaseImageLabel = "io.x-k8s.kinder.base-image"
+
+// baseImageRequirements are the files that must exist in a base image, because
+// the altered image is started with the kind entrypoint and systemd
+var baseImageRequirements = []string{"/usr/local/bin/entrypoint", "/sbin/init"}
+
 // Context is used to alter the kind node image, and contains
 // alter configuration
 type Context struct {
@@ -52,11 +65,42 @@ type Context struct {
 	initArtifactsSrc        string
 	imageSrcs               []string
 	imageNamePrefix         string
-	upgradeArtifactsSrc     string
+	upgradeArtifactsSrcs    []string
 	kubeadmSrc              string
 	kubeletSrc              string
+	packagesVersion         string
+	containerdSrc           string
+	runcSrc                 string
+	crioSrc                 string
 	prePullAdditionalImages bool
+	prePullAddonImages      bool
+	imagesFromFile          string
+	etcdImage               string
+	upgradeEtcdImage        string
+	sandboxImage            string
 	paths                   []string
+	systemdDir              string
+	kubeletConfigs          []string
+	optimize                bool
+	sbomPath                string
+	sbomFormat              string
+	reproducible            bool
+	sourceDateEpoch         int64
+	smokeTest               bool
+	proxy                   extract.ProxyConfig
+	caCertificates          []string
+	buildCacheFrom          string
+	buildCacheTo            string
+	arch                    string
+	extractOptions          []extract.Option
+	customInstallers        []bits.Installer
+
+	// pulledImages are the images pulled into the image, recorded in the bits manifest
+	pulledImages []string
+
+	// cacheFrom and cacheTo are the build caches parsed from buildCacheFrom and buildCacheTo
+	cacheFrom *buildCache
+	cacheTo   *buildCache
 }
 
 // Option is Context configuration option supplied to NewContext
@@ -97,10 +141,11 @@ func WithImageNamePrefix(namePrefix string) Option {
 	}
 }
 
-// WithUpgradeArtifacts configures a NewContext to include binaries & images for upgrade
-func WithUpgradeArtifacts(src string) Option {
+// WithUpgradeArtifacts configures a NewContext to include binaries & images for upgrade; many srcs
+// add artifacts for many versions, e.g. for upgrades across many versions
+func WithUpgradeArtifacts(srcs []string) Option {
 	return func(b *Context) {
-		b.upgradeArtifactsSrc = src
+		b.upgradeArtifactsSrcs = append(b.upgradeArtifactsSrcs, srcs...)
 	}
 }
 
@@ -118,6 +163,38 @@ func WithKubelet(src string) Option {
 	}
 }
 
+// WithPackages configures a NewContext to install kubeadm, kubelet and kubectl from the packages
+// of the community repositories for the given version
+func WithPackages(version string) Option {
+	return func(b *Context) {
+		b.packagesVersion = version
+	}
+}
+
+// WithContainerd configures a NewContext to replace the containerd binaries in the image with
+// a containerd release, e.g. v2.0.0, or with a local containerd release tarball
+func WithContainerd(src string) Option {
+	return func(b *Context) {
+		b.containerdSrc = src
+	}
+}
+
+// WithRunc configures a NewContext to replace the runc binary in the image with a runc release,
+// e.g. v1.2.0, or with a local runc binary
+func WithRunc(src string) Option {
+	return func(b *Context) {
+		b.runcSrc = src
+	}
+}
+
+// WithCRIO configures a NewContext to replace containerd with CRI-O as the container runtime of the image;
+// src can be a CRI-O version, e.g. v1.31.0, or a local CRI-O release bundle
+func WithCRIO(src string) Option {
+	return func(b *Context) {
+		b.crioSrc = src
+	}
+}
+
 // WithPrePullAdditionalImages configures a NewContext to pre-pull kubeadm additional required images
 func WithPrePullAdditionalImages(pull bool) Option {
 	return func(b *Context) {
@@ -125,6 +202,46 @@ func WithPrePullAdditionalImages(pull bool) Option {
 	}
 }
 
+// WithPrePullAddonImages configures a NewContext to pre-pull the images of the CNI plugin and of the addons installed
+// by kinder, and the CoreDNS and pause images matching the Kubernetes version of the image
+func WithPrePullAddonImages(pull bool) Option {
+	return func(b *Context) {
+		b.prePullAddonImages = pull
+	}
+}
+
+// WithImagesFromFile configures a NewContext to pre-pull the images listed in a file, one image per line,
+// e.g. for making images used by test workloads available in air-gapped environments
+func WithImagesFromFile(path string) Option {
+	return func(b *Context) {
+		b.imagesFromFile = path
+	}
+}
+
+// WithEtcd configures a NewContext to embed an etcd image, that kinder uses instead of the kubeadm default;
+// src can be a tag of the etcd image used by kubeadm, e.g. 3.5.21-0, or an image, e.g. registry.example.com/dev/etcd:3.6.0
+func WithEtcd(src string) Option {
+	return func(b *Context) {
+		b.etcdImage = src
+	}
+}
+
+// WithUpgradeEtcd configures a NewContext to embed an etcd image for the upgrade artifacts, that kinder uses
+// when upgrading instead of the kubeadm default; src has the same format of WithEtcd
+func WithUpgradeEtcd(src string) Option {
+	return func(b *Context) {
+		b.upgradeEtcdImage = src
+	}
+}
+
+// WithSandboxImage configures a NewContext to use the given sandbox image in the container runtime config,
+// instead of the pause image recommended by kubeadm, and to pre-load it into the image
+func WithSandboxImage(image string) Option {
+	return func(b *Context) {
+		b.sandboxImage = image
+	}
+}
+
 // WithPath configures a NewContext to include a file/dir on the host
 func WithPath(paths []string) Option {
 	return func(b *Context) {
@@ -132,11 +249,119 @@ func WithPath(paths []string) Option {
 	}
 }
 
+// WithSystemdDir configures a NewContext to install and enable the systemd units and drop-ins in a folder on the host
+func WithSystemdDir(dir string) Option {
+	return func(b *Context) {
+		b.systemdDir = dir
+	}
+}
+
+// WithKubeletConfig configures a NewContext to bake KubeletConfiguration fragments into the kubelet drop-in configuration folder
+func WithKubeletConfig(paths []string) Option {
+	return func(b *Context) {
+		b.kubeletConfigs = append(b.kubeletConfigs, paths...)
+	}
+}
+
+// WithOptimize configures a NewContext to reduce the size of the image, by removing caches and
+// squashing the image into a single layer, and to print a size breakdown per bits source
+func WithOptimize(optimize bool) Option {
+	return func(b *Context) {
+		b.optimize = optimize
+	}
+}
+
+// WithSBOM configures a NewContext to write the SBOM of the image to path, in one of SBOMFormats
+func WithSBOM(path, format string) Option {
+	return func(b *Context) {
+		b.sbomPath = path
+		b.sbomFormat = format
+	}
+}
+
+// WithReproducible configures a NewContext to build the image in reproducible mode, pinning the time of the
+// files changed in the image and the creation time of the image to sourceDateEpoch, in seconds since the epoch
+func WithReproducible(reproducible bool, sourceDateEpoch int64) Option {
+	return func(b *Context) {
+		b.reproducible = reproducible
+		b.sourceDateEpoch = sourceDateEpoch
+	}
+}
+
+// WithSmokeTest configures a NewContext to boot a node from the altered image and verify it, failing the
+// alter if the node is not working
+func WithSmokeTest(smokeTest bool) Option {
+	return func(b *Context) {
+		b.smokeTest = smokeTest
+	}
+}
+
+// WithProxy configures a NewContext to download bits and packages through a proxy; empty
+// settings default to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY env variables
+func WithProxy(proxy extract.ProxyConfig) Option {
+	return func(b *Context) {
+		b.proxy = proxy
+	}
+}
+
+// WithCACertificates configures a NewContext to trust additional CA certificates while building the image,
+// e.g. on networks with TLS interception; the certificates are not added to the altered image
+func WithCACertificates(paths []string) Option {
+	return func(b *Context) {
+		b.caCertificates = paths
+	}
+}
+
+// WithBuildCache configures a NewContext to load the altered image from a build cache, if the cache has an image
+// built from the same inputs, and to save the altered image into a build cache; caches are set in the format
+// of the docker buildx cache flags, e.g. type=local,dest=DIR or type=registry,ref=REPOSITORY
+func WithBuildCache(from, to string) Option {
+	return func(b *Context) {
+		b.buildCacheFrom = from
+		b.buildCacheTo = to
+	}
+}
+
+// WithBitsInstallers configures a NewContext to install bits with custom installers, e.g. for
+// downstream projects adding their own bits; custom installers run after the kinder ones
+func WithBitsInstallers(installers ...bits.Installer) Option {
+	return func(b *Context) {
+		b.customInstallers = append(b.customInstallers, installers...)
+	}
+}
+
+// WithArch configures a NewContext to build an image for the given architecture, e.g. arm64;
+// architectures different from the host one require QEMU to be registered with binfmt_misc
+func WithArch(arch string) Option {
+	return func(b *Context) {
+		if arch != "" {
+			b.arch = arch
+		}
+	}
+}
+
+// WithChecksums configures a NewContext to verify bits downloaded from URLs with the sha256 checksums
+// in the given file or URL, in the format of sha256sum
+func WithChecksums(src string) Option {
+	return func(b *Context) {
+		b.extractOptions = append(b.extractOptions, extract.WithChecksums(src))
+	}
+}
+
+// WithSignatures configures a NewContext to verify the sigstore signatures of bits downloaded from URLs
+func WithSignatures(enable bool, identity, issuer string) Option {
+	return func(b *Context) {
+		b.extractOptions = append(b.extractOptions, extract.WithSignatures(enable, identity, issuer))
+	}
+}
+
 // NewContext creates a new Context with default configuration,
 // overridden by the options supplied in the order that they are supplied
 func NewContext(options ...Option) (ctx *Context, err error) {
 	// default options
-	ctx = &Context{}
+	ctx = &Context{
+		arch: goruntime.GOARCH,
+	}
 
 	// apply user options
 	for _, option := range options {
@@ -148,6 +373,27 @@ func NewContext(options ...Option) (ctx *Context, err error) {
 
 // Alter alters the cluster node image
 func (c *Context) Alter() (err error) {
+	if c.sbomPath != "" && !slices.Contains(SBOMFormats, c.sbomFormat) {
+		return errors.Errorf("invalid SBOM format %q, expected one of %v", c.sbomFormat, SBOMFormats)
+	}
+
+	if c.reproducible {
+		if err := c.checkPinnedSources(); err != nil {
+			return err
+		}
+	}
+
+	if c.buildCacheFrom != "" {
+		if c.cacheFrom, err = parseBuildCache(c.buildCacheFrom); err != nil {
+			return err
+		}
+	}
+	if c.buildCacheTo != "" {
+		if c.cacheTo, err = parseBuildCache(c.buildCacheTo); err != nil {
+			return err
+		}
+	}
+
 	// create tempdir to alter the image in
 	alterDir, err := kindfs.TempDir("", "kinder-alter-image")
 	if err != nil {
@@ -156,7 +402,11 @@ func (c *Context) Alter() (err error) {
 	defer os.RemoveAll(alterDir)
 
 	// initialize the build context
-	bc := bits.NewBuildContext(alterDir)
+	bc := bits.NewBuildContext(alterDir, c.arch, c.extractOptions...)
+
+	if err := c.configureNetwork(bc); err != nil {
+		return err
+	}
 
 	// always create folder for storing bits output
 	bitsDir := bc.HostBitsPath()
@@ -167,6 +417,21 @@ func (c *Context) Alter() (err error) {
 	// initialize bits installers
 	var bitsInstallers []bits.Installer
 
+	// the container runtime is replaced before other bits, so it is in place when the CRI starts
+	if c.crioSrc != "" {
+		bitsInstallers = append(bitsInstallers, bits.NewCRIOBits(c.crioSrc))
+	}
+	if c.containerdSrc != "" || c.runcSrc != "" {
+		bitsInstallers = append(bitsInstallers, bits.NewRuntimeBits(c.containerdSrc, c.runcSrc))
+	}
+
+	if c.packagesVersion != "" {
+		if c.initArtifactsSrc != "" || c.kubeadmSrc != "" || c.kubeletSrc != "" {
+			return errors.New("packages can't be installed together with init artifacts or with kubeadm/kubelet binaries")
+		}
+		bitsInstallers = append(bitsInstallers, bits.NewPackageBits(c.packagesVersion))
+	}
+
 	if c.initArtifactsSrc != "" {
 		bitsInstallers = append(bitsInstallers, bits.NewInitBits(c.initArtifactsSrc))
 	}
@@ -182,24 +447,49 @@ func (c *Context) Alter() (err error) {
 		bitsInstallers = append(bitsInstallers, bits.NewImageBits(c.imageSrcs, c.imageNamePrefix))
 	}
 
-	if c.upgradeArtifactsSrc != "" {
+	// bits installers reading the bits prepared by other installers, that are prepared after the others
+	var dependentInstallers []bits.Installer
+
+	if len(c.upgradeArtifactsSrcs) > 0 {
 		// If the upgrade artifacts source is the same as the init artifacts source,
 		// avoid downloading artifacts again and just copy them.
-		src := c.upgradeArtifactsSrc
-		if src == c.initArtifactsSrc {
-			src = filepath.Join(bc.HostBitsPath(), bits.InitBitsDir)
+		var srcs []string
+		copyInit := false
+		for _, src := range c.upgradeArtifactsSrcs {
+			if src == c.initArtifactsSrc {
+				src = filepath.Join(bc.HostBitsPath(), bits.InitBitsDir)
+				copyInit = true
+			}
+			srcs = append(srcs, src)
+		}
+		upgradeBits := bits.NewUpgradeBits(srcs...)
+		bitsInstallers = append(bitsInstallers, upgradeBits)
+		if copyInit {
+			dependentInstallers = append(dependentInstallers, upgradeBits)
 		}
-		bitsInstallers = append(bitsInstallers, bits.NewUpgradeBits(src))
 	}
 
 	if len(c.paths) > 0 {
 		bitsInstallers = append(bitsInstallers, bits.NewPathBits(c.paths))
 	}
 
-	log.Infof("Altering node image in: %s", alterDir)
+	if c.systemdDir != "" {
+		bitsInstallers = append(bitsInstallers, bits.NewSystemdBits(c.systemdDir))
+	}
+
+	if len(c.kubeletConfigs) > 0 {
+		bitsInstallers = append(bitsInstallers, bits.NewKubeletConfigBits(c.kubeletConfigs))
+	}
+
+	bitsInstallers = append(bitsInstallers, c.customInstallers...)
+
+	log.Infof("Altering node image for linux/%s in: %s", c.arch, alterDir)
+	if c.arch != goruntime.GOARCH {
+		log.Infof("linux/%s is not the host architecture; commands in the image are emulated with QEMU", c.arch)
+	}
 
 	// populate the kubernetes artifacts first
-	if err := c.prepareBits(bitsInstallers, bc); err != nil {
+	if err := c.prepareBits(bitsInstallers, dependentInstallers, bc); err != nil {
 		return err
 	}
 
@@ -207,23 +497,45 @@ func (c *Context) Alter() (err error) {
 	return c.alterImage(bitsInstallers, bc)
 }
 
-func (c *Context) prepareBits(bitsInstallers []bits.Installer, bc *bits.BuildContext) error {
+// prepareBits prepares the bits concurrently, so downloads from different sources happen at the same time;
+// dependent installers are prepared after all the others
+func (c *Context) prepareBits(bitsInstallers, dependentInstallers []bits.Installer, bc *bits.BuildContext) error {
 	log.Info("Preparing bits ...")
 
+	var independentInstallers []bits.Installer
 	for _, b := range bitsInstallers {
-		// prepare bits
-		bits, err := b.Prepare(bc)
-		if err != nil {
-			return errors.Wrap(err, "failed to copy alter bits")
+		if !slices.Contains(dependentInstallers, b) {
+			independentInstallers = append(independentInstallers, b)
+		}
+	}
+
+	for _, installers := range [][]bits.Installer{independentInstallers, dependentInstallers} {
+		results := make([]map[string]string, len(installers))
+		errs := make([]error, len(installers))
+		var wg sync.WaitGroup
+		for i, b := range installers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], errs[i] = b.Prepare(bc)
+			}()
 		}
+		wg.Wait()
+
+		for i := range installers {
+			if errs[i] != nil {
+				return errors.Wrap(errs[i], "failed to copy alter bits")
+			}
 
-		// fix the bits in order to match kubeadm/kinder expectations
-		// NB. this is done here so all the bits gets fixes, no matter of the source
-		for k, v := range bits {
-			// if the bit is one of the kubernetes images, we should ensure the repository/name matches kubeadm expectations
-			if slices.Contains(extract.AllKubernetesImages, k) {
-				if err := fixImageTar(v); err != nil {
-					return errors.Wrap(err, "failed to fix bits")
+			// fix the bits in order to match kubeadm/kinder expectations
+			// NB. this is done here so all the bits gets fixes, no matter of the source
+			for k, v := range results[i] {
+				// if the bit is one of the kubernetes images, we should ensure the repository/name matches kubeadm expectations;
+				// bits for many versions are keyed by version folder and name, e.g. v1.33.0/kube-apiserver.tar
+				if slices.Contains(extract.AllKubernetesImages, filepath.Base(k)) {
+					if err := fixImageTar(v, c.arch); err != nil {
+						return errors.Wrap(err, "failed to fix bits")
+					}
 				}
 			}
 		}
@@ -233,7 +545,7 @@ func (c *Context) prepareBits(bitsInstallers []bits.Installer, bc *bits.BuildCon
 }
 
 // fixImageTar ensure the repository/name matches kubeadm expectations
-func fixImageTar(v string) error {
+func fixImageTar(v, arch string) error {
 	log.Infof("fixing %s", v)
 
 	// prepare to read the image tar
@@ -245,7 +557,9 @@ func fixImageTar(v string) error {
 
 	// read the image tar and write the fixed version on a string builder
 	var w strings.Builder
-	err = host.EditArchiveRepositories(f, &w, fixRepository)
+	err = host.EditArchiveRepositories(f, &w, func(repository string) string {
+		return fixRepository(repository, arch)
+	})
 	if err != nil {
 		return err
 	}
@@ -264,8 +578,8 @@ func fixImageTar(v string) error {
 // this is necessary for kubernetes v1.15+
 // Nb. for < v1.12 it was requested to do the opposite, but it not necessary anymore
 // because v.11 is already out of the kubeadm e2e test matrix
-func fixRepository(repository string) string {
-	archSuffix := "-amd64"
+func fixRepository(repository, arch string) string {
+	archSuffix := "-" + arch
 
 	if strings.HasSuffix(repository, archSuffix) {
 		fixed := strings.TrimSuffix(repository, archSuffix)
@@ -277,18 +591,56 @@ func fixRepository(repository string) string {
 }
 
 func (c *Context) alterImage(bitsInstallers []bits.Installer, bc *bits.BuildContext) error {
+	var imagesFromFile []string
+	if c.imagesFromFile != "" {
+		var err error
+		if imagesFromFile, err = readImageList(c.imagesFromFile); err != nil {
+			return err
+		}
+	}
+
 	// get the container runtime from the base image
 	runtime, err := status.InspectCRIinImage(c.baseImage)
 	if err != nil {
 		return errors.Wrap(err, "error detecting CRI!")
 	}
 	log.Infof("Detected %s as container runtime", runtime)
+	if c.containerdSrc != "" && runtime != status.ContainerdRuntime {
+		return errors.Errorf("containerd can't be replaced in an image using %s as container runtime", runtime)
+	}
+	if c.crioSrc != "" {
+		if runtime != status.ContainerdRuntime {
+			return errors.Errorf("CRI-O can be installed only in images using containerd as container runtime, got %s", runtime)
+		}
+		// the image is altered as a CRI-O image after CRI-O is installed by the bits installer
+		runtime = status.CRIORuntime
+		log.Infof("Using %s as container runtime", runtime)
+	}
 
 	alterHelper, err := nodes.NewAlterHelper(runtime)
 	if err != nil {
 		return err
 	}
 
+	var cacheKey string
+	if c.cacheFrom != nil || c.cacheTo != nil {
+		if cacheKey, err = c.buildCacheKey(bc, imagesFromFile); err != nil {
+			return errors.Wrap(err, "failed to compute the key of the build cache")
+		}
+		if c.loadFromBuildCache(cacheKey) {
+			if c.smokeTest {
+				if err := c.runSmokeTest(os.Stdout, alterHelper, runtime); err != nil {
+					return err
+				}
+			}
+			if c.cacheTo != nil && *c.cacheTo != *c.cacheFrom {
+				c.saveToBuildCache(cacheKey)
+			}
+			log.Info("Image alter completed.")
+			return nil
+		}
+	}
+
 	// get the args for the alter container depending on the underlying CR
 	runArgs, containerArgs := alterHelper.GetAlterContainerArgs()
 
@@ -299,6 +651,8 @@ func (c *Context) alterImage(bitsInstallers []bits.Installer, bc *bits.BuildCont
 	// This also allows the KubeBit implementations to perform programmatic
 	// install in the image
 	log.Debug("Starting alter container ...")
+	// files changed after start, including the mount points created with the container, are changed by the build
+	start := time.Now().Add(-time.Second)
 	containerID, err := c.createAlterContainer(bc, runArgs, containerArgs)
 	// ensure we will delete it
 	if containerID != "" {
@@ -317,6 +671,14 @@ func (c *Context) alterImage(bitsInstallers []bits.Installer, bc *bits.BuildCont
 	// binds the BuildContext the container
 	bc.BindToContainer(containerID)
 
+	if err := c.checkBaseImage(bc); err != nil {
+		return err
+	}
+
+	if err := c.installCACertificates(bc); err != nil {
+		return errors.Wrap(err, "image alter Failed! Failed to add the CA certificates")
+	}
+
 	// Make sure the /kind/images folder exists
 	if err := bc.RunInContainer("mkdir", "-p", "/kind/images"); err != nil {
 		return err
@@ -332,7 +694,7 @@ func (c *Context) alterImage(bitsInstallers []bits.Installer, bc *bits.BuildCont
 	}
 
 	log.Info("Setup CRI ...")
-	if err := alterHelper.SetupCRI(bc); err != nil {
+	if err := alterHelper.SetupCRI(bc, c.sandboxImage); err != nil {
 		return errors.Wrapf(err, "image build Failed! Failed to setup %s", runtime)
 	}
 
@@ -346,48 +708,98 @@ func (c *Context) alterImage(bitsInstallers []bits.Installer, bc *bits.BuildCont
 		return errors.Wrapf(err, "image build Failed! Failed to start %s", runtime)
 	}
 
-	if c.prePullAdditionalImages {
+	if c.sandboxImage != "" {
+		log.Infof("Adding the sandbox image %s ...", c.sandboxImage)
+		if err := pullImages(alterHelper, bc, []string{c.sandboxImage}, "/kind/images", containerID, c.arch); err != nil {
+			return err
+		}
+		c.pulledImages = append(c.pulledImages, c.sandboxImage)
+	}
+
+	if c.prePullAdditionalImages || c.prePullAddonImages {
 		log.Info("Pre-pulling additional images ...")
 
 		// pull images required for init / join
 		initPath := "/kind"
-		images, err := alterHelper.GetImagesForKubeadmBinary(bc, filepath.Join(initPath, "bin", "kubeadm"))
+		images, err := c.imagesToPrePull(alterHelper, bc, filepath.Join(initPath, "bin", "kubeadm"), true)
 		if err != nil {
 			return err
 		}
 
-		// add the kindnet image
-		images = append(images, assets.KindnetImage054)
-
-		if err := pullImages(alterHelper, bc, images, filepath.Join(initPath, "images"), containerID); err != nil {
+		if err := pullImages(alterHelper, bc, images, filepath.Join(initPath, "images"), containerID, c.arch); err != nil {
 			return err
 		}
+		c.pulledImages = append(c.pulledImages, images...)
 
-		// pull images required for upgrade
-		upgradePath := "/kinder/upgrade"
-		// check if the version file for the upgrade artifacts is in place
-		versionFile := filepath.Join(upgradePath, "version")
-		version, err := bc.CombinedOutputLinesInContainer(
-			"bash",
-			"-c",
-			"cat "+versionFile+" 2> /dev/null",
-		)
-
-		// don't return the error if the version file is missing
-		if err == nil {
-			if len(version) != 1 {
-				return errors.Errorf("expected the version file %q to have 1 line, got: %v", versionFile, version)
-			}
-
+		// pull images required for upgrade, for each upgrade version
+		upgradeVersions, err := upgradeVersionsInImage(bc)
+		if err != nil {
+			return err
+		}
+		for _, upgradeVersion := range upgradeVersions {
 			// use the resulting upgrade path e.g. /kinder/upgrade/v1.19.0-alpha.3.36+8c4e3faed35411
-			upgradeImages, err := alterHelper.GetImagesForKubeadmBinary(bc, filepath.Join(upgradePath, version[0], "kubeadm"))
+			upgradeImages, err := c.imagesToPrePull(alterHelper, bc, filepath.Join(upgradePath, upgradeVersion, "kubeadm"), false)
 			if err != nil {
 				return err
 			}
 
-			if err := pullImages(alterHelper, bc, upgradeImages, filepath.Join(upgradePath, version[0]), containerID); err != nil {
+			if err := pullImages(alterHelper, bc, upgradeImages, filepath.Join(upgradePath, upgradeVersion), containerID, c.arch); err != nil {
 				return err
 			}
+			c.pulledImages = append(c.pulledImages, upgradeImages...)
+		}
+	}
+
+	if len(imagesFromFile) > 0 {
+		log.Infof("Pre-pulling images from %s ...", c.imagesFromFile)
+		if err := pullImages(alterHelper, bc, imagesFromFile, "/kind/images", containerID, c.arch); err != nil {
+			return err
+		}
+		c.pulledImages = append(c.pulledImages, imagesFromFile...)
+	}
+
+	if c.etcdImage != "" {
+		log.Infof("Adding etcd %s ...", c.etcdImage)
+		if err := c.addEtcdImage(alterHelper, bc, c.etcdImage, "/kind/bin/kubeadm", "/kind", "/kind/images", containerID); err != nil {
+			return err
+		}
+	}
+
+	if c.upgradeEtcdImage != "" {
+		log.Infof("Adding etcd %s for upgrades ...", c.upgradeEtcdImage)
+		upgradeVersion, err := upgradeVersionInImage(bc)
+		if err != nil {
+			return err
+		}
+		if upgradeVersion == "" {
+			return errors.New("the etcd image for upgrades requires upgrade artifacts in the image")
+		}
+		dir := filepath.Join(upgradePath, upgradeVersion)
+		if err := c.addEtcdImage(alterHelper, bc, c.upgradeEtcdImage, filepath.Join(dir, "kubeadm"), dir, dir, containerID); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Writing the bits manifest to %s ...", BitsManifestPath)
+	if err := c.writeBitsManifest(bc); err != nil {
+		return errors.Wrap(err, "image alter Failed! Failed to write the bits manifest")
+	}
+
+	if c.sbomPath != "" {
+		log.Infof("Writing the SBOM to %s ...", c.sbomPath)
+		if err := c.writeSBOM(alterHelper, bc); err != nil {
+			return errors.Wrap(err, "image alter Failed! Failed to write the SBOM")
+		}
+	}
+
+	if err := c.removeCACertificates(bc); err != nil {
+		return errors.Wrap(err, "image alter Failed! Failed to remove the CA certificates")
+	}
+
+	// caches and logs change on every build, so they are removed in reproducible mode too
+	if c.optimize || c.reproducible {
+		if err := cleanupImage(bc); err != nil {
+			return errors.Wrap(err, "image alter Failed! Failed to remove caches")
 		}
 	}
 
@@ -396,37 +808,88 @@ func (c *Context) alterImage(bitsInstallers []bits.Installer, bc *bits.BuildCont
 		return errors.Wrapf(err, "image build Failed! Failed to stop %s", runtime)
 	}
 
+	changes := []string{fmt.Sprintf("LABEL %s=%q", BaseImageLabel, c.baseImage)}
+	if c.reproducible {
+		digest, err := c.pinTimestamps(bc, start)
+		if err != nil {
+			return errors.Wrap(err, "image alter Failed! Failed to pin timestamps")
+		}
+		log.Infof("Content digest of the image is %s", digest)
+		changes = append(changes, fmt.Sprintf("LABEL %s=%q", ContentDigestLabel, digest))
+	}
+
 	log.Infof("Commit to %s ...", c.image)
-	if err = alterHelper.Commit(containerID, c.image); err != nil {
+	if err = alterHelper.Commit(containerID, c.image, changes...); err != nil {
 		return errors.Wrap(err, "image alter Failed! Failed to commit image")
 	}
 
+	if c.optimize {
+		if err := squashImage(containerID, c.image, c.arch); err != nil {
+			return errors.Wrap(err, "image alter Failed! Failed to squash image")
+		}
+	}
+
+	if c.reproducible {
+		if err := pinImageConfig(c.image, c.buildTime()); err != nil {
+			return errors.Wrap(err, "image alter Failed! Failed to pin the image config")
+		}
+	}
+
+	if c.optimize {
+		if err := printSizeReport(os.Stdout, bc, c.baseImage, c.image); err != nil {
+			log.Warnf("failed to report the image size: %v", err)
+		}
+	}
+
+	if c.smokeTest {
+		if err := c.runSmokeTest(os.Stdout, alterHelper, runtime); err != nil {
+			return err
+		}
+	}
+
+	if cacheKey != "" {
+		c.saveToBuildCache(cacheKey)
+	}
+
 	log.Info("Image alter completed.")
 
 	return nil
 }
 
-func pullImages(alterHelper *nodes.AlterHelper, bc *bits.BuildContext, images []string, savePath, containerID string) error {
+// checkBaseImage checks that the base image can be used for kind(er) nodes, so custom base
+// images missing some requirement fail before bits are installed
+func (c *Context) checkBaseImage(bc *bits.BuildContext) error {
+	missing, err := bc.CombinedOutputLinesInContainer(
+		"sh", "-c",
+		fmt.Sprintf("for f in %s; do [ -e $f ] || echo $f; done", strings.Join(baseImageRequirements, " ")),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check the base image %s", c.baseImage)
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("%s can't be used as a base image, because %s are missing; base images should be built from a kind base image", c.baseImage, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func pullImages(alterHelper *nodes.AlterHelper, bc *bits.BuildContext, images []string, savePath, containerID, arch string) error {
 	tempDir, err := os.MkdirTemp("", "kinder-image-path")
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tempDir)
 
-	imageRegExp := regexp.MustCompile("[/:]")
+	imageRegExp := regexp.MustCompile("[/:@]")
 
 	for _, image := range images {
 		// Pull the image on the host
-		if err := exec.NewHostCmd("docker", "pull", image).Run(); err != nil {
+		if err := exec.NewHostCmd("docker", "pull", "--platform=linux/"+arch, image).Run(); err != nil {
 			return errors.Wrapf(err, "failed to pull image %q on the host", image)
 		}
 
-		// Create the path where the tar is going to be saved
-		s := imageRegExp.Split(image, -1)
-		if len(s) < 3 {
-			return errors.Errorf("unsupported image URL: %s", image)
-		}
-		fileName := s[len(s)-2] + ".tar"
+		// Create the path where the tar is going to be saved; the file name is derived from the
+		// whole image reference, so images with the same name from different repositories don't conflict
+		fileName := imageRegExp.ReplaceAllString(image, "_") + ".tar"
 		hostPath := filepath.Join(tempDir, fileName)
 
 		// Save the tar
@@ -447,10 +910,150 @@ func pullImages(alterHelper *nodes.AlterHelper, bc *bits.BuildContext, images []
 	return nil
 }
 
+// upgradePath is the folder with the upgrade artifacts in the image
+const upgradePath = "/kinder/upgrade"
+
+// upgradeVersionsInImage returns the versions of the upgrade artifacts in the image, that are stored
+// in a folder for each version
+func upgradeVersionsInImage(bc *bits.BuildContext) ([]string, error) {
+	versions, err := bc.CombinedOutputLinesInContainer(
+		"bash",
+		"-c",
+		"find "+upgradePath+" -mindepth 1 -maxdepth 1 -type d -name 'v*' -printf '%f\\n' 2> /dev/null | sort -V",
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the upgrade versions in %s", upgradePath)
+	}
+	return versions, nil
+}
+
+// upgradeVersionInImage returns the version of the upgrade artifacts in the image, if any; when the image
+// contains artifacts for many versions, this is the latest version
+func upgradeVersionInImage(bc *bits.BuildContext) (string, error) {
+	// check if the version file for the upgrade artifacts is in place
+	versionFile := filepath.Join(upgradePath, "version")
+	version, err := bc.CombinedOutputLinesInContainer(
+		"bash",
+		"-c",
+		"cat "+versionFile+" 2> /dev/null",
+	)
+
+	// don't return the error if the version file is missing
+	if err != nil {
+		return "", nil
+	}
+	if len(version) != 1 {
+		return "", errors.Errorf("expected the version file %q to have 1 line, got: %v", versionFile, version)
+	}
+	return version[0], nil
+}
+
+// addEtcdImage pulls an etcd image into imagesPath, and writes the etcd image file in dir, so kinder instructs
+// kubeadm to use this image; src can be a tag of the etcd image used by the kubeadm binary at kubeadmPath
+func (c *Context) addEtcdImage(alterHelper *nodes.AlterHelper, bc *bits.BuildContext, src, kubeadmPath, dir, imagesPath, containerID string) error {
+	image := src
+	if !strings.ContainsAny(src, "/:") {
+		kubeadmImages, err := alterHelper.GetImagesForKubeadmBinary(bc, kubeadmPath)
+		if err != nil {
+			return err
+		}
+		image = ""
+		for _, i := range kubeadmImages {
+			if imageName(i) == "etcd" {
+				repository, _, err := kubeadm.SplitImage(i)
+				if err != nil {
+					return err
+				}
+				image = repository + ":" + src
+			}
+		}
+		if image == "" {
+			return errors.Errorf("failed to get the etcd image used by %s", kubeadmPath)
+		}
+	}
+	if err := kubeadm.ValidateEtcdImage(image); err != nil {
+		return err
+	}
+
+	if err := pullImages(alterHelper, bc, []string{image}, imagesPath, containerID, c.arch); err != nil {
+		return err
+	}
+	c.pulledImages = append(c.pulledImages, image)
+	return bc.RunInContainer("bash", "-c", fmt.Sprintf("echo %s > %s", image, filepath.Join(dir, constants.EtcdImageFile)))
+}
+
+// imagesToPrePull returns the images to pre-pull for the kubeadm binary at kubeadmPath;
+// withAddons adds the images of the CNI plugin and of the addons, that don't depend on the Kubernetes version
+func (c *Context) imagesToPrePull(alterHelper *nodes.AlterHelper, bc *bits.BuildContext, kubeadmPath string, withAddons bool) ([]string, error) {
+	kubeadmImages, err := alterHelper.GetImagesForKubeadmBinary(bc, kubeadmPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, image := range kubeadmImages {
+		if c.prePullAdditionalImages || isAddonImage(image) {
+			images = append(images, image)
+		}
+	}
+	if withAddons {
+		if c.prePullAdditionalImages {
+			images = append(images, assets.KindnetImage054)
+		}
+		if c.prePullAddonImages {
+			images = append(images, assets.AddonImages...)
+		}
+	}
+
+	// remove duplicates, preserving the order
+	seen := map[string]bool{}
+	var result []string
+	for _, image := range images {
+		if !seen[image] {
+			seen[image] = true
+			result = append(result, image)
+		}
+	}
+	return result, nil
+}
+
+// isAddonImage returns true for the images of the addons deployed by kubeadm, that are CoreDNS and the pause image
+func isAddonImage(image string) bool {
+	name := imageName(image)
+	return name == "coredns" || name == "pause"
+}
+
+// imageName returns the name of an image without repository and tag, e.g. etcd for registry.k8s.io/etcd:3.5.21-0
+func imageName(image string) string {
+	name := strings.SplitN(image, "@", 2)[0]
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.SplitN(name, ":", 2)[0]
+}
+
+// readImageList reads a file with an image reference per line; empty lines and lines starting with # are ignored
+func readImageList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the list of images %s", path)
+	}
+	var images []string
+	for _, l := range strings.Split(string(data), "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		if strings.ContainsAny(l, " \t") {
+			return nil, errors.Errorf("invalid image reference %q in %s", l, path)
+		}
+		images = append(images, l)
+	}
+	return images, nil
+}
+
 func (c *Context) createAlterContainer(bc *bits.BuildContext, runArgs, containerArgs []string) (id string, err error) {
 	// attempt to explicitly pull the image if it doesn't exist locally
 	// we don't care if this errors, we'll still try to run which also pulls
-	_, _ = host.PullImage(c.baseImage, 4)
+	_, _ = host.PullImageForArch(c.baseImage, c.arch, 4)
 
 	// define docker default args
 	id = "kind-build-" + uuid.New().String()
@@ -458,6 +1061,8 @@ func (c *Context) createAlterContainer(bc *bits.BuildContext, runArgs, container
 		"-d", // make the client exit while the container continues to run
 		"-v", fmt.Sprintf("%s:%s", bc.HostBasePath(), bc.ContainerBasePath()),
 		"--name=" + id,
+		"--label=" + constants.TemporaryLabelKey,
+		"--platform=linux/" + c.arch,
 	}
 	args = append(args, runArgs...)
 
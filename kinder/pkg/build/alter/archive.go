@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+const (
+	// archiveMetadataFile is the file with the metadata of the image in node image archives
+	archiveMetadataFile = "kinder-image.json"
+
+	// archiveImageFile is the file with the image, in the format of docker save, in node image archives
+	archiveImageFile = "image.tar"
+)
+
+// ArchiveMetadata describes the node image in an archive created by ExportImage
+type ArchiveMetadata struct {
+	Image   string `json:"image"`
+	ImageID string `json:"imageID"`
+	// SHA256 and Size are the digest and the size of the image file in the archive
+	SHA256 string        `json:"sha256"`
+	Size   int64         `json:"size"`
+	Bits   *BitsManifest `json:"bits,omitempty"`
+}
+
+// ExportImage exports a node image into an archive at path, that can be imported with ImportImage
+// e.g. on another machine; the archive is compressed if path ends with .gz or .tgz
+func ExportImage(image, path string) error {
+	lines, err := exec.NewHostCmd("docker", "image", "inspect", "-f", "{{.Id}}", image).RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect %s", image)
+	}
+	if len(lines) != 1 {
+		return errors.Errorf("failed to inspect %s: unexpected output %v", image, lines)
+	}
+	metadata := &ArchiveMetadata{Image: image, ImageID: lines[0]}
+
+	tmpDir, err := os.MkdirTemp("", "kinder-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if metadata.Bits, err = readBitsManifest(image, tmpDir); err != nil {
+		log.Warnf("%s does not contain a bits manifest: %v", image, err)
+	}
+
+	log.Infof("Saving %s ...", image)
+	imageTar := filepath.Join(tmpDir, archiveImageFile)
+	if err := exec.NewHostCmd("docker", "save", "-o", imageTar, image).Run(); err != nil {
+		return errors.Wrapf(err, "failed to save %s", image)
+	}
+	info, err := os.Stat(imageTar)
+	if err != nil {
+		return err
+	}
+	metadata.Size = info.Size()
+	if metadata.SHA256, err = extract.FileDigest(imageTar); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Writing %s ...", path)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", path)
+	}
+	defer f.Close()
+	var w io.Writer = f
+	var gw *gzip.Writer
+	if isGzip(path) {
+		gw = gzip.NewWriter(f)
+		w = gw
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeTarEntry(tw, archiveMetadataFile, int64(len(data)), strings.NewReader(string(data))); err != nil {
+		return err
+	}
+	i, err := os.Open(imageTar)
+	if err != nil {
+		return err
+	}
+	defer i.Close()
+	if err := writeTarEntry(tw, archiveImageFile, metadata.Size, i); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if gw != nil {
+		if err := gw.Close(); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// ImportImage imports a node image from an archive created by ExportImage, after checking the digest
+// of the image in the archive; if tag is set, the image is tagged also with it
+func ImportImage(out io.Writer, path, tag string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if isGzip(path) {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kinder-import-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var metadata *ArchiveMetadata
+	imageTar := filepath.Join(tmpDir, archiveImageFile)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		switch hdr.Name {
+		case archiveMetadataFile:
+			metadata = &ArchiveMetadata{}
+			if err := json.NewDecoder(tr).Decode(metadata); err != nil {
+				return errors.Wrapf(err, "failed to read %s in %s", archiveMetadataFile, path)
+			}
+		case archiveImageFile:
+			i, err := os.Create(imageTar)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(i, tr)
+			i.Close()
+			if err != nil {
+				return errors.Wrapf(err, "failed to read %s in %s", archiveImageFile, path)
+			}
+		}
+	}
+	if metadata == nil {
+		return errors.Errorf("%s is not a node image archive: %s is missing", path, archiveMetadataFile)
+	}
+
+	digest, err := extract.FileDigest(imageTar)
+	if err != nil {
+		return errors.Wrapf(err, "%s is not a node image archive: %s is missing", path, archiveImageFile)
+	}
+	if digest != metadata.SHA256 {
+		return errors.Errorf("checksum of the image in %s is %s, expected %s", path, digest, metadata.SHA256)
+	}
+
+	log.Infof("Loading %s ...", metadata.Image)
+	if err := exec.NewHostCmd("docker", "load", "-i", imageTar).Run(); err != nil {
+		return errors.Wrapf(err, "failed to load %s", metadata.Image)
+	}
+	image := metadata.Image
+	if tag != "" {
+		if err := exec.NewHostCmd("docker", "tag", metadata.ImageID, tag).Run(); err != nil {
+			return errors.Wrapf(err, "failed to tag %s as %s", metadata.Image, tag)
+		}
+		image = tag
+	}
+
+	fmt.Fprintf(out, "imported %s (%s)\n", image, metadata.ImageID)
+	if b := metadata.Bits; b != nil {
+		fmt.Fprintf(out, "  base image: %s, arch: %s\n", b.BaseImage, b.Arch)
+		if b.KubernetesVersion != "" {
+			fmt.Fprintf(out, "  kubernetes: %s\n", b.KubernetesVersion)
+		}
+		if b.UpgradeVersion != "" {
+			fmt.Fprintf(out, "  upgrade: %s\n", b.UpgradeVersion)
+		}
+		for _, s := range b.Bits {
+			fmt.Fprintf(out, "  bits/%s: %d files %s\n", s.Name, len(s.Files), s.Source)
+		}
+	}
+	return nil
+}
+
+// readBitsManifest reads the bits manifest from an image, using dir for copying it out of the image
+func readBitsManifest(image, dir string) (*BitsManifest, error) {
+	lines, err := exec.NewHostCmd("docker", "create", "--label", constants.TemporaryLabelKey, image).RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create a container for %s", image)
+	}
+	if len(lines) == 0 {
+		return nil, errors.Errorf("failed to create a container for %s", image)
+	}
+	id := lines[len(lines)-1]
+	defer func() {
+		_ = exec.NewHostCmd("docker", "rm", "-f", "-v", id).Run()
+	}()
+
+	path := filepath.Join(dir, filepath.Base(BitsManifestPath))
+	if err := exec.NewHostCmd("docker", "cp", id+":"+BitsManifestPath, path).Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to copy %s", BitsManifestPath)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &BitsManifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", BitsManifestPath)
+	}
+	return m, nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: size}); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
+
+func isGzip(path string) bool {
+	return strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz")
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/cri/host"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+const (
+	// BuildCacheLocal is the type of build caches stored in a local folder
+	BuildCacheLocal = "local"
+
+	// BuildCacheRegistry is the type of build caches stored in an image repository
+	BuildCacheRegistry = "registry"
+
+	// buildCacheKeyVersion changes when the inputs of the key change, so older entries are ignored
+	buildCacheKeyVersion = "v1"
+)
+
+// buildCache is a cache of altered images, stored in a local folder as image archives or in an
+// image repository as tags, by the key of the inputs of the build
+type buildCache struct {
+	cacheType string
+	// location is the folder for local caches, or the image repository for registry caches
+	location string
+}
+
+// parseBuildCache parses a build cache in the format of the docker buildx cache flags, that is
+// type=local,dest=DIR (or src=DIR) and type=registry,ref=REPOSITORY, or REPOSITORY as shortcut
+func parseBuildCache(s string) (*buildCache, error) {
+	if !strings.Contains(s, "=") {
+		return &buildCache{cacheType: BuildCacheRegistry, location: s}, nil
+	}
+	attrs := map[string]string{}
+	for _, f := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid build cache %q: %q is not a key=value attribute", s, f)
+		}
+		attrs[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	c := &buildCache{cacheType: attrs["type"]}
+	switch c.cacheType {
+	case BuildCacheLocal:
+		c.location = attrs["dest"]
+		if c.location == "" {
+			c.location = attrs["src"]
+		}
+	case BuildCacheRegistry:
+		c.location = attrs["ref"]
+	default:
+		return nil, errors.Errorf("invalid build cache %q: type must be %s or %s", s, BuildCacheLocal, BuildCacheRegistry)
+	}
+	if c.location == "" {
+		return nil, errors.Errorf("invalid build cache %q: the folder or the repository is missing", s)
+	}
+	return c, nil
+}
+
+// String returns the description of the cache for logs
+func (b *buildCache) String() string {
+	return fmt.Sprintf("%s cache %s", b.cacheType, b.location)
+}
+
+func (b *buildCache) archivePath(key string) string {
+	return filepath.Join(b.location, key+".tar")
+}
+
+func (b *buildCache) imageRef(key string) string {
+	return fmt.Sprintf("%s:%s", b.location, key)
+}
+
+// load loads the image for key from the cache and tags it as image; false is returned if the cache has no image for key
+func (b *buildCache) load(key, image, arch string) (bool, error) {
+	switch b.cacheType {
+	case BuildCacheLocal:
+		path := b.archivePath(key)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return false, nil
+		}
+		if err := ImportImage(io.Discard, path, image); err != nil {
+			return false, err
+		}
+	case BuildCacheRegistry:
+		ref := b.imageRef(key)
+		// a missing tag is a cache miss, so the output of docker pull is not echoed
+		if _, err := exec.NewHostCmd("docker", "pull", "--platform=linux/"+arch, ref).RunAndCapture(); err != nil {
+			log.Debugf("failed to pull %s: %v", ref, err)
+			return false, nil
+		}
+		if err := exec.NewHostCmd("docker", "tag", ref, image).Run(); err != nil {
+			return false, errors.Wrapf(err, "failed to tag %s as %s", ref, image)
+		}
+		_ = exec.NewHostCmd("docker", "rmi", ref).Run()
+	}
+	return true, nil
+}
+
+// save saves image into the cache for key
+func (b *buildCache) save(key, image string) error {
+	switch b.cacheType {
+	case BuildCacheLocal:
+		if err := os.MkdirAll(b.location, 0755); err != nil {
+			return errors.Wrapf(err, "failed to create the build cache folder %s", b.location)
+		}
+		// the archive is written next to the final one, so concurrent builds never read partial archives
+		tmp := b.archivePath(key) + ".tmp"
+		defer os.Remove(tmp)
+		if err := ExportImage(image, tmp); err != nil {
+			return err
+		}
+		return os.Rename(tmp, b.archivePath(key))
+	case BuildCacheRegistry:
+		ref := b.imageRef(key)
+		if err := exec.NewHostCmd("docker", "tag", image, ref).Run(); err != nil {
+			return errors.Wrapf(err, "failed to tag %s as %s", image, ref)
+		}
+		defer exec.NewHostCmd("docker", "rmi", ref).Run()
+		return PushImage(ref)
+	}
+	return nil
+}
+
+// buildCacheKey is the input of the build, that identifies an altered image in the build cache
+type buildCacheKey struct {
+	Version     string       `json:"version"`
+	Image       string       `json:"image"`
+	BaseImageID string       `json:"baseImageID"`
+	Arch        string       `json:"arch"`
+	Bits        []BitsSource `json:"bits,omitempty"`
+	Installers  []string     `json:"installers,omitempty"`
+	Options     []string     `json:"options,omitempty"`
+}
+
+// buildCacheKey returns the key of the build, computed from the base image, the prepared bits, that are identified
+// by their digest, and the options changing the image; images are identified by reference, like the docker build cache
+func (c *Context) buildCacheKey(bc *bits.BuildContext, imagesFromFile []string) (string, error) {
+	_, _ = host.PullImageForArch(c.baseImage, c.arch, 4)
+	lines, err := exec.NewHostCmd("docker", "image", "inspect", "-f", "{{.Id}}", c.baseImage).RunAndCapture()
+	if err != nil || len(lines) != 1 {
+		return "", errors.Wrapf(err, "failed to inspect %s", c.baseImage)
+	}
+
+	m, err := c.bitsManifest(bc, "", nil)
+	if err != nil {
+		return "", err
+	}
+	k := &buildCacheKey{
+		Version:     buildCacheKeyVersion,
+		Image:       c.image,
+		BaseImageID: lines[0],
+		Arch:        c.arch,
+		Bits:        m.Bits,
+		Options: []string{
+			fmt.Sprintf("packages=%s", c.packagesVersion),
+			fmt.Sprintf("pre-pull-additional-images=%t", c.prePullAdditionalImages),
+			fmt.Sprintf("pre-pull-addon-images=%t", c.prePullAddonImages),
+			fmt.Sprintf("images-from-file=%s", strings.Join(imagesFromFile, ",")),
+			fmt.Sprintf("etcd=%s", c.etcdImage),
+			fmt.Sprintf("upgrade-etcd=%s", c.upgradeEtcdImage),
+			fmt.Sprintf("sandbox-image=%s", c.sandboxImage),
+			fmt.Sprintf("optimize=%t", c.optimize),
+			fmt.Sprintf("reproducible=%t,%d", c.reproducible, c.sourceDateEpoch),
+		},
+	}
+	// custom installers can install anything, so they are identified at least by type
+	for _, i := range c.customInstallers {
+		k.Installers = append(k.Installers, fmt.Sprintf("%T", i))
+	}
+
+	data, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// loadFromBuildCache loads the altered image from the build cache, if any; errors are logged,
+// because the image can always be built
+func (c *Context) loadFromBuildCache(key string) bool {
+	if c.cacheFrom == nil {
+		return false
+	}
+	if c.sbomPath != "" {
+		log.Infof("Ignoring the %s, because the SBOM is written only while building the image", c.cacheFrom)
+		return false
+	}
+	hit, err := c.cacheFrom.load(key, c.image, c.arch)
+	if err != nil {
+		log.Warnf("failed to load %s from the %s: %v", c.image, c.cacheFrom, err)
+		return false
+	}
+	if hit {
+		log.Infof("Loaded %s from the %s, key %s", c.image, c.cacheFrom, key)
+	} else {
+		log.Infof("%s is not in the %s, key %s", c.image, c.cacheFrom, key)
+	}
+	return hit
+}
+
+// saveToBuildCache saves the altered image into the build cache, if any; errors are logged,
+// because the image is built anyway
+func (c *Context) saveToBuildCache(key string) {
+	if c.cacheTo == nil {
+		return
+	}
+	log.Infof("Saving %s into the %s, key %s ...", c.image, c.cacheTo, key)
+	if err := c.cacheTo.save(key, c.image); err != nil {
+		log.Warnf("failed to save %s into the %s: %v", c.image, c.cacheTo, err)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// NodeImage describes a node image built by kinder
+type NodeImage struct {
+	// Name is the name:tag of the image, empty for dangling images
+	Name      string    `json:"name"`
+	ID        string    `json:"id"`
+	Created   time.Time `json:"created"`
+	SizeBytes int64     `json:"sizeBytes"`
+	BaseImage string    `json:"baseImage"`
+	// ContentDigest is set for images built in reproducible mode
+	ContentDigest string `json:"contentDigest,omitempty"`
+}
+
+// ListNodeImages returns the node images built by kinder on the host, identified by BaseImageLabel
+func ListNodeImages() ([]NodeImage, error) {
+	lines, err := exec.NewHostCmd("docker", "images", "--no-trunc",
+		"--filter", "label="+BaseImageLabel,
+		"--format", "{{.Repository}}:{{.Tag}} {{.ID}}",
+	).RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the node images: %s", strings.Join(lines, " "))
+	}
+
+	images := []NodeImage{}
+	inspected := map[string]NodeImage{}
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) != 2 {
+			continue
+		}
+		name, id := fields[0], fields[1]
+		if name == "<none>:<none>" {
+			name = ""
+		}
+
+		image, ok := inspected[id]
+		if !ok {
+			if image, err = inspectNodeImage(id); err != nil {
+				return nil, err
+			}
+			inspected[id] = image
+		}
+		image.Name = name
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func inspectNodeImage(id string) (NodeImage, error) {
+	lines, err := exec.NewHostCmd("docker", "image", "inspect",
+		"-f", fmt.Sprintf(`{{.Created}}|{{.Size}}|{{index .Config.Labels %q}}|{{index .Config.Labels %q}}`, BaseImageLabel, ContentDigestLabel),
+		id,
+	).RunAndCapture()
+	if err != nil || len(lines) != 1 {
+		return NodeImage{}, errors.Wrapf(err, "failed to inspect image %s: %s", id, strings.Join(lines, " "))
+	}
+	fields := strings.Split(lines[0], "|")
+	if len(fields) != 4 {
+		return NodeImage{}, errors.Errorf("unexpected output inspecting image %s: %q", id, lines[0])
+	}
+
+	image := NodeImage{ID: id, BaseImage: fields[2]}
+	image.Created, _ = time.Parse(time.RFC3339Nano, fields[0])
+	image.SizeBytes, _ = strconv.ParseInt(fields[1], 10, 64)
+	// missing labels are printed by docker as <no value>
+	if fields[3] != "<no value>" {
+		image.ContentDigest = fields[3]
+	}
+	return image, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+// BitsManifestPath is the path of the bits manifest in the altered images
+const BitsManifestPath = "/kind/bits-manifest.json"
+
+// BitsManifest describes the bits added to a node image, so the content of an image can be
+// inspected and verified also after it is moved to another machine
+type BitsManifest struct {
+	Image             string       `json:"image"`
+	BaseImage         string       `json:"baseImage"`
+	Arch              string       `json:"arch"`
+	KubernetesVersion string       `json:"kubernetesVersion,omitempty"`
+	UpgradeVersion    string       `json:"upgradeVersion,omitempty"`
+	UpgradeVersions   []string     `json:"upgradeVersions,omitempty"`
+	Bits              []BitsSource `json:"bits,omitempty"`
+	Images            []string     `json:"images,omitempty"`
+}
+
+// BitsSource are the files prepared from one of the alter sources
+type BitsSource struct {
+	Name   string     `json:"name"`
+	Source string     `json:"source,omitempty"`
+	Files  []BitsFile `json:"files"`
+}
+
+// BitsFile is a file added to a node image
+type BitsFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// bitsManifest returns the manifest of the bits prepared in the build context and of the pulled images
+func (c *Context) bitsManifest(bc *bits.BuildContext, upgradeVersion string, upgradeVersions []string) (*BitsManifest, error) {
+	m := &BitsManifest{
+		Image:           c.image,
+		BaseImage:       c.baseImage,
+		Arch:            c.arch,
+		UpgradeVersion:  upgradeVersion,
+		UpgradeVersions: upgradeVersions,
+		Images:          c.pulledImages,
+	}
+	if v, err := os.ReadFile(filepath.Join(bc.HostBitsPath(), bits.InitBitsDir, "version")); err == nil {
+		m.KubernetesVersion = strings.TrimSpace(string(v))
+	}
+
+	entries, err := os.ReadDir(bc.HostBitsPath())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		s := BitsSource{Name: e.Name(), Source: c.bitsSource(e.Name())}
+		root := filepath.Join(bc.HostBitsPath(), e.Name())
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			digest, err := extract.FileDigest(path)
+			if err != nil {
+				return err
+			}
+			name, _ := filepath.Rel(bc.HostBitsPath(), path)
+			s.Files = append(s.Files, BitsFile{Name: filepath.ToSlash(name), SHA256: digest, Size: info.Size()})
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the bits in %s", root)
+		}
+		m.Bits = append(m.Bits, s)
+	}
+	return m, nil
+}
+
+// bitsSource returns the alter source of a folder or a file in the bits folder
+func (c *Context) bitsSource(name string) string {
+	switch name {
+	case bits.InitBitsDir, "systemd":
+		return c.initArtifactsSrc
+	case "upgrade":
+		return strings.Join(c.upgradeArtifactsSrcs, ",")
+	case "images":
+		return strings.Join(c.imageSrcs, ",")
+	case "kubeadm":
+		return c.kubeadmSrc
+	case "kubelet":
+		return c.kubeletSrc
+	case "files":
+		return strings.Join(c.paths, ",")
+	case "runtime":
+		var srcs []string
+		for k, v := range map[string]string{"containerd": c.containerdSrc, "runc": c.runcSrc} {
+			if v != "" {
+				srcs = append(srcs, k+"="+v)
+			}
+		}
+		sort.Strings(srcs)
+		return strings.Join(srcs, ",")
+	case "crio":
+		return c.crioSrc
+	case "extra-systemd":
+		return c.systemdDir
+	case "kubelet-config":
+		return strings.Join(c.kubeletConfigs, ",")
+	}
+	return ""
+}
+
+// writeBitsManifest writes the manifest of the bits into the alter container
+func (c *Context) writeBitsManifest(bc *bits.BuildContext) error {
+	upgradeVersion, err := upgradeVersionInImage(bc)
+	if err != nil {
+		return err
+	}
+	upgradeVersions, err := upgradeVersionsInImage(bc)
+	if err != nil {
+		return err
+	}
+	m, err := c.bitsManifest(bc, upgradeVersion, upgradeVersions)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(bc.HostBasePath(), "bits-manifest.json"), data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write the bits manifest")
+	}
+	return bc.RunInContainer("cp", filepath.Join(bc.ContainerBasePath(), "bits-manifest.json"), BitsManifestPath)
+}
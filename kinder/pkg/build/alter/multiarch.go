@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// ArchImage returns the name:tag of the image for an architecture, when altering an image for many
+// architectures, e.g. kindest/node:v1.33.0-arm64 for kindest/node:v1.33.0
+func ArchImage(image, arch string) string {
+	name, tag := image, "latest"
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	return fmt.Sprintf("%s:%s-%s", name, tag, arch)
+}
+
+// PushImage pushes an image to its registry
+func PushImage(image string) error {
+	log.Infof("Pushing %s ...", image)
+	if err := exec.NewHostCmd("docker", "push", image).RunWithEcho(); err != nil {
+		return errors.Wrapf(err, "failed to push image %s", image)
+	}
+	return nil
+}
+
+// PushManifestList pushes the images for each architecture, and then a manifest list named image
+// that references all of them; creating the manifest list requires docker buildx
+func PushManifestList(image string, archImages []string) error {
+	for _, i := range archImages {
+		if err := PushImage(i); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Creating manifest list %s for %s ...", image, strings.Join(archImages, ", "))
+	args := append([]string{"buildx", "imagetools", "create", "--tag", image}, archImages...)
+	if err := exec.NewHostCmd("docker", args...).RunWithEcho(); err != nil {
+		return errors.Wrapf(err, "failed to create manifest list %s", image)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	kindfs "sigs.k8s.io/kind/pkg/fs"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+// caCertificatesDir is the folder in the build context with the CA certificates trusted during the build
+const caCertificatesDir = "ca-certificates"
+
+// caCertificatesScript detects where the CA certificates are installed in the image, for Debian and
+// for Fedora based images, and then runs the given commands
+const caCertificatesScript = `set -e
+if command -v update-ca-certificates > /dev/null; then
+  dst=/usr/local/share/ca-certificates; update="update-ca-certificates --fresh"
+elif command -v update-ca-trust > /dev/null; then
+  dst=/etc/pki/ca-trust/source/anchors; update="update-ca-trust extract"
+else
+  echo "the image has no tool for updating the CA certificates" >&2; exit 1
+fi
+%s
+$update > /dev/null
+`
+
+// configureNetwork configures the proxy and the CA certificates for the downloads on the host
+// and for the commands executed in the alter container, e.g. for installing packages
+func (c *Context) configureNetwork(bc *bits.BuildContext) error {
+	proxy := c.proxy.WithEnvDefaults()
+	if env := proxy.Env(); len(env) > 0 {
+		// proxy URLs can contain credentials, so they are not logged
+		log.Info("Using a proxy for the commands executed in the image")
+		bc.SetContainerEnv(env)
+	}
+	if len(c.caCertificates) == 0 && c.proxy == (extract.ProxyConfig{}) {
+		return nil
+	}
+	return extract.ConfigureHTTP(c.proxy, c.caCertificates)
+}
+
+// installCACertificates adds the CA certificates to the trusted certificates of the alter container,
+// so they are trusted by the tools executed during the build, e.g. apt or curl
+func (c *Context) installCACertificates(bc *bits.BuildContext) error {
+	if len(c.caCertificates) == 0 {
+		return nil
+	}
+	log.Info("Adding the CA certificates for the build ...")
+	dir := filepath.Join(bc.HostBasePath(), caCertificatesDir)
+	if err := os.Mkdir(dir, 0777); err != nil {
+		return errors.Wrap(err, "failed to make the CA certificates dir")
+	}
+	for i, src := range c.caCertificates {
+		if err := kindfs.CopyFile(src, filepath.Join(dir, fmt.Sprintf("kinder-build-%d.crt", i))); err != nil {
+			return errors.Wrapf(err, "failed to copy %s", src)
+		}
+	}
+	cmd := fmt.Sprintf(`mkdir -p $dst; cp %s/*.crt $dst/`, filepath.Join(bc.ContainerBasePath(), caCertificatesDir))
+	return bc.RunInContainer("bash", "-c", fmt.Sprintf(caCertificatesScript, cmd))
+}
+
+// removeCACertificates removes the CA certificates added for the build, so they are not committed into the image
+func (c *Context) removeCACertificates(bc *bits.BuildContext) error {
+	if len(c.caCertificates) == 0 {
+		return nil
+	}
+	log.Info("Removing the CA certificates for the build ...")
+	return bc.RunInContainer("bash", "-c", fmt.Sprintf(caCertificatesScript, `rm -f $dst/kinder-build-*.crt`))
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// cleanupPaths are removed from the image when optimizing it; those are caches and logs
+// left by package managers and by the bits installers
+var cleanupPaths = []string{
+	"/var/lib/apt/lists/*",
+	"/var/cache/apt/*",
+	"/var/cache/debconf/*-old",
+	"/var/log/apt/*",
+	"/var/log/dpkg.log",
+	"/tmp/*",
+	"/var/tmp/*",
+}
+
+// cleanupImage removes caches and temporary files from the alter container
+func cleanupImage(bc *bits.BuildContext) error {
+	log.Info("Removing caches from the image ...")
+	return bc.RunInContainer("bash", "-c", "rm -rf "+strings.Join(cleanupPaths, " "))
+}
+
+// imageConfig is the part of the config of an image that must be preserved when squashing it
+type imageConfig struct {
+	Env        []string            `json:"Env"`
+	Entrypoint []string            `json:"Entrypoint"`
+	Cmd        []string            `json:"Cmd"`
+	Volumes    map[string]struct{} `json:"Volumes"`
+	Labels     map[string]string   `json:"Labels"`
+	StopSignal string              `json:"StopSignal"`
+	WorkingDir string              `json:"WorkingDir"`
+	User       string              `json:"User"`
+}
+
+// changes returns the Dockerfile instructions that restore the config of an image
+func (c *imageConfig) changes() []string {
+	var changes []string
+	for _, e := range c.Env {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			changes = append(changes, fmt.Sprintf("ENV %s=%q", k, v))
+		}
+	}
+	if len(c.Entrypoint) > 0 {
+		b, _ := json.Marshal(c.Entrypoint)
+		changes = append(changes, "ENTRYPOINT "+string(b))
+	}
+	if len(c.Cmd) > 0 {
+		b, _ := json.Marshal(c.Cmd)
+		changes = append(changes, "CMD "+string(b))
+	}
+	var volumes []string
+	for v := range c.Volumes {
+		volumes = append(volumes, v)
+	}
+	if len(volumes) > 0 {
+		sort.Strings(volumes)
+		b, _ := json.Marshal(volumes)
+		changes = append(changes, "VOLUME "+string(b))
+	}
+	var labels []string
+	for k, v := range c.Labels {
+		labels = append(labels, fmt.Sprintf("LABEL %s=%q", k, v))
+	}
+	sort.Strings(labels)
+	changes = append(changes, labels...)
+	if c.StopSignal != "" {
+		changes = append(changes, "STOPSIGNAL "+c.StopSignal)
+	}
+	if c.WorkingDir != "" {
+		changes = append(changes, "WORKDIR "+c.WorkingDir)
+	}
+	if c.User != "" {
+		changes = append(changes, "USER "+c.User)
+	}
+	return changes
+}
+
+// squashImage replaces image with a single layer image with the filesystem of the alter container
+// and the config of image; the filesystem of the container is the same committed into image,
+// because both docker commit and docker export ignore volumes
+func squashImage(containerID, image, arch string) error {
+	log.Infof("Squashing %s ...", image)
+	lines, err := exec.NewHostCmd("docker", "image", "inspect", "-f", "{{json .Config}}", image).RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect %s", image)
+	}
+	config := &imageConfig{}
+	if err := json.Unmarshal([]byte(strings.Join(lines, "")), config); err != nil {
+		return errors.Wrapf(err, "failed to read the config of %s", image)
+	}
+
+	args := []string{"import", "--platform=linux/" + arch}
+	for _, c := range config.changes() {
+		args = append(args, "--change", c)
+	}
+	export := osexec.Command("docker", "export", containerID)
+	imp := osexec.Command("docker", append(args, "-", image)...)
+	pipe, err := export.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	imp.Stdin = pipe
+	imp.Stdout = io.Discard
+	export.Stderr, imp.Stderr = os.Stderr, os.Stderr
+
+	if err := export.Start(); err != nil {
+		return errors.Wrap(err, "failed to export the alter container")
+	}
+	if err := imp.Run(); err != nil {
+		_ = export.Wait()
+		return errors.Wrapf(err, "failed to import %s", image)
+	}
+	if err := export.Wait(); err != nil {
+		return errors.Wrap(err, "failed to export the alter container")
+	}
+	return nil
+}
+
+// printSizeReport prints the size of the bits added by each bits source, together with the size of the base
+// image and of the resulting image
+func printSizeReport(out io.Writer, bc *bits.BuildContext, baseImage, image string) error {
+	entries, err := os.ReadDir(bc.HostBitsPath())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tSIZE")
+	for _, e := range entries {
+		size, err := dirSize(filepath.Join(bc.HostBitsPath(), e.Name()))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "bits/%s\t%s\n", e.Name(), humanSize(size))
+	}
+	for _, i := range []string{baseImage, image} {
+		lines, err := exec.NewHostCmd("docker", "image", "inspect", "-f", "{{.Size}}", i).RunAndCapture()
+		if err != nil || len(lines) != 1 {
+			continue
+		}
+		if size, err := strconv.ParseInt(lines[0], 10, 64); err == nil {
+			fmt.Fprintf(w, "image %s\t%s\n", i, humanSize(size))
+		}
+	}
+	return w.Flush()
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func humanSize(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+// ContentDigestLabel is the label of the images built in reproducible mode with the digest of the
+// files added or changed by kinder, for comparing builds from identical inputs
+const ContentDigestLabel = "io.x-k8s.kinder.content-digest"
+
+// nonReproduciblePaths are files that change on every build, no matter of the inputs, and that are
+// excluded from the content digest; the metadata database of containerd records when images are imported
+var nonReproduciblePaths = []string{
+	"./var/lib/containerd/io.containerd.metadata.v1.bolt/meta.db",
+}
+
+// checkPinnedSources checks that the bits sources are pinned, because labels, e.g. ci/latest,
+// resolve to different versions over time
+func (c *Context) checkPinnedSources() error {
+	srcs := append([]string{c.initArtifactsSrc, c.kubeadmSrc, c.kubeletSrc}, c.upgradeArtifactsSrcs...)
+	srcs = append(srcs, c.imageSrcs...)
+	for _, src := range srcs {
+		if src != "" && extract.IsLabel(src) {
+			return errors.Errorf("reproducible builds require pinned sources, use a version or a commit sha instead of %s", src)
+		}
+	}
+	if !strings.Contains(c.baseImage, "@sha256:") {
+		log.Warnf("the base image %s is not pinned by digest, so builds are reproducible only while the tag points to the same image", c.baseImage)
+	}
+	return nil
+}
+
+// buildTime returns the time recorded in the image and in the artifacts of the build, that in
+// reproducible mode is the source date epoch
+func (c *Context) buildTime() time.Time {
+	if c.reproducible {
+		return time.Unix(c.sourceDateEpoch, 0).UTC()
+	}
+	return time.Now().UTC()
+}
+
+// pinTimestamps sets the modification time of the files added or changed in the alter container after
+// start to the source date epoch, and returns the digest of the content of those files
+func (c *Context) pinTimestamps(bc *bits.BuildContext, start time.Time) (string, error) {
+	exclude := ""
+	for _, p := range nonReproduciblePaths {
+		exclude += fmt.Sprintf(" ! -path %s", p)
+	}
+	// files are sorted, so the digest does not depend on the order of the filesystem
+	lines, err := bc.CombinedOutputLinesInContainer("bash", "-c", fmt.Sprintf(
+		"set -o pipefail; cd / && find . -xdev -newermt @%d -type f%s -print0 | LC_ALL=C sort -z | xargs -0r sha256sum | sha256sum",
+		start.Unix(), exclude,
+	))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute the content digest")
+	}
+	if len(lines) != 1 {
+		return "", errors.Errorf("failed to compute the content digest: %s", strings.Join(lines, " "))
+	}
+	digest, _, _ := strings.Cut(lines[0], " ")
+
+	log.Infof("Setting the time of the files changed in the image to %s ...", c.buildTime().Format(time.RFC3339))
+	if err := bc.RunInContainer("bash", "-c", fmt.Sprintf(
+		"find / -xdev -newermt @%d -exec touch -h -d @%d {} +", start.Unix(), c.sourceDateEpoch,
+	)); err != nil {
+		return "", errors.Wrap(err, "failed to set the time of the files")
+	}
+	return "sha256:" + digest, nil
+}
+
+// pinImageConfig sets the creation time of an image and of its history to created, and removes from the
+// config of the image the fields docker commit sets from the alter container, e.g. the container id;
+// the image is saved, rewritten and loaded again, so the layers are not changed
+func pinImageConfig(image string, created time.Time) error {
+	log.Infof("Setting the creation time of %s to %s ...", image, created.Format(time.RFC3339))
+	dir, err := os.MkdirTemp("", "kinder-reproducible-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	saved, rewritten := filepath.Join(dir, "saved.tar"), filepath.Join(dir, "rewritten.tar")
+	if err := exec.NewHostCmd("docker", "save", "-o", saved, image).Run(); err != nil {
+		return errors.Wrapf(err, "failed to save %s", image)
+	}
+	if err := rewriteImageArchive(saved, rewritten, created); err != nil {
+		return errors.Wrapf(err, "failed to rewrite %s", image)
+	}
+	if err := exec.NewHostCmd("docker", "load", "-q", "-i", rewritten).Run(); err != nil {
+		return errors.Wrapf(err, "failed to load %s", image)
+	}
+	return nil
+}
+
+// rewriteImageArchive copies an archive created by docker save, replacing the config of the image;
+// the OCI index is dropped, so the image is loaded using the rewritten docker manifest
+func rewriteImageArchive(src, dst string, created time.Time) error {
+	var manifest []map[string]interface{}
+	configs := map[string][]byte{}
+	if err := readTar(src, func(h *tar.Header, r io.Reader) error {
+		// the manifest and configs are small, while layers are skipped by size
+		if h.Typeflag != tar.TypeReg || h.Size > 1<<20 {
+			return nil
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if h.Name == "manifest.json" {
+			return json.Unmarshal(data, &manifest)
+		}
+		configs[h.Name] = data
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(manifest) != 1 {
+		return errors.Errorf("expected one image in the archive, got %d", len(manifest))
+	}
+
+	oldConfig, _ := manifest[0]["Config"].(string)
+	data, ok := configs[oldConfig]
+	if !ok {
+		return errors.Errorf("config %s not found in the archive", oldConfig)
+	}
+	config, err := pinConfig(data, created)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(config)
+	newConfig := hex.EncodeToString(sum[:]) + ".json"
+	if strings.HasPrefix(oldConfig, "blobs/") {
+		newConfig = path.Join(path.Dir(oldConfig), hex.EncodeToString(sum[:]))
+	}
+	manifest[0]["Config"] = newConfig
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	if err := readTar(src, func(h *tar.Header, r io.Reader) error {
+		switch h.Name {
+		case "manifest.json", "index.json", "oci-layout", "repositories", oldConfig:
+			return nil
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+		_, err := io.Copy(tw, r)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, newConfig, int64(len(config)), bytes.NewReader(config)); err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, "manifest.json", int64(len(manifestData)), bytes.NewReader(manifestData)); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// pinConfig returns the config of an image with the creation time set to created
+func pinConfig(data []byte, created time.Time) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	// numbers are preserved as they are
+	d.UseNumber()
+	config := map[string]interface{}{}
+	if err := d.Decode(&config); err != nil {
+		return nil, errors.Wrap(err, "failed to read the image config")
+	}
+
+	ts := created.UTC().Format(time.RFC3339)
+	config["created"] = ts
+	if history, ok := config["history"].([]interface{}); ok {
+		for _, h := range history {
+			if entry, ok := h.(map[string]interface{}); ok {
+				entry["created"] = ts
+			}
+		}
+	}
+	delete(config, "container")
+	delete(config, "container_config")
+	if c, ok := config["config"].(map[string]interface{}); ok {
+		c["Hostname"] = ""
+	}
+	return json.Marshal(config)
+}
+
+// readTar calls fn for each entry of a tar archive
+func readTar(path string, fn func(h *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(h, tr); err != nil {
+			return err
+		}
+	}
+}
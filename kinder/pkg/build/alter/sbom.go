@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes"
+)
+
+const (
+	// SBOMFormatSPDX is the SPDX 2.3 JSON format for SBOMs
+	SBOMFormatSPDX = "spdx"
+
+	// SBOMFormatCycloneDX is the CycloneDX 1.5 JSON format for SBOMs
+	SBOMFormatCycloneDX = "cyclonedx"
+)
+
+// SBOMFormats are the supported formats for SBOMs
+var SBOMFormats = []string{SBOMFormatSPDX, SBOMFormatCycloneDX}
+
+// kubernetesBinariesVersionCmd prints the path and the version of the Kubernetes binaries in the image,
+// including the binaries for upgrades
+const kubernetesBinariesVersionCmd = `for b in $(command -v kubeadm kubelet kubectl) /kinder/upgrade/*/kubeadm /kinder/upgrade/*/kubelet /kinder/upgrade/*/kubectl; do ` +
+	`[ -x $b ] || continue; ` +
+	`case $(basename $b) in ` +
+	`kubeadm) v=$($b version -o short);; ` +
+	`kubelet) v=$($b --version | cut -d' ' -f2);; ` +
+	`kubectl) v=$($b version --client -o json | grep gitVersion | cut -d'"' -f4);; ` +
+	`esac; echo $b $v; done`
+
+// sbomComponent is a component of a node image listed in the SBOM
+type sbomComponent struct {
+	// kind is the CycloneDX component type, e.g. application, container or library
+	kind    string
+	name    string
+	version string
+	purl    string
+}
+
+// sbomComponents returns the Kubernetes binaries, the container images and the OS packages in the alter container
+func sbomComponents(alterHelper *nodes.AlterHelper, bc *bits.BuildContext) ([]sbomComponent, error) {
+	var components []sbomComponent
+
+	lines, err := bc.CombinedOutputLinesInContainer("bash", "-c", kubernetesBinariesVersionCmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the version of the Kubernetes binaries")
+	}
+	seen := map[string]bool{}
+	for _, l := range lines {
+		path, version, ok := strings.Cut(l, " ")
+		name := filepath.Base(path)
+		if !ok || version == "" || seen[name+version] {
+			continue
+		}
+		seen[name+version] = true
+		components = append(components, sbomComponent{
+			kind:    "application",
+			name:    name,
+			version: version,
+			purl:    fmt.Sprintf("pkg:golang/k8s.io/kubernetes@%s#cmd/%s", version, name),
+		})
+	}
+
+	images, err := alterHelper.ListImages(bc)
+	if err != nil {
+		return nil, err
+	}
+	// images for upgrades are loaded at upgrade time, so they are not in the container runtime
+	upgradeImages, err := imagesInTars(filepath.Join(bc.HostBitsPath(), "upgrade"))
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, upgradeImages...)
+	sort.Strings(images)
+	for i, image := range images {
+		if i > 0 && images[i-1] == image {
+			continue
+		}
+		name, tag := image, ""
+		if j := strings.LastIndex(image, ":"); j > strings.LastIndex(image, "/") {
+			name, tag = image[:j], image[j+1:]
+		}
+		c := sbomComponent{kind: "container", name: name, version: tag}
+		if tag != "" {
+			c.purl = fmt.Sprintf("pkg:oci/%s?repository_url=%s&tag=%s", filepath.Base(name), url.QueryEscape(name), url.QueryEscape(tag))
+		}
+		components = append(components, c)
+	}
+
+	lines, err = bc.CombinedOutputLinesInContainer("bash", "-c",
+		`. /etc/os-release; dpkg-query -W -f='${Package} ${Version} ${Architecture}\n' | sed "s/^/$ID /"`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the OS packages")
+	}
+	for _, l := range lines {
+		f := strings.Fields(l)
+		if len(f) != 4 {
+			continue
+		}
+		components = append(components, sbomComponent{
+			kind:    "library",
+			name:    f[1],
+			version: f[2],
+			purl:    fmt.Sprintf("pkg:deb/%s/%s@%s?arch=%s", f[0], f[1], url.QueryEscape(f[2]), f[3]),
+		})
+	}
+	return components, nil
+}
+
+// imagesInTars returns the names of the images in the image tars in a folder and its subfolders
+func imagesInTars(dir string) ([]string, error) {
+	var images []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".tar" {
+			return err
+		}
+		tags, err := repoTags(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		images = append(images, tags...)
+		return nil
+	})
+	return images, err
+}
+
+// repoTags returns the names of the images in the manifest of an image tar
+func repoTags(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Name != "manifest.json" {
+			continue
+		}
+		var manifest []struct {
+			RepoTags []string `json:"RepoTags"`
+		}
+		if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+			return nil, err
+		}
+		var tags []string
+		for _, m := range manifest {
+			tags = append(tags, m.RepoTags...)
+		}
+		return tags, nil
+	}
+}
+
+// writeSBOM writes the SBOM of the node image in the alter container to the sbom file
+func (c *Context) writeSBOM(alterHelper *nodes.AlterHelper, bc *bits.BuildContext) error {
+	components, err := sbomComponents(alterHelper, bc)
+	if err != nil {
+		return err
+	}
+
+	// in reproducible mode, the id of the document is derived from the components, so identical builds have identical SBOMs
+	id := uuid.New()
+	if c.reproducible {
+		id = uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s %v", c.image, components)))
+	}
+
+	var doc interface{}
+	created := c.buildTime().Format(time.RFC3339)
+	switch c.sbomFormat {
+	case SBOMFormatCycloneDX:
+		doc = cycloneDXDocument(c.image, created, id, components)
+	default:
+		doc = spdxDocument(c.image, created, id, components)
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.sbomPath, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", c.sbomPath)
+	}
+	log.Infof("SBOM with %d components written to %s", len(components), c.sbomPath)
+	return nil
+}
+
+func spdxDocument(image, created string, id uuid.UUID, components []sbomComponent) map[string]interface{} {
+	packages := []map[string]interface{}{{
+		"SPDXID":                "SPDXRef-Image",
+		"name":                  image,
+		"downloadLocation":      "NOASSERTION",
+		"filesAnalyzed":         false,
+		"primaryPackagePurpose": "CONTAINER",
+	}}
+	relationships := []map[string]string{{
+		"spdxElementId":      "SPDXRef-DOCUMENT",
+		"relationshipType":   "DESCRIBES",
+		"relatedSpdxElement": "SPDXRef-Image",
+	}}
+	for i, c := range components {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		p := map[string]interface{}{
+			"SPDXID":           id,
+			"name":             c.name,
+			"versionInfo":      c.version,
+			"downloadLocation": "NOASSERTION",
+			"filesAnalyzed":    false,
+		}
+		if c.purl != "" {
+			p["externalRefs"] = []map[string]string{{
+				"referenceCategory": "PACKAGE-MANAGER",
+				"referenceType":     "purl",
+				"referenceLocator":  c.purl,
+			}}
+		}
+		packages = append(packages, p)
+		relationships = append(relationships, map[string]string{
+			"spdxElementId":      "SPDXRef-Image",
+			"relationshipType":   "CONTAINS",
+			"relatedSpdxElement": id,
+		})
+	}
+	return map[string]interface{}{
+		"spdxVersion":       "SPDX-2.3",
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"name":              image,
+		"documentNamespace": fmt.Sprintf("https://sigs.k8s.io/kinder/sbom/%s-%s", url.PathEscape(image), id),
+		"creationInfo": map[string]interface{}{
+			"created":  created,
+			"creators": []string{"Tool: kinder-" + constants.KinderVersion},
+		},
+		"packages":      packages,
+		"relationships": relationships,
+	}
+}
+
+func cycloneDXDocument(image, created string, id uuid.UUID, components []sbomComponent) map[string]interface{} {
+	var list []map[string]string
+	for i, c := range components {
+		e := map[string]string{
+			"bom-ref": fmt.Sprintf("component-%d", i+1),
+			"type":    c.kind,
+			"name":    c.name,
+			"version": c.version,
+		}
+		if c.purl != "" {
+			e["purl"] = c.purl
+		}
+		list = append(list, e)
+	}
+	return map[string]interface{}{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": "urn:uuid:" + id.String(),
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": created,
+			"tools": map[string]interface{}{
+				"components": []map[string]string{{"type": "application", "name": "kinder", "version": constants.KinderVersion}},
+			},
+			"component": map[string]string{"bom-ref": "image", "type": "container", "name": image},
+		},
+		"components": list,
+	}
+}
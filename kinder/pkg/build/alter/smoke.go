@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes"
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes/common"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// smokeTestTimeout is how long the smoke test waits for systemd to boot in the node
+const smokeTestTimeout = 2 * time.Minute
+
+// runtimeHealthChecks are the commands checking that the container runtime in a node is serving requests
+var runtimeHealthChecks = map[status.ContainerRuntime][]string{
+	status.ContainerdRuntime: {"crictl", "info"},
+	status.CRIORuntime:       {"crictl", "info"},
+	status.DockerRuntime:     {"docker", "info"},
+}
+
+// smokeCheck is the result of a check of the smoke test
+type smokeCheck struct {
+	name string
+	err  error
+}
+
+// runSmokeTest boots a throwaway node from the altered image, like kinder create does, and checks that systemd,
+// the container runtime and the Kubernetes binaries work and that the expected images are in the node, so
+// broken images fail the build instead of failing later, at cluster create
+func (c *Context) runSmokeTest(out io.Writer, alterHelper *nodes.AlterHelper, runtime status.ContainerRuntime) error {
+	log.Infof("Running the smoke test of %s ...", c.image)
+	id := "kinder-smoke-" + uuid.New().String()
+	args := []string{
+		"run", "--detach", "--tty",
+		"--name", id,
+		"--label", constants.TemporaryLabelKey,
+		"--hostname", "kinder-smoke",
+		"--platform=linux/" + c.arch,
+		"--privileged",
+		"--security-opt", "seccomp=unconfined",
+		"--tmpfs", "/tmp",
+		"--tmpfs", "/run",
+		"--mount", fmt.Sprintf("type=volume,dst=/var,volume-label=%s", constants.TemporaryLabelKey),
+		"--volume", "/lib/modules:/lib/modules:ro",
+		c.image,
+	}
+	if err := exec.NewHostCmd("docker", args...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to start a node from %s", c.image)
+	}
+	defer func() {
+		exec.NewHostCmd("docker", "rm", "-f", "-v", id).Run()
+	}()
+
+	bc := bits.NewBuildContext("", c.arch)
+	bc.BindToContainer(id)
+
+	checks := []smokeCheck{{name: "systemd", err: waitForSystemd(bc)}}
+	// without systemd nothing else is running, so other checks are pointless
+	if checks[0].err == nil {
+		m, err := bitsManifestInNode(bc)
+		if err != nil {
+			return err
+		}
+		checks = append(checks,
+			smokeCheck{name: "container runtime", err: checkRuntime(bc, runtime)},
+			smokeCheck{name: "kubelet unit", err: checkUnitEnabled(bc, "kubelet")},
+			smokeCheck{name: "binaries", err: c.checkBinaries(bc, m)},
+			smokeCheck{name: "images", err: c.checkImages(alterHelper, bc, m)},
+		)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tRESULT")
+	var failed []string
+	for _, check := range checks {
+		result := "ok"
+		if check.err != nil {
+			result = "FAILED: " + check.err.Error()
+			failed = append(failed, check.name)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", check.name, result)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%s failed the smoke test: %s checks failed", c.image, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// bitsManifestInNode reads the bits manifest from a node
+func bitsManifestInNode(bc *bits.BuildContext) (*BitsManifest, error) {
+	lines, err := bc.CombinedOutputLinesInContainer("cat", BitsManifestPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", BitsManifestPath)
+	}
+	m := &BitsManifest{}
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), m); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", BitsManifestPath)
+	}
+	return m, nil
+}
+
+// waitForSystemd waits for systemd to complete the boot, and then checks that no unit failed
+func waitForSystemd(bc *bits.BuildContext) error {
+	var state string
+	booted := common.TryUntil(time.Now().Add(smokeTestTimeout), func() bool {
+		// is-system-running exits with an error when the system is not running, but the state is printed anyway
+		lines, _ := bc.CombinedOutputLinesInContainer("bash", "-c", "systemctl is-system-running 2> /dev/null || true")
+		if len(lines) > 0 {
+			state = lines[0]
+		}
+		if state == "running" || state == "degraded" {
+			return true
+		}
+		time.Sleep(time.Second)
+		return false
+	})
+	if !booted {
+		return errors.Errorf("systemd did not complete the boot in %v, state is %q", smokeTestTimeout, state)
+	}
+
+	units, err := bc.CombinedOutputLinesInContainer("systemctl", "list-units", "--failed", "--plain", "--no-legend")
+	if err != nil {
+		return errors.Wrap(err, "failed to list the failed units")
+	}
+	var failed []string
+	for _, u := range units {
+		if fields := strings.Fields(u); len(fields) > 0 {
+			failed = append(failed, fields[0])
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("units %s failed", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// checkRuntime checks that the container runtime is active and serving requests
+func checkRuntime(bc *bits.BuildContext, runtime status.ContainerRuntime) error {
+	unit := string(runtime)
+	if lines, err := bc.CombinedOutputLinesInContainer("systemctl", "is-active", unit); err != nil {
+		return errors.Errorf("%s is not active: %s", unit, strings.Join(lines, " "))
+	}
+	health, ok := runtimeHealthChecks[runtime]
+	if !ok {
+		return errors.Errorf("unknown cri: %s", runtime)
+	}
+	if lines, err := bc.CombinedOutputLinesInContainer(health[0], health[1:]...); err != nil {
+		return errors.Errorf("%s is not healthy: %s", unit, lastLine(lines))
+	}
+	return nil
+}
+
+func checkUnitEnabled(bc *bits.BuildContext, unit string) error {
+	lines, err := bc.CombinedOutputLinesInContainer("systemctl", "is-enabled", unit)
+	if err != nil {
+		return errors.Errorf("%s is not enabled: %s", unit, strings.Join(lines, " "))
+	}
+	return nil
+}
+
+// checkBinaries checks that the Kubernetes binaries work, and that their version is the version of the bits
+// added to the image; the versions of kubeadm and kubelet are not checked if the binaries were replaced
+func (c *Context) checkBinaries(bc *bits.BuildContext, m *BitsManifest) error {
+	expected := func(replaced string) string {
+		if replaced != "" {
+			return ""
+		}
+		return m.KubernetesVersion
+	}
+	var errs []string
+	for _, b := range []struct {
+		name, version string
+		versionFunc   func(*bits.BuildContext, string) (string, error)
+	}{
+		{name: "kubeadm", version: expected(c.kubeadmSrc), versionFunc: kubeadmVersion},
+		{name: "kubelet", version: expected(c.kubeletSrc), versionFunc: kubeletVersion},
+		{name: "kubectl", version: m.KubernetesVersion, versionFunc: kubectlVersion},
+	} {
+		if err := checkBinaryVersion(bc, b.name, b.version, b.versionFunc); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for _, v := range m.UpgradeVersions {
+		if err := checkBinaryVersion(bc, path.Join(upgradePath, v, "kubeadm"), v, kubeadmVersion); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func checkBinaryVersion(bc *bits.BuildContext, binary, expected string, versionFunc func(*bits.BuildContext, string) (string, error)) error {
+	version, err := versionFunc(bc, binary)
+	if err != nil {
+		return errors.Wrapf(err, "%s does not work", binary)
+	}
+	if expected != "" && version != expected {
+		return errors.Errorf("%s is %s, expected %s", binary, version, expected)
+	}
+	return nil
+}
+
+func kubeadmVersion(bc *bits.BuildContext, binary string) (string, error) {
+	lines, err := bc.CombinedOutputLinesInContainer(binary, "version", "-o", "short")
+	if err != nil || len(lines) == 0 {
+		return "", errors.Errorf("%s", lastLine(lines))
+	}
+	return lines[len(lines)-1], nil
+}
+
+// kubeletVersion returns the version of the kubelet, that prints e.g. Kubernetes v1.33.0
+func kubeletVersion(bc *bits.BuildContext, binary string) (string, error) {
+	lines, err := bc.CombinedOutputLinesInContainer(binary, "--version")
+	if err != nil || len(lines) == 0 {
+		return "", errors.Errorf("%s", lastLine(lines))
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	return fields[len(fields)-1], nil
+}
+
+func kubectlVersion(bc *bits.BuildContext, binary string) (string, error) {
+	lines, err := bc.CombinedOutputLinesInContainer(binary, "version", "--client", "-o", "json")
+	if err != nil {
+		return "", errors.Errorf("%s", lastLine(lines))
+	}
+	v := struct {
+		ClientVersion struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"clientVersion"`
+	}{}
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &v); err != nil {
+		return "", errors.Wrap(err, "failed to read the version")
+	}
+	return v.ClientVersion.GitVersion, nil
+}
+
+// checkImages checks that the images pulled during the build and, when init artifacts are added to the image,
+// the images of the Kubernetes components are in the node
+func (c *Context) checkImages(alterHelper *nodes.AlterHelper, bc *bits.BuildContext, m *BitsManifest) error {
+	expected := append([]string{}, m.Images...)
+	if c.initArtifactsSrc != "" && m.KubernetesVersion != "" {
+		lines, err := bc.CombinedOutputLinesInContainer("bash", "-c",
+			"/kind/bin/kubeadm config images list --kubernetes-version="+m.KubernetesVersion+" 2> /dev/null | grep 'kube-'",
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to list the images of the Kubernetes components")
+		}
+		expected = append(expected, lines...)
+	}
+
+	images, err := alterHelper.ListImages(bc)
+	if err != nil {
+		return err
+	}
+	found := map[string]bool{}
+	for _, i := range images {
+		found[normalizeImage(i)] = true
+	}
+	var missing []string
+	for _, i := range expected {
+		if !found[normalizeImage(i)] {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("images %s are missing", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// normalizeImage returns the fully qualified reference of an image, e.g. docker.io/library/nginx:latest for nginx,
+// because container runtimes list images with different forms of the same reference
+func normalizeImage(image string) string {
+	domain, remainder, ok := strings.Cut(image, "/")
+	if !ok || (!strings.ContainsAny(domain, ".:") && domain != "localhost") {
+		if !ok {
+			image = "library/" + image
+		}
+		image = "docker.io/" + image
+	} else if domain == "index.docker.io" {
+		image = "docker.io/" + remainder
+	}
+	if !strings.Contains(image, "@") && !strings.Contains(image[strings.LastIndex(image, "/")+1:], ":") {
+		image += ":latest"
+	}
+	return image
+}
+
+func lastLine(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[len(lines)-1]
+}
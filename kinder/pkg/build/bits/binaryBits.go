@@ -48,8 +48,10 @@ func (b *binaryBits) Prepare(c *BuildContext) (map[string]string, error) {
 	// and save it to the HostBitsPath
 	e := extract.NewExtractor(
 		b.src, c.HostBitsPath(),
-		extract.OnlyKubeadm(b.binaryName == "kubeadm"),
-		extract.OnlyKubelet(b.binaryName == "kubelet"),
+		c.ExtractOptions(
+			extract.OnlyKubeadm(b.binaryName == "kubeadm"),
+			extract.OnlyKubelet(b.binaryName == "kubelet"),
+		)...,
 	)
 
 	// Extracts the binary bit
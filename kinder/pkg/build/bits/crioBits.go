@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bits
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// crioBitsDir is the directory from which to install CRI-O
+	crioBitsDir = "crio"
+
+	crioReleases = "https://github.com/cri-o/cri-o/releases/download"
+
+	// crioTarball is the name of the CRI-O release bundle in crioBitsDir
+	crioTarball = "cri-o.tar.gz"
+)
+
+// crioBits defines a bit installer that replaces containerd with CRI-O as the container runtime of the node image
+type crioBits struct {
+	src string
+}
+
+var _ Installer = &crioBits{}
+
+// NewCRIOBits returns a new crioBits; src can be a CRI-O version, e.g. v1.31.0, or
+// the path to a CRI-O release bundle, e.g. cri-o.amd64.v1.31.0.tar.gz
+func NewCRIOBits(src string) Installer {
+	return &crioBits{
+		src: src,
+	}
+}
+
+// Prepare implements Installer.Prepare
+func (b *crioBits) Prepare(c *BuildContext) (map[string]string, error) {
+	// ensure the dest path exists on host/inside the HostBitsPath
+	dst := filepath.Join(c.HostBitsPath(), crioBitsDir)
+	if err := os.Mkdir(dst, 0777); err != nil {
+		return nil, errors.Wrap(err, "failed to make bits dir")
+	}
+
+	p, err := prepareRuntimeBit(c, b.src, crioReleases+"/v%[1]s", "cri-o.%[2]s.v%[1]s.tar.gz", dst, crioTarball)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get CRI-O %s", b.src)
+	}
+	return map[string]string{crioTarball: p}, nil
+}
+
+// Install implements Installer.Install
+func (b *crioBits) Install(c *BuildContext) error {
+	// The src path is a subfolder into the alterDir, that is mounted in the
+	// container as /alter
+	src := filepath.Join(c.ContainerBitsPath(), crioBitsDir)
+
+	log.Infof("Installing CRI-O %s in the image", b.src)
+	steps := []struct {
+		name   string
+		script string
+	}{
+		{
+			name: "install CRI-O",
+			// the bundle contains an install script that installs CRI-O, its dependencies, its config and the systemd unit
+			script: fmt.Sprintf("set -e; tmp=$(mktemp -d); tar -C $tmp -xzf %s; cd $tmp/cri-o; ./install; rm -rf $tmp", filepath.Join(src, crioTarball)),
+		},
+		{
+			name: "remove the CRI-O default CNI config",
+			// the node network is configured by the CNI plugin installed by kinder
+			script: "rm -f /etc/cni/net.d/*crio*",
+		},
+		{
+			name:   "use CRI-O as the container runtime",
+			script: "set -e; systemctl disable containerd; systemctl enable crio; printf 'runtime-endpoint: unix:///var/run/crio/crio.sock\\n' > /etc/crictl.yaml",
+		},
+		{
+			name: "install skopeo",
+			// skopeo is used for loading images tars into the CRI-O storage
+			script: "command -v skopeo > /dev/null || (apt-get update && apt-get install -y --no-install-recommends skopeo && rm -rf /var/lib/apt/lists/*)",
+		},
+	}
+	for _, s := range steps {
+		if err := c.RunInContainer("bash", "-c", s.script); err != nil {
+			log.Errorf("Image alter failed! %v", err)
+			return errors.Wrapf(err, "failed to %s", s.name)
+		}
+	}
+
+	if err := c.RunInContainer("crio", "--version"); err != nil {
+		return errors.Wrap(err, "CRI-O installed in the image does not work")
+	}
+	return nil
+}
@@ -60,13 +60,15 @@ func (b *imageBits) Prepare(c *BuildContext) (map[string]string, error) {
 		// and save it to the dest path (inside HostBitsPath)
 		e := extract.NewExtractor(
 			src, dst,
-			extract.OnlyKubernetesImages(true),
-			extract.WithNamePrefix(b.namePrefix),
+			c.ExtractOptions(
+				extract.OnlyKubernetesImages(true),
+				extract.WithNamePrefix(b.namePrefix),
+			)...,
 		)
 
-		// if the source is a local repository
-		if extract.GetSourceType(src) == extract.LocalRepositorySource {
-			// sets the extractor for importing all image tarballs existing in the local repository,
+		// if the source is a local repository or an OCI artifact
+		if t := extract.GetSourceType(src); t == extract.LocalRepositorySource || t == extract.OCIRegistrySource {
+			// sets the extractor for importing all image tarballs existing in the source,
 			// not only the kubernetes ones (this will allow to use this function for loading other images)
 			e.SetFiles(extract.AllImagesPattern)
 		}
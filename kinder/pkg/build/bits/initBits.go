@@ -59,6 +59,7 @@ func (b *initBits) Prepare(c *BuildContext) (map[string]string, error) {
 	// and save it to the dst folder
 	e := extract.NewExtractor(
 		b.src, dst,
+		c.ExtractOptions()...,
 	)
 
 	// Extracts the binaries & images
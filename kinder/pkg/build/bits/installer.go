@@ -15,7 +15,12 @@ limitations under the License.
 */
 
 /*
-Package bits provide utilities for managing bits (files/artifacts) to be installed into the image at build time
+Package bits provide utilities for managing bits (files/artifacts) to be installed into the image at build time.
+
+Bits are installed by Installers in two phases: all the installers prepare their bits on the host first,
+concurrently, and then each installer installs its bits in the alter container, in order. Downstream projects
+can add their own installers with alter.WithBitsInstallers, and their own sources of Kubernetes artifacts,
+e.g. internal mirrors, with extract.RegisterProvider; sources are available to all the installers.
 */
 package bits
 
@@ -23,13 +28,17 @@ import (
 	"path/filepath"
 
 	"k8s.io/kubeadm/kinder/pkg/exec"
+	"k8s.io/kubeadm/kinder/pkg/extract"
 )
 
 // Installer interface defines the behaviour of a type in charge of installing a specific set of bits (files/artifacts)
 type Installer interface {
-	// Prepare a set of bits into the temporary folder on the host machine
+	// Prepare a set of bits into the temporary folder on the host machine, usually in a subfolder of HostBitsPath
+	// named after the installer; it returns the paths of the prepared files by name. Installers are prepared
+	// concurrently, so Prepare must not depend on other installers
 	Prepare(*BuildContext) (map[string]string, error)
-	// Install should install (deploy) the bits on the image being altered
+	// Install should install (deploy) the bits on the image being altered; bits prepared in HostBitsPath are
+	// available in the alter container in ContainerBitsPath
 	Install(*BuildContext) error
 }
 
@@ -37,15 +46,32 @@ type Installer interface {
 type BuildContext struct {
 	hostBasePath string
 	containerID  string
+	arch         string
+	// extractOptions are applied when extracting bits from any source, e.g. for verifying downloaded files
+	extractOptions []extract.Option
+	// containerEnv are the env variables of the commands executed in the container, e.g. the proxy settings
+	containerEnv []string
 }
 
-// NewBuildContext returns a new BuildContext
-func NewBuildContext(tmpFolder string) *BuildContext {
+// NewBuildContext returns a new BuildContext for building an image for the given architecture
+func NewBuildContext(tmpFolder, arch string, extractOptions ...extract.Option) *BuildContext {
 	return &BuildContext{
-		hostBasePath: tmpFolder,
+		hostBasePath:   tmpFolder,
+		arch:           arch,
+		extractOptions: extractOptions,
 	}
 }
 
+// ExtractOptions returns the options for extracting bits for the image, followed by the given options
+func (c *BuildContext) ExtractOptions(options ...extract.Option) []extract.Option {
+	return append(append([]extract.Option{extract.WithArch(c.arch)}, c.extractOptions...), options...)
+}
+
+// Arch returns the architecture of the image being built, e.g. amd64
+func (c *BuildContext) Arch() string {
+	return c.arch
+}
+
 // HostBasePath returns the path of the temporary folder on the host machine used for the image build process
 func (c *BuildContext) HostBasePath() string {
 	return c.hostBasePath
@@ -71,26 +97,29 @@ func (c *BuildContext) BindToContainer(containerID string) {
 	c.containerID = containerID
 }
 
+// SetContainerEnv sets the env variables, in the KEY=VALUE format, of the commands executed in the container; the
+// variables are not set in the container itself, so they are not committed into the altered image
+func (c *BuildContext) SetContainerEnv(env []string) {
+	c.containerEnv = env
+}
+
+// execArgs returns the args of docker exec for running a command in the container
+func (c *BuildContext) execArgs(command string, args ...string) []string {
+	execArgs := []string{"exec"}
+	for _, e := range c.containerEnv {
+		execArgs = append(execArgs, "-e", e)
+	}
+	return append(append(execArgs, c.containerID, command), args...)
+}
+
 // RunInContainer executes a command on the container used for altering the image
 func (c *BuildContext) RunInContainer(command string, args ...string) error {
-	cmd := exec.NewHostCmd(
-		"docker",
-		append(
-			[]string{"exec", c.containerID, command},
-			args...,
-		)...,
-	)
+	cmd := exec.NewHostCmd("docker", c.execArgs(command, args...)...)
 	return cmd.RunWithEcho()
 }
 
 // CombinedOutputLinesInContainer executes a command on the container used for altering the image and returns CombinedOutputLines
 func (c *BuildContext) CombinedOutputLinesInContainer(command string, args ...string) ([]string, error) {
-	cmd := exec.NewHostCmd(
-		"docker",
-		append(
-			[]string{"exec", c.containerID, command},
-			args...,
-		)...,
-	)
+	cmd := exec.NewHostCmd("docker", c.execArgs(command, args...)...)
 	return cmd.RunAndCapture()
 }
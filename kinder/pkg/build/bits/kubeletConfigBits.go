@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bits
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kubeletConfigBitsDir is the directory from which to install the kubelet configuration fragments
+	kubeletConfigBitsDir = "kubelet-config"
+
+	// kubeletConfigDir is the kubelet drop-in configuration folder in the image
+	kubeletConfigDir = "/etc/kubernetes/kubelet.conf.d"
+
+	// kubeletConfigDropIn is the kubelet service drop-in passing kubeletConfigDir to the kubelet; it sorts after
+	// the kubeadm drop-in, so it overrides the KUBELET_CONFIG_ARGS defined there
+	kubeletConfigDropIn = "/etc/systemd/system/kubelet.service.d/20-kinder-kubelet-config.conf"
+)
+
+// minKubeletConfigDirVersion is the first kubelet version with the --config-dir flag enabled by default
+var minKubeletConfigDirVersion = K8sVersion.MustParseSemantic("v1.30.0")
+
+// kubeletConfigBits defines a bit installer that bakes KubeletConfiguration fragments into the node image;
+// fragments are merged by the kubelet on top of the configuration generated by kubeadm, in the given order
+type kubeletConfigBits struct {
+	srcs []string
+}
+
+var _ Installer = &kubeletConfigBits{}
+
+// NewKubeletConfigBits returns a new kubeletConfigBits; srcs are files with a KubeletConfiguration fragment,
+// e.g. one setting only maxPods
+func NewKubeletConfigBits(srcs []string) Installer {
+	return &kubeletConfigBits{
+		srcs: srcs,
+	}
+}
+
+// Prepare implements Installer.Prepare
+func (b *kubeletConfigBits) Prepare(c *BuildContext) (map[string]string, error) {
+	// ensure the dest path exists on host/inside the HostBitsPath; fragments are in the conf.d
+	// subfolder, and the kubelet service drop-in next to it
+	dst := filepath.Join(c.HostBitsPath(), kubeletConfigBitsDir, "conf.d")
+	if err := os.MkdirAll(dst, 0777); err != nil {
+		return nil, errors.Wrap(err, "failed to make bits dir")
+	}
+	dropIn := filepath.Join(c.HostBitsPath(), kubeletConfigBitsDir, filepath.Base(kubeletConfigDropIn))
+	if err := os.WriteFile(dropIn, []byte(kubeletConfigDropInContent), 0644); err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s", dropIn)
+	}
+
+	paths := map[string]string{}
+	for i, src := range b.srcs {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", src)
+		}
+		if err := validateKubeletConfig(data); err != nil {
+			return nil, errors.Wrapf(err, "invalid kubelet configuration in %s", src)
+		}
+
+		// the kubelet reads only files with the .conf extension, sorted by name
+		name := fmt.Sprintf("%02d-%s.conf", i+10, strings.TrimSuffix(filepath.Base(src), filepath.Ext(src)))
+		if err := os.WriteFile(filepath.Join(dst, name), data, 0644); err != nil {
+			return nil, errors.Wrapf(err, "failed to copy %s", src)
+		}
+		paths[name] = filepath.Join(dst, name)
+	}
+	return paths, nil
+}
+
+// validateKubeletConfig checks that a fragment is a KubeletConfiguration, because the kubelet
+// refuses to start with invalid files in the drop-in folder
+func validateKubeletConfig(data []byte) error {
+	var typeMeta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(typeMeta.APIVersion, "kubelet.config.k8s.io/") || typeMeta.Kind != "KubeletConfiguration" {
+		return errors.Errorf("expected kind KubeletConfiguration of kubelet.config.k8s.io, got %q of %q", typeMeta.Kind, typeMeta.APIVersion)
+	}
+	return nil
+}
+
+// Install implements Installer.Install
+func (b *kubeletConfigBits) Install(c *BuildContext) error {
+	// the drop-in folder is ignored by older kubelets, so check the kubelet in the image,
+	// that is installed with the other bits before this installer
+	lines, err := c.CombinedOutputLinesInContainer("kubelet", "--version")
+	if err != nil {
+		return errors.Wrap(err, "failed to get the kubelet version")
+	}
+	if len(lines) == 0 {
+		return errors.New("failed to get the kubelet version")
+	}
+	v, err := K8sVersion.ParseSemantic(strings.TrimPrefix(lines[0], "Kubernetes "))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the kubelet version %q", lines[0])
+	}
+	if v.LessThan(minKubeletConfigDirVersion) {
+		return errors.Errorf("kubelet configuration fragments require kubelet %s or greater, the image has kubelet %s", minKubeletConfigDirVersion, v)
+	}
+
+	// The src path is a subfolder into the alterDir, that is mounted in the
+	// container as /alter
+	src := filepath.Join(c.ContainerBitsPath(), kubeletConfigBitsDir)
+
+	log.Infof("Adding kubelet configuration fragments to %s", kubeletConfigDir)
+	if err := c.RunInContainer("mkdir", "-p", kubeletConfigDir, filepath.Dir(kubeletConfigDropIn)); err != nil {
+		log.Errorf("Image alter failed! %v", err)
+		return err
+	}
+	if err := c.RunInContainer("cp", "-r", filepath.Join(src, "conf.d")+"/.", kubeletConfigDir); err != nil {
+		log.Errorf("Image alter failed! %v", err)
+		return err
+	}
+	if err := c.RunInContainer("cp", filepath.Join(src, filepath.Base(kubeletConfigDropIn)), kubeletConfigDropIn); err != nil {
+		log.Errorf("Image alter failed! %v", err)
+		return err
+	}
+	if err := c.RunInContainer("chown", "-R", "root:root", kubeletConfigDir, kubeletConfigDropIn); err != nil {
+		log.Errorf("Image alter failed! %v", err)
+		return err
+	}
+	return nil
+}
+
+// kubeletConfigDropInContent passes the drop-in folder to the kubelet, together with the config file written by kubeadm
+var kubeletConfigDropInContent = `[Service]
+Environment="KUBELET_CONFIG_ARGS=--config=/var/lib/kubelet/config.yaml --config-dir=` + kubeletConfigDir + `"
+`
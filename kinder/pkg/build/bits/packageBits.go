@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bits
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// packagesRepositoryURL is the URL of the community repositories of the Kubernetes packages,
+// formatted with the channel, stable or prerelease, and the minor version, e.g. v1.33
+const packagesRepositoryURL = "https://pkgs.k8s.io/core:/%s:/%s"
+
+// minorVersionRegexp matches minor versions, e.g. v1.33
+var minorVersionRegexp = regexp.MustCompile(`^v?[0-9]+\.[0-9]+$`)
+
+// packages are the Kubernetes packages installed by packageBits
+var packages = []string{"kubeadm", "kubelet", "kubectl"}
+
+// packageBits defines a bit installer that installs kubeadm, kubelet and kubectl from the deb or rpm packages
+// published in the community repositories, so the installation path most users follow is tested
+type packageBits struct {
+	version string
+}
+
+var _ Installer = &packageBits{}
+
+// NewPackageBits returns a new packageBits; version can be a minor version, e.g. v1.33, for the latest packages of the
+// minor, or a version, e.g. v1.33.1 or v1.34.0-alpha.1, for the packages of that version
+func NewPackageBits(version string) Installer {
+	return &packageBits{
+		version: version,
+	}
+}
+
+// Prepare implements Installer.Prepare
+func (b *packageBits) Prepare(c *BuildContext) (map[string]string, error) {
+	// packages are downloaded by the package manager in the image
+	if _, _, err := packagesRepository(b.version); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// packagesRepository returns the repository of the packages for a version, and the version of the packages,
+// that is empty when the latest packages of the minor should be installed
+func packagesRepository(version string) (repository, packageVersion string, err error) {
+	if v, err := K8sVersion.ParseSemantic(version); err == nil {
+		channel := "stable"
+		packageVersion = fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+		if v.PreRelease() != "" {
+			// pre-releases are published in a separate repository, and sorted before the release with ~
+			channel = "prerelease"
+			packageVersion += "~" + v.PreRelease()
+		}
+		return fmt.Sprintf(packagesRepositoryURL, channel, fmt.Sprintf("v%d.%d", v.Major(), v.Minor())), packageVersion, nil
+	}
+	if !minorVersionRegexp.MatchString(version) {
+		return "", "", errors.Errorf("invalid packages version %q, expected a minor version, e.g. v1.33, or a version, e.g. v1.33.1", version)
+	}
+	return fmt.Sprintf(packagesRepositoryURL, "stable", "v"+strings.TrimPrefix(version, "v")), "", nil
+}
+
+// Install implements Install.Install
+func (b *packageBits) Install(c *BuildContext) error {
+	repository, packageVersion, err := packagesRepository(b.version)
+	if err != nil {
+		return err
+	}
+
+	var debs, rpms []string
+	for _, p := range packages {
+		if packageVersion == "" {
+			debs, rpms = append(debs, p), append(rpms, p)
+			continue
+		}
+		debs = append(debs, fmt.Sprintf("%s=%s-*", p, packageVersion))
+		rpms = append(rpms, fmt.Sprintf("%s-%s", p, packageVersion))
+	}
+
+	// the binaries in the image, if any, are symlinks to /kind/bin; they are removed, so the binaries
+	// from the packages are installed in /usr/bin, and /kind/bin points to them instead
+	script := fmt.Sprintf(`set -e
+rm -f /usr/bin/kubeadm /usr/bin/kubelet /usr/bin/kubectl
+if command -v apt-get > /dev/null; then
+  apt-get update
+  apt-get install -y --no-install-recommends ca-certificates curl gpg
+  mkdir -p -m 755 /etc/apt/keyrings
+  curl -fsSL %[1]s/deb/Release.key | gpg --dearmor --yes -o /etc/apt/keyrings/kubernetes-apt-keyring.gpg
+  echo 'deb [signed-by=/etc/apt/keyrings/kubernetes-apt-keyring.gpg] %[1]s/deb/ /' > /etc/apt/sources.list.d/kubernetes.list
+  apt-get update
+  apt-get install -y --allow-downgrades --allow-change-held-packages %[2]s
+  apt-mark hold %[4]s
+  rm -rf /var/lib/apt/lists/*
+elif command -v dnf > /dev/null || command -v yum > /dev/null; then
+  printf '[kubernetes]\nname=Kubernetes\nbaseurl=%[1]s/rpm/\nenabled=1\ngpgcheck=1\ngpgkey=%[1]s/rpm/repodata/repomd.xml.key\n' > /etc/yum.repos.d/kubernetes.repo
+  $(command -v dnf || command -v yum) install -y %[3]s
+else
+  echo "no supported package manager found in the image" >&2
+  exit 1
+fi
+mkdir -p /kind/bin
+for b in %[4]s; do ln -sf /usr/bin/$b /kind/bin/$b; done
+kubeadm version -o short > /kind/version
+`, repository, strings.Join(debs, " "), strings.Join(rpms, " "), strings.Join(packages, " "))
+
+	log.Infof("Installing %s packages from %s", strings.Join(packages, ", "), repository)
+	if err := c.RunInContainer("bash", "-c", script); err != nil {
+		log.Errorf("Image alter failed! %v", err)
+		return errors.Wrapf(err, "failed to install the packages for %s", b.version)
+	}
+	return nil
+}
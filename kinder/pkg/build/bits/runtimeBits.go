@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bits
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+
+	"k8s.io/kubeadm/kinder/pkg/extract"
+)
+
+const (
+	// runtimeBitsDir is the directory from which to install the container runtime bits
+	runtimeBitsDir = "runtime"
+
+	containerdReleases = "https://github.com/containerd/containerd/releases/download"
+	runcReleases       = "https://github.com/opencontainers/runc/releases/download"
+
+	// file names of the container runtime bits in runtimeBitsDir
+	containerdTarball = "containerd.tar.gz"
+	runcBinary        = "runc"
+)
+
+// runtimeBits defines a bit installer that allows to replace the containerd and the runc binaries
+// in the node image, e.g. for testing kubeadm with upcoming or minimum supported runtime versions
+type runtimeBits struct {
+	containerd string
+	runc       string
+}
+
+var _ Installer = &runtimeBits{}
+
+// NewRuntimeBits returns a new runtimeBits; containerd and runc can be a version, e.g. v2.0.0, or
+// the path to the containerd release tarball or to the runc binary; empty values are ignored
+func NewRuntimeBits(containerd, runc string) Installer {
+	return &runtimeBits{
+		containerd: containerd,
+		runc:       runc,
+	}
+}
+
+// Prepare implements Installer.Prepare
+func (b *runtimeBits) Prepare(c *BuildContext) (map[string]string, error) {
+	// ensure the dest path exists on host/inside the HostBitsPath
+	dst := filepath.Join(c.HostBitsPath(), runtimeBitsDir)
+	if err := os.Mkdir(dst, 0777); err != nil {
+		return nil, errors.Wrap(err, "failed to make bits dir")
+	}
+
+	paths := map[string]string{}
+	if b.containerd != "" {
+		p, err := prepareRuntimeBit(c, b.containerd, containerdReleases+"/v%[1]s", "containerd-%[1]s-linux-%[2]s.tar.gz", dst, containerdTarball)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get containerd %s", b.containerd)
+		}
+		paths[containerdTarball] = p
+	}
+	if b.runc != "" {
+		p, err := prepareRuntimeBit(c, b.runc, runcReleases+"/v%[1]s", "runc.%[2]s", dst, runcBinary)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get runc %s", b.runc)
+		}
+		paths[runcBinary] = p
+	}
+	return paths, nil
+}
+
+// prepareRuntimeBit gets a file from the releases of a project, if src is a version, or from a local path, and
+// saves it into dst with the given name; the release url and file name are formatted with the version and the arch
+func prepareRuntimeBit(c *BuildContext, src, releaseURL, releaseFile, dst, name string) (string, error) {
+	var files []string
+	if v, err := K8sVersion.ParseSemantic(src); err == nil {
+		version := v.String()
+		src = fmt.Sprintf(releaseURL, version)
+		files = []string{fmt.Sprintf(releaseFile, version, c.arch)}
+	}
+
+	e := extract.NewExtractor(
+		src, dst,
+		c.ExtractOptions(
+			extract.WithVersionFile(false),
+			extract.WithNameOverride(name),
+		)...,
+	)
+	if files != nil {
+		e.SetFiles(files)
+	}
+	if _, err := e.Extract(); err != nil {
+		return "", err
+	}
+	return filepath.Join(dst, name), nil
+}
+
+// Install implements Installer.Install
+func (b *runtimeBits) Install(c *BuildContext) error {
+	// The src path is a subfolder into the alterDir, that is mounted in the
+	// container as /alter
+	src := filepath.Join(c.ContainerBitsPath(), runtimeBitsDir)
+
+	// the binaries are replaced in the folders where the image has them, defaulting to the folders
+	// used by the kind base image
+	if b.containerd != "" {
+		log.Infof("Replacing containerd binaries in the image with %s", b.containerd)
+		script := fmt.Sprintf(
+			"set -e; dir=$(dirname $(command -v containerd || echo /usr/local/bin/containerd)); tmp=$(mktemp -d); "+
+				"tar -C $tmp -xzf %s; cp $tmp/bin/* $dir/; chown root:root $dir/*; rm -rf $tmp",
+			filepath.Join(src, containerdTarball),
+		)
+		if err := c.RunInContainer("bash", "-c", script); err != nil {
+			log.Errorf("Image alter failed! %v", err)
+			return err
+		}
+		if err := c.RunInContainer("containerd", "--version"); err != nil {
+			return errors.Wrap(err, "containerd installed in the image does not work")
+		}
+	}
+
+	if b.runc != "" {
+		log.Infof("Replacing runc binary in the image with %s", b.runc)
+		script := fmt.Sprintf(
+			"set -e; dst=$(command -v runc || echo /usr/local/sbin/runc); cp %s $dst; chown root:root $dst; chmod 755 $dst",
+			filepath.Join(src, runcBinary),
+		)
+		if err := c.RunInContainer("bash", "-c", script); err != nil {
+			log.Errorf("Image alter failed! %v", err)
+			return err
+		}
+		if err := c.RunInContainer("runc", "--version"); err != nil {
+			return errors.Wrap(err, "runc installed in the image does not work")
+		}
+	}
+
+	return nil
+}
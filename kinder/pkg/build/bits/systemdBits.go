@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bits
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	kindfs "sigs.k8s.io/kind/pkg/fs"
+)
+
+const (
+	// systemdBitsDir is the directory from which to install the systemd units; this is different from the
+	// systemd directory used by initBits for the kubelet unit
+	systemdBitsDir = "extra-systemd"
+
+	// systemdUnitsPath is the folder for the systemd units in the image
+	systemdUnitsPath = "/etc/systemd/system"
+)
+
+// systemdUnitTypes are the types of systemd units that can be installed
+var systemdUnitTypes = []string{".service", ".socket", ".timer", ".mount", ".automount", ".path", ".target", ".slice"}
+
+// systemdBits defines a bit installer that allows to install systemd units and drop-ins from a folder on the host
+// into the node image; units with an [Install] section are enabled too
+type systemdBits struct {
+	src   string
+	units []string
+}
+
+var _ Installer = &systemdBits{}
+
+// NewSystemdBits returns a new systemdBits; src is a folder with unit files, e.g. sysctl.service,
+// and drop-in folders, e.g. kubelet.service.d/10-custom.conf
+func NewSystemdBits(src string) Installer {
+	return &systemdBits{
+		src: src,
+	}
+}
+
+// Prepare implements Installer.Prepare
+func (b *systemdBits) Prepare(c *BuildContext) (map[string]string, error) {
+	entries, err := os.ReadDir(b.src)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the systemd units folder %s", b.src)
+	}
+
+	// checks the content of the folder before copying it, so typos in file names don't go unnoticed
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			if !strings.HasSuffix(name, ".d") {
+				return nil, errors.Errorf("invalid folder %s in %s, only drop-in folders, e.g. kubelet.service.d, are supported", name, b.src)
+			}
+			continue
+		}
+		if !isSystemdUnit(name) {
+			return nil, errors.Errorf("invalid file %s in %s, only systemd units are supported", name, b.src)
+		}
+		data, err := os.ReadFile(filepath.Join(b.src, name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", name)
+		}
+		if strings.Contains(string(data), "[Install]") {
+			b.units = append(b.units, name)
+		}
+	}
+
+	dst := filepath.Join(c.HostBitsPath(), systemdBitsDir)
+	if err := kindfs.Copy(b.src, dst); err != nil {
+		return nil, errors.Wrapf(err, "failed to copy %s", b.src)
+	}
+	return nil, nil
+}
+
+// Install implements Installer.Install
+func (b *systemdBits) Install(c *BuildContext) error {
+	// The src path is a subfolder into the alterDir, that is mounted in the
+	// container as /alter
+	src := filepath.Join(c.ContainerBitsPath(), systemdBitsDir)
+
+	log.Infof("Installing systemd units and drop-ins from %s", b.src)
+	if err := c.RunInContainer("cp", "-r", src+"/.", systemdUnitsPath); err != nil {
+		log.Errorf("Image alter Failed! %v", err)
+		return err
+	}
+	if err := c.RunInContainer("chown", "-R", "root:root", systemdUnitsPath); err != nil {
+		log.Errorf("Image alter failed! %v", err)
+		return err
+	}
+
+	for _, u := range b.units {
+		if err := c.RunInContainer("systemctl", "enable", u); err != nil {
+			return errors.Wrapf(err, "failed to enable %s", u)
+		}
+	}
+	return nil
+}
+
+func isSystemdUnit(name string) bool {
+	for _, t := range systemdUnitTypes {
+		if strings.HasSuffix(name, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
 
 	"k8s.io/kubeadm/kinder/pkg/extract"
 )
@@ -29,15 +30,16 @@ import (
 // upgradeBits defines a bit installer that allows to add Kubernetes binaries & images to the /kinder/upgrade folder into the node image;
 // those artifact will be used by the kinder do kubeadm-upgrade script
 type upgradeBits struct {
-	src string
+	srcs []string
 }
 
 var _ Installer = &upgradeBits{}
 
-// NewUpgradeBits returns a new upgradeBits
-func NewUpgradeBits(arg string) Installer {
+// NewUpgradeBits returns a new upgradeBits; artifacts from many srcs are stored in a folder for each version,
+// e.g. for testing upgrades across many versions
+func NewUpgradeBits(srcs ...string) Installer {
 	return &upgradeBits{
-		src: arg,
+		srcs: srcs,
 	}
 }
 
@@ -49,16 +51,58 @@ func (b *upgradeBits) Prepare(c *BuildContext) (map[string]string, error) {
 		return nil, errors.Wrap(err, "failed to make bits dir")
 	}
 
-	// Creates an extractor instance, that will read binaries & images required from upgrades from the src,
-	// where source can be one of version/build-label/folder containing the  binaries & images,
-	// and save it to the dst folder
-	e := extract.NewExtractor(
-		b.src, dst,
-		extract.WithVersionFolder(true),
-	)
+	paths := map[string]string{}
+	for _, src := range b.srcs {
+		// Creates an extractor instance, that will read binaries & images required from upgrades from the src,
+		// where source can be one of version/build-label/folder containing the  binaries & images,
+		// and save it to the dst folder
+		e := extract.NewExtractor(
+			src, dst,
+			c.ExtractOptions(
+				extract.WithVersionFolder(true),
+			)...,
+		)
+
+		// Extracts the binary bit
+		p, err := e.Extract()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range p {
+			paths[filepath.Join(filepath.Base(filepath.Dir(v)), k)] = v
+		}
+	}
+
+	// each src overrides the version file, so it is written again with the latest version
+	if len(b.srcs) > 1 {
+		if err := writeLatestVersion(dst); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
 
-	// Extracts the binary bit
-	return e.Extract()
+// writeLatestVersion writes in the version file in dst the latest version among the version folders in dst
+func writeLatestVersion(dst string) error {
+	entries, err := os.ReadDir(dst)
+	if err != nil {
+		return err
+	}
+	var latest *K8sVersion.Version
+	for _, e := range entries {
+		v, err := K8sVersion.ParseSemantic(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		if latest == nil || latest.LessThan(v) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return errors.Errorf("no version folder in %s", dst)
+	}
+	log.Infof("Upgrade artifacts for many versions, v%s is the default version for upgrades", latest)
+	return os.WriteFile(filepath.Join(dst, "version"), []byte("v"+latest.String()), 0644)
 }
 
 // Install implements bits.Install
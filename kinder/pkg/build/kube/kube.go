@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package kube implements support for building Kubernetes binaries and images from
+a local Kubernetes source tree, e.g. for testing uncommitted kubeadm changes
+*/
+package kube
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/exec"
+	kindfs "sigs.k8s.io/kind/pkg/fs"
+)
+
+var (
+	// binaries built from the Kubernetes source tree
+	binaries = []string{"kubeadm", "kubelet", "kubectl"}
+
+	// images built from the Kubernetes source tree
+	images = []string{"kube-apiserver.tar", "kube-controller-manager.tar", "kube-scheduler.tar", "kube-proxy.tar"}
+)
+
+// FindKubeRoot returns the Kubernetes source tree in GOPATH, if any
+func FindKubeRoot() (string, error) {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		lines, err := exec.NewHostCmd("go", "env", "GOPATH").RunAndCapture()
+		if err != nil || len(lines) == 0 || lines[0] == "" {
+			return "", errors.New("unable to get GOPATH env variable. Please provide Kubernetes source path using the --kube-root flag")
+		}
+		gopath = lines[0]
+	}
+	kubeRoot := filepath.Join(gopath, "src", "k8s.io", "kubernetes")
+	if err := checkKubeRoot(kubeRoot); err != nil {
+		return "", errors.Errorf("%v. Please provide Kubernetes source path using the --kube-root flag", err)
+	}
+	return kubeRoot, nil
+}
+
+// checkKubeRoot checks that a folder looks like a Kubernetes source tree
+func checkKubeRoot(kubeRoot string) error {
+	if _, err := os.Stat(filepath.Join(kubeRoot, "hack", "print-workspace-status.sh")); err != nil {
+		return errors.Errorf("%s does not seems a valid Kubernetes source folder", kubeRoot)
+	}
+	return nil
+}
+
+// Build builds kubeadm, kubelet, kubectl and the control plane images for linux/arch from a Kubernetes
+// source tree, and copies them into dst together with a version file, so dst can be used as a source
+// for the kinder extractor, e.g. as init artifacts for altering a node image
+func Build(kubeRoot, arch, dst string) error {
+	kubeRoot, err := filepath.Abs(kubeRoot)
+	if err != nil {
+		return errors.Wrapf(err, "invalid Kubernetes source path %s", kubeRoot)
+	}
+	if err := checkKubeRoot(kubeRoot); err != nil {
+		return err
+	}
+
+	version, err := sourceVersion(kubeRoot)
+	if err != nil {
+		return err
+	}
+	log.Infof("Building Kubernetes %s for linux/%s from %s", version, arch, kubeRoot)
+
+	platform := fmt.Sprintf("KUBE_BUILD_PLATFORMS=linux/%s", arch)
+	var targets []string
+	for _, b := range binaries {
+		targets = append(targets, "cmd/"+b)
+	}
+	what := fmt.Sprintf("WHAT=%s", strings.Join(targets, " "))
+
+	// binaries are built in a container, so they do not depend on the host toolchain
+	if err := runBuild(filepath.Join(kubeRoot, "build", "run.sh"), "make", "all", what, platform); err != nil {
+		return errors.Wrap(err, "error building Kubernetes binaries")
+	}
+	if err := runBuild("make", "-C", kubeRoot, "quick-release-images", platform, "KUBE_BUILD_CONFORMANCE=n"); err != nil {
+		return errors.Wrap(err, "error building Kubernetes images")
+	}
+
+	binDir := filepath.Join(kubeRoot, "_output", "dockerized", "bin", "linux", arch)
+	for _, b := range binaries {
+		if err := copyFile(filepath.Join(binDir, b), filepath.Join(dst, b), 0755); err != nil {
+			return err
+		}
+	}
+	imagesDir := filepath.Join(kubeRoot, "_output", "release-images", arch)
+	for _, i := range images {
+		if err := copyFile(filepath.Join(imagesDir, i), filepath.Join(dst, i), 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dst, "version"), []byte(version), 0644); err != nil {
+		return errors.Wrapf(err, "error creating version file in %s", dst)
+	}
+	return nil
+}
+
+// sourceVersion returns the version of the Kubernetes source tree, as computed by the Kubernetes build
+// scripts; uncommitted changes are reported with the -dirty suffix
+func sourceVersion(kubeRoot string) (string, error) {
+	lines, err := exec.NewHostCmd(filepath.Join(kubeRoot, "hack", "print-workspace-status.sh")).RunAndCapture()
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting the Kubernetes version from %s", kubeRoot)
+	}
+	for _, l := range lines {
+		if parts := strings.Fields(l); len(parts) == 2 && parts[0] == "gitVersion" {
+			return parts[1], nil
+		}
+	}
+	return "", errors.Errorf("unable to get the Kubernetes version from %s", kubeRoot)
+}
+
+// runBuild runs a build command, echoing its output
+func runBuild(command string, args ...string) error {
+	return exec.NewHostCmd(command, args...).
+		SetEnv(append(os.Environ(), "KUBE_VERBOSE=0")...).
+		RunWithEcho()
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	log.Infof("Copying %s", src)
+	if err := kindfs.CopyFile(src, dst); err != nil {
+		return errors.Wrapf(err, "error copying build output %s", src)
+	}
+	return os.Chmod(dst, mode)
+}
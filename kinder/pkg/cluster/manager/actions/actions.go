@@ -20,6 +20,7 @@ Package actions implements kinder actions executed by the Kinder cluster manager
 package actions
 
 import (
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -28,43 +29,249 @@ import (
 
 	K8sVersion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+// action defines an entry of the action registry
+type action struct {
+	// description of the action, used for documenting the action catalog
+	description string
+	// flags lists the kinder do flags supported by the action, in addition to --name, --dry-run, --results-file,
+	// --artifacts-dir and the node selection flags, that are supported by all the actions
+	flags []string
+	// roles lists the node roles the action applies to
+	roles []string
+	// artifacts lists files and command outputs to be captured from nodes when the action completes,
+	// in addition to failureArtifacts that are captured for all the actions
+	artifacts []artifact
+	// run is the action entry point
+	run func(*status.Cluster, *RunOptions) error
+}
+
+var (
+	k8sNodeRoles          = []string{constants.ControlPlaneNodeRoleValue, constants.WorkerNodeRoleValue}
+	controlPlaneNodeRoles = []string{constants.ControlPlaneNodeRoleValue}
 )
 
 // action registry defines the list of available actions and the corresponding entry point.
-var actionRegistry = map[string]func(*status.Cluster, *RunOptions) error{
-	"loadbalancer": func(c *status.Cluster, flags *RunOptions) error {
-		// Nb. this action is invoked automatically at kubeadm init/join time, but it is possible
-		// to invoke it separately as well
-		return LoadBalancer(c, c.ControlPlanes()...)
+var actionRegistry = map[string]action{
+	"loadbalancer": {
+		description: "Updates the load balancer configuration, if present",
+		roles:       []string{constants.ExternalLoadBalancerNodeRoleValue},
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			// Nb. this action is invoked automatically at kubeadm init/join time, but it is possible
+			// to invoke it separately as well
+			return LoadBalancer(c, c.ControlPlanes()...)
+		},
+	},
+	"kubeadm-config": {
+		description: "Creates the kubeadm config file on nodes",
+		flags:       []string{"kubeadm-config-version", "copy-certs", "discovery-mode", "kubeadm-feature-gate", "kubeadm-encryption-algorithm", "cloud-provider", "external-etcd-endpoints", "external-etcd-ca-file", "external-etcd-cert-file", "external-etcd-key-file", "ignore-preflight-errors", "upgrade-version"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			// Nb. this action is invoked automatically at kubeadm init/join time, but it is possible
+			// to invoke it separately as well
+			return KubeadmConfig(c, flags.kubeadmConfigVersion, flags.copyCertsMode, flags.discoveryMode, flags.featureGate, flags.encryptionAlgorithm, flags.cloudProvider, flags.externalEtcd, flags.ignorePreflightErrors, flags.upgradeVersion, c.K8sNodes().EligibleForActions()...)
+		},
+	},
+	"cloud-provider-external": {
+		description: "Initializes nodes registered with --cloud-provider=external using a fake cloud controller manager",
+		flags:       []string{"wait"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return CloudProviderExternalTest(c, flags.wait)
+		},
+	},
+	"componentconfig-validate": {
+		description: "Validates the kubelet and kube-proxy component configs and compares them with the kubeadm defaults",
+		flags:       []string{"kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return ComponentConfigValidate(c, flags.vLevel)
+		},
+	},
+	"etcd-members-verify": {
+		description: "Verifies that each joined control-plane node has one etcd member, promoted from learner and healthy",
+		flags:       []string{"wait"},
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return EtcdMembersVerify(c, flags.wait)
+		},
+	},
+	"kubeadm-configmaps": {
+		description: "Dumps the kubeadm-config and kubelet-config ConfigMaps and verifies their content matches the installed versions",
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmConfigMaps(c)
+		},
+	},
+	"kubeadm-config-migrate": {
+		description: "Tests kubeadm config migrate from an older kubeadm config API version",
+		flags:       []string{"kubeadm-config-version", "kubeadm-verbosity"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmConfigMigrate(c, flags.kubeadmConfigVersion, flags.vLevel)
+		},
+	},
+	"kubeadm-init": {
+		description: "Executes the kubeadm init workflow, installs the CNI plugin and copies the kubeconfig file on the host",
+		flags:       []string{"use-phases", "init-phases", "skip-init-phases", "copy-certs", "kubeadm-config-version", "patches", "ignore-preflight-errors", "kubeadm-feature-gate", "kubeadm-encryption-algorithm", "cloud-provider", "external-etcd-endpoints", "external-etcd-ca-file", "external-etcd-cert-file", "external-etcd-key-file", "kubeadm-extra-flags", "wait", "kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		artifacts:   kubeadmArtifacts,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			initPhases, err := resolveInitPhases(flags.initPhases, flags.skipInitPhases)
+			if err != nil {
+				return err
+			}
+			return KubeadmInit(c, flags.usePhases, initPhases, flags.copyCertsMode, flags.kubeadmConfigVersion, flags.patchesDir, flags.ignorePreflightErrors, flags.featureGate, flags.encryptionAlgorithm, flags.cloudProvider, flags.externalEtcd, flags.kubeadmExtraFlags, flags.wait, flags.vLevel)
+		},
+	},
+	"kubeadm-join": {
+		description: "Executes the kubeadm join workflow on secondary control-plane nodes and on worker nodes",
+		flags:       []string{"use-phases", "copy-certs", "discovery-mode", "kubeadm-config-version", "patches", "ignore-preflight-errors", "cloud-provider", "kubeadm-extra-flags", "verify-etcd-members", "wait", "kubeadm-verbosity"},
+		roles:       k8sNodeRoles,
+		artifacts:   kubeadmArtifacts,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmJoin(c, flags.usePhases, flags.copyCertsMode, flags.discoveryMode, flags.kubeadmConfigVersion, flags.patchesDir, flags.ignorePreflightErrors, flags.cloudProvider, flags.kubeadmExtraFlags, flags.verifyEtcdMembers, flags.wait, flags.vLevel)
+		},
 	},
-	"kubeadm-config": func(c *status.Cluster, flags *RunOptions) error {
-		// Nb. this action is invoked automatically at kubeadm init/join time, but it is possible
-		// to invoke it separately as well
-		return KubeadmConfig(c, flags.kubeadmConfigVersion, flags.copyCertsMode, flags.discoveryMode, flags.featureGate, flags.encryptionAlgorithm, flags.ignorePreflightErrors, flags.upgradeVersion, c.K8sNodes().EligibleForActions()...)
+	"kubeadm-upgrade": {
+		description: "Executes the kubeadm upgrade workflow and upgrades kubelet and kubectl",
+		flags:       []string{"upgrade-version", "patches", "ignore-preflight-errors", "kubeadm-extra-flags", "wait", "kubeadm-verbosity"},
+		roles:       k8sNodeRoles,
+		artifacts:   kubeadmArtifacts,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmUpgrade(c, flags.upgradeVersion, flags.patchesDir, flags.ignorePreflightErrors, flags.kubeadmExtraFlags, flags.wait, flags.vLevel)
+		},
 	},
-	"kubeadm-init": func(c *status.Cluster, flags *RunOptions) error {
-		return KubeadmInit(c, flags.usePhases, flags.copyCertsMode, flags.kubeadmConfigVersion, flags.patchesDir, flags.ignorePreflightErrors, flags.featureGate, flags.encryptionAlgorithm, flags.wait, flags.vLevel)
+	"kubeadm-rollback-node": {
+		description: "Swaps kubeadm back to a previous version on the selected nodes and checks the result of kubeadm upgrade node",
+		flags:       []string{"upgrade-version", "rollback-expect", "ignore-preflight-errors", "wait", "kubeadm-verbosity"},
+		roles:       k8sNodeRoles,
+		artifacts:   kubeadmArtifacts,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmRollbackNode(c, flags.upgradeVersion, flags.rollbackExpectation, flags.ignorePreflightErrors, flags.wait, flags.vLevel)
+		},
 	},
-	"kubeadm-join": func(c *status.Cluster, flags *RunOptions) error {
-		return KubeadmJoin(c, flags.usePhases, flags.copyCertsMode, flags.discoveryMode, flags.kubeadmConfigVersion, flags.patchesDir, flags.ignorePreflightErrors, flags.wait, flags.vLevel)
+	"kubeadm-upgrade-skip-minor": {
+		description: "Checks that kubeadm upgrade apply rejects upgrades skipping a minor version",
+		flags:       []string{"upgrade-version", "ignore-preflight-errors", "kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmUpgradeSkipMinor(c, flags.upgradeVersion, flags.ignorePreflightErrors, flags.vLevel)
+		},
 	},
-	"kubeadm-upgrade": func(c *status.Cluster, flags *RunOptions) error {
-		return KubeadmUpgrade(c, flags.upgradeVersion, flags.patchesDir, flags.ignorePreflightErrors, flags.wait, flags.vLevel)
+	"kubeadm-reset": {
+		description: "Executes the kubeadm reset workflow on nodes",
+		flags:       []string{"kubeadm-extra-flags", "kubeadm-verbosity"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmReset(c, flags.kubeadmExtraFlags, flags.vLevel)
+		},
 	},
-	"kubeadm-reset": func(c *status.Cluster, flags *RunOptions) error {
-		return KubeadmReset(c, flags.vLevel)
+	"local-storage": {
+		description: "Deploys the local-path provisioner and runs a PersistentVolumeClaim smoke test",
+		flags:       []string{"wait"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return LocalStorage(c, flags.wait)
+		},
 	},
-	"copy-certs": func(c *status.Cluster, flags *RunOptions) error {
-		return CopyCertificates(c)
+	"remove-control-plane": {
+		description: "Removes a control-plane node from the cluster and verifies etcd health",
+		flags:       []string{"wait", "kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return RemoveControlPlane(c, flags.wait, flags.vLevel)
+		},
 	},
-	"setup-external-ca": func(c *status.Cluster, flags *RunOptions) error {
-		return SetupExternalCA(c, flags.vLevel)
+	"kubeadm-kubeconfig-user": {
+		description: "Tests kubeadm kubeconfig user with a test identity and RBAC validation",
+		flags:       []string{"kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return KubeadmKubeconfigUser(c, flags.vLevel)
+		},
 	},
-	"cluster-info": func(c *status.Cluster, flags *RunOptions) error {
-		return CluterInfo(c)
+	"copy-certs": {
+		description: "Copies the shared certificates from the bootstrap control-plane to secondary control-plane nodes",
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return CopyCertificates(c)
+		},
 	},
-	"smoke-test": func(c *status.Cluster, flags *RunOptions) error {
-		return SmokeTest(c, flags.wait)
+	"setup-external-ca": {
+		description: "Setups the cluster for external CA mode",
+		flags:       []string{"kubeadm-verbosity"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return SetupExternalCA(c, flags.vLevel)
+		},
+	},
+	"chaos-stop-service": {
+		description: "Stops the kubelet or the container runtime for a while and checks nodes recover",
+		flags:       []string{"chaos-service", "chaos-duration", "wait"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return ChaosStopService(c, flags.chaosService, flags.chaosDuration, flags.wait)
+		},
+	},
+	"cert-key-expiry-join": {
+		description: "Verifies a control-plane join fails after the certificate key expiry and succeeds after uploading certificates again",
+		flags:       []string{"kubeadm-config-version", "discovery-mode", "ignore-preflight-errors", "wait", "kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		artifacts:   kubeadmArtifacts,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return CertificateKeyExpiry(c, flags.kubeadmConfigVersion, flags.discoveryMode, flags.ignorePreflightErrors, flags.wait, flags.vLevel)
+		},
+	},
+	"cert-expiration-recovery": {
+		description: "Expires the control-plane certificates, then checks the documented renewal and restart procedure recovers the cluster",
+		flags:       []string{"wait", "kubeadm-verbosity"},
+		roles:       controlPlaneNodeRoles,
+		artifacts:   kubeadmArtifacts,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return CertExpirationRecovery(c, flags.wait, flags.vLevel)
+		},
+	},
+	"chaos-network-partition": {
+		description: "Partitions nodes with iptables for a while and checks the cluster reconciles",
+		flags:       []string{"partition-mode", "chaos-duration", "wait"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return ChaosNetworkPartition(c, flags.partitionMode, flags.chaosDuration, flags.wait)
+		},
+	},
+	"cluster-info": {
+		description: "Prints nodes, pods, images and etcd members of the cluster",
+		roles:       controlPlaneNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return CluterInfo(c)
+		},
+	},
+	"run-on-nodes": {
+		description: "Executes a shell command on the nodes matching a node selector, sequentially or in parallel",
+		flags:       []string{"node-selector", "command", "parallel"},
+		roles:       []string{constants.ControlPlaneNodeRoleValue, constants.WorkerNodeRoleValue, constants.ExternalLoadBalancerNodeRoleValue, constants.ExternalEtcdNodeRoleValue},
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return RunOnNodes(c, flags.nodeSelector, flags.command, flags.parallel)
+		},
+	},
+	"smoke-test": {
+		description: "Runs a set of simple tests checking the cluster works",
+		flags:       []string{"wait"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return SmokeTest(c, flags.wait)
+		},
+	},
+	"verify": {
+		description: "Asserts the expected state defined in a YAML spec against the cluster",
+		flags:       []string{"verify-spec"},
+		roles:       k8sNodeRoles,
+		run: func(c *status.Cluster, flags *RunOptions) error {
+			return Verify(c, flags.verifySpec)
+		},
 	},
 }
 
@@ -79,6 +286,33 @@ func KnownActions() []string {
 	return names
 }
 
+// nodeSelectionFlags lists the flags restricting the nodes targeted by any action
+var nodeSelectionFlags = []string{"only-node", "skip-node"}
+
+// ActionInfo describes an action in the action catalog
+type ActionInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Flags       []string `json:"flags"`
+	Roles       []string `json:"roles"`
+}
+
+// Catalog returns the description of all the known actions, sorted by name
+func Catalog() []ActionInfo {
+	catalog := []ActionInfo{}
+	for _, n := range KnownActions() {
+		a := actionRegistry[n]
+		flags := append(append([]string{}, nodeSelectionFlags...), a.flags...)
+		catalog = append(catalog, ActionInfo{
+			Name:        n,
+			Description: a.description,
+			Flags:       flags,
+			Roles:       a.roles,
+		})
+	}
+	return catalog
+}
+
 // Option is configuration option supplied to actions.Run
 type Option func(*RunOptions)
 
@@ -89,6 +323,20 @@ func UsePhases(usePhases bool) Option {
 	}
 }
 
+// InitPhases option sets the ordered list of phases executed by kubeadm init when using phases
+func InitPhases(phases []string) Option {
+	return func(r *RunOptions) {
+		r.initPhases = phases
+	}
+}
+
+// SkipInitPhases option sets the list of phases to be skipped by kubeadm init when using phases
+func SkipInitPhases(phases []string) Option {
+	return func(r *RunOptions) {
+		r.skipInitPhases = phases
+	}
+}
+
 // CopyCerts option instructs kubeadm init/join actions to use use different methods for copying certs when initializing the cluster and
 // when joining control-plane nodes
 func CopyCerts(copyCertsMode CopyCertsMode) Option {
@@ -167,9 +415,102 @@ func EncryptionAlgorithm(algorithm string) Option {
 	}
 }
 
+// CloudProvider option sets the value of the kubelet --cloud-provider flag for kubeadm init and join
+func CloudProvider(cloudProvider string) Option {
+	return func(r *RunOptions) {
+		r.cloudProvider = cloudProvider
+	}
+}
+
+// NodeSelector option sets the nodes targeted by the run-on-nodes action
+func NodeSelector(nodeSelector string) Option {
+	return func(r *RunOptions) {
+		r.nodeSelector = nodeSelector
+	}
+}
+
+// Command option sets the shell command executed by the run-on-nodes action
+func Command(command string) Option {
+	return func(r *RunOptions) {
+		r.command = command
+	}
+}
+
+// Parallel option instructs the run-on-nodes action to execute the command on all the nodes at the same time
+func Parallel(parallel bool) Option {
+	return func(r *RunOptions) {
+		r.parallel = parallel
+	}
+}
+
+// ArtifactsDir option sets the folder where the artifacts declared by actions are collected
+func ArtifactsDir(dir string) Option {
+	return func(r *RunOptions) {
+		r.artifactsDir = dir
+	}
+}
+
+// ExternalEtcd option sets the external etcd cluster used by kubeadm init
+func ExternalEtcd(externalEtcd ExternalEtcdOptions) Option {
+	return func(r *RunOptions) {
+		r.externalEtcd = externalEtcd
+	}
+}
+
+// RollbackExpect option sets the expected outcome of the kubeadm-rollback-node action
+func RollbackExpect(expectation RollbackExpectation) Option {
+	return func(r *RunOptions) {
+		r.rollbackExpectation = expectation
+	}
+}
+
+// VerifyEtcdMembers option instructs kubeadm-join to verify the etcd members after each control-plane join
+func VerifyEtcdMembers(verify bool) Option {
+	return func(r *RunOptions) {
+		r.verifyEtcdMembers = verify
+	}
+}
+
+// KubeadmExtraFlags option sets additional flags appended to the kubeadm command line
+func KubeadmExtraFlags(extraFlags []string) Option {
+	return func(r *RunOptions) {
+		r.kubeadmExtraFlags = extraFlags
+	}
+}
+
+// ChaosServiceToStop option sets the service stopped by the chaos-stop-service action
+func ChaosServiceToStop(service ChaosService) Option {
+	return func(r *RunOptions) {
+		r.chaosService = service
+	}
+}
+
+// Partition option sets how nodes are partitioned by the chaos-network-partition action
+func Partition(mode PartitionMode) Option {
+	return func(r *RunOptions) {
+		r.partitionMode = mode
+	}
+}
+
+// ChaosDuration option sets for how long chaos actions keep the failure condition
+func ChaosDuration(duration time.Duration) Option {
+	return func(r *RunOptions) {
+		r.chaosDuration = duration
+	}
+}
+
+// VerifySpecFile option sets the file with the expected cluster state used by the verify action
+func VerifySpecFile(file string) Option {
+	return func(r *RunOptions) {
+		r.verifySpec = file
+	}
+}
+
 // RunOptions holds options supplied to actions.Run
 type RunOptions struct {
 	usePhases             bool
+	initPhases            []string
+	skipInitPhases        []string
 	copyCertsMode         CopyCertsMode
 	discoveryMode         DiscoveryMode
 	wait                  time.Duration
@@ -180,6 +521,19 @@ type RunOptions struct {
 	kubeadmConfigVersion  string
 	featureGate           string
 	encryptionAlgorithm   string
+	cloudProvider         string
+	externalEtcd          ExternalEtcdOptions
+	chaosService          ChaosService
+	chaosDuration         time.Duration
+	partitionMode         PartitionMode
+	verifySpec            string
+	nodeSelector          string
+	command               string
+	parallel              bool
+	artifactsDir          string
+	rollbackExpectation   RollbackExpectation
+	kubeadmExtraFlags     []string
+	verifyEtcdMembers     bool
 }
 
 // DiscoveryMode defines discovery mode supported by kubeadm join
@@ -270,8 +624,37 @@ func Run(c *status.Cluster, action string, options ...Option) error {
 	}
 
 	if a, ok := actionRegistry[action]; ok {
-		return a(c, flags)
+		var nodeRoles, eligibleRoles []string
+		for _, n := range c.AllNodes() {
+			nodeRoles = append(nodeRoles, n.Role())
+		}
+		for _, n := range c.AllNodes().EligibleForActions() {
+			eligibleRoles = append(eligibleRoles, n.Role())
+		}
+		if err := checkNodeSelection(action, a.roles, nodeRoles, eligibleRoles); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		err := a.run(c, flags)
+		if flags.artifactsDir != "" {
+			collectArtifacts(c, action, a.artifacts, flags.artifactsDir, start, err)
+		}
+		return err
 	}
 
 	return errors.Errorf("%s is not a valid action name. Use one of %s", action, KnownActions())
 }
+
+// checkNodeSelection returns an error if --only-node or --skip-node excluded all the nodes targeted by an action,
+// given the roles of all the nodes and of the nodes eligible for actions; actions targeting roles not present
+// in the cluster are not rejected, e.g. loadbalancer is a no-op on clusters without an external load balancer
+func checkNodeSelection(action string, roles, nodeRoles, eligibleRoles []string) error {
+	if !slices.ContainsFunc(nodeRoles, func(r string) bool { return slices.Contains(roles, r) }) {
+		return nil
+	}
+	if !slices.ContainsFunc(eligibleRoles, func(r string) bool { return slices.Contains(roles, r) }) {
+		return errors.Errorf("no nodes selected for the %s action, that targets nodes with roles %s", action, roles)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+// artifact defines a file or a command output to be captured from nodes when an action completes
+type artifact struct {
+	// name of the file where the artifact is stored, under the action artifacts folder for each node
+	name string
+	// roles lists the node roles the artifact is collected from
+	roles []string
+	// onFailure instructs to collect the artifact only when the action fails
+	onFailure bool
+	// command returns the command to be executed on the node; since is the time the action started
+	command func(since time.Time) []string
+}
+
+// fileArtifact returns an artifact capturing a file, or all the files in a folder, on nodes
+func fileArtifact(name, path string, roles []string, onFailure bool) artifact {
+	return artifact{
+		name:      name,
+		roles:     roles,
+		onFailure: onFailure,
+		command: func(time.Time) []string {
+			return []string{"/bin/sh", "-c", fmt.Sprintf("for f in $(find %s -type f | sort); do echo \"# $f\"; cat \"$f\"; done", path)}
+		},
+	}
+}
+
+// journalArtifact returns an artifact capturing the journal of a systemd unit since the action started
+func journalArtifact(name, unit string, roles []string, onFailure bool) artifact {
+	return artifact{
+		name:      name,
+		roles:     roles,
+		onFailure: onFailure,
+		command: func(since time.Time) []string {
+			return []string{"journalctl", "--no-pager", "-u", unit, fmt.Sprintf("--since=@%d", since.Unix())}
+		},
+	}
+}
+
+// failureArtifacts are collected by any action failing on Kubernetes nodes
+var failureArtifacts = []artifact{
+	journalArtifact("kubelet-journal.txt", "kubelet", k8sNodeRoles, true),
+	{
+		name:      "containers.txt",
+		roles:     k8sNodeRoles,
+		onFailure: true,
+		command: func(time.Time) []string {
+			return []string{"crictl", "ps", "-a"}
+		},
+	},
+}
+
+// kubeadmArtifacts are collected by actions running kubeadm workflows on Kubernetes nodes
+var kubeadmArtifacts = []artifact{
+	fileArtifact("kubeadm.conf", constants.KubeadmConfigPath, k8sNodeRoles, false),
+	fileArtifact("static-pod-manifests.txt", "/etc/kubernetes/manifests", controlPlaneNodeRoles, false),
+	fileArtifact("kubelet-config.txt", "/var/lib/kubelet/config.yaml /var/lib/kubelet/kubeadm-flags.env", k8sNodeRoles, true),
+}
+
+// collectArtifacts captures the artifacts declared for an action into dir/<action>-<timestamp>/<node>/,
+// collecting failure only artifacts only if the action failed.
+// Errors are logged but not returned, because collecting artifacts should never change the action result.
+func collectArtifacts(c *status.Cluster, actionName string, artifacts []artifact, dir string, start time.Time, actionErr error) {
+	base := filepath.Join(dir, fmt.Sprintf("%s-%s", actionName, start.Format("20060102-150405")))
+
+	all := append(append([]artifact{}, artifacts...), failureArtifacts...)
+	for _, a := range all {
+		if a.onFailure && actionErr == nil {
+			continue
+		}
+
+		for _, n := range c.AllNodes() {
+			if !hasRole(n, a.roles) {
+				continue
+			}
+
+			if err := collectArtifact(n, a, filepath.Join(base, n.Name()), start); err != nil {
+				log.Warnf("failed to collect artifact %s from node %s: %v", a.name, n.Name(), err)
+			}
+		}
+	}
+}
+
+func collectArtifact(n *status.Node, a artifact, dir string, since time.Time) error {
+	cmd := a.command(since)
+	lines, err := n.Command(cmd[0], cmd[1:]...).Silent().RunAndCapture()
+	if err != nil {
+		// the partial output is stored anyway, because it could be useful for triage
+		lines = append(lines, fmt.Sprintf("# command %q failed: %v", strings.Join(cmd, " "), err))
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create the artifacts folder %s", dir)
+	}
+	return os.WriteFile(filepath.Join(dir, a.name), []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+func hasRole(n *status.Node, roles []string) bool {
+	for _, r := range roles {
+		if n.Role() == r {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,9 @@ package assets
 // KindnetImage054 is the image for kindnet 0.5.4
 const KindnetImage054 = "kindest/kindnetd:0.5.4"
 
+// AddonImages are the images of the CNI plugin and of the addons installed by kinder actions
+var AddonImages = []string{KindnetImage054, LocalPathProvisionerImage0030}
+
 // KindnetManifest054 holds the kindnet manifest for 0.5.4
 const KindnetManifest054 = `
 # kindnetd networking manifest
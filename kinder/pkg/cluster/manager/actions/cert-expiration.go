@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/util/keyutil"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// expiredCertificate defines a certificate to be replaced with an expired copy, and the CA that signed it
+type expiredCertificate struct {
+	name string
+	ca   string
+}
+
+// expiredCertificates lists the certificates expired by the cert-expiration-recovery action
+var expiredCertificates = []expiredCertificate{
+	{name: "apiserver", ca: "ca"},
+	{name: "apiserver-kubelet-client", ca: "ca"},
+	{name: "front-proxy-client", ca: "front-proxy-ca"},
+}
+
+// controlPlaneComponents lists the static pods restarted after certificates are changed
+var controlPlaneComponents = []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+
+const pkiDir = "/etc/kubernetes/pki"
+
+// CertExpirationRecovery replaces the certificates of the selected control-plane nodes with copies already
+// expired, verifies the API server fails with expired certificate errors, then executes the documented
+// recovery procedure, that is "kubeadm certs renew all" followed by the restart of the control-plane
+// static pods, and finally checks the cluster returns healthy.
+//
+// Expired certificates are signed with the cluster CAs, so the CA keys must be available on the nodes.
+func CertExpirationRecovery(c *status.Cluster, wait time.Duration, vLevel int) error {
+	var controlPlanes status.NodeList
+	for _, n := range c.K8sNodes().EligibleForActions() {
+		if n.IsControlPlane() {
+			controlPlanes = append(controlPlanes, n)
+		}
+	}
+	if len(controlPlanes) == 0 {
+		return errors.New("no control-plane nodes selected for the cert-expiration-recovery action")
+	}
+
+	for _, cp := range controlPlanes {
+		cp.Infof("replace certificates with expired copies")
+		for _, cert := range expiredCertificates {
+			if err := expireCertificate(cp, cert); err != nil {
+				return err
+			}
+		}
+
+		if err := restartStaticPods(cp, wait, "kube-apiserver"); err != nil {
+			return err
+		}
+
+		cp.Infof("verify the API server fails with an expired certificate error (timeout %s)", wait)
+		if pass := waitFor(c, cp, wait, apiServerCertificateIsExpired); !pass {
+			return errors.New("timeout: the API server did not report an expired certificate")
+		}
+
+		lines, err := cp.Command(
+			"kubeadm", "certs", "check-expiration",
+			fmt.Sprintf("--v=%d", vLevel),
+		).ReadOnly().RunAndCapture()
+		fmt.Println(strings.Join(lines, "\n"))
+		if err != nil {
+			return errors.Wrap(err, "failed to check certificates expiration")
+		}
+		if !strings.Contains(strings.Join(lines, "\n"), "<invalid>") {
+			return errors.New("kubeadm certs check-expiration does not report expired certificates")
+		}
+	}
+
+	for _, cp := range controlPlanes {
+		cp.Infof("renew certificates")
+		if err := cp.Command(
+			"kubeadm", "certs", "renew", "all",
+			fmt.Sprintf("--v=%d", vLevel),
+		).RunWithEcho(); err != nil {
+			return errors.Wrap(err, "failed to renew certificates")
+		}
+
+		// as documented, control-plane components must be restarted for picking up renewed certificates
+		if err := restartStaticPods(cp, wait, controlPlaneComponents...); err != nil {
+			return err
+		}
+	}
+
+	for _, cp := range controlPlanes {
+		if err := waitNewControlPlaneNodeReady(c, cp, wait); err != nil {
+			return err
+		}
+
+		lines, err := cp.Command(
+			"kubeadm", "certs", "check-expiration",
+			fmt.Sprintf("--v=%d", vLevel),
+		).ReadOnly().RunAndCapture()
+		fmt.Println(strings.Join(lines, "\n"))
+		if err != nil {
+			return errors.Wrap(err, "failed to check certificates expiration")
+		}
+		if strings.Contains(strings.Join(lines, "\n"), "<invalid>") {
+			return errors.New("kubeadm certs check-expiration still reports expired certificates after renewal")
+		}
+	}
+
+	fmt.Printf("\nCluster recovered from expired certificates!\n")
+	return nil
+}
+
+// expireCertificate replaces a certificate on a node with a copy that is already expired
+func expireCertificate(n *status.Node, cert expiredCertificate) error {
+	read := func(file string) ([]byte, error) {
+		path := fmt.Sprintf("%s/%s", pkiDir, file)
+		lines, err := n.Command("cat", path).Silent().ReadOnly().RunAndCapture()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s on node %s", path, n.Name())
+		}
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+
+	certPEM, err := read(cert.name + ".crt")
+	if err != nil {
+		return err
+	}
+	caCertPEM, err := read(cert.ca + ".crt")
+	if err != nil {
+		return err
+	}
+	caKeyPEM, err := read(cert.ca + ".key")
+	if err != nil {
+		return errors.Wrap(err, "the CA key is required for signing expired certificates")
+	}
+
+	now := time.Now()
+	expired, err := backdateCertificate(certPEM, caCertPEM, caKeyPEM, now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create an expired %s certificate", cert.name)
+	}
+
+	if err := n.WriteFile(fmt.Sprintf("%s/%s.crt", pkiDir, cert.name), expired); err != nil {
+		return err
+	}
+	return nil
+}
+
+// backdateCertificate returns a copy of a PEM encoded certificate with the given validity, signed by the given CA
+func backdateCertificate(certPEM, caCertPEM, caKeyPEM []byte, notBefore, notAfter time.Time) ([]byte, error) {
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := parseCertificatePEM(caCertPEM)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyutil.ParsePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the CA key")
+	}
+	caKey, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("the CA key can't be used for signing certificates")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate a serial number")
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               cert.Subject,
+		DNSNames:              cert.DNSNames,
+		IPAddresses:           cert.IPAddresses,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              cert.KeyUsage,
+		ExtKeyUsage:           cert.ExtKeyUsage,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, cert.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign the certificate")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+func parseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to decode the PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the certificate")
+	}
+	return cert, nil
+}
+
+// restartStaticPods restarts static pods on a node, by temporarily moving their manifest out of
+// the manifests folder and waiting for the kubelet to stop the containers
+func restartStaticPods(n *status.Node, wait time.Duration, components ...string) error {
+	for _, component := range components {
+		n.Infof("restart %s", component)
+
+		manifest := fmt.Sprintf("/etc/kubernetes/manifests/%s.yaml", component)
+		backup := fmt.Sprintf("/etc/kubernetes/%s.yaml.kinder", component)
+		if err := n.Command("mv", manifest, backup).RunWithEcho(); err != nil {
+			return errors.Wrapf(err, "failed to move the %s manifest", component)
+		}
+
+		// if timeout is 0 or dry running, skip the wait like waitFor does
+		stopped := wait == time.Duration(0) || n.IsDryRun()
+		if stopped {
+			fmt.Println("Timeout set 0 or dry run, skipping wait")
+		}
+		for start := time.Now(); !stopped && time.Since(start) < wait; time.Sleep(time.Second) {
+			lines, err := n.Command(
+				"crictl", "ps", "-q", "--name", fmt.Sprintf("^%s$", component),
+			).Silent().ReadOnly().RunAndCapture()
+			if err == nil && len(lines) == 0 {
+				stopped = true
+				break
+			}
+		}
+
+		if err := n.Command("mv", backup, manifest).RunWithEcho(); err != nil {
+			return errors.Wrapf(err, "failed to restore the %s manifest", component)
+		}
+		if !stopped {
+			return errors.Errorf("timeout: %s was not stopped by the kubelet", component)
+		}
+	}
+	return nil
+}
+
+// apiServerCertificateIsExpired implements a function that tests if the API server serves an expired certificate
+func apiServerCertificateIsExpired(c *status.Cluster, n *status.Node) bool {
+	lines, err := n.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"get", "--raw=/healthz",
+	).Silent().ReadOnly().RunAndCapture()
+	return err != nil && strings.Contains(strings.Join(lines, "\n"), "certificate has expired")
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+// CertificateKeyExpiry automates the join of a control-plane node after the certificate key TTL is passed.
+//
+// Certificates are uploaded with the well known certificate key, then the bootstrap token owning the
+// kubeadm-certs Secret is forced to expire, so the token cleaner and the garbage collector delete the Secret
+// exactly like it happens two hours after upload. The action verifies that kubeadm join fails with an error
+// about the kubeadm-certs Secret, then resets the node, uploads the certificates again and joins successfully.
+func CertificateKeyExpiry(c *status.Cluster, kubeadmConfigVersion string, discoveryMode DiscoveryMode, ignorePreflightErrors string, wait time.Duration, vLevel int) error {
+	cp1 := c.BootstrapControlPlane()
+
+	// control-plane nodes already joined are kept in the load balancer config
+	isJoined := func(cp *status.Node) bool {
+		return cp.Command(
+			"test", "-f", "/etc/kubernetes/manifests/kube-apiserver.yaml",
+		).Silent().ReadOnly().Run() == nil
+	}
+	cpX := []*status.Node{cp1}
+	for _, cp := range c.SecondaryControlPlanes() {
+		if isJoined(cp) {
+			cpX = append(cpX, cp)
+		}
+	}
+	var toJoin []*status.Node
+	for _, cp := range c.SecondaryControlPlanes().EligibleForActions() {
+		if !isJoined(cp) {
+			toJoin = append(toJoin, cp)
+		}
+	}
+	if len(toJoin) == 0 {
+		return errors.New("cert-key-expiry-join action requires a secondary control-plane node not yet joined")
+	}
+
+	for _, cp := range toJoin {
+		if err := uploadCertsWithCertificateKey(cp1, vLevel); err != nil {
+			return err
+		}
+		if err := expireCertificateKey(cp1, wait); err != nil {
+			return err
+		}
+
+		if err := KubeadmJoinConfig(c, kubeadmConfigVersion, CopyCertsModeAuto, discoveryMode, "", ignorePreflightErrors, cp); err != nil {
+			return err
+		}
+
+		cp.Infof("verify kubeadm join fails after the certificate key expiry")
+		lines, err := cp.Command(
+			"kubeadm", "join",
+			fmt.Sprintf("--config=%s", constants.KubeadmConfigPath),
+			fmt.Sprintf("--v=%d", vLevel),
+		).RunAndCapture()
+		fmt.Println(strings.Join(lines, "\n"))
+		if err == nil {
+			return errors.Errorf("kubeadm join on node %s succeeded, but it was expected to fail after the certificate key expiry", cp.Name())
+		}
+		if !strings.Contains(strings.Join(lines, "\n"), "kubeadm-certs") {
+			return errors.Wrap(err, "kubeadm join failed, but not for the missing kubeadm-certs Secret")
+		}
+
+		// cleanups what is left on the node by the failed join
+		if err := cp.Command("kubeadm", "reset", "--force", fmt.Sprintf("--v=%d", vLevel)).RunWithEcho(); err != nil {
+			return errors.Wrap(err, "failed to reset the node after the failed join")
+		}
+
+		cp.Infof("re-upload certificates and join")
+		if err := uploadCertsWithCertificateKey(cp1, vLevel); err != nil {
+			return err
+		}
+		if err := KubeadmJoinConfig(c, kubeadmConfigVersion, CopyCertsModeAuto, discoveryMode, "", ignorePreflightErrors, cp); err != nil {
+			return err
+		}
+		if err := kubeadmJoinControlPlane(cp, nil, vLevel); err != nil {
+			return err
+		}
+
+		cpX = append(cpX, cp)
+		if err := LoadBalancer(c, cpX...); err != nil {
+			return err
+		}
+
+		if err := waitNewControlPlaneNodeReady(c, cp, wait); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\nControl-plane join after certificate key expiry works as expected!\n")
+	return nil
+}
+
+// uploadCertsWithCertificateKey uploads the certificates into the kubeadm-certs Secret, encrypted with the
+// well known certificate key used by kinder
+func uploadCertsWithCertificateKey(cp1 *status.Node, vLevel int) error {
+	if err := cp1.Command(
+		"kubeadm", "init", "phase", "upload-certs", "--upload-certs",
+		fmt.Sprintf("--certificate-key=%s", constants.CertificateKey),
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		fmt.Sprintf("--v=%d", vLevel),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to upload certificates")
+	}
+	return nil
+}
+
+// expireCertificateKey sets the expiration of the bootstrap token owning the kubeadm-certs Secret
+// to a few seconds from now, and waits for the Secret to be deleted
+func expireCertificateKey(cp1 *status.Node, wait time.Duration) error {
+	owner := kubectlOutput(cp1,
+		"get", "secret", "kubeadm-certs", "-n", "kube-system",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"-o=jsonpath='{.metadata.ownerReferences[0].name}'",
+	)
+	owner = strings.Trim(owner, "'")
+	if !strings.HasPrefix(owner, "bootstrap-token-") {
+		return errors.Errorf("the kubeadm-certs Secret is not owned by a bootstrap token, owner %q", owner)
+	}
+
+	expiration := time.Now().UTC().Add(10 * time.Second).Format(time.RFC3339)
+	cp1.Infof("expire the bootstrap token %s owning the kubeadm-certs Secret at %s", owner, expiration)
+	if err := cp1.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"patch", "secret", owner, "-n", "kube-system", "--type=merge",
+		fmt.Sprintf("-p={\"stringData\":{\"expiration\":%q}}", expiration),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to change the expiration of the bootstrap token")
+	}
+
+	cp1.Infof("waiting for the kubeadm-certs Secret to be deleted (timeout %s)", wait)
+	for start := time.Now(); time.Since(start) < wait; time.Sleep(2 * time.Second) {
+		lines, err := cp1.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+			"get", "secret", "kubeadm-certs", "-n", "kube-system", "--ignore-not-found",
+			"-o=name",
+		).Silent().ReadOnly().RunAndCapture()
+		if err == nil && len(lines) == 0 {
+			return nil
+		}
+	}
+	return errors.New("timeout: the kubeadm-certs Secret was not deleted after the certificate key expiry")
+}
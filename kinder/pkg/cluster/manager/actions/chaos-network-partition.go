@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// PartitionMode defines how nodes are partitioned by the chaos-network-partition action
+type PartitionMode string
+
+const (
+	// PartitionFromControlPlane isolates the selected nodes from all the other control-plane nodes
+	// and from the external load balancer, if any
+	PartitionFromControlPlane = PartitionMode("control-plane")
+
+	// PartitionEtcdPeers blocks etcd peer traffic between the selected control-plane nodes and the other ones
+	PartitionEtcdPeers = PartitionMode("etcd-peers")
+)
+
+// etcdPeerPort is the port used by etcd for peer communication
+const etcdPeerPort = 2380
+
+// KnownPartitionModes returns the list of known PartitionMode
+func KnownPartitionModes() []string {
+	return []string{
+		string(PartitionFromControlPlane),
+		string(PartitionEtcdPeers),
+	}
+}
+
+// ValidatePartitionMode validates a PartitionMode
+func ValidatePartitionMode(m PartitionMode) error {
+	switch m {
+	case PartitionFromControlPlane:
+	case PartitionEtcdPeers:
+	default:
+		return errors.Errorf("invalid partition mode. Use one of %s", KnownPartitionModes())
+	}
+	return nil
+}
+
+// iptablesRule defines an iptables rule in a format that can be used both for inserting and deleting the rule
+type iptablesRule []string
+
+// ChaosNetworkPartition uses iptables inside node containers for partitioning the selected nodes
+// for the given duration, then removes the partition and verifies the cluster reconciles
+func ChaosNetworkPartition(c *status.Cluster, mode PartitionMode, duration, wait time.Duration) (err error) {
+	// nb. waitFor skips the wait when the timeout is zero, that would report checks not executed at all as passed
+	if wait <= 0 {
+		return errors.Errorf("invalid wait %s; the chaos-network-partition action requires a --wait greater than zero for verifying the cluster reconciles", wait)
+	}
+
+	nodes := c.K8sNodes().EligibleForActions()
+	if mode == PartitionEtcdPeers {
+		if c.ExternalEtcd() != nil {
+			return errors.New("the etcd-peers partition mode can't be used with external etcd")
+		}
+		controlPlanes := status.NodeList{}
+		for _, n := range nodes {
+			if n.IsControlPlane() {
+				controlPlanes = append(controlPlanes, n)
+			}
+		}
+		nodes = controlPlanes
+	}
+	if len(nodes) == 0 {
+		return errors.Errorf("no nodes selected for the %s partition", mode)
+	}
+
+	// compute the iptables rules to be applied on each node
+	rules := map[string][]iptablesRule{}
+	for _, n := range nodes {
+		r, err := partitionRules(c, n, mode)
+		if err != nil {
+			return err
+		}
+		rules[n.Name()] = r
+	}
+
+	// whatever happens, ensure the partition is removed
+	defer func() {
+		if cerr := removePartition(nodes, rules); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for _, n := range nodes {
+		n.Infof("partitioning node (%s)", mode)
+		for _, r := range rules[n.Name()] {
+			if err := n.Command("iptables", append([]string{"-I"}, r...)...).RunWithEcho(); err != nil {
+				return errors.Wrapf(err, "failed to add iptables rule on node %s", n.Name())
+			}
+		}
+	}
+
+	fmt.Printf("\nkeeping the %s partition for %s...\n", mode, duration)
+	time.Sleep(duration)
+
+	if err := removePartition(nodes, rules); err != nil {
+		return err
+	}
+	rules = nil
+
+	// verify the cluster reconciles
+	for _, n := range c.K8sNodes() {
+		if n.IsControlPlane() {
+			err = waitNewControlPlaneNodeReady(c, n, wait)
+		} else {
+			err = waitNewWorkerNodeReady(c, n, wait)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "node %s did not reconcile after removing the partition", n.Name())
+		}
+	}
+
+	if c.ExternalEtcd() == nil {
+		cp1 := c.BootstrapControlPlane()
+		etcdArgs, err := etcdctlArgs(cp1)
+		if err != nil {
+			return err
+		}
+
+		cp1.Infof("waiting for etcd members to become healthy (timeout %s)", wait)
+		if pass := waitFor(c, cp1, wait, etcdClusterIsHealthy(etcdArgs)); !pass {
+			return errors.New("timeout: etcd cluster did not become healthy")
+		}
+	}
+
+	fmt.Printf("\nCluster reconciled after the %s partition!\n", mode)
+	return nil
+}
+
+// partitionRules returns the iptables rules partitioning a node according to the given mode
+func partitionRules(c *status.Cluster, n *status.Node, mode PartitionMode) ([]iptablesRule, error) {
+	peers := status.NodeList{}
+	for _, cp := range c.ControlPlanes() {
+		if cp.Name() != n.Name() {
+			peers = append(peers, cp)
+		}
+	}
+	if mode == PartitionFromControlPlane && c.ExternalLoadBalancer() != nil {
+		peers = append(peers, c.ExternalLoadBalancer())
+	}
+
+	rules := []iptablesRule{}
+	for _, p := range peers {
+		ip, _, err := p.IP()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get IP for node: %s", p.Name())
+		}
+
+		switch mode {
+		case PartitionFromControlPlane:
+			rules = append(rules,
+				iptablesRule{"INPUT", "-s", ip, "-j", "DROP"},
+				iptablesRule{"OUTPUT", "-d", ip, "-j", "DROP"},
+			)
+		case PartitionEtcdPeers:
+			port := fmt.Sprintf("%d", etcdPeerPort)
+			rules = append(rules,
+				iptablesRule{"INPUT", "-s", ip, "-p", "tcp", "--dport", port, "-j", "DROP"},
+				iptablesRule{"OUTPUT", "-d", ip, "-p", "tcp", "--dport", port, "-j", "DROP"},
+			)
+		}
+	}
+	return rules, nil
+}
+
+// removePartition deletes the iptables rules added for partitioning nodes
+func removePartition(nodes status.NodeList, rules map[string][]iptablesRule) error {
+	var errs []error
+	for _, n := range nodes {
+		if len(rules[n.Name()]) == 0 {
+			continue
+		}
+		n.Infof("removing partition")
+		for _, r := range rules[n.Name()] {
+			if err := n.Command("iptables", append([]string{"-D"}, r...)...).RunWithEcho(); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to delete iptables rule on node %s", n.Name()))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("failed to remove the partition: %v", errs)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// ChaosService defines a service that can be stopped by the chaos-stop-service action
+type ChaosService string
+
+const (
+	// ChaosServiceKubelet stops the kubelet
+	ChaosServiceKubelet = ChaosService("kubelet")
+
+	// ChaosServiceContainerRuntime stops the container runtime installed on the node (containerd or docker)
+	ChaosServiceContainerRuntime = ChaosService("container-runtime")
+)
+
+// KnownChaosServices returns the list of known ChaosService
+func KnownChaosServices() []string {
+	return []string{
+		string(ChaosServiceKubelet),
+		string(ChaosServiceContainerRuntime),
+	}
+}
+
+// ValidateChaosService validates a ChaosService
+func ValidateChaosService(s ChaosService) error {
+	switch s {
+	case ChaosServiceKubelet:
+	case ChaosServiceContainerRuntime:
+	default:
+		return errors.Errorf("invalid chaos service. Use one of %s", KnownChaosServices())
+	}
+	return nil
+}
+
+// ChaosStopService stops the kubelet or the container runtime on the selected nodes for the given
+// duration, then restarts the service and asserts that the nodes recover
+func ChaosStopService(c *status.Cluster, service ChaosService, duration, wait time.Duration) (err error) {
+	// nb. waitFor skips the wait when the timeout is zero, that would report checks not executed at all as passed
+	if wait <= 0 {
+		return errors.Errorf("invalid wait %s; the chaos-stop-service action requires a --wait greater than zero for verifying the nodes recover", wait)
+	}
+
+	nodes := c.K8sNodes().EligibleForActions()
+	if len(nodes) == 0 {
+		return errors.New("no nodes selected for the chaos-stop-service action")
+	}
+
+	// resolve the systemd unit to be stopped on each node
+	units := map[string]string{}
+	for _, n := range nodes {
+		unit, err := chaosServiceUnit(n, service)
+		if err != nil {
+			return err
+		}
+		units[n.Name()] = unit
+	}
+
+	// in case of errors, ensure services already stopped are restarted
+	stopped := []*status.Node{}
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, n := range stopped {
+			n.Command("systemctl", "start", units[n.Name()]).Silent().Run()
+		}
+	}()
+
+	for _, n := range nodes {
+		n.Infof("stopping %s", units[n.Name()])
+		if err := n.Command("systemctl", "stop", units[n.Name()]).RunWithEcho(); err != nil {
+			return errors.Wrapf(err, "failed to stop %s on node %s", units[n.Name()], n.Name())
+		}
+		stopped = append(stopped, n)
+	}
+
+	// skips the wait when dry running, given that no service was actually stopped
+	if !nodes[0].IsDryRun() {
+		fmt.Printf("\nkeeping %s stopped for %s...\n", service, duration)
+		time.Sleep(duration)
+	}
+
+	for _, n := range nodes {
+		n.Infof("starting %s", units[n.Name()])
+		if err := n.Command("systemctl", "start", units[n.Name()]).RunWithEcho(); err != nil {
+			return errors.Wrapf(err, "failed to start %s on node %s", units[n.Name()], n.Name())
+		}
+	}
+	stopped = nil
+
+	// assert the nodes recover
+	for _, n := range nodes {
+		if err := n.Command("systemctl", "is-active", "--quiet", units[n.Name()]).Silent().Run(); err != nil {
+			return errors.Wrapf(err, "%s is not active on node %s after restart", units[n.Name()], n.Name())
+		}
+
+		if n.IsControlPlane() {
+			err = waitNewControlPlaneNodeReady(c, n, wait)
+		} else {
+			err = waitNewWorkerNodeReady(c, n, wait)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "node %s did not recover after restarting %s", n.Name(), units[n.Name()])
+		}
+	}
+
+	fmt.Printf("\nAll the nodes recovered after restarting %s!\n", service)
+	return nil
+}
+
+// chaosServiceUnit returns the name of the systemd unit corresponding to a ChaosService on a node
+func chaosServiceUnit(n *status.Node, service ChaosService) (string, error) {
+	switch service {
+	case ChaosServiceKubelet:
+		return "kubelet", nil
+	case ChaosServiceContainerRuntime:
+		cri, err := n.CRI()
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to detect the container runtime on node %s", n.Name())
+		}
+		return string(cri), nil
+	}
+	return "", errors.Errorf("invalid chaos service. Use one of %s", KnownChaosServices())
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+const (
+	// CloudProviderExternal instructs the kubelet to delegate node initialization to an external cloud controller manager
+	CloudProviderExternal = "external"
+
+	// uninitializedTaint is the taint added by the kubelet on nodes registered with --cloud-provider=external
+	uninitializedTaint = "node.cloudprovider.kubernetes.io/uninitialized"
+
+	// fakeProviderIDPrefix is the provider ID prefix assigned to nodes by the fake cloud controller manager
+	fakeProviderIDPrefix = "kinder://"
+)
+
+// ValidateCloudProvider validates the value of the kubelet --cloud-provider flag set by kinder
+func ValidateCloudProvider(cloudProvider string) error {
+	if cloudProvider != "" && cloudProvider != CloudProviderExternal {
+		return errors.Errorf("invalid cloud provider %q. Only %q is supported", cloudProvider, CloudProviderExternal)
+	}
+	return nil
+}
+
+// CloudProviderExternalTest exercises the kubeadm external cloud provider path on a cluster where
+// kubeadm init and kubeadm join were executed with --cloud-provider=external.
+//
+// The action checks that the kubelet on each node is configured for an external cloud provider and
+// that the nodes are registered with the uninitialized taint, then acts as a fake cloud controller
+// manager by assigning a provider ID and removing the taint from each node, like the node controller
+// of a real CCM would do. Finally it checks that workloads blocked by the taint, e.g. CoreDNS, are scheduled.
+func CloudProviderExternalTest(c *status.Cluster, wait time.Duration) error {
+	cp1 := c.BootstrapControlPlane()
+
+	for _, n := range c.K8sNodes().EligibleForActions() {
+		n.Infof("verify the kubelet is configured with --cloud-provider=%s", CloudProviderExternal)
+		lines, err := n.Command(
+			"cat", "/var/lib/kubelet/kubeadm-flags.env",
+		).Silent().RunAndCapture()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the kubelet flags on node %s", n.Name())
+		}
+		if !strings.Contains(strings.Join(lines, "\n"), fmt.Sprintf("--cloud-provider=%s", CloudProviderExternal)) {
+			return errors.Errorf("the kubelet on node %s is not configured with --cloud-provider=%s; use --cloud-provider=%s for kubeadm-init and kubeadm-join", n.Name(), CloudProviderExternal, CloudProviderExternal)
+		}
+
+		// nodes already initialized are skipped, so the action can be executed more than once
+		providerID := kubectlOutput(cp1,
+			"get", "node", n.Name(),
+			"--kubeconfig=/etc/kubernetes/admin.conf",
+			"-o=jsonpath='{.spec.providerID}'",
+		)
+		if strings.HasPrefix(strings.Trim(providerID, "'"), fakeProviderIDPrefix) {
+			fmt.Printf("node %s already initialized by the fake cloud controller manager\n", n.Name())
+			continue
+		}
+
+		taints := kubectlOutput(cp1,
+			"get", "node", n.Name(),
+			"--kubeconfig=/etc/kubernetes/admin.conf",
+			"-o=jsonpath='{.spec.taints[*].key}'",
+		)
+		if !strings.Contains(taints, uninitializedTaint) {
+			return errors.Errorf("node %s was expected to have the %s taint, found %s", n.Name(), uninitializedTaint, taints)
+		}
+
+		n.Infof("initialize the node with the fake cloud controller manager")
+		if err := cp1.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+			"patch", "node", n.Name(), "--type=merge",
+			fmt.Sprintf("-p={\"spec\":{\"providerID\":\"%s%s\"}}", fakeProviderIDPrefix, n.Name()),
+		).RunWithEcho(); err != nil {
+			return errors.Wrapf(err, "failed to set the provider ID on node %s", n.Name())
+		}
+		if err := cp1.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+			"taint", "node", n.Name(), fmt.Sprintf("%s-", uninitializedTaint),
+		).RunWithEcho(); err != nil {
+			return errors.Wrapf(err, "failed to remove the %s taint from node %s", uninitializedTaint, n.Name())
+		}
+	}
+
+	for _, n := range c.K8sNodes().EligibleForActions() {
+		taints := kubectlOutput(cp1,
+			"get", "node", n.Name(),
+			"--kubeconfig=/etc/kubernetes/admin.conf",
+			"-o=jsonpath='{.spec.taints[*].key}'",
+		)
+		if strings.Contains(taints, uninitializedTaint) {
+			return errors.Errorf("the %s taint is still present on node %s", uninitializedTaint, n.Name())
+		}
+	}
+
+	// CoreDNS does not tolerate the uninitialized taint, so it can be scheduled only after nodes are initialized
+	cp1.Infof("waiting for CoreDNS Pods to become Running (timeout %s)", wait)
+	if pass := waitFor(c, cp1, wait, coreDNSIsRunning); !pass {
+		return errors.New("timeout: CoreDNS Pods did not become Running after nodes were initialized")
+	}
+
+	fmt.Printf("\nNodes initialized by the fake cloud controller manager!\n")
+	return nil
+}
+
+// coreDNSIsRunning implements a function that tests if all the CoreDNS Pods are running
+func coreDNSIsRunning(c *status.Cluster, n *status.Node) bool {
+	output := kubectlOutput(n,
+		"get", "pods", "-n", "kube-system", "-l", "k8s-app=kube-dns",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"-o=jsonpath='{.items[*].status.phase}'",
+	)
+
+	statuses := strings.Fields(strings.Trim(output, "'"))
+	if len(statuses) == 0 {
+		return false
+	}
+	for _, s := range statuses {
+		if s != "Running" {
+			return false
+		}
+	}
+	return true
+}
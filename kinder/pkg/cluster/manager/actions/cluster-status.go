@@ -0,0 +1,379 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/cri/host"
+)
+
+// certificateExpirationWarning is how long before the expiration a certificate is reported in the warnings
+const certificateExpirationWarning = 30 * 24 * time.Hour
+
+// ClusterStatus summarizes the status of a cluster
+type ClusterStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+
+	Nodes        []NodeStatus        `json:"nodes"`
+	Etcd         []EtcdMemberStatus  `json:"etcd,omitempty"`
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// Problems lists the reasons why the cluster is not healthy, including the failures getting the status
+	Problems []string `json:"problems,omitempty"`
+	// Warnings lists issues that don't make the cluster unhealthy yet, e.g. certificates about to expire
+	Warnings []string `json:"warnings,omitempty"`
+
+	// apiReachable reports if the status of the Node objects was read from the API server
+	apiReachable bool
+}
+
+// NodeStatus is the status of a node of the cluster
+type NodeStatus struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+	// Container is the state of the node container, e.g. running or exited
+	Container string `json:"container"`
+	// Ready is the status of the Ready condition of the Node object, if any
+	Ready          string `json:"ready,omitempty"`
+	KubeletVersion string `json:"kubeletVersion,omitempty"`
+	KubeadmVersion string `json:"kubeadmVersion,omitempty"`
+	// Components are the images of the control-plane components running on the node, by component
+	Components map[string]string `json:"components,omitempty"`
+}
+
+// EtcdMemberStatus is the status of a member of the local etcd cluster
+type EtcdMemberStatus struct {
+	Name       string `json:"name"`
+	ID         string `json:"id"`
+	ClientURLs string `json:"clientURLs"`
+	Learner    bool   `json:"learner"`
+	Healthy    bool   `json:"healthy"`
+}
+
+// CertificateStatus is the expiration of a certificate on a node
+type CertificateStatus struct {
+	Node     string    `json:"node"`
+	Path     string    `json:"path"`
+	NotAfter time.Time `json:"notAfter"`
+	Expired  bool      `json:"expired"`
+}
+
+// GetClusterStatus returns the status of the node containers, the readiness of the Kubernetes nodes, the versions
+// of the components, the health of the etcd members and the expiration of the certificates; failures getting
+// the status are reported as problems, so the status can be read also for broken clusters
+func GetClusterStatus(c *status.Cluster) *ClusterStatus {
+	s := &ClusterStatus{Name: c.Name()}
+
+	running := map[string]bool{}
+	for _, n := range c.AllNodes() {
+		ns := NodeStatus{Name: n.Name(), Role: n.Role(), Container: "unknown"}
+		if lines, err := host.InspectContainer(n.Name(), "{{.State.Status}}"); err == nil && len(lines) == 1 {
+			ns.Container = lines[0]
+		}
+		running[n.Name()] = ns.Container == "running"
+
+		if running[n.Name()] && hasRole(n, k8sNodeRoles) {
+			if v, err := n.KubeadmVersion(); err == nil {
+				ns.KubeadmVersion = "v" + v.String()
+			}
+			s.Certificates = append(s.Certificates, nodeCertificates(n)...)
+		}
+		s.Nodes = append(s.Nodes, ns)
+	}
+
+	cp1 := c.BootstrapControlPlane()
+	if cp1 == nil || !running[cp1.Name()] {
+		s.Problems = append(s.Problems, "the bootstrap control-plane node is not running")
+	} else {
+		s.readKubernetesNodes(cp1)
+		if c.ExternalEtcd() == nil && s.apiReachable {
+			s.readEtcdMembers(cp1)
+		}
+	}
+
+	s.evaluate(time.Now())
+	return s
+}
+
+// readKubernetesNodes reads the readiness and the versions from the Node objects, and the images of
+// the control-plane static pods
+func (s *ClusterStatus) readKubernetesNodes(cp *status.Node) {
+	lines, err := cp.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "--request-timeout=10s", "get", "nodes",
+		`-o=jsonpath={range .items[*]}{.metadata.name}{" "}{.status.conditions[?(@.type=="Ready")].status}{" "}{.status.nodeInfo.kubeletVersion}{"\n"}{end}`,
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		s.Problems = append(s.Problems, fmt.Sprintf("failed to get the nodes from the API server: %v", err))
+		return
+	}
+	s.apiReachable = true
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) != 3 {
+			continue
+		}
+		if ns := s.node(fields[0]); ns != nil {
+			ns.Ready, ns.KubeletVersion = fields[1], fields[2]
+		}
+	}
+
+	lines, err = cp.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "--request-timeout=10s", "get", "pods",
+		"-n=kube-system", "-l=tier=control-plane",
+		`-o=jsonpath={range .items[*]}{.spec.nodeName}{" "}{.metadata.labels.component}{" "}{.spec.containers[0].image}{"\n"}{end}`,
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		s.Problems = append(s.Problems, fmt.Sprintf("failed to get the control-plane pods from the API server: %v", err))
+		return
+	}
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) != 3 {
+			continue
+		}
+		if ns := s.node(fields[0]); ns != nil {
+			if ns.Components == nil {
+				ns.Components = map[string]string{}
+			}
+			ns.Components[fields[1]] = fields[2]
+		}
+	}
+}
+
+// readEtcdMembers reads the members of the local etcd cluster and their health
+func (s *ClusterStatus) readEtcdMembers(cp *status.Node) {
+	etcdArgs, err := etcdctlArgsWithInfof(cp, func(message string, args ...any) { log.Debugf(message, args...) })
+	if err != nil {
+		s.Problems = append(s.Problems, fmt.Sprintf("failed to run etcdctl: %v", err))
+		return
+	}
+
+	lines, err := cp.Command("kubectl", append(append([]string{}, etcdArgs...), "member", "list")...).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		s.Problems = append(s.Problems, fmt.Sprintf("failed to list the etcd members: %v", err))
+		return
+	}
+	members, err := parseEtcdMemberList(lines)
+	if err != nil {
+		s.Problems = append(s.Problems, err.Error())
+		return
+	}
+
+	// unhealthy endpoints make the command fail, but the health of all the endpoints is printed anyway
+	lines, _ = cp.Command("kubectl", append(append([]string{}, etcdArgs...), "endpoint", "health", "--cluster")...).Silent().ReadOnly().RunAndCapture()
+	health := parseEtcdEndpointHealth(lines)
+	for _, m := range members {
+		s.Etcd = append(s.Etcd, EtcdMemberStatus{
+			Name:       m.name,
+			ID:         m.id,
+			ClientURLs: m.clientURLs,
+			Learner:    m.isLearner,
+			Healthy:    health[m.clientURLs],
+		})
+	}
+}
+
+// parseEtcdEndpointHealth parses the output of "etcdctl endpoint health" and returns the health of each endpoint
+func parseEtcdEndpointHealth(lines []string) map[string]bool {
+	health := map[string]bool{}
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) < 3 || fields[1] != "is" {
+			continue
+		}
+		health[fields[0]] = strings.TrimSuffix(fields[2], ":") == "healthy"
+	}
+	return health
+}
+
+// nodeCertificates returns the expiration of the certificates in /etc/kubernetes/pki, of the client certificates
+// embedded in the kubeconfig files, and of the kubelet client certificate
+func nodeCertificates(n *status.Node) []CertificateStatus {
+	script := "for f in $(find /etc/kubernetes/pki -name '*.crt' 2>/dev/null | sort) /var/lib/kubelet/pki/kubelet-client-current.pem; do " +
+		"if [ -f $f ]; then echo \"# $f\"; cat $f; fi; done; " +
+		"for f in /etc/kubernetes/*.conf; do d=$(grep client-certificate-data: $f 2>/dev/null | awk '{print $2}'); " +
+		"if [ -n \"$d\" ]; then echo \"# $f\"; echo $d | base64 -d; fi; done; true"
+	lines, err := n.Command("/bin/sh", "-c", script).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		log.Warnf("failed to read the certificates on node %s: %v", n.Name(), err)
+		return nil
+	}
+
+	certs := []CertificateStatus{}
+	for path, cert := range parseCertificateFiles(lines) {
+		certs = append(certs, CertificateStatus{Node: n.Name(), Path: path, NotAfter: cert.NotAfter})
+	}
+	sort.Slice(certs, func(i, j int) bool { return certs[i].Path < certs[j].Path })
+	return certs
+}
+
+// parseCertificateFiles parses the content of many files, each one starting with a "# <path>" line, and returns
+// the first certificate of each file
+func parseCertificateFiles(lines []string) map[string]*x509.Certificate {
+	files := map[string][]string{}
+	var path string
+	for _, l := range lines {
+		if strings.HasPrefix(l, "# ") {
+			path = strings.TrimPrefix(l, "# ")
+			continue
+		}
+		if path != "" {
+			files[path] = append(files[path], l)
+		}
+	}
+
+	certs := map[string]*x509.Certificate{}
+	for path, content := range files {
+		rest := []byte(strings.Join(content, "\n"))
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				certs[path] = cert
+			}
+			break
+		}
+	}
+	return certs
+}
+
+// evaluate checks the status and sets the problems, the warnings and the overall health
+func (s *ClusterStatus) evaluate(now time.Time) {
+	for _, n := range s.Nodes {
+		if n.Container != "running" {
+			s.Problems = append(s.Problems, fmt.Sprintf("the container of node %s is %s", n.Name, n.Container))
+			continue
+		}
+		if !s.apiReachable || (n.Role != constants.ControlPlaneNodeRoleValue && n.Role != constants.WorkerNodeRoleValue) {
+			continue
+		}
+		switch n.Ready {
+		case "True":
+		case "":
+			s.Problems = append(s.Problems, fmt.Sprintf("node %s is not registered in the cluster", n.Name))
+		default:
+			s.Problems = append(s.Problems, fmt.Sprintf("node %s is not Ready", n.Name))
+		}
+	}
+
+	for _, m := range s.Etcd {
+		if !m.Healthy {
+			s.Problems = append(s.Problems, fmt.Sprintf("etcd member %s is not healthy", m.Name))
+		}
+	}
+
+	for i, c := range s.Certificates {
+		switch {
+		case now.After(c.NotAfter):
+			s.Certificates[i].Expired = true
+			s.Problems = append(s.Problems, fmt.Sprintf("certificate %s on node %s is expired", c.Path, c.Node))
+		case c.NotAfter.Sub(now) < certificateExpirationWarning:
+			s.Warnings = append(s.Warnings, fmt.Sprintf("certificate %s on node %s expires on %s", c.Path, c.Node, c.NotAfter.Format(time.RFC3339)))
+		}
+	}
+
+	s.Healthy = len(s.Problems) == 0
+}
+
+func (s *ClusterStatus) node(name string) *NodeStatus {
+	for i := range s.Nodes {
+		if s.Nodes[i].Name == name {
+			return &s.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Print prints the status in a human readable format
+func (s *ClusterStatus) Print(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tROLE\tCONTAINER\tREADY\tKUBELET\tKUBEADM")
+	for _, n := range s.Nodes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", n.Name, n.Role, n.Container, orDash(n.Ready), orDash(n.KubeletVersion), orDash(n.KubeadmVersion))
+	}
+
+	var components []string
+	for _, n := range s.Nodes {
+		for _, c := range sortedKeys(n.Components) {
+			components = append(components, fmt.Sprintf("%s\t%s\t%s", n.Name, c, n.Components[c]))
+		}
+	}
+	if len(components) > 0 {
+		fmt.Fprintln(w, "\nNODE\tCOMPONENT\tIMAGE")
+		for _, c := range components {
+			fmt.Fprintln(w, c)
+		}
+	}
+
+	if len(s.Etcd) > 0 {
+		fmt.Fprintln(w, "\nETCD MEMBER\tID\tHEALTHY\tLEARNER")
+		for _, m := range s.Etcd {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%t\n", m.Name, m.ID, m.Healthy, m.Learner)
+		}
+	}
+
+	if len(s.Certificates) > 0 {
+		fmt.Fprintln(w, "\nNODE\tCERTIFICATE\tEXPIRES")
+		for _, c := range s.Certificates {
+			expires := fmt.Sprintf("%s (%dd)", c.NotAfter.Format("2006-01-02"), int(time.Until(c.NotAfter).Hours()/24))
+			if c.Expired {
+				expires = "EXPIRED on " + c.NotAfter.Format("2006-01-02")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", c.Node, c.Path, expires)
+		}
+	}
+	w.Flush()
+
+	fmt.Fprintln(out)
+	for _, warning := range s.Warnings {
+		fmt.Fprintf(out, "warning: %s\n", warning)
+	}
+	if s.Healthy {
+		fmt.Fprintf(out, "cluster %s is healthy\n", s.Name)
+		return
+	}
+	fmt.Fprintf(out, "cluster %s is not healthy:\n", s.Name)
+	for _, p := range s.Problems {
+		fmt.Fprintf(out, "- %s\n", p)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseEtcdEndpointHealth(t *testing.T) {
+	lines := []string{
+		"https://172.18.0.3:2379 is healthy: successfully committed proposal: took = 9.2ms",
+		"https://172.18.0.4:2379 is unhealthy: failed to commit proposal: context deadline exceeded",
+		"Error: unhealthy cluster",
+	}
+	expected := map[string]bool{
+		"https://172.18.0.3:2379": true,
+		"https://172.18.0.4:2379": false,
+	}
+	if health := parseEtcdEndpointHealth(lines); !reflect.DeepEqual(health, expected) {
+		t.Errorf("expected %v, got %v", expected, health)
+	}
+}
+
+func TestClusterStatusEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	ready := NodeStatus{Name: "kind-control-plane", Role: "control-plane", Container: "running", Ready: "True"}
+
+	var tests = []struct {
+		name             string
+		status           ClusterStatus
+		expectedProblems []string
+		expectedWarnings []string
+	}{
+		{
+			name: "healthy cluster",
+			status: ClusterStatus{
+				Nodes:        []NodeStatus{ready, {Name: "kind-lb", Role: "external-load-balancer", Container: "running"}},
+				Etcd:         []EtcdMemberStatus{{Name: "kind-control-plane", Healthy: true}},
+				Certificates: []CertificateStatus{{Node: "kind-control-plane", Path: "/etc/kubernetes/pki/ca.crt", NotAfter: now.AddDate(10, 0, 0)}},
+				apiReachable: true,
+			},
+		},
+		{
+			name: "stopped and not ready nodes",
+			status: ClusterStatus{
+				Nodes: []NodeStatus{
+					ready,
+					{Name: "kind-worker", Role: "worker", Container: "running", Ready: "False"},
+					{Name: "kind-worker2", Role: "worker", Container: "running"},
+					{Name: "kind-worker3", Role: "worker", Container: "exited"},
+				},
+				apiReachable: true,
+			},
+			expectedProblems: []string{
+				"node kind-worker is not Ready",
+				"node kind-worker2 is not registered in the cluster",
+				"the container of node kind-worker3 is exited",
+			},
+		},
+		{
+			name: "readiness is not checked when the API server is not reachable",
+			status: ClusterStatus{
+				Nodes:    []NodeStatus{{Name: "kind-control-plane", Role: "control-plane", Container: "running"}},
+				Problems: []string{"failed to get the nodes from the API server"},
+			},
+			expectedProblems: []string{"failed to get the nodes from the API server"},
+		},
+		{
+			name: "unhealthy etcd member and certificates",
+			status: ClusterStatus{
+				Nodes: []NodeStatus{ready},
+				Etcd:  []EtcdMemberStatus{{Name: "kind-control-plane", Healthy: false}},
+				Certificates: []CertificateStatus{
+					{Node: "kind-control-plane", Path: "/etc/kubernetes/pki/apiserver.crt", NotAfter: now.Add(-time.Hour)},
+					{Node: "kind-control-plane", Path: "/etc/kubernetes/admin.conf", NotAfter: now.AddDate(0, 0, 7)},
+				},
+				apiReachable: true,
+			},
+			expectedProblems: []string{
+				"etcd member kind-control-plane is not healthy",
+				"certificate /etc/kubernetes/pki/apiserver.crt on node kind-control-plane is expired",
+			},
+			expectedWarnings: []string{"certificate /etc/kubernetes/admin.conf on node kind-control-plane expires on 2026-10-08T00:00:00Z"},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			s := rt.status
+			s.evaluate(now)
+			if !reflect.DeepEqual(s.Problems, rt.expectedProblems) {
+				t.Errorf("expected problems %v, got %v", rt.expectedProblems, s.Problems)
+			}
+			if !reflect.DeepEqual(s.Warnings, rt.expectedWarnings) {
+				t.Errorf("expected warnings %v, got %v", rt.expectedWarnings, s.Warnings)
+			}
+			if s.Healthy != (len(rt.expectedProblems) == 0) {
+				t.Errorf("expected healthy to be %t", len(rt.expectedProblems) == 0)
+			}
+		})
+	}
+}
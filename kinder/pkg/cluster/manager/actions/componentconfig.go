@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+const componentConfigValidatePath = "/kinder/componentconfig-validate.yaml"
+
+// componentConfig defines where a component config managed by kubeadm is stored in the cluster
+type componentConfig struct {
+	kind      string
+	configMap string
+	key       string
+}
+
+var componentConfigs = []componentConfig{
+	{kind: "KubeletConfiguration", configMap: "kubelet-config", key: "kubelet"},
+	{kind: "KubeProxyConfiguration", configMap: "kube-proxy", key: "config.conf"},
+}
+
+// yamlDocumentSeparatorRE matches the separator between documents in a multi-document YAML
+var yamlDocumentSeparatorRE = regexp.MustCompile(`(?m)^---\s*$`)
+
+// ComponentConfigValidate fetches the kubelet and kube-proxy component configs stored in the cluster,
+// validates them using "kubeadm config validate" and compares them with the defaults printed by
+// "kubeadm config print init-defaults" for the kubeadm version installed on the bootstrap control-plane.
+//
+// Fields existing in the defaults but missing in the cluster, or a different apiVersion, are
+// reported as errors; fields with values different from the defaults are only printed.
+func ComponentConfigValidate(c *status.Cluster, vLevel int) error {
+	cp1 := c.BootstrapControlPlane()
+
+	cp1.Infof("print the component config defaults for the current kubeadm version")
+	lines, err := cp1.Command(
+		"kubeadm", "config", "print", "init-defaults",
+		"--component-configs=KubeletConfiguration,KubeProxyConfiguration",
+	).Silent().RunAndCapture()
+	if err != nil {
+		return errors.Wrap(err, "failed to print the kubeadm defaults")
+	}
+	defaults := splitYAMLDocumentsByKind(strings.Join(lines, "\n"))
+
+	clusterConfiguration, err := getConfigMapData(cp1, "kube-system", "kubeadm-config", "ClusterConfiguration")
+	if err != nil {
+		return err
+	}
+	documents := []string{clusterConfiguration}
+
+	failures := []string{}
+	for _, cc := range componentConfigs {
+		cp1.Infof("compare %s with the defaults", cc.kind)
+
+		live, err := getConfigMapData(cp1, "kube-system", cc.configMap, cc.key)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, live)
+
+		expected, ok := defaults[cc.kind]
+		if !ok {
+			return errors.Errorf("defaults for %s not found in the kubeadm config print output", cc.kind)
+		}
+
+		missing, changed, err := diffComponentConfig(expected, live)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compare %s with the defaults", cc.kind)
+		}
+		for _, d := range changed {
+			fmt.Printf("%s: %s\n", cc.kind, d)
+		}
+		for _, d := range missing {
+			failures = append(failures, fmt.Sprintf("%s: %s", cc.kind, d))
+		}
+	}
+
+	// kubeadm validates the component configs embedded in the config file using strict decoding
+	cp1.Infof("validate the component configs with kubeadm")
+	if err := cp1.Command(
+		"cp", "/dev/stdin", componentConfigValidatePath,
+	).Stdin(strings.NewReader(strings.Join(documents, "\n---\n"))).Silent().Run(); err != nil {
+		return errors.Wrapf(err, "failed to write %s", componentConfigValidatePath)
+	}
+	if err := cp1.Command(
+		"kubeadm", "config", "validate",
+		fmt.Sprintf("--config=%s", componentConfigValidatePath),
+		fmt.Sprintf("--v=%d", vLevel),
+	).RunWithEcho(); err != nil {
+		failures = append(failures, fmt.Sprintf("kubeadm config validate failed: %v", err))
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("component config validation failed:\n%s", strings.Join(failures, "\n"))
+	}
+
+	fmt.Printf("\nComponent configs are valid!\n")
+	return nil
+}
+
+// splitYAMLDocumentsByKind splits a multi-document YAML and returns the documents indexed by kind
+func splitYAMLDocumentsByKind(data string) map[string]string {
+	documents := map[string]string{}
+	for _, d := range yamlDocumentSeparatorRE.Split(data, -1) {
+		meta := struct {
+			Kind string `json:"kind"`
+		}{}
+		if err := yaml.Unmarshal([]byte(d), &meta); err != nil || meta.Kind == "" {
+			continue
+		}
+		documents[meta.Kind] = d
+	}
+	return documents
+}
+
+// diffComponentConfig compares a component config with its defaults; it returns the list of fields
+// that are missing or that have a different apiVersion/kind, and the list of fields with a different value
+func diffComponentConfig(expected, actual string) (missing []string, changed []string, err error) {
+	expectedFields := map[string]any{}
+	if err := yaml.Unmarshal([]byte(expected), &expectedFields); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse defaults")
+	}
+	actualFields := map[string]any{}
+	if err := yaml.Unmarshal([]byte(actual), &actualFields); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse config")
+	}
+
+	e := map[string]any{}
+	flattenFields("", expectedFields, e)
+	a := map[string]any{}
+	flattenFields("", actualFields, a)
+
+	keys := []string{}
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, ok := a[k]
+		switch {
+		case !ok:
+			missing = append(missing, fmt.Sprintf("field %s is missing, default is %v", k, e[k]))
+		case !reflect.DeepEqual(v, e[k]) && (k == "apiVersion" || k == "kind"):
+			missing = append(missing, fmt.Sprintf("field %s is %v, expected %v", k, v, e[k]))
+		case !reflect.DeepEqual(v, e[k]):
+			changed = append(changed, fmt.Sprintf("field %s is %v, default is %v", k, v, e[k]))
+		}
+	}
+	return missing, changed, nil
+}
+
+// flattenFields flattens nested maps into a map of dotted paths; lists are treated as values
+func flattenFields(prefix string, fields map[string]any, flattened map[string]any) {
+	for k, v := range fields {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			flattenFields(path, m, flattened)
+			continue
+		}
+		flattened[path] = v
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffComponentConfig(t *testing.T) {
+	defaults := `apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+cgroupDriver: systemd
+authentication:
+  webhook:
+    enabled: true
+`
+	var cases = []struct {
+		TestName        string
+		Actual          string
+		ExpectedMissing []string
+		ExpectedChanged []string
+	}{
+		{
+			TestName: "same as defaults",
+			Actual:   defaults,
+		},
+		{
+			TestName: "changed value",
+			Actual: `apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+cgroupDriver: cgroupfs
+authentication:
+  webhook:
+    enabled: true
+`,
+			ExpectedChanged: []string{"field cgroupDriver is cgroupfs, default is systemd"},
+		},
+		{
+			TestName: "missing nested field and different apiVersion",
+			Actual: `apiVersion: kubelet.config.k8s.io/v1
+kind: KubeletConfiguration
+cgroupDriver: systemd
+extra: true
+`,
+			ExpectedMissing: []string{
+				"field apiVersion is kubelet.config.k8s.io/v1, expected kubelet.config.k8s.io/v1beta1",
+				"field authentication.webhook.enabled is missing, default is true",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.TestName, func(t *testing.T) {
+			missing, changed, err := diffComponentConfig(defaults, c.Actual)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(missing, c.ExpectedMissing) {
+				t.Errorf("expected missing %v, saw %v", c.ExpectedMissing, missing)
+			}
+			if !reflect.DeepEqual(changed, c.ExpectedChanged) {
+				t.Errorf("expected changed %v, saw %v", c.ExpectedChanged, changed)
+			}
+		})
+	}
+}
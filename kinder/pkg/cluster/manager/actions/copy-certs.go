@@ -32,7 +32,7 @@ const etcKubernetes = "/etc/kubernetes"
 // CopyCertificates actions automate the manual copy of
 // certificates from the bootstrap control-plane to the secondary control-plane nodes
 func CopyCertificates(c *status.Cluster) error {
-	for _, n := range c.SecondaryControlPlanes() {
+	for _, n := range c.SecondaryControlPlanes().EligibleForActions() {
 		if err := copyCertificatesToNode(c, n); err != nil {
 			return err
 		}
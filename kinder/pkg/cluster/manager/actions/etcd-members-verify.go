@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// EtcdMembersVerify checks the local etcd member list against the control-plane nodes already joined to the cluster.
+//
+// With EtcdLearnerMode, kubeadm join adds new etcd members as learners and promotes them once in sync;
+// this action asserts that each joined control-plane node has exactly one member, started, promoted to
+// voting member and with its own peer address, and that no other members exist. The same checks are
+// executed after each control-plane join by kubeadm-join --verify-etcd-members, thus asserting that
+// members are added and promoted one at a time, in the same order of the joins.
+func EtcdMembersVerify(c *status.Cluster, wait time.Duration) error {
+	if c.ExternalEtcd() != nil {
+		return errors.New("etcd-members-verify action can't be used with an external etcd")
+	}
+
+	// control-plane nodes not yet joined don't have an etcd member
+	expected := map[string]string{}
+	for _, cp := range c.ControlPlanes() {
+		if err := cp.Command(
+			"test", "-f", "/etc/kubernetes/manifests/etcd.yaml",
+		).Silent().ReadOnly().Run(); err != nil {
+			continue
+		}
+		ipv4, ipv6, err := cp.IP()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the IP of node %s", cp.Name())
+		}
+		if ipv4 != "" {
+			expected[cp.Name()] = ipv4
+		} else {
+			expected[cp.Name()] = ipv6
+		}
+	}
+	if len(expected) == 0 {
+		return errors.New("no control-plane nodes with a local etcd member found")
+	}
+
+	cp1 := c.BootstrapControlPlane()
+	etcdArgs, err := etcdctlArgs(cp1)
+	if err != nil {
+		return err
+	}
+
+	cp1.Infof("waiting for etcd members to be promoted and healthy (timeout %s)", wait)
+	var lines, failures []string
+	for start := time.Now(); ; time.Sleep(time.Second) {
+		lines, err = cp1.Command(
+			"kubectl", append(append([]string{}, etcdArgs...), "member", "list")...,
+		).Silent().ReadOnly().RunAndCapture()
+		if err != nil {
+			failures = []string{fmt.Sprintf("failed to list etcd members: %v", err)}
+		} else if members, err := parseEtcdMemberList(lines); err != nil {
+			// the member list can be incomplete while a member is being added, so try again
+			failures = []string{fmt.Sprintf("failed to parse etcd members: %v", err)}
+		} else {
+			failures = checkEtcdMembers(members, expected)
+		}
+		if len(failures) == 0 || time.Since(start) >= wait {
+			break
+		}
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	if len(failures) > 0 {
+		return errors.Errorf("unexpected etcd members:\n%s", strings.Join(failures, "\n"))
+	}
+
+	if pass := waitFor(c, cp1, wait, etcdClusterIsHealthy(etcdArgs)); !pass {
+		return errors.New("timeout: etcd cluster is not healthy")
+	}
+
+	fmt.Printf("\netcd has %d voting members, one for each control-plane node!\n", len(expected))
+	return nil
+}
+
+// checkEtcdMembers returns the list of differences between etcd members and the expected members,
+// given as a map of member names to the address expected in peer URLs
+func checkEtcdMembers(members []etcdMember, expected map[string]string) []string {
+	var failures []string
+
+	found := map[string]int{}
+	for _, m := range members {
+		if m.name == "" || m.status != "started" {
+			failures = append(failures, fmt.Sprintf("member %s is not started", m.id))
+			continue
+		}
+		found[m.name]++
+
+		if m.isLearner {
+			failures = append(failures, fmt.Sprintf("member %s (%s) is still a learner", m.name, m.id))
+		}
+
+		address, ok := expected[m.name]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("member %s (%s) does not match any joined control-plane node", m.name, m.id))
+			continue
+		}
+		if address != "" && !strings.Contains(m.peerURLs, address) {
+			failures = append(failures, fmt.Sprintf("member %s (%s) has peer URLs %s, expected address %s", m.name, m.id, m.peerURLs, address))
+		}
+	}
+
+	for name := range expected {
+		switch found[name] {
+		case 0:
+			failures = append(failures, fmt.Sprintf("member %s is missing", name))
+		case 1:
+		default:
+			failures = append(failures, fmt.Sprintf("member %s exists %d times", name, found[name]))
+		}
+	}
+
+	sort.Strings(failures)
+	return failures
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// etcdctlArgs returns the kubectl args for running etcdctl inside the local etcd pod
+// of the given control-plane node; etcdctl subcommands should be appended to the returned args
+func etcdctlArgs(cp *status.Node) ([]string, error) {
+	return etcdctlArgsWithInfof(cp, cp.Infof)
+}
+
+// etcdctlArgsWithInfof is etcdctlArgs, printing progress messages with infof
+func etcdctlArgsWithInfof(cp *status.Node, infof func(message string, args ...any)) ([]string, error) {
+	// NB. before v1.13 local etcd is listening on localhost only; after v1.13
+	// local etcd is listening on localhost and on the advertise address; we are
+	// using localhost to accommodate both the use cases
+
+	etcdArgs := []string{
+		"--kubeconfig=/etc/kubernetes/admin.conf", "exec", "-n=kube-system", fmt.Sprintf("etcd-%s", cp.Name()),
+		"--",
+	}
+
+	var lines []string
+	var err error
+
+	// Get the version of etcdctl from the etcd binary
+	// Retry the version command for a while to avoid "exec" flakes
+	versionArgs := append(etcdArgs, "etcd", "--version")
+	versionArgs = append([]string{"--request-timeout=2"}, versionArgs...) // Ensure shorter timeout
+	for i := range 10 {
+		lines, err = cp.Command("kubectl", versionArgs...).RunAndCapture()
+		if err == nil {
+			break
+		}
+		infof("Could not execute 'etcd --version' inside %q (attempt %d/%d): %v\n", cp.Name(), i+1, 10,
+			errors.Wrap(err, strings.Join(lines, "\n")))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	etcdctlVersion, err := parseEtcdctlVersion(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	infof("Using etcdctl version: %s\n", etcdctlVersion)
+	etcdArgs = append(etcdArgs, "etcdctl", "--endpoints=https://127.0.0.1:2379")
+
+	// Append version specific etcdctl certificate flags
+	if err := appendEtcdctlCertArgs(etcdctlVersion, &etcdArgs); err != nil {
+		return nil, err
+	}
+	return etcdArgs, nil
+}
+
+// etcdClusterIsHealthy implement a function that test when all the members of the local etcd
+// cluster are healthy, as seen from the given control-plane node
+func etcdClusterIsHealthy(etcdArgs []string) try {
+	return func(c *status.Cluster, n *status.Node) bool {
+		args := append(append([]string{}, etcdArgs...), "endpoint", "health", "--cluster")
+		if err := n.Command("kubectl", args...).Silent().Run(); err != nil {
+			return false
+		}
+		fmt.Printf("etcd cluster is healthy\n")
+		return true
+	}
+}
+
+// etcdMember holds the details of an etcd member as reported by "etcdctl member list"
+type etcdMember struct {
+	id         string
+	status     string
+	name       string
+	peerURLs   string
+	clientURLs string
+	isLearner  bool
+}
+
+// parseEtcdMemberList parses the output of "etcdctl member list"; each line is expected to be in the
+// format "ID, status, name, peer addrs, client addrs[, is learner]"
+func parseEtcdMemberList(lines []string) ([]etcdMember, error) {
+	members := []etcdMember{}
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		fields := strings.Split(l, ",")
+		if len(fields) < 5 {
+			return nil, errors.Errorf("unexpected format for etcd member %q", l)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		m := etcdMember{
+			id:         fields[0],
+			status:     fields[1],
+			name:       fields[2],
+			peerURLs:   fields[3],
+			clientURLs: fields[4],
+		}
+		// the learner flag, when present, is always the last column
+		if len(fields) > 5 {
+			m.isLearner = fields[len(fields)-1] == "true"
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// etcdMembers parses the output of "etcdctl member list" and returns a map of member names to member IDs
+func etcdMembers(lines []string) (map[string]string, error) {
+	list, err := parseEtcdMemberList(lines)
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]string{}
+	for _, m := range list {
+		members[m.name] = m.id
+	}
+	return members, nil
+}
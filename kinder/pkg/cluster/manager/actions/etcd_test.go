@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEtcdMembers(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputLines      []string
+		expectedMembers map[string]string
+		expectedError   bool
+	}{
+		{
+			name: "valid: multiple members",
+			inputLines: []string{
+				"8e9e05c52164694d, started, kind-control-plane-1, https://172.17.0.3:2380, https://172.17.0.3:2379, false",
+				"91bc3c398fb3c146, started, kind-control-plane-2, https://172.17.0.4:2380, https://172.17.0.4:2379, false",
+			},
+			expectedMembers: map[string]string{
+				"kind-control-plane-1": "8e9e05c52164694d",
+				"kind-control-plane-2": "91bc3c398fb3c146",
+			},
+		},
+		{
+			name: "valid: old format without learner column and empty lines",
+			inputLines: []string{
+				"8e9e05c52164694d, started, kind-control-plane-1, https://172.17.0.3:2380, https://172.17.0.3:2379",
+				"",
+			},
+			expectedMembers: map[string]string{
+				"kind-control-plane-1": "8e9e05c52164694d",
+			},
+		},
+		{
+			name:            "valid: empty input",
+			expectedMembers: map[string]string{},
+		},
+		{
+			name: "invalid: unexpected format",
+			inputLines: []string{
+				"member 8e9e05c52164694d is healthy",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			members, err := etcdMembers(test.inputLines)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("expected error: %v, found %v, error: %v", test.expectedError, err != nil, err)
+			}
+			if test.expectedError {
+				return
+			}
+			if !reflect.DeepEqual(members, test.expectedMembers) {
+				t.Fatalf("expected members: %v, found %v", test.expectedMembers, members)
+			}
+		})
+	}
+}
+
+func TestCheckEtcdMembers(t *testing.T) {
+	expected := map[string]string{
+		"kind-control-plane-1": "172.17.0.3",
+		"kind-control-plane-2": "172.17.0.4",
+	}
+	tests := []struct {
+		name             string
+		inputLines       []string
+		expectedFailures []string
+	}{
+		{
+			name: "all members promoted",
+			inputLines: []string{
+				"8e9e05c52164694d, started, kind-control-plane-1, https://172.17.0.3:2380, https://172.17.0.3:2379, false",
+				"91bc3c398fb3c146, started, kind-control-plane-2, https://172.17.0.4:2380, https://172.17.0.4:2379, false",
+			},
+		},
+		{
+			name: "learner not yet promoted",
+			inputLines: []string{
+				"8e9e05c52164694d, started, kind-control-plane-1, https://172.17.0.3:2380, https://172.17.0.3:2379, false",
+				"91bc3c398fb3c146, started, kind-control-plane-2, https://172.17.0.4:2380, https://172.17.0.4:2379, true",
+			},
+			expectedFailures: []string{"member kind-control-plane-2 (91bc3c398fb3c146) is still a learner"},
+		},
+		{
+			name: "unstarted learner and wrong peer address",
+			inputLines: []string{
+				"8e9e05c52164694d, started, kind-control-plane-1, https://172.17.0.9:2380, https://172.17.0.3:2379, false",
+				"91bc3c398fb3c146, unstarted, , https://172.17.0.4:2380, , true",
+			},
+			expectedFailures: []string{
+				"member 91bc3c398fb3c146 is not started",
+				"member kind-control-plane-1 (8e9e05c52164694d) has peer URLs https://172.17.0.9:2380, expected address 172.17.0.3",
+				"member kind-control-plane-2 is missing",
+			},
+		},
+		{
+			name: "member of a removed node",
+			inputLines: []string{
+				"8e9e05c52164694d, started, kind-control-plane-1, https://172.17.0.3:2380, https://172.17.0.3:2379, false",
+				"91bc3c398fb3c146, started, kind-control-plane-2, https://172.17.0.4:2380, https://172.17.0.4:2379, false",
+				"a1bc3c398fb3c146, started, kind-control-plane-3, https://172.17.0.5:2380, https://172.17.0.5:2379, false",
+			},
+			expectedFailures: []string{"member kind-control-plane-3 (a1bc3c398fb3c146) does not match any joined control-plane node"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			members, err := parseEtcdMemberList(test.inputLines)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			failures := checkEtcdMembers(members, expected)
+			if !reflect.DeepEqual(failures, test.expectedFailures) {
+				t.Fatalf("expected failures: %v, found %v", test.expectedFailures, failures)
+			}
+		})
+	}
+}
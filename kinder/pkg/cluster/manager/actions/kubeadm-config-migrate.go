@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/kubeadm"
+)
+
+const (
+	kubeadmMigrateOldConfigPath = "/kinder/kubeadm-migrate-old.conf"
+	kubeadmMigrateNewConfigPath = "/kinder/kubeadm-migrate-new.conf"
+	kubeadmMigrateBackupPath    = "/kinder/kubeadm.conf.migrate.bak"
+)
+
+// KubeadmConfigMigrate generates the kubeadm config for each node using the fromVersion config API,
+// runs "kubeadm config migrate" and checks that the migrated config uses the latest config API
+// supported by kubeadm and passes "kubeadm config validate".
+//
+// The kubeadm config already existing on nodes, if any, is preserved.
+func KubeadmConfigMigrate(c *status.Cluster, fromVersion string, vLevel int) error {
+	if fromVersion == "" {
+		fromVersion = "v1beta3"
+	}
+
+	for _, n := range c.K8sNodes().EligibleForActions() {
+		if err := kubeadmConfigMigrate(c, n, fromVersion, vLevel); err != nil {
+			return errors.Wrapf(err, "failed to migrate the kubeadm config on node %s", n.Name())
+		}
+	}
+
+	fmt.Printf("\nkubeadm config migrated from %s successfully!\n", fromVersion)
+	return nil
+}
+
+func kubeadmConfigMigrate(c *status.Cluster, n *status.Node, fromVersion string, vLevel int) (err error) {
+	kubeadmVersion, err := n.KubeadmVersion()
+	if err != nil {
+		return err
+	}
+	toVersion := kubeadm.GetKubeadmConfigVersion(kubeadmVersion)
+
+	// preserve the existing kubeadm config, because the config to be migrated is generated in the same place
+	if err := n.Command(
+		"/bin/sh", "-c",
+		fmt.Sprintf("if [ -f %[1]s ]; then cp -f %[1]s %[2]s; fi", constants.KubeadmConfigPath, kubeadmMigrateBackupPath),
+	).Silent().Run(); err != nil {
+		return errors.Wrap(err, "failed to backup the kubeadm config")
+	}
+	defer func() {
+		if rerr := n.Command(
+			"/bin/sh", "-c",
+			fmt.Sprintf("if [ -f %[2]s ]; then mv -f %[2]s %[1]s; fi", constants.KubeadmConfigPath, kubeadmMigrateBackupPath),
+		).Silent().Run(); rerr != nil && err == nil {
+			err = errors.Wrap(rerr, "failed to restore the kubeadm config")
+		}
+	}()
+
+	if err := KubeadmConfig(c, fromVersion, "", "", "", "", "", ExternalEtcdOptions{}, constants.KubeadmIgnorePreflightErrors, nil, n); err != nil {
+		return err
+	}
+	if err := n.Command(
+		"cp", "-f", constants.KubeadmConfigPath, kubeadmMigrateOldConfigPath,
+	).Silent().Run(); err != nil {
+		return errors.Wrap(err, "failed to copy the kubeadm config to be migrated")
+	}
+
+	n.Infof("migrate the kubeadm config from %s to %s", fromVersion, toVersion)
+	if err := n.Command(
+		"kubeadm", "config", "migrate",
+		fmt.Sprintf("--old-config=%s", kubeadmMigrateOldConfigPath),
+		fmt.Sprintf("--new-config=%s", kubeadmMigrateNewConfigPath),
+		fmt.Sprintf("--v=%d", vLevel),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "kubeadm config migrate failed")
+	}
+
+	lines, err := n.Command("cat", kubeadmMigrateNewConfigPath).Silent().RunAndCapture()
+	if err != nil {
+		return errors.Wrap(err, "failed to read the migrated kubeadm config")
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+
+	expectedAPIVersion := fmt.Sprintf("apiVersion: kubeadm.k8s.io/%s", toVersion)
+	found := false
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if strings.HasPrefix(l, "apiVersion: kubeadm.k8s.io/") && l != expectedAPIVersion {
+			return errors.Errorf("the migrated kubeadm config contains %q, expected %q", l, expectedAPIVersion)
+		}
+		if l == expectedAPIVersion {
+			found = true
+		}
+	}
+	if !found {
+		return errors.Errorf("the migrated kubeadm config does not contain %q", expectedAPIVersion)
+	}
+
+	n.Infof("validate the migrated kubeadm config")
+	if err := n.Command(
+		"kubeadm", "config", "validate",
+		fmt.Sprintf("--config=%s", kubeadmMigrateNewConfigPath),
+		fmt.Sprintf("--v=%d", vLevel),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "kubeadm config validate failed for the migrated kubeadm config")
+	}
+
+	return nil
+}
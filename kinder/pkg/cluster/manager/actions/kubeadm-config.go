@@ -18,6 +18,7 @@ package actions
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -37,38 +38,80 @@ type kubeadmConfigOptions struct {
 	configVersion string
 	copyCertsMode CopyCertsMode
 	discoveryMode DiscoveryMode
+	externalEtcd  ExternalEtcdOptions
+	// noForceUpgrade removes forceUpgrade from the UpgradeConfiguration, so kubeadm upgrade apply
+	// does not ignore version skew errors
+	noForceUpgrade bool
+}
+
+// ExternalEtcdOptions defines the external etcd cluster used by kubeadm init.
+// If Endpoints are not set, the external etcd node in the cluster topology is used, if any.
+type ExternalEtcdOptions struct {
+	// Endpoints of the external etcd cluster
+	Endpoints []string
+	// CAFile, CertFile and KeyFile are paths on the control-plane nodes of the files used for
+	// securing the communication with the external etcd cluster
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// Validate validates ExternalEtcdOptions
+func (o ExternalEtcdOptions) Validate() error {
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return errors.New("the external etcd cert file and key file must be set together")
+	}
+	if len(o.Endpoints) == 0 && (o.CAFile != "" || o.CertFile != "") {
+		return errors.New("the external etcd certificate files can be set only together with the external etcd endpoints")
+	}
+	return nil
 }
 
 // KubeadmInitConfig action writes the InitConfiguration into /kind/kubeadm.conf file on all the K8s nodes in the cluster.
 // Please note that this action is automatically executed at create time, but it is possible
 // to invoke it separately as well.
-func KubeadmInitConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode CopyCertsMode, featureGate, encryptionAlgorithm, ignorePreflightErrors string, nodes ...*status.Node) error {
+func KubeadmInitConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode CopyCertsMode, featureGate, encryptionAlgorithm, cloudProvider string, externalEtcd ExternalEtcdOptions, ignorePreflightErrors string, nodes ...*status.Node) error {
 	// defaults everything not relevant for the Init Config
-	return KubeadmConfig(c, kubeadmConfigVersion, copyCertsMode, TokenDiscovery, featureGate, encryptionAlgorithm, ignorePreflightErrors, nil, nodes...)
+	return KubeadmConfig(c, kubeadmConfigVersion, copyCertsMode, TokenDiscovery, featureGate, encryptionAlgorithm, cloudProvider, externalEtcd, ignorePreflightErrors, nil, nodes...)
 }
 
 // KubeadmJoinConfig action writes the JoinConfiguration into /kind/kubeadm.conf file on all the K8s nodes in the cluster.
 // Please note that this action is automatically executed at create time, but it is possible
 // to invoke it separately as well.
-func KubeadmJoinConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, ignorePreflightErrors string, nodes ...*status.Node) error {
+func KubeadmJoinConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, cloudProvider, ignorePreflightErrors string, nodes ...*status.Node) error {
 	// defaults everything not relevant for the join Config
-	return KubeadmConfig(c, kubeadmConfigVersion, copyCertsMode, discoveryMode, "", "", ignorePreflightErrors, nil, nodes...)
+	return KubeadmConfig(c, kubeadmConfigVersion, copyCertsMode, discoveryMode, "", "", cloudProvider, ExternalEtcdOptions{}, ignorePreflightErrors, nil, nodes...)
 }
 
 // KubeadmUpgradeConfig action writes the UpgradeConfiguration into /kind/kubeadm.conf file on all the K8s nodes in the cluster.
 func KubeadmUpgradeConfig(c *status.Cluster, ignorePreflightErrors string, upgradeVersion *version.Version, nodes ...*status.Node) error {
-	return KubeadmConfig(c, "", "", "", "", "", ignorePreflightErrors, upgradeVersion, nodes...)
+	return KubeadmConfig(c, "", "", "", "", "", "", ExternalEtcdOptions{}, ignorePreflightErrors, upgradeVersion, nodes...)
 }
 
 // KubeadmResetConfig action writes the UpgradeConfiguration into /kind/kubeadm.conf file on all the K8s nodes in the cluster.
 func KubeadmResetConfig(c *status.Cluster, ignorePreflightErrors string, nodes ...*status.Node) error {
-	return KubeadmConfig(c, "", "", "", "", "", ignorePreflightErrors, nil, nodes...)
+	return KubeadmConfig(c, "", "", "", "", "", "", ExternalEtcdOptions{}, ignorePreflightErrors, nil, nodes...)
 }
 
 // KubeadmConfig action writes the /kind/kubeadm.conf file on all the K8s nodes in the cluster.
 // Please note that this action is automatically executed at create time, but it is possible
 // to invoke it separately as well.
-func KubeadmConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, featureGate, encryptionAlgorithm, ignorePreflightErrors string, upgradeVersion *version.Version, nodes ...*status.Node) error {
+func KubeadmConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, featureGate, encryptionAlgorithm, cloudProvider string, externalEtcd ExternalEtcdOptions, ignorePreflightErrors string, upgradeVersion *version.Version, nodes ...*status.Node) error {
+	// create configOptions with all the kinder flags that impact on the kubeadm config generation
+	configOptions := kubeadmConfigOptions{
+		configVersion: kubeadmConfigVersion,
+		copyCertsMode: copyCertsMode,
+		discoveryMode: discoveryMode,
+		externalEtcd:  externalEtcd,
+	}
+	return kubeadmConfig(c, configOptions, featureGate, encryptionAlgorithm, cloudProvider, ignorePreflightErrors, upgradeVersion, nodes...)
+}
+
+func kubeadmConfig(c *status.Cluster, configOptions kubeadmConfigOptions, featureGate, encryptionAlgorithm, cloudProvider, ignorePreflightErrors string, upgradeVersion *version.Version, nodes ...*status.Node) error {
+	if err := configOptions.externalEtcd.Validate(); err != nil {
+		return err
+	}
+
 	cp1 := c.BootstrapControlPlane()
 
 	// get installed kubernetes version from the node image
@@ -107,12 +150,12 @@ func KubeadmConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode
 		featureGateValue = split[1]
 	}
 
-	if copyCertsMode == "" {
-		copyCertsMode = CopyCertsModeAuto
+	if configOptions.copyCertsMode == "" {
+		configOptions.copyCertsMode = CopyCertsModeAuto
 	}
 
-	if discoveryMode == "" {
-		discoveryMode = TokenDiscovery
+	if configOptions.discoveryMode == "" {
+		configOptions.discoveryMode = TokenDiscovery
 	}
 
 	// Use a placeholder upgrade version for non-upgrade actions.
@@ -134,17 +177,11 @@ func KubeadmConfig(c *status.Cluster, kubeadmConfigVersion string, copyCertsMode
 		FeatureGateName:       featureGateName,
 		FeatureGateValue:      featureGateValue,
 		EncryptionAlgorithm:   encryptionAlgorithm,
+		CloudProvider:         cloudProvider,
 		UpgradeVersion:        fmt.Sprintf("v%s", upgradeVersion.String()),
 		IgnorePreflightErrors: strings.Split(ignorePreflightErrors, ","),
 	}
 
-	// create configOptions with all the kinder flags that impact on the kubeadm config generation
-	configOptions := kubeadmConfigOptions{
-		configVersion: kubeadmConfigVersion,
-		copyCertsMode: copyCertsMode,
-		discoveryMode: discoveryMode,
-	}
-
 	// writs the kubeadm config file on all the K8s nodes.
 	for _, node := range nodes {
 		if err := writeKubeadmConfig(c, node, configData, configOptions); err != nil {
@@ -317,25 +354,51 @@ func getKubeadmConfig(c *status.Cluster, n *status.Node, data kubeadm.ConfigData
 		}
 	}
 
-	// if the cluster is using an external etcd node, add patches for configuring access
-	// to external etcd cluster
-	if c.ExternalEtcd() != nil {
-		externalEtcdIP, externalEtcdIPV6, err := c.ExternalEtcd().IP()
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to get IP for node: %s", c.ExternalEtcd().Name())
-		}
+	// if the cluster is using an external etcd node or external etcd endpoints are set,
+	// add patches for configuring access to external etcd cluster
+	if c.ExternalEtcd() != nil || len(options.externalEtcd.Endpoints) > 0 {
+		endpoints := options.externalEtcd.Endpoints
+		if len(endpoints) == 0 {
+			externalEtcdIP, externalEtcdIPV6, err := c.ExternalEtcd().IP()
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to get IP for node: %s", c.ExternalEtcd().Name())
+			}
 
-		// configure the right protocol addresses
-		if c.Settings.IPFamily == status.IPv6Family {
-			externalEtcdIP = externalEtcdIPV6
+			// configure the right protocol addresses
+			if c.Settings.IPFamily == status.IPv6Family {
+				externalEtcdIP = fmt.Sprintf("[%s]", externalEtcdIPV6)
+			}
+			endpoints = []string{fmt.Sprintf("http://%s:2379", externalEtcdIP)}
 		}
 
-		externalEtcdPatch, err := kubeadm.GetExternalEtcdPatch(kubeadmConfigVersion, externalEtcdIP)
+		externalEtcdPatch, err := kubeadm.GetExternalEtcdPatch(kubeadmConfigVersion, kubeadm.ExternalEtcd{
+			Endpoints: endpoints,
+			CAFile:    options.externalEtcd.CAFile,
+			CertFile:  options.externalEtcd.CertFile,
+			KeyFile:   options.externalEtcd.KeyFile,
+		})
 		if err != nil {
 			return "", err
 		}
 
 		patches = append(patches, externalEtcdPatch)
+	} else if etcdImage := etcdImageOnNode(n, "/kind"); etcdImage != "" {
+		// if the node image embeds an etcd image, add patches for using it instead of the kubeadm default
+		etcdImagePatch, err := kubeadm.GetEtcdImagePatch(kubeadmConfigVersion, etcdImage)
+		if err != nil {
+			return "", err
+		}
+
+		patches = append(patches, etcdImagePatch)
+	}
+
+	// remove forceUpgrade from the UpgradeConfiguration, if requested
+	if options.noForceUpgrade {
+		noForceUpgradePatch, err := kubeadm.GetNoForceUpgradePatch(kubeadmConfigVersion)
+		// skip if kubeadm config version is not v1beta4
+		if err == nil {
+			jsonPatches = append(jsonPatches, noForceUpgradePatch)
+		}
 	}
 
 	// encryption algorithm
@@ -373,6 +436,15 @@ func getKubeadmConfig(c *status.Cluster, n *status.Node, data kubeadm.ConfigData
 	), nil
 }
 
+// etcdImageOnNode returns the etcd image embedded in the node image for the artifacts in dir, if any
+func etcdImageOnNode(n *status.Node, dir string) string {
+	lines, err := n.Command("cat", filepath.Join(dir, constants.EtcdImageFile)).Silent().RunAndCapture()
+	if err != nil || len(lines) != 1 {
+		return ""
+	}
+	return strings.TrimSpace(lines[0])
+}
+
 func createDiscoveryFile(c *status.Cluster, n *status.Node, discoveryMode DiscoveryMode) error {
 	// the discovery file is a kubeaconfig file, so for sake of semplicity in setting up this test,
 	// we are using the admin.conf file created by kubeadm on the bootstrap control plane node
@@ -380,7 +452,7 @@ func createDiscoveryFile(c *status.Cluster, n *status.Node, discoveryMode Discov
 	// IMPORTANT. Don't do this in production, admin.conf contains cluster-admin credentials.
 	lines, err := c.BootstrapControlPlane().Command(
 		"cat", "/etc/kubernetes/admin.conf",
-	).Silent().RunAndCapture()
+	).Silent().ReadOnly().RunAndCapture()
 	if err != nil {
 		return errors.Wrapf(err, "failed to read /etc/kubernetes/admin.conf from %s", c.BootstrapControlPlane().Name())
 	}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/kubeadm"
+)
+
+const (
+	kubeadmConfigMap        = "kubeadm-config"
+	kubeletConfigMap        = "kubelet-config"
+	kubeletConfigMapKey     = "kubelet"
+	clusterConfigKey        = "ClusterConfiguration"
+	clusterStatusKey        = "ClusterStatus"
+	kubeletConfigAPIVersion = "kubelet.config.k8s.io/v1beta1"
+)
+
+// KubeadmConfigMaps dumps the kubeadm-config and kubelet-config ConfigMaps and verifies their content is
+// consistent with the kubeadm version and the Kubernetes version installed on the bootstrap control-plane.
+//
+// In particular, the ClusterConfiguration must be stored with the API version used by the installed kubeadm
+// and must report the current Kubernetes version, and the legacy ClusterStatus key must not exist; this
+// action is intended to be used after init and after each upgrade for catching ConfigMap migration regressions.
+func KubeadmConfigMaps(c *status.Cluster) error {
+	cp1 := c.BootstrapControlPlane()
+
+	kubeadmVersion, err := cp1.KubeadmVersion()
+	if err != nil {
+		return err
+	}
+	kubeVersion, err := cp1.KubeVersion()
+	if err != nil {
+		return err
+	}
+
+	kubeadmData, err := getConfigMap(cp1, "kube-system", kubeadmConfigMap)
+	if err != nil {
+		return err
+	}
+	kubeletData, err := getConfigMap(cp1, "kube-system", kubeletConfigMap)
+	if err != nil {
+		return err
+	}
+
+	dumpConfigMap(kubeadmConfigMap, kubeadmData)
+	dumpConfigMap(kubeletConfigMap, kubeletData)
+
+	failures := checkKubeadmConfigMap(kubeadmData, kubeadm.GetKubeadmConfigVersion(kubeadmVersion), kubeVersion)
+	failures = append(failures, checkKubeletConfigMap(kubeletData)...)
+	if len(failures) > 0 {
+		return errors.Errorf("unexpected content of the kubeadm ConfigMaps:\n%s", strings.Join(failures, "\n"))
+	}
+
+	fmt.Printf("\nkubeadm ConfigMaps are consistent with kubeadm %s and Kubernetes %s!\n", kubeadmVersion, kubeVersion)
+	return nil
+}
+
+// getConfigMap returns all the data stored in a ConfigMap
+func getConfigMap(n *status.Node, namespace, name string) (map[string]string, error) {
+	lines, err := n.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"get", "configmap", name, "-n", namespace,
+		"-o=jsonpath={.data}",
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get configmap %s/%s", namespace, name)
+	}
+
+	data := map[string]string{}
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the data of configmap %s/%s", namespace, name)
+	}
+	return data, nil
+}
+
+func dumpConfigMap(name string, data map[string]string) {
+	fmt.Printf("\n# ConfigMap %s\n", name)
+	for _, k := range sortedKeys(data) {
+		fmt.Printf("## %s\n%s\n", k, strings.TrimSpace(data[k]))
+	}
+}
+
+// checkKubeadmConfigMap returns the list of unexpected findings in the kubeadm-config ConfigMap data
+func checkKubeadmConfigMap(data map[string]string, configVersion, kubernetesVersion string) []string {
+	var failures []string
+
+	if _, ok := data[clusterStatusKey]; ok {
+		failures = append(failures, fmt.Sprintf("%s: the %s key should not exist anymore", kubeadmConfigMap, clusterStatusKey))
+	}
+	for _, k := range sortedKeys(data) {
+		if k != clusterConfigKey && k != clusterStatusKey {
+			failures = append(failures, fmt.Sprintf("%s: unexpected key %s", kubeadmConfigMap, k))
+		}
+	}
+
+	clusterConfig, ok := data[clusterConfigKey]
+	if !ok {
+		return append(failures, fmt.Sprintf("%s: the %s key is missing", kubeadmConfigMap, clusterConfigKey))
+	}
+
+	var cfg struct {
+		APIVersion        string `json:"apiVersion"`
+		Kind              string `json:"kind"`
+		KubernetesVersion string `json:"kubernetesVersion"`
+	}
+	if err := yaml.Unmarshal([]byte(clusterConfig), &cfg); err != nil {
+		return append(failures, fmt.Sprintf("%s: invalid %s: %v", kubeadmConfigMap, clusterConfigKey, err))
+	}
+
+	if expected := fmt.Sprintf("kubeadm.k8s.io/%s", configVersion); cfg.APIVersion != expected {
+		failures = append(failures, fmt.Sprintf("%s: expected %s apiVersion %s, found %q", kubeadmConfigMap, clusterConfigKey, expected, cfg.APIVersion))
+	}
+	if cfg.Kind != clusterConfigKey {
+		failures = append(failures, fmt.Sprintf("%s: expected kind %s, found %q", kubeadmConfigMap, clusterConfigKey, cfg.Kind))
+	}
+	if cfg.KubernetesVersion != kubernetesVersion {
+		failures = append(failures, fmt.Sprintf("%s: expected kubernetesVersion %s, found %q", kubeadmConfigMap, kubernetesVersion, cfg.KubernetesVersion))
+	}
+
+	return failures
+}
+
+// checkKubeletConfigMap returns the list of unexpected findings in the kubelet-config ConfigMap data
+func checkKubeletConfigMap(data map[string]string) []string {
+	kubeletConfig, ok := data[kubeletConfigMapKey]
+	if !ok {
+		return []string{fmt.Sprintf("%s: the %s key is missing", kubeletConfigMap, kubeletConfigMapKey)}
+	}
+
+	var cfg struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := yaml.Unmarshal([]byte(kubeletConfig), &cfg); err != nil {
+		return []string{fmt.Sprintf("%s: invalid %s: %v", kubeletConfigMap, kubeletConfigMapKey, err)}
+	}
+
+	var failures []string
+	if cfg.APIVersion != kubeletConfigAPIVersion {
+		failures = append(failures, fmt.Sprintf("%s: expected apiVersion %s, found %q", kubeletConfigMap, kubeletConfigAPIVersion, cfg.APIVersion))
+	}
+	if cfg.Kind != "KubeletConfiguration" {
+		failures = append(failures, fmt.Sprintf("%s: expected kind KubeletConfiguration, found %q", kubeletConfigMap, cfg.Kind))
+	}
+	return failures
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckKubeadmConfigMap(t *testing.T) {
+	clusterConfig := `apiVersion: kubeadm.k8s.io/v1beta4
+kind: ClusterConfiguration
+kubernetesVersion: v1.33.0
+`
+	var cases = []struct {
+		TestName         string
+		Data             map[string]string
+		ExpectedFailures []string
+	}{
+		{
+			TestName: "valid",
+			Data:     map[string]string{"ClusterConfiguration": clusterConfig},
+		},
+		{
+			TestName: "legacy ClusterStatus",
+			Data: map[string]string{
+				"ClusterConfiguration": clusterConfig,
+				"ClusterStatus":        "apiEndpoints: {}",
+			},
+			ExpectedFailures: []string{"kubeadm-config: the ClusterStatus key should not exist anymore"},
+		},
+		{
+			TestName:         "missing ClusterConfiguration",
+			Data:             map[string]string{},
+			ExpectedFailures: []string{"kubeadm-config: the ClusterConfiguration key is missing"},
+		},
+		{
+			TestName: "config version and kubernetes version not bumped",
+			Data: map[string]string{"ClusterConfiguration": `apiVersion: kubeadm.k8s.io/v1beta3
+kind: ClusterConfiguration
+kubernetesVersion: v1.32.0
+`},
+			ExpectedFailures: []string{
+				`kubeadm-config: expected ClusterConfiguration apiVersion kubeadm.k8s.io/v1beta4, found "kubeadm.k8s.io/v1beta3"`,
+				`kubeadm-config: expected kubernetesVersion v1.33.0, found "v1.32.0"`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.TestName, func(t *testing.T) {
+			failures := checkKubeadmConfigMap(c.Data, "v1beta4", "v1.33.0")
+			if !reflect.DeepEqual(failures, c.ExpectedFailures) {
+				t.Errorf("expected failures %v, saw %v", c.ExpectedFailures, failures)
+			}
+		})
+	}
+}
@@ -29,14 +29,21 @@ import (
 
 	"github.com/pkg/errors"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions/assets"
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
 	"k8s.io/kubeadm/kinder/pkg/constants"
 )
 
 // KubeadmInit executes the kubeadm init workflow including also post init task
-// like installing the CNI network plugin
-func KubeadmInit(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, featureGates, encryptionAlgorithm string, wait time.Duration, vLevel int) (err error) {
+// like installing the CNI network plugin.
+// When using phases, initPhases defines the ordered list of phases to be executed.
+func KubeadmInit(c *status.Cluster, usePhases bool, initPhases []string, copyCertsMode CopyCertsMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, featureGates, encryptionAlgorithm, cloudProvider string, externalEtcd ExternalEtcdOptions, kubeadmExtraFlags []string, wait time.Duration, vLevel int) (err error) {
+	// extra flags are meant for the kubeadm init command line, and they are not valid for all the phases
+	if usePhases && len(kubeadmExtraFlags) > 0 {
+		return errors.New("--kubeadm-extra-flags can't be used together with --use-phases")
+	}
+
 	cp1 := c.BootstrapControlPlane()
 
 	if err := copyPatchesToNode(cp1, patchesDir); err != nil {
@@ -54,7 +61,7 @@ func KubeadmInit(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode,
 	}
 
 	// prepares the kubeadm config on this node
-	if err := KubeadmInitConfig(c, kubeadmConfigVersion, copyCertsMode, featureGates, encryptionAlgorithm, ignorePreflightErrors, cp1); err != nil {
+	if err := KubeadmInitConfig(c, kubeadmConfigVersion, copyCertsMode, featureGates, encryptionAlgorithm, cloudProvider, externalEtcd, ignorePreflightErrors, cp1); err != nil {
 		return err
 	}
 
@@ -65,9 +72,9 @@ func KubeadmInit(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode,
 
 	// execs the kubeadm init workflow
 	if usePhases {
-		err = kubeadmInitWithPhases(cp1, copyCertsMode, vLevel)
+		err = kubeadmInitWithPhases(cp1, copyCertsMode, initPhases, vLevel)
 	} else {
-		err = kubeadmInit(cp1, copyCertsMode, vLevel)
+		err = kubeadmInit(cp1, copyCertsMode, kubeadmExtraFlags, vLevel)
 	}
 	if err != nil {
 		return err
@@ -81,7 +88,7 @@ func KubeadmInit(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode,
 	return nil
 }
 
-func kubeadmInit(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) error {
+func kubeadmInit(cp1 *status.Node, copyCertsMode CopyCertsMode, extraFlags []string, vLevel int) error {
 	initArgs := []string{
 		"init",
 		fmt.Sprintf("--config=%s", constants.KubeadmConfigPath),
@@ -93,6 +100,7 @@ func kubeadmInit(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) erro
 			// NB. certificate key is passed via the config file)
 		)
 	}
+	initArgs = append(initArgs, extraFlags...)
 
 	if err := cp1.Command(
 		"kubeadm", initArgs...,
@@ -103,95 +111,126 @@ func kubeadmInit(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) erro
 	return nil
 }
 
-func kubeadmInitWithPhases(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) error {
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "preflight", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
-	}
-
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "kubelet-start", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
-	}
+// kubeadmInitPhase defines a phase of the kubeadm init workflow that can be executed individually
+type kubeadmInitPhase struct {
+	name string
+	run  func(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) error
+	// needsAPIServer is set for phases that can run only after the API server is up and running
+	needsAPIServer bool
+}
 
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "certs", "all", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
-	}
+// kubeadmInitPhases lists the kubeadm init phases in the order documented by kubeadm init --help
+var kubeadmInitPhases = []kubeadmInitPhase{
+	{name: "preflight", run: runKubeadmInitPhase("preflight")},
+	{name: "certs", run: runKubeadmInitPhase("certs", "all")},
+	{name: "kubeconfig", run: runKubeadmInitPhase("kubeconfig", "all")},
+	{name: "etcd", run: runKubeadmInitPhase("etcd", "local")},
+	{name: "control-plane", run: runKubeadmInitPhase("control-plane", "all")},
+	{name: "kubelet-start", run: runKubeadmInitPhase("kubelet-start")},
+	{name: "wait-control-plane", run: runKubeadmInitPhase("wait-control-plane")},
+	{name: "upload-config", run: runKubeadmInitPhase("upload-config", "all"), needsAPIServer: true},
+	{name: "upload-certs", run: uploadCerts, needsAPIServer: true},
+	{name: "mark-control-plane", run: runKubeadmInitPhase("mark-control-plane"), needsAPIServer: true},
+	{name: "bootstrap-token", run: runKubeadmInitPhase("bootstrap-token"), needsAPIServer: true},
+	{name: "kubelet-finalize", run: runKubeadmInitPhase("kubelet-finalize", "all"), needsAPIServer: true},
+	{name: "addon", run: runKubeadmInitPhase("addon", "all"), needsAPIServer: true},
+	{name: "show-join-command", run: runKubeadmInitPhase("show-join-command"), needsAPIServer: true},
+}
 
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "kubeconfig", "all", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
-	}
+// defaultInitPhases lists the phases executed when no phases are requested, in the order used by kinder
+// before the phases could be selected; this order works for all the kubeadm versions supported by kinder,
+// while wait-control-plane, kubelet-finalize and show-join-command are not available in all of them
+var defaultInitPhases = []string{
+	"preflight", "kubelet-start", "certs", "kubeconfig", "control-plane", "etcd",
+	"upload-config", "upload-certs", "mark-control-plane", "bootstrap-token", "addon",
+}
 
-	controlplaneArgs := []string{
-		"init", "phase", "control-plane", "all", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	}
-	if err := cp1.Command(
-		"kubeadm", controlplaneArgs...,
-	).RunWithEcho(); err != nil {
-		return err
+// KnownInitPhases returns the list of kubeadm init phases supported by kinder, in the documented kubeadm order
+func KnownInitPhases() []string {
+	names := []string{}
+	for _, p := range kubeadmInitPhases {
+		names = append(names, p.name)
 	}
+	return names
+}
 
-	etcdArgs := []string{
-		"init", "phase", "etcd", "local", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	}
-	if err := cp1.Command(
-		"kubeadm", etcdArgs...,
-	).RunWithEcho(); err != nil {
-		return err
+// resolveInitPhases returns the ordered list of kubeadm init phases to execute.
+// If phases is empty, defaultInitPhases are executed; phases listed in skip are removed.
+func resolveInitPhases(phases, skip []string) ([]string, error) {
+	known := sets.NewString(KnownInitPhases()...)
+	for _, p := range append(append([]string{}, phases...), skip...) {
+		if !known.Has(p) {
+			return nil, errors.Errorf("%s is not a valid kubeadm init phase. Use one of %s", p, KnownInitPhases())
+		}
 	}
 
-	cp1.Infof("waiting for the api server to start")
-	if err := cp1.Command(
-		"/bin/bash", "-c", //use shell to get $(...) resolved into the container
-		fmt.Sprintf("while [[ \"$(curl -k https://localhost:%d/healthz -s -o /dev/null -w ''%%{http_code}'')\" != \"200\" ]]; do sleep 1; done", constants.APIServerPort),
-	).Silent().Run(); err != nil {
-		return err
+	if len(phases) == 0 {
+		phases = defaultInitPhases
 	}
 
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "upload-config", "all", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
+	skipped := sets.NewString(skip...)
+	resolved := []string{}
+	for _, p := range phases {
+		if skipped.Has(p) {
+			continue
+		}
+		resolved = append(resolved, p)
 	}
+	return resolved, nil
+}
 
-	if copyCertsMode == CopyCertsModeAuto {
-		uploadCertsArgs := []string{
-			"init", "phase", "upload-certs", "--upload-certs",
-			fmt.Sprintf("--config=%s", constants.KubeadmConfigPath),
-			fmt.Sprintf("--v=%d", vLevel),
-		}
-		if err := cp1.Command(
-			"kubeadm", uploadCertsArgs...,
-		).RunWithEcho(); err != nil {
-			return err
+func kubeadmInitWithPhases(cp1 *status.Node, copyCertsMode CopyCertsMode, phases []string, vLevel int) error {
+	apiServerReady := false
+	for _, name := range phases {
+		for _, p := range kubeadmInitPhases {
+			if p.name != name {
+				continue
+			}
+			// waits for the API server before the first phase requiring it; this is not a kubeadm phase
+			// but it is required because the control-plane phase does not wait for the static pods to start
+			if p.needsAPIServer && !apiServerReady {
+				if err := waitForAPIServer(cp1); err != nil {
+					return err
+				}
+				apiServerReady = true
+			}
+			if err := p.run(cp1, copyCertsMode, vLevel); err != nil {
+				return errors.Wrapf(err, "failed to run the %s phase", name)
+			}
 		}
 	}
+	return nil
+}
 
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "mark-control-plane", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
+// runKubeadmInitPhase returns a function running "kubeadm init phase" with the given args
+func runKubeadmInitPhase(args ...string) func(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) error {
+	return func(cp1 *status.Node, _ CopyCertsMode, vLevel int) error {
+		phaseArgs := append([]string{"init", "phase"}, args...)
+		phaseArgs = append(phaseArgs, fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel))
+		return cp1.Command(
+			"kubeadm", phaseArgs...,
+		).RunWithEcho()
 	}
+}
 
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "bootstrap-token", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
-	}
+func waitForAPIServer(cp1 *status.Node) error {
+	cp1.Infof("waiting for the api server to start")
+	return cp1.Command(
+		"/bin/bash", "-c", //use shell to get $(...) resolved into the container
+		fmt.Sprintf("while [[ \"$(curl -k https://localhost:%d/healthz -s -o /dev/null -w ''%%{http_code}'')\" != \"200\" ]]; do sleep 1; done", constants.APIServerPort),
+	).Silent().Run()
+}
 
-	if err := cp1.Command(
-		"kubeadm", "init", "phase", "addon", "all", fmt.Sprintf("--config=%s", constants.KubeadmConfigPath), fmt.Sprintf("--v=%d", vLevel),
-	).RunWithEcho(); err != nil {
-		return err
+func uploadCerts(cp1 *status.Node, copyCertsMode CopyCertsMode, vLevel int) error {
+	// certificates are uploaded only when using the automatic copy certs feature
+	if copyCertsMode != CopyCertsModeAuto {
+		return nil
 	}
-
-	return nil
+	return cp1.Command(
+		"kubeadm", "init", "phase", "upload-certs", "--upload-certs",
+		fmt.Sprintf("--config=%s", constants.KubeadmConfigPath),
+		fmt.Sprintf("--v=%d", vLevel),
+	).RunWithEcho()
 }
 
 func postInit(c *status.Cluster, wait time.Duration) error {
@@ -286,9 +325,28 @@ var serverAddressRE = regexp.MustCompile(`^(\s+server:) https://.*:\d+$`)
 // is replaced with local host and the control plane port with
 // a randomly generated port reserved during node creation.
 func writeKubeConfig(c *status.Cluster, hostPort int32) error {
+	data, err := hostKubeConfig(c, hostPort)
+	if err != nil {
+		return err
+	}
+
+	// create the directory to contain the KUBECONFIG file.
+	// 0755 is taken from client-go's config handling logic: https://github.com/kubernetes/client-go/blob/5d107d4ebc00ee0ea606ad7e39fd6ce4b0d9bf9e/tools/clientcmd/loader.go#L412
+	dest := c.KubeConfigPath()
+	err = os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create kubeconfig output directory")
+	}
+
+	return os.WriteFile(dest, data, 0600)
+}
+
+// hostKubeConfig returns the admin.conf file of the bootstrap control plane, with the server
+// address replaced by the address on the host, that is localhost:hostPort
+func hostKubeConfig(c *status.Cluster, hostPort int32) ([]byte, error) {
 	lines, err := c.BootstrapControlPlane().Command("cat", "/etc/kubernetes/admin.conf").Silent().RunAndCapture()
 	if err != nil {
-		return errors.Wrap(err, "failed to get kubeconfig from node")
+		return nil, errors.Wrap(err, "failed to get kubeconfig from node")
 	}
 
 	// fix the config file, swapping out the server for the forwarded localhost:port
@@ -302,16 +360,7 @@ func writeKubeConfig(c *status.Cluster, hostPort int32) error {
 		buff.WriteString(line)
 		buff.WriteString("\n")
 	}
-
-	// create the directory to contain the KUBECONFIG file.
-	// 0755 is taken from client-go's config handling logic: https://github.com/kubernetes/client-go/blob/5d107d4ebc00ee0ea606ad7e39fd6ce4b0d9bf9e/tools/clientcmd/loader.go#L412
-	dest := c.KubeConfigPath()
-	err = os.MkdirAll(filepath.Dir(dest), 0755)
-	if err != nil {
-		return errors.Wrap(err, "failed to create kubeconfig output directory")
-	}
-
-	return os.WriteFile(dest, buff.Bytes(), 0600)
+	return buff.Bytes(), nil
 }
 
 func copyPatchesToNode(n *status.Node, dir string) error {
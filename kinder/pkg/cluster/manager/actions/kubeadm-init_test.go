@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveInitPhases(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputPhases    []string
+		inputSkip      []string
+		expectedPhases []string
+		expectedError  bool
+	}{
+		{
+			name: "valid: default phases",
+			expectedPhases: []string{
+				"preflight", "kubelet-start", "certs", "kubeconfig", "control-plane", "etcd",
+				"upload-config", "upload-certs", "mark-control-plane", "bootstrap-token", "addon",
+			},
+		},
+		{
+			name:           "valid: custom order",
+			inputPhases:    []string{"preflight", "kubelet-start", "certs"},
+			expectedPhases: []string{"preflight", "kubelet-start", "certs"},
+		},
+		{
+			name:           "valid: phases not executed by default",
+			inputPhases:    []string{"kubelet-start", "wait-control-plane", "kubelet-finalize", "show-join-command"},
+			expectedPhases: []string{"kubelet-start", "wait-control-plane", "kubelet-finalize", "show-join-command"},
+		},
+		{
+			name:           "valid: skip phases from custom order",
+			inputPhases:    []string{"preflight", "certs", "kubeconfig"},
+			inputSkip:      []string{"certs"},
+			expectedPhases: []string{"preflight", "kubeconfig"},
+		},
+		{
+			name:           "valid: skip phases from default order",
+			inputSkip:      []string{"preflight", "bootstrap-token", "addon"},
+			expectedPhases: defaultInitPhases[1 : len(defaultInitPhases)-2],
+		},
+		{
+			name:          "invalid: unknown phase",
+			inputPhases:   []string{"preflight", "foo"},
+			expectedError: true,
+		},
+		{
+			name:          "invalid: unknown skipped phase",
+			inputSkip:     []string{"foo"},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			phases, err := resolveInitPhases(test.inputPhases, test.inputSkip)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("expected error: %v, found %v, error: %v", test.expectedError, err != nil, err)
+			}
+			if test.expectedError {
+				return
+			}
+			if !reflect.DeepEqual(phases, test.expectedPhases) {
+				t.Fatalf("expected phases: %v, found %v", test.expectedPhases, phases)
+			}
+		})
+	}
+}
+
+func TestKnownInitPhases(t *testing.T) {
+	// phases are listed in the order documented by kubeadm init --help
+	expected := []string{
+		"preflight", "certs", "kubeconfig", "etcd", "control-plane", "kubelet-start", "wait-control-plane",
+		"upload-config", "upload-certs", "mark-control-plane", "bootstrap-token", "kubelet-finalize", "addon", "show-join-command",
+	}
+	if phases := KnownInitPhases(); !reflect.DeepEqual(phases, expected) {
+		t.Fatalf("expected phases: %v, found %v", expected, phases)
+	}
+}
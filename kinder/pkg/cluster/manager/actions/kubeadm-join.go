@@ -20,24 +20,31 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
 	"k8s.io/kubeadm/kinder/pkg/constants"
 )
 
 // KubeadmJoin executes the kubeadm join workflow both for control-plane nodes and
-// worker nodes
-func KubeadmJoin(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors string, wait time.Duration, vLevel int) (err error) {
-	if err := joinControlPlanes(c, usePhases, copyCertsMode, discoveryMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, wait, vLevel); err != nil {
+// worker nodes; if verifyEtcdMembers is set, the etcd members are verified after each control-plane join
+func KubeadmJoin(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, cloudProvider string, kubeadmExtraFlags []string, verifyEtcdMembers bool, wait time.Duration, vLevel int) (err error) {
+	// extra flags are meant for the kubeadm join command line, and they are not valid for all the phases
+	if usePhases && len(kubeadmExtraFlags) > 0 {
+		return errors.New("--kubeadm-extra-flags can't be used together with --use-phases")
+	}
+
+	if err := joinControlPlanes(c, usePhases, copyCertsMode, discoveryMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, cloudProvider, kubeadmExtraFlags, verifyEtcdMembers, wait, vLevel); err != nil {
 		return err
 	}
 
-	if err := joinWorkers(c, usePhases, discoveryMode, wait, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, vLevel); err != nil {
+	if err := joinWorkers(c, usePhases, discoveryMode, wait, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, cloudProvider, kubeadmExtraFlags, vLevel); err != nil {
 		return err
 	}
 	return nil
 }
 
-func joinControlPlanes(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors string, wait time.Duration, vLevel int) (err error) {
+func joinControlPlanes(c *status.Cluster, usePhases bool, copyCertsMode CopyCertsMode, discoveryMode DiscoveryMode, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, cloudProvider string, kubeadmExtraFlags []string, verifyEtcdMembers bool, wait time.Duration, vLevel int) (err error) {
 	cpX := []*status.Node{c.BootstrapControlPlane()}
 
 	for _, cp2 := range c.SecondaryControlPlanes().EligibleForActions() {
@@ -63,7 +70,7 @@ func joinControlPlanes(c *status.Cluster, usePhases bool, copyCertsMode CopyCert
 		}
 
 		// prepares the kubeadm config on this node
-		if err := KubeadmJoinConfig(c, kubeadmConfigVersion, copyCertsMode, discoveryMode, ignorePreflightErrors, cp2); err != nil {
+		if err := KubeadmJoinConfig(c, kubeadmConfigVersion, copyCertsMode, discoveryMode, cloudProvider, ignorePreflightErrors, cp2); err != nil {
 			return err
 		}
 
@@ -71,7 +78,7 @@ func joinControlPlanes(c *status.Cluster, usePhases bool, copyCertsMode CopyCert
 		if usePhases {
 			err = kubeadmJoinControlPlaneWithPhases(cp2, vLevel)
 		} else {
-			err = kubeadmJoinControlPlane(cp2, vLevel)
+			err = kubeadmJoinControlPlane(cp2, kubeadmExtraFlags, vLevel)
 		}
 		if err != nil {
 			return err
@@ -86,16 +93,24 @@ func joinControlPlanes(c *status.Cluster, usePhases bool, copyCertsMode CopyCert
 		if err := waitNewControlPlaneNodeReady(c, cp2, wait); err != nil {
 			return err
 		}
+
+		// checks the new etcd member is promoted before joining the next control-plane node
+		if verifyEtcdMembers && c.ExternalEtcd() == nil {
+			if err := EtcdMembersVerify(c, wait); err != nil {
+				return errors.Wrapf(err, "etcd members verification failed after joining %s", cp2.Name())
+			}
+		}
 	}
 	return nil
 }
 
-func kubeadmJoinControlPlane(cp *status.Node, vLevel int) (err error) {
+func kubeadmJoinControlPlane(cp *status.Node, extraFlags []string, vLevel int) (err error) {
 	joinArgs := []string{
 		"join",
 		fmt.Sprintf("--config=%s", constants.KubeadmConfigPath),
 		fmt.Sprintf("--v=%d", vLevel),
 	}
+	joinArgs = append(joinArgs, extraFlags...)
 
 	if err := cp.Command(
 		"kubeadm", joinArgs...,
@@ -158,7 +173,7 @@ func kubeadmJoinControlPlaneWithPhases(cp *status.Node, vLevel int) (err error)
 	return nil
 }
 
-func joinWorkers(c *status.Cluster, usePhases bool, discoveryMode DiscoveryMode, wait time.Duration, kubeadmConfigVersion, patchesDir, ignorePreflightErrors string, vLevel int) (err error) {
+func joinWorkers(c *status.Cluster, usePhases bool, discoveryMode DiscoveryMode, wait time.Duration, kubeadmConfigVersion, patchesDir, ignorePreflightErrors, cloudProvider string, kubeadmExtraFlags []string, vLevel int) (err error) {
 	for _, w := range c.Workers().EligibleForActions() {
 		// checks pre-loaded images available on the node (this will report missing images, if any)
 		kubeVersion, err := w.KubeVersion()
@@ -175,7 +190,7 @@ func joinWorkers(c *status.Cluster, usePhases bool, discoveryMode DiscoveryMode,
 		}
 
 		// prepares the kubeadm config on this node
-		if err := KubeadmJoinConfig(c, kubeadmConfigVersion, CopyCertsModeNone, discoveryMode, ignorePreflightErrors, w); err != nil {
+		if err := KubeadmJoinConfig(c, kubeadmConfigVersion, CopyCertsModeNone, discoveryMode, cloudProvider, ignorePreflightErrors, w); err != nil {
 			return err
 		}
 
@@ -183,7 +198,7 @@ func joinWorkers(c *status.Cluster, usePhases bool, discoveryMode DiscoveryMode,
 		if usePhases {
 			err = kubeadmJoinWorkerWithPhases(w, vLevel)
 		} else {
-			err = kubeadmJoinWorker(w, vLevel)
+			err = kubeadmJoinWorker(w, kubeadmExtraFlags, vLevel)
 		}
 		if err != nil {
 			return err
@@ -196,11 +211,16 @@ func joinWorkers(c *status.Cluster, usePhases bool, discoveryMode DiscoveryMode,
 	return nil
 }
 
-func kubeadmJoinWorker(w *status.Node, vLevel int) (err error) {
-	if err := w.Command(
-		"kubeadm", "join",
+func kubeadmJoinWorker(w *status.Node, extraFlags []string, vLevel int) (err error) {
+	joinArgs := []string{
+		"join",
 		fmt.Sprintf("--config=%s", constants.KubeadmConfigPath),
 		fmt.Sprintf("--v=%d", vLevel),
+	}
+	joinArgs = append(joinArgs, extraFlags...)
+
+	if err := w.Command(
+		"kubeadm", joinArgs...,
 	).RunWithEcho(); err != nil {
 		return err
 	}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+const (
+	kubeconfigUserClientName  = "kinder-test-user"
+	kubeconfigUserOrg         = "kinder:test-users"
+	kubeconfigUserBinding     = "kinder-test-users-view"
+	kubeconfigUserClusterPath = "/kinder/kubeconfig-user-cluster.yaml"
+	kubeconfigUserPath        = "/kinder/kubeconfig-user.conf"
+)
+
+// KubeadmKubeconfigUser generates a kubeconfig file for a test identity using
+// "kubeadm kubeconfig user", binds the identity to the "view" ClusterRole and then
+// verifies that the generated kubeconfig can authenticate and is authorized as expected
+func KubeadmKubeconfigUser(c *status.Cluster, vLevel int) error {
+	cp1 := c.BootstrapControlPlane()
+
+	// cleanups garbage from previous runs
+	cleanupKubeconfigUser(cp1)
+
+	// kubeadm kubeconfig user reads the ClusterConfiguration for getting the cluster name,
+	// the control plane endpoint and the certificate dir; use the one stored in the cluster
+	cp1.Infof("fetch the ClusterConfiguration from the kubeadm-config ConfigMap")
+
+	if err := cp1.Command(
+		"/bin/sh", "-c",
+		fmt.Sprintf("kubectl --kubeconfig=/etc/kubernetes/admin.conf get cm kubeadm-config -n kube-system -o jsonpath='{.data.ClusterConfiguration}' > %s", kubeconfigUserClusterPath),
+	).Silent().Run(); err != nil {
+		return errors.Wrap(err, "failed to read the ClusterConfiguration from the kubeadm-config ConfigMap")
+	}
+
+	cp1.Infof("generate a kubeconfig file for the %q identity", kubeconfigUserClientName)
+
+	if err := cp1.Command(
+		"/bin/sh", "-c",
+		fmt.Sprintf("kubeadm kubeconfig user --config=%s --client-name=%s --org=%s --v=%d > %s",
+			kubeconfigUserClusterPath,
+			kubeconfigUserClientName,
+			kubeconfigUserOrg,
+			vLevel,
+			kubeconfigUserPath,
+		),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to run kubeadm kubeconfig user")
+	}
+
+	// without a binding, the identity should be authenticated but not authorized
+	cp1.Infof("check the %q identity is not authorized before binding", kubeconfigUserClientName)
+
+	if allowed, err := kubeconfigUserCanI(cp1, "list", "pods"); err != nil {
+		return err
+	} else if allowed {
+		return errors.Errorf("identity %q is unexpectedly allowed to list pods without a RBAC binding", kubeconfigUserClientName)
+	}
+
+	cp1.Infof("bind the %q group to the view ClusterRole", kubeconfigUserOrg)
+
+	if err := cp1.Command(
+		"kubectl",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"create", "clusterrolebinding", kubeconfigUserBinding,
+		"--clusterrole=view",
+		fmt.Sprintf("--group=%s", kubeconfigUserOrg),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to create the clusterrolebinding for the test identity")
+	}
+
+	cp1.Infof("check RBAC bindings for the %q identity", kubeconfigUserClientName)
+
+	checks := []struct {
+		verb, resource string
+		expected       bool
+	}{
+		{verb: "list", resource: "pods", expected: true},
+		{verb: "get", resource: "nodes", expected: false},
+		{verb: "delete", resource: "pods", expected: false},
+	}
+	for _, check := range checks {
+		allowed, err := kubeconfigUserCanI(cp1, check.verb, check.resource)
+		if err != nil {
+			return err
+		}
+		if allowed != check.expected {
+			return errors.Errorf("identity %q can %s %s: %t, expected %t", kubeconfigUserClientName, check.verb, check.resource, allowed, check.expected)
+		}
+		fmt.Printf("can %s %s: %t\n", check.verb, check.resource, allowed)
+	}
+
+	cp1.Infof("perform an authenticated request with the generated kubeconfig")
+
+	lines, err := cp1.Command(
+		"kubectl", fmt.Sprintf("--kubeconfig=%s", kubeconfigUserPath), "get", "pods", "-n", "kube-system", "-o", "name",
+	).RunAndCapture()
+	if err != nil {
+		return errors.Wrap(err, "failed to list pods using the generated kubeconfig")
+	}
+	fmt.Printf("%d pods returned\n", len(lines))
+
+	// cleanups and print final message
+	cleanupKubeconfigUser(cp1)
+	fmt.Printf("\nkubeadm kubeconfig user test passed!\n")
+
+	return nil
+}
+
+// kubeconfigUserCanI returns the result of kubectl auth can-i executed using the generated kubeconfig
+func kubeconfigUserCanI(n *status.Node, verb, resource string) (bool, error) {
+	// kubectl auth can-i exits with 1 when the answer is "no", so output is checked instead of the exit code
+	lines, err := n.Command(
+		"/bin/sh", "-c",
+		fmt.Sprintf("kubectl --kubeconfig=%s auth can-i %s %s -n kube-system || true", kubeconfigUserPath, verb, resource),
+	).Silent().RunAndCapture()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to run kubectl auth can-i %s %s", verb, resource)
+	}
+	if len(lines) != 1 {
+		return false, errors.Errorf("failed to parse kubectl auth can-i %s %s output: %v", verb, resource, lines)
+	}
+
+	switch strings.TrimSpace(lines[0]) {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	return false, errors.Errorf("unexpected kubectl auth can-i %s %s output: %s", verb, resource, lines[0])
+}
+
+func cleanupKubeconfigUser(cp1 *status.Node) {
+	cp1.Command(
+		"kubectl",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"delete", "clusterrolebinding", kubeconfigUserBinding, "--ignore-not-found",
+	).Silent().Run()
+
+	cp1.Command(
+		"rm", "-f", kubeconfigUserClusterPath, kubeconfigUserPath,
+	).Silent().Run()
+}
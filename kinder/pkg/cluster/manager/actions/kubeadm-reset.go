@@ -27,30 +27,36 @@ import (
 )
 
 // KubeadmReset executes the kubeadm reset workflow
-func KubeadmReset(c *status.Cluster, vLevel int) error {
+func KubeadmReset(c *status.Cluster, kubeadmExtraFlags []string, vLevel int) error {
 	//TODO: implements kubeadm reset with phases
 	for _, n := range c.K8sNodes().EligibleForActions() {
-		flags := []string{"reset", fmt.Sprintf("--v=%d", vLevel)}
-
-		// After upgrade, the 'kubeadm version' should return the version of the kubeadm used
-		// to perform the upgrade. Use this version to determine if v1beta4 is enabled. If yes,
-		// use ResetConfiguration with a 'force: true', else just use the '--force' flag.
-		v, err := n.KubeadmVersion()
-		if err != nil {
-			return errors.Wrap(err, "could not obtain the kubeadm version before calling 'kubeadm reset'")
-		}
-		if kubeadm.GetKubeadmConfigVersion(v) == "v1beta4" {
-			if err := KubeadmResetConfig(c, "", n); err != nil {
-				return errors.Wrap(err, "could not write kubeadm config before calling 'kubeadm reset'")
-			}
-			flags = append(flags, "--config", constants.KubeadmConfigPath)
-		} else {
-			flags = append(flags, "--force")
-		}
-
-		if err := n.Command("kubeadm", flags...).RunWithEcho(); err != nil {
+		if err := kubeadmResetNode(c, n, kubeadmExtraFlags, vLevel); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// kubeadmResetNode runs kubeadm reset on a single node
+func kubeadmResetNode(c *status.Cluster, n *status.Node, kubeadmExtraFlags []string, vLevel int) error {
+	flags := []string{"reset", fmt.Sprintf("--v=%d", vLevel)}
+
+	// After upgrade, the 'kubeadm version' should return the version of the kubeadm used
+	// to perform the upgrade. Use this version to determine if v1beta4 is enabled. If yes,
+	// use ResetConfiguration with a 'force: true', else just use the '--force' flag.
+	v, err := n.KubeadmVersion()
+	if err != nil {
+		return errors.Wrap(err, "could not obtain the kubeadm version before calling 'kubeadm reset'")
+	}
+	if kubeadm.GetKubeadmConfigVersion(v) == "v1beta4" {
+		if err := KubeadmResetConfig(c, "", n); err != nil {
+			return errors.Wrap(err, "could not write kubeadm config before calling 'kubeadm reset'")
+		}
+		flags = append(flags, "--config", constants.KubeadmConfigPath)
+	} else {
+		flags = append(flags, "--force")
+	}
+	flags = append(flags, kubeadmExtraFlags...)
+
+	return n.Command("kubeadm", flags...).RunWithEcho()
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/kubeadm"
+)
+
+// RollbackExpectation defines the expected outcome of kubeadm upgrade node after a binary rollback
+type RollbackExpectation string
+
+const (
+	// RollbackSupported expects kubeadm upgrade node to succeed with the previous version binaries
+	RollbackSupported = RollbackExpectation("supported")
+
+	// RollbackRejected expects kubeadm upgrade node to fail with the previous version binaries
+	RollbackRejected = RollbackExpectation("rejected")
+)
+
+// KnownRollbackExpectations returns the list of known RollbackExpectation
+func KnownRollbackExpectations() []string {
+	return []string{
+		string(RollbackSupported),
+		string(RollbackRejected),
+	}
+}
+
+// ValidateRollbackExpectation validates a RollbackExpectation
+func ValidateRollbackExpectation(e RollbackExpectation) error {
+	switch e {
+	case RollbackSupported:
+	case RollbackRejected:
+	default:
+		return errors.Errorf("invalid rollback expectation. Use one of %s", KnownRollbackExpectations())
+	}
+	return nil
+}
+
+const (
+	rollbackKubeadmBackupPath = "/kinder/kubeadm.rollback.bak"
+	rollbackConfigBackupPath  = "/kinder/kubeadm.conf.rollback.bak"
+)
+
+// KubeadmRollbackNode swaps the kubeadm binary on the selected nodes back to a previous version and re-runs
+// "kubeadm upgrade node", checking that the result matches the expectation.
+//
+// When the rollback is expected to be supported, kubelet and kubectl are rolled back as well and the
+// node is expected to report the previous version; when it is expected to be rejected, the kubeadm
+// binary and kubeadm config are restored after the test.
+//
+// The binaries for the previous version are expected in the /kinder/upgrade/{version} folder.
+func KubeadmRollbackNode(c *status.Cluster, rollbackVersion *version.Version, expectation RollbackExpectation, ignorePreflightErrors string, wait time.Duration, vLevel int) error {
+	if rollbackVersion == nil {
+		return errors.New("kubeadm-rollback-node action requires the --upgrade-version parameter to be set to the previous version")
+	}
+
+	nodeList := c.K8sNodes().EligibleForActions()
+	if len(nodeList) == 0 {
+		return errors.New("no nodes selected for the rollback; use --only-node")
+	}
+
+	for _, n := range nodeList {
+		if err := rollbackNode(c, n, rollbackVersion, expectation, ignorePreflightErrors, wait, vLevel); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\nkubeadm upgrade node with the previous version binaries is %s as expected!\n", expectation)
+	return nil
+}
+
+func rollbackNode(c *status.Cluster, n *status.Node, rollbackVersion *version.Version, expectation RollbackExpectation, ignorePreflightErrors string, wait time.Duration, vLevel int) (err error) {
+	currentVersion, err := n.KubeadmVersion()
+	if err != nil {
+		return err
+	}
+	if cmp, err := currentVersion.Compare(rollbackVersion.String()); err != nil || cmp <= 0 {
+		return errors.Errorf("the rollback version %s should be lower than the current kubeadm version %s on node %s", rollbackVersion, currentVersion, n.Name())
+	}
+
+	// if the rollback is expected to be rejected, restore the kubeadm binary and config whatever happens
+	if expectation == RollbackRejected {
+		if err := n.Command(
+			"cp", "-fL", "/usr/bin/kubeadm", rollbackKubeadmBackupPath,
+		).Silent().Run(); err != nil {
+			return errors.Wrap(err, "failed to backup the kubeadm binary")
+		}
+		if err := n.Command(
+			"cp", "-f", constants.KubeadmConfigPath, rollbackConfigBackupPath,
+		).Silent().Run(); err != nil {
+			return errors.Wrap(err, "failed to backup the kubeadm config")
+		}
+		defer func() {
+			n.Infof("restore the kubeadm binary and config")
+			if rerr := n.Command(
+				"mv", "-f", rollbackKubeadmBackupPath, "/usr/bin/kubeadm",
+			).Silent().Run(); rerr != nil && err == nil {
+				err = errors.Wrap(rerr, "failed to restore the kubeadm binary")
+			}
+			if rerr := n.Command(
+				"mv", "-f", rollbackConfigBackupPath, constants.KubeadmConfigPath,
+			).Silent().Run(); rerr != nil && err == nil {
+				err = errors.Wrap(rerr, "failed to restore the kubeadm config")
+			}
+		}()
+	}
+
+	n.Infof("rollback kubeadm from %s to %s", currentVersion, rollbackVersion)
+	if err := upgradeKubeadmBinary(n, rollbackVersion); err != nil {
+		return err
+	}
+
+	if err := KubeadmUpgradeConfig(c, ignorePreflightErrors, rollbackVersion, n); err != nil {
+		return err
+	}
+
+	nodeArgs := []string{
+		"upgrade", "node", fmt.Sprintf("--v=%d", vLevel),
+	}
+	if kubeadm.GetKubeadmConfigVersion(rollbackVersion) == "v1beta4" {
+		nodeArgs = append(nodeArgs, "--config", constants.KubeadmConfigPath)
+	}
+
+	lines, upgradeErr := n.Command(
+		"kubeadm", nodeArgs...,
+	).RunAndCapture()
+	fmt.Println(strings.Join(lines, "\n"))
+
+	if expectation == RollbackRejected {
+		if upgradeErr == nil {
+			return errors.Errorf("kubeadm upgrade node with kubeadm %s succeeded on node %s, but it was expected to be rejected", rollbackVersion, n.Name())
+		}
+		return nil
+	}
+
+	if upgradeErr != nil {
+		return errors.Wrapf(upgradeErr, "kubeadm upgrade node with kubeadm %s failed on node %s", rollbackVersion, n.Name())
+	}
+
+	// the rollback is supported, so also kubelet and kubectl are rolled back
+	return upgradeKubeletKubectl(c, n, rollbackVersion, wait)
+}
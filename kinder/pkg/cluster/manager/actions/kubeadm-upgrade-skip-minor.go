@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/kubeadm"
+)
+
+// skipMinorUpgradeErrorRE matches the error returned by kubeadm when the upgrade skips a minor version, e.g.
+// Specified version to upgrade to "v1.32.0" is too high; kubeadm can upgrade only 1 minor version at a time
+var skipMinorUpgradeErrorRE = regexp.MustCompile(`is too high; kubeadm can upgrade only \d+ minor version at a time`)
+
+const (
+	skipMinorKubeadmBackupPath = "/kinder/kubeadm.skip-minor.bak"
+	skipMinorConfigBackupPath  = "/kinder/kubeadm.conf.skip-minor.bak"
+)
+
+// KubeadmUpgradeSkipMinor attempts "kubeadm upgrade apply" on the bootstrap control-plane to a version that
+// is more than one minor version higher than the current one, and asserts that kubeadm rejects the upgrade.
+//
+// The kubeadm binary for the target version is expected in the /kinder/upgrade/{version} folder; the original
+// kubeadm binary and kubeadm config are restored after the test.
+func KubeadmUpgradeSkipMinor(c *status.Cluster, upgradeVersion *version.Version, ignorePreflightErrors string, vLevel int) (err error) {
+	if upgradeVersion == nil {
+		return errors.New("kubeadm-upgrade-skip-minor actions requires the --upgrade-version parameter to be set")
+	}
+
+	cp1 := c.BootstrapControlPlane()
+
+	currentVersion, err := cp1.KubeVersion()
+	if err != nil {
+		return err
+	}
+	current, err := version.ParseSemantic(currentVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the current Kubernetes version %q", currentVersion)
+	}
+	if upgradeVersion.Major() != current.Major() || upgradeVersion.Minor() < current.Minor()+2 {
+		return errors.Errorf("upgrade version %s does not skip a minor version from the current version %s", upgradeVersion, current)
+	}
+
+	// backup the kubeadm binary and the kubeadm config, and restore them whatever happens
+	if err := cp1.Command(
+		"cp", "-fL", "/usr/bin/kubeadm", skipMinorKubeadmBackupPath,
+	).Silent().Run(); err != nil {
+		return errors.Wrap(err, "failed to backup the kubeadm binary")
+	}
+	if err := cp1.Command(
+		"cp", "-f", constants.KubeadmConfigPath, skipMinorConfigBackupPath,
+	).Silent().Run(); err != nil {
+		return errors.Wrap(err, "failed to backup the kubeadm config")
+	}
+	defer func() {
+		cp1.Infof("restore the kubeadm binary and config")
+		if rerr := cp1.Command(
+			"mv", "-f", skipMinorKubeadmBackupPath, "/usr/bin/kubeadm",
+		).Silent().Run(); rerr != nil && err == nil {
+			err = errors.Wrap(rerr, "failed to restore the kubeadm binary")
+		}
+		if rerr := cp1.Command(
+			"mv", "-f", skipMinorConfigBackupPath, constants.KubeadmConfigPath,
+		).Silent().Run(); rerr != nil && err == nil {
+			err = errors.Wrap(rerr, "failed to restore the kubeadm config")
+		}
+	}()
+
+	if err := upgradeKubeadmBinary(cp1, upgradeVersion); err != nil {
+		return err
+	}
+
+	// writes an UpgradeConfiguration without forceUpgrade; for CI and pre-release versions kubeadm reports
+	// the minor skip as a skippable error, so forcing the upgrade would make it run
+	if err := kubeadmConfig(c, kubeadmConfigOptions{noForceUpgrade: true}, "", "", "", ignorePreflightErrors, upgradeVersion, cp1); err != nil {
+		return err
+	}
+
+	v, err := cp1.KubeadmVersion()
+	if err != nil {
+		return errors.Wrap(err, "could not obtain the kubeadm version before calling kubeadm upgrade apply")
+	}
+
+	applyArgs := []string{
+		"upgrade", "apply", fmt.Sprintf("--v=%d", vLevel),
+	}
+	if kubeadm.GetKubeadmConfigVersion(v) == "v1beta4" {
+		applyArgs = append(applyArgs, "--config", constants.KubeadmConfigPath)
+	} else {
+		// NB. --yes skips the confirmation prompt without forcing the upgrade like -f does
+		applyArgs = append(applyArgs, "--yes", fmt.Sprintf("v%s", upgradeVersion.String()))
+	}
+
+	cp1.Infof("attempt upgrading from %s to %s, expecting failure", current, upgradeVersion)
+
+	lines, applyErr := cp1.Command(
+		"kubeadm", applyArgs...,
+	).RunAndCapture()
+	output := strings.Join(lines, "\n")
+	fmt.Println(output)
+
+	if applyErr == nil {
+		return errors.Errorf("kubeadm upgrade apply from %s to %s succeeded, but it was expected to be rejected", current, upgradeVersion)
+	}
+	if !skipMinorUpgradeErrorRE.MatchString(output) {
+		return errors.Errorf("kubeadm upgrade apply failed, but the output does not match the expected error %q", skipMinorUpgradeErrorRE)
+	}
+
+	fmt.Printf("\nkubeadm rejected the upgrade skipping a minor version as expected!\n")
+	return nil
+}
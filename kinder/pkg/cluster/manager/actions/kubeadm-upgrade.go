@@ -17,8 +17,10 @@ limitations under the License.
 package actions
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -37,7 +39,7 @@ import (
 //
 // The implementation assumes that the kubeadm/kubelet/kubectl binaries and all the necessary images
 // for the new kubernetes version are available in the /kinder/upgrade/{version} folder.
-func KubeadmUpgrade(c *status.Cluster, upgradeVersion *version.Version, patchesDir, ignorePreflightErrors string, wait time.Duration, vLevel int) (err error) {
+func KubeadmUpgrade(c *status.Cluster, upgradeVersion *version.Version, patchesDir, ignorePreflightErrors string, kubeadmExtraFlags []string, wait time.Duration, vLevel int) (err error) {
 	if upgradeVersion == nil {
 		return errors.New("kubeadm-upgrade actions requires the --upgrade-version parameter to be set")
 	}
@@ -55,23 +57,27 @@ func KubeadmUpgrade(c *status.Cluster, upgradeVersion *version.Version, patchesD
 		// If there is a difference print a warning and fallback to what is on the node image.
 		// This is useful in debug scenarios where the ci/latest version label changed during
 		// debugging a particular workflow.
-		versionPath := filepath.Join("/kinder", "upgrade", "version")
-		out, err := n.Command("cat", versionPath).Silent().RunAndCapture()
-		if err != nil {
-			return errors.Wrapf(err, "could not compare %s file before upgrade", versionPath)
-		}
-		if len(out) != 1 {
-			return errors.Errorf("expected %s to have 1 line, got %d", versionPath, len(out))
-		}
-		nodeVersion := version.MustParseSemantic(out[0])
-		cmp, err := nodeVersion.Compare(upgradeVersion.String())
-		if err != nil {
-			return errors.Wrapf(err, "cannot compare %s to provided upgrade version", versionPath)
-		}
-		if cmp != 0 {
-			log.Warnf("provided upgrade version is %s, but the node has %s, using the node version",
-				upgradeVersion, nodeVersion)
-			upgradeVersion = nodeVersion
+		// Nb. images can contain artifacts for many versions, and any of them can be used
+		versionDir := filepath.Join("/kinder", "upgrade", fmt.Sprintf("v%s", upgradeVersion))
+		if err := n.Command("test", "-d", versionDir).Silent().Run(); err != nil {
+			versionPath := filepath.Join("/kinder", "upgrade", "version")
+			out, err := n.Command("cat", versionPath).Silent().RunAndCapture()
+			if err != nil {
+				return errors.Wrapf(err, "could not compare %s file before upgrade", versionPath)
+			}
+			if len(out) != 1 {
+				return errors.Errorf("expected %s to have 1 line, got %d", versionPath, len(out))
+			}
+			nodeVersion := version.MustParseSemantic(out[0])
+			cmp, err := nodeVersion.Compare(upgradeVersion.String())
+			if err != nil {
+				return errors.Wrapf(err, "cannot compare %s to provided upgrade version", versionPath)
+			}
+			if cmp != 0 {
+				log.Warnf("provided upgrade version is %s, but the node has %s, using the node version",
+					upgradeVersion, nodeVersion)
+				upgradeVersion = nodeVersion
+			}
 		}
 
 		if err := upgradeKubeadmBinary(n, upgradeVersion); err != nil {
@@ -90,15 +96,18 @@ func KubeadmUpgrade(c *status.Cluster, upgradeVersion *version.Version, patchesD
 		kubeadmConfigVersion := kubeadm.GetKubeadmConfigVersion(v)
 
 		if n.Name() == c.BootstrapControlPlane().Name() {
+			if err := setUpgradeEtcdImage(n, upgradeVersion); err != nil {
+				return err
+			}
 			if err := kubeadmUpgradePlan(c, n, kubeadmConfigVersion, upgradeVersion, vLevel); err != nil {
 				return err
 			}
 			if err := kubeadmUpgradeDiff(c, n, kubeadmConfigVersion, upgradeVersion, vLevel); err != nil {
 				return err
 			}
-			err = kubeadmUpgradeApply(c, n, kubeadmConfigVersion, upgradeVersion, patchesDir, wait, vLevel)
+			err = kubeadmUpgradeApply(c, n, kubeadmConfigVersion, upgradeVersion, patchesDir, kubeadmExtraFlags, wait, vLevel)
 		} else {
-			err = kubeadmUpgradeNode(c, n, kubeadmConfigVersion, upgradeVersion, patchesDir, wait, vLevel)
+			err = kubeadmUpgradeNode(c, n, kubeadmConfigVersion, upgradeVersion, patchesDir, kubeadmExtraFlags, wait, vLevel)
 		}
 		if err != nil {
 			return err
@@ -200,7 +209,46 @@ func kubeadmUpgradePlan(c *status.Cluster, cp1 *status.Node, configVersion strin
 	return nil
 }
 
-func kubeadmUpgradeApply(c *status.Cluster, cp1 *status.Node, configVersion string, upgradeVersion *version.Version, patchesDir string, wait time.Duration, vLevel int) error {
+// setUpgradeEtcdImage updates the etcd image in the ClusterConfiguration stored in the cluster, in case
+// the node image embeds an etcd image for the upgrade artifacts
+func setUpgradeEtcdImage(cp1 *status.Node, upgradeVersion *version.Version) error {
+	image := etcdImageOnNode(cp1, filepath.Join("/kinder", "upgrade", fmt.Sprintf("v%s", upgradeVersion)))
+	if image == "" {
+		return nil
+	}
+	cp1.Infof("using etcd %s for the upgrade", image)
+
+	lines, err := cp1.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"-n", "kube-system", "get", "configmap", "kubeadm-config",
+		"-o", "jsonpath={.data.ClusterConfiguration}",
+	).Silent().RunAndCapture()
+	if err != nil {
+		return errors.Wrap(err, "failed to read the kubeadm-config ConfigMap")
+	}
+
+	clusterConfiguration, err := kubeadm.SetEtcdImage(strings.Join(lines, "\n"), image)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{"ClusterConfiguration": clusterConfiguration},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := cp1.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"-n", "kube-system", "patch", "configmap", "kubeadm-config",
+		"--type=merge", "-p", string(patch),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to update the etcd image in the kubeadm-config ConfigMap")
+	}
+	return nil
+}
+
+func kubeadmUpgradeApply(c *status.Cluster, cp1 *status.Node, configVersion string, upgradeVersion *version.Version, patchesDir string, extraFlags []string, wait time.Duration, vLevel int) error {
 	applyArgs := []string{
 		"upgrade", "apply", fmt.Sprintf("--v=%d", vLevel),
 	}
@@ -213,6 +261,7 @@ func kubeadmUpgradeApply(c *status.Cluster, cp1 *status.Node, configVersion stri
 		}
 		applyArgs = append(applyArgs, "-f", fmt.Sprintf("v%s", upgradeVersion.String()))
 	}
+	applyArgs = append(applyArgs, extraFlags...)
 
 	if err := cp1.Command(
 		"kubeadm", applyArgs...,
@@ -227,7 +276,7 @@ func kubeadmUpgradeApply(c *status.Cluster, cp1 *status.Node, configVersion stri
 	return nil
 }
 
-func kubeadmUpgradeNode(c *status.Cluster, n *status.Node, configVersion string, upgradeVersion *version.Version, patchesDir string, wait time.Duration, vLevel int) error {
+func kubeadmUpgradeNode(c *status.Cluster, n *status.Node, configVersion string, upgradeVersion *version.Version, patchesDir string, extraFlags []string, wait time.Duration, vLevel int) error {
 	// waitKubeletHasRBAC waits for the kubelet to have access to the expected config map
 	// please note that this is a temporary workaround for a problem we are observing on upgrades while
 	// executing node upgrades immediately after control-plane upgrade.
@@ -247,6 +296,7 @@ func kubeadmUpgradeNode(c *status.Cluster, n *status.Node, configVersion string,
 			nodeArgs = append(nodeArgs, fmt.Sprintf("--patches=%s", constants.PatchesDir))
 		}
 	}
+	nodeArgs = append(nodeArgs, extraFlags...)
 
 	if err := n.Command(
 		"kubeadm", nodeArgs...,
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// KubeConfigContextName returns the default name of the context for a cluster in merged kubeconfig files
+func KubeConfigContextName(clusterName string) string {
+	return "kinder-" + clusterName
+}
+
+// KubeConfig returns the admin kubeconfig of a cluster, with the API server endpoint reachable from the host,
+// e.g. the external load balancer, and with the cluster, the user and the context named contextName
+func KubeConfig(c *status.Cluster, contextName string) (*clientcmdapi.Config, error) {
+	if c.BootstrapControlPlane() == nil {
+		return nil, errors.Errorf("the cluster %s has no control plane nodes", c.Name())
+	}
+	hostPort, err := getAPIServerPort(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the API server port on the host")
+	}
+	data, err := hostKubeConfig(c, hostPort)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse /etc/kubernetes/admin.conf, is kubeadm init completed?")
+	}
+	return renameKubeConfig(config, contextName)
+}
+
+// renameKubeConfig returns a kubeconfig with only the current context of config, where the cluster,
+// the user and the context are named name
+func renameKubeConfig(config *clientcmdapi.Config, name string) (*clientcmdapi.Config, error) {
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, errors.Errorf("the current context %q is not defined in the kubeconfig", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[context.Cluster]
+	if !ok {
+		return nil, errors.Errorf("the cluster %q is not defined in the kubeconfig", context.Cluster)
+	}
+	user, ok := config.AuthInfos[context.AuthInfo]
+	if !ok {
+		return nil, errors.Errorf("the user %q is not defined in the kubeconfig", context.AuthInfo)
+	}
+
+	renamed := clientcmdapi.NewConfig()
+	renamed.Clusters[name] = cluster
+	renamed.AuthInfos[name] = user
+	renamed.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: context.Namespace}
+	renamed.CurrentContext = name
+	return renamed, nil
+}
+
+// MergeKubeConfig merges the clusters, the users and the contexts of config into the kubeconfig file at path,
+// replacing the entries with the same name, and sets the current context of config as current context;
+// the file is created if it does not exist
+func MergeKubeConfig(config *clientcmdapi.Config, path string) error {
+	existing, err := clientcmd.LoadFromFile(path)
+	if os.IsNotExist(err) {
+		existing, err = clientcmdapi.NewConfig(), nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	for k, v := range config.Clusters {
+		existing.Clusters[k] = v
+	}
+	for k, v := range config.AuthInfos {
+		existing.AuthInfos[k] = v
+	}
+	for k, v := range config.Contexts {
+		existing.Contexts[k] = v
+	}
+	existing.CurrentContext = config.CurrentContext
+
+	// 0755 is the same used by client-go when creating the folder of kubeconfig files
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create the folder for %s", path)
+	}
+	if err := clientcmd.WriteToFile(*existing, path); err != nil {
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestMergeKubeConfig(t *testing.T) {
+	admin := clientcmdapi.NewConfig()
+	admin.Clusters["kubernetes"] = &clientcmdapi.Cluster{Server: "https://localhost:32768"}
+	admin.AuthInfos["kubernetes-admin"] = &clientcmdapi.AuthInfo{Token: "secret"}
+	admin.Contexts["kubernetes-admin@kubernetes"] = &clientcmdapi.Context{Cluster: "kubernetes", AuthInfo: "kubernetes-admin"}
+	admin.CurrentContext = "kubernetes-admin@kubernetes"
+
+	config, err := renameKubeConfig(admin, "kinder-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), ".kube", "config")
+	other := clientcmdapi.NewConfig()
+	other.Clusters["other"] = &clientcmdapi.Cluster{Server: "https://other:6443"}
+	other.Clusters["kinder-test"] = &clientcmdapi.Cluster{Server: "https://localhost:1234"}
+	other.CurrentContext = "other"
+
+	// merging into a missing file creates it, and merging again keeps the other entries
+	if err := MergeKubeConfig(other, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := MergeKubeConfig(config, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	merged, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.CurrentContext != "kinder-test" {
+		t.Errorf("expected current context kinder-test, got %s", merged.CurrentContext)
+	}
+	if _, ok := merged.Clusters["other"]; !ok {
+		t.Errorf("expected the other cluster to be preserved")
+	}
+	if s := merged.Clusters["kinder-test"].Server; s != "https://localhost:32768" {
+		t.Errorf("expected the kinder-test cluster to be replaced, got server %s", s)
+	}
+	if c := merged.Contexts["kinder-test"]; c == nil || c.Cluster != "kinder-test" || c.AuthInfo != "kinder-test" {
+		t.Errorf("expected the kinder-test context to refer to the renamed cluster and user, got %v", c)
+	}
+	if u := merged.AuthInfos["kinder-test"]; u == nil || u.Token != "secret" {
+		t.Errorf("expected the kinder-test user, got %v", u)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions/assets"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// localStorageTestMarker is the content written on the persistent volume by the test workload
+const localStorageTestMarker = "kinder-local-storage"
+
+// localStorageTestManifest defines a PVC using the local-path StorageClass and a Deployment writing on it
+const localStorageTestManifest = `
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: local-storage-test
+spec:
+  accessModes: ["ReadWriteOnce"]
+  storageClassName: local-path
+  resources:
+    requests:
+      storage: 16Mi
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: local-storage-test
+spec:
+  replicas: 1
+  strategy:
+    type: Recreate
+  selector:
+    matchLabels:
+      app: local-storage-test
+  template:
+    metadata:
+      labels:
+        app: local-storage-test
+    spec:
+      containers:
+      - name: test
+        image: docker.io/library/busybox:1.36
+        command: ["sh", "-c", "echo ` + localStorageTestMarker + ` > /data/marker && sleep infinity"]
+        volumeMounts:
+        - name: data
+          mountPath: /data
+      volumes:
+      - name: data
+        persistentVolumeClaim:
+          claimName: local-storage-test
+`
+
+// LocalStorage deploys the local-path provisioner, then creates a PVC and a Deployment writing on it
+// and checks that the volume is provisioned, bound and writable.
+//
+// The test workload is left in place, so actions executed afterwards, e.g. kubeadm-upgrade or
+// remove-control-plane, run with a stateful workload on the cluster; a new execution of this
+// action recreates it.
+func LocalStorage(c *status.Cluster, wait time.Duration) error {
+	cp1 := c.BootstrapControlPlane()
+
+	// cleanups garbage from previous test
+	cleanupLocalStorageTest(cp1)
+
+	cp1.Infof("deploy the local-path provisioner (%s)", assets.LocalPathProvisionerImage0030)
+	if err := cp1.Command(
+		"kubectl", "apply", "--kubeconfig=/etc/kubernetes/admin.conf", "-f", "-",
+	).Stdin(strings.NewReader(assets.LocalPathProvisionerManifest0030)).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to deploy the local-path provisioner")
+	}
+
+	if err := cp1.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"rollout", "status", "deployment/local-path-provisioner", "-n", "local-path-storage",
+		fmt.Sprintf("--timeout=%s", wait),
+	).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "the local-path provisioner did not become available")
+	}
+
+	cp1.Infof("test a PersistentVolumeClaim")
+	if err := cp1.Command(
+		"kubectl", "apply", "--kubeconfig=/etc/kubernetes/admin.conf", "-f", "-",
+	).Stdin(strings.NewReader(localStorageTestManifest)).RunWithEcho(); err != nil {
+		return errors.Wrap(err, "failed to create the PVC test workload")
+	}
+
+	if err := waitForPodsRunning(c, cp1, wait, "local-storage-test", 1); err != nil {
+		return err
+	}
+
+	phase := kubectlOutput(cp1,
+		"get", "pvc", "local-storage-test",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"-o=jsonpath='{.status.phase}'",
+	)
+	if strings.Trim(phase, "'") != "Bound" {
+		return errors.Errorf("expected PVC local-storage-test to be Bound, found %s", phase)
+	}
+
+	podName, err := getPodName(cp1, "local-storage-test")
+	if err != nil {
+		return err
+	}
+
+	// the marker is written when the container starts, so it might take a while before it shows up
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines, err = cp1.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+			"exec", podName, "--", "cat", "/data/marker",
+		).Silent().RunAndCapture()
+		if err == nil && len(lines) == 1 && lines[0] == localStorageTestMarker {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to read data from the persistent volume")
+	}
+	if len(lines) != 1 || lines[0] != localStorageTestMarker {
+		return errors.Errorf("unexpected data read from the persistent volume: %v", lines)
+	}
+	fmt.Printf("data read from the persistent volume: %s\n", lines[0])
+
+	fmt.Printf("\nLocal storage test passed!\n")
+	return nil
+}
+
+func cleanupLocalStorageTest(cp1 *status.Node) {
+	cp1.Command(
+		"kubectl",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"delete", "deployments/local-storage-test", "--wait",
+	).Silent().Run()
+
+	cp1.Command(
+		"kubectl",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"delete", "pvc/local-storage-test", "--wait",
+	).Silent().Run()
+}
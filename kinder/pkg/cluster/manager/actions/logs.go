@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+const (
+	// logsIndexFile is the name of the file listing the content of a logs bundle
+	logsIndexFile = "index.json"
+
+	// logsNodesDir and logsClusterDir are the folders of a logs bundle with the files collected from each node,
+	// and with the files collected from the cluster API server
+	logsNodesDir   = "nodes"
+	logsClusterDir = "cluster"
+)
+
+// LogsIndex describes the content of a logs bundle
+type LogsIndex struct {
+	Cluster string           `json:"cluster"`
+	Created time.Time        `json:"created"`
+	Since   *time.Time       `json:"since,omitempty"`
+	Entries []LogsIndexEntry `json:"entries"`
+}
+
+// LogsIndexEntry describes a file or a folder in a logs bundle
+type LogsIndexEntry struct {
+	// Path of the file or of the folder, relative to the bundle folder
+	Path        string `json:"path"`
+	Node        string `json:"node,omitempty"`
+	Description string `json:"description"`
+	// Error reports why the entry could not be collected, or was collected only in part
+	Error string `json:"error,omitempty"`
+}
+
+// nodeLog defines a file or a folder collected from each node into the logs bundle
+type nodeLog struct {
+	// path of the file or of the folder, relative to the node folder
+	path        string
+	description string
+	// roles lists the node roles the log is collected from; all the nodes if empty
+	roles   []string
+	collect func(n *status.Node, dst string, since time.Time) error
+}
+
+// commandLog returns a nodeLog storing the output of an artifact command
+func commandLog(a artifact, description string) nodeLog {
+	return nodeLog{
+		path:        a.name,
+		description: description,
+		roles:       a.roles,
+		collect: func(n *status.Node, dst string, since time.Time) error {
+			file := a
+			file.name = filepath.Base(dst)
+			return collectArtifact(n, file, filepath.Dir(dst), since)
+		},
+	}
+}
+
+// hostCommandLog returns a nodeLog storing the output of a command executed on the host for the node container
+func hostCommandLog(path, description string, command func(n *status.Node) []string) nodeLog {
+	return nodeLog{
+		path:        path,
+		description: description,
+		collect: func(n *status.Node, dst string, _ time.Time) error {
+			cmd := command(n)
+			lines, err := exec.NewHostCmd(cmd[0], cmd[1:]...).RunAndCapture()
+			if werr := os.WriteFile(dst, []byte(strings.Join(lines, "\n")+"\n"), 0644); werr != nil {
+				return werr
+			}
+			return err
+		},
+	}
+}
+
+// nodeLogs lists the files collected from each node; the layout of the node folders must be kept stable,
+// because it is what CI triage relies on
+var nodeLogs = []nodeLog{
+	hostCommandLog("inspect.json", "docker inspect of the node container", func(n *status.Node) []string {
+		return []string{"docker", "inspect", n.Name()}
+	}),
+	hostCommandLog("container.log", "output of the node container, e.g. the boot of systemd", func(n *status.Node) []string {
+		return []string{"docker", "logs", n.Name()}
+	}),
+	commandLog(journalArtifact("journal/kubelet.log", "kubelet", k8sNodeRoles, false), "journal of the kubelet"),
+	commandLog(journalArtifact("journal/containerd.log", "containerd", k8sNodeRoles, false), "journal of containerd"),
+	commandLog(artifact{
+		name:  "kubernetes-version.txt",
+		roles: k8sNodeRoles,
+		command: func(time.Time) []string {
+			return []string{"cat", "/kind/version"}
+		},
+	}, "Kubernetes version the node image was built with"),
+	commandLog(artifact{
+		name:  "containers.txt",
+		roles: k8sNodeRoles,
+		command: func(time.Time) []string {
+			return []string{"crictl", "ps", "-a"}
+		},
+	}, "containers running on the node"),
+	commandLog(artifact{
+		name:  "images.txt",
+		roles: k8sNodeRoles,
+		command: func(time.Time) []string {
+			return []string{"crictl", "images"}
+		},
+	}, "images available on the node"),
+	commandLog(fileArtifact("kubelet-config.txt", "/var/lib/kubelet/config.yaml /var/lib/kubelet/kubeadm-flags.env", k8sNodeRoles, false),
+		"kubelet configuration and flags written by kubeadm"),
+	{
+		path:        "etc-kubernetes",
+		description: "content of /etc/kubernetes, without private keys and with credentials in kubeconfig files redacted",
+		roles:       k8sNodeRoles,
+		collect: func(n *status.Node, dst string, _ time.Time) error {
+			if err := n.CopyFrom("/etc/kubernetes", dst); err != nil {
+				return err
+			}
+			return redactKubernetesDir(dst)
+		},
+	},
+	{
+		path:        "pods",
+		description: "logs of the pods running on the node, including static pods, from /var/log/pods",
+		roles:       k8sNodeRoles,
+		collect: func(n *status.Node, dst string, _ time.Time) error {
+			return n.CopyFrom("/var/log/pods", dst)
+		},
+	},
+}
+
+// clusterLog defines a command output collected from the bootstrap control-plane into the logs bundle
+type clusterLog struct {
+	// path of the file, relative to the cluster folder
+	path        string
+	description string
+	args        []string
+}
+
+// kubectlLog returns a clusterLog storing the output of kubectl, using the admin kubeconfig
+func kubectlLog(path, description string, args ...string) clusterLog {
+	return clusterLog{
+		path:        path,
+		description: description,
+		args:        append([]string{"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "--request-timeout=30s"}, args...),
+	}
+}
+
+// clusterLogs lists the files collected from the cluster API server
+var clusterLogs = []clusterLog{
+	kubectlLog("kubeadm-configmaps/kubeadm-config.yaml", "kubeadm-config ConfigMap", "get", "configmap", kubeadmConfigMap, "-n=kube-system", "-o=yaml"),
+	kubectlLog("kubeadm-configmaps/kubelet-config.yaml", "kubelet-config ConfigMap", "get", "configmap", kubeletConfigMap, "-n=kube-system", "-o=yaml"),
+	kubectlLog("kubeadm-configmaps/kube-proxy.yaml", "kube-proxy ConfigMap", "get", "configmap", "kube-proxy", "-n=kube-system", "-o=yaml"),
+	kubectlLog("kubeadm-configmaps/cluster-info.yaml", "cluster-info ConfigMap", "get", "configmap", "cluster-info", "-n=kube-public", "-o=yaml"),
+	kubectlLog("api/readyz.txt", "readiness checks of the API server", "get", "--raw=/readyz?verbose"),
+	kubectlLog("api/nodes.yaml", "nodes", "get", "nodes", "-o=yaml"),
+	kubectlLog("api/namespaces.yaml", "namespaces", "get", "namespaces", "-o=yaml"),
+	kubectlLog("api/pods.yaml", "pods in all the namespaces", "get", "pods", "-A", "-o=yaml"),
+	kubectlLog("api/deployments.yaml", "deployments in all the namespaces", "get", "deployments", "-A", "-o=yaml"),
+	kubectlLog("api/daemonsets.yaml", "daemonsets in all the namespaces", "get", "daemonsets", "-A", "-o=yaml"),
+	kubectlLog("api/services.yaml", "services in all the namespaces", "get", "services", "-A", "-o=yaml"),
+	kubectlLog("api/leases.yaml", "leases in kube-system, e.g. of the leader election of the control-plane components", "get", "leases", "-n=kube-system", "-o=yaml"),
+	kubectlLog("api/csrs.yaml", "certificate signing requests", "get", "csr", "-o=yaml"),
+	kubectlLog("api/events.txt", "events in all the namespaces, sorted by time", "get", "events", "-A", "--sort-by=.lastTimestamp"),
+}
+
+// ExportLogs exports a logs bundle of the cluster into dir, with a folder for each node under nodes/, the kubeadm
+// ConfigMaps, the etcd health and the dumps of the API objects under cluster/, and an index.json file listing
+// the content of the bundle; the journal is limited to the entries after since, if not zero.
+// Files that can't be collected are reported in the index, because a partial bundle is still useful for triage
+func ExportLogs(c *status.Cluster, dir string, since time.Time) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create the logs folder %s", dir)
+	}
+
+	index := &LogsIndex{Cluster: c.Name(), Created: time.Now().UTC()}
+	if !since.IsZero() {
+		index.Since = &since
+	} else {
+		since = time.Unix(0, 0)
+	}
+	add := func(path, node, description string, err error) {
+		e := LogsIndexEntry{Path: filepath.ToSlash(path), Node: node, Description: description}
+		if err != nil {
+			log.Warnf("failed to collect %s: %v", path, err)
+			e.Error = err.Error()
+		}
+		index.Entries = append(index.Entries, e)
+	}
+
+	for _, n := range c.AllNodes() {
+		for _, l := range nodeLogs {
+			if len(l.roles) > 0 && !hasRole(n, l.roles) {
+				continue
+			}
+			path := filepath.Join(logsNodesDir, n.Name(), l.path)
+			dst := filepath.Join(dir, path)
+			err := os.MkdirAll(filepath.Dir(dst), 0755)
+			if err == nil {
+				err = l.collect(n, dst, since)
+			}
+			add(path, n.Name(), l.description, err)
+		}
+	}
+
+	if cp1 := c.BootstrapControlPlane(); cp1 != nil {
+		for _, l := range clusterLogs {
+			path := filepath.Join(logsClusterDir, l.path)
+			add(path, cp1.Name(), l.description, writeCommandOutput(cp1, l.args, filepath.Join(dir, path)))
+		}
+
+		path := filepath.Join(logsClusterDir, "etcd.txt")
+		add(path, cp1.Name(), "health, status and members of the etcd cluster", collectEtcdHealth(c, cp1, filepath.Join(dir, path)))
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, logsIndexFile), data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write the index of the logs bundle")
+	}
+
+	failed := 0
+	for _, e := range index.Entries {
+		if e.Error != "" {
+			failed++
+		}
+	}
+	fmt.Printf("Exported logs of cluster %s to %s, %d files collected, %d with errors\n", c.Name(), dir, len(index.Entries)-failed, failed)
+	return nil
+}
+
+// writeCommandOutput writes the output of a command executed on a node; the partial output is written also
+// when the command fails
+func writeCommandOutput(n *status.Node, cmd []string, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	lines, err := n.Command(cmd[0], cmd[1:]...).Silent().ReadOnly().RunAndCapture()
+	if werr := os.WriteFile(dst, []byte(strings.Join(lines, "\n")+"\n"), 0644); werr != nil {
+		return werr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "command %q failed", strings.Join(cmd, " "))
+	}
+	return nil
+}
+
+// collectEtcdHealth writes the health, the status and the members of the local etcd cluster, as seen from
+// the given control-plane node
+func collectEtcdHealth(c *status.Cluster, cp *status.Node, dst string) error {
+	if c.ExternalEtcd() != nil {
+		return errors.New("not collected: the cluster uses an external etcd")
+	}
+	etcdArgs, err := etcdctlArgs(cp)
+	if err != nil {
+		return err
+	}
+
+	var out []string
+	var errs []string
+	for _, args := range [][]string{
+		{"endpoint", "health", "--cluster"},
+		{"endpoint", "status", "--cluster", "-w=table"},
+		{"member", "list", "-w=table"},
+	} {
+		out = append(out, "# etcdctl "+strings.Join(args, " "))
+		lines, err := cp.Command("kubectl", append(append([]string{}, etcdArgs...), args...)...).Silent().ReadOnly().RunAndCapture()
+		out = append(out, lines...)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("etcdctl %s failed: %v", args[0], err))
+		}
+	}
+	if err := os.WriteFile(dst, []byte(strings.Join(out, "\n")+"\n"), 0644); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// kubeconfigCredential matches the credentials embedded in kubeconfig files
+var kubeconfigCredential = regexp.MustCompile(`(?m)^(\s*(?:client-key-data|token|password):).*$`)
+
+// redactKubeconfig replaces the credentials embedded in a kubeconfig file
+func redactKubeconfig(data []byte) []byte {
+	return kubeconfigCredential.ReplaceAll(data, []byte("$1 REDACTED"))
+}
+
+// redactKubernetesDir removes private keys from a copy of /etc/kubernetes and redacts the kubeconfig files,
+// so the logs bundle can be published, e.g. as CI artifacts
+func redactKubernetesDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".key":
+			return os.Remove(path)
+		case ".conf":
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(path, redactKubeconfig(data), info.Mode().Perm())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRedactKubernetesDir(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: Y2E=
+    server: https://kind-control-plane:6443
+  name: kind
+users:
+- name: kubernetes-admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+- name: bootstrap
+  user:
+    token: abcdef.0123456789abcdef
+`
+	expected := `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: Y2E=
+    server: https://kind-control-plane:6443
+  name: kind
+users:
+- name: kubernetes-admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: REDACTED
+- name: bootstrap
+  user:
+    token: REDACTED
+`
+
+	var tests = []struct {
+		path            string
+		content         string
+		expectedContent string
+		expectedRemoved bool
+	}{
+		{path: "admin.conf", content: kubeconfig, expectedContent: expected},
+		{path: "manifests/etcd.yaml", content: "token: not a kubeconfig\n", expectedContent: "token: not a kubeconfig\n"},
+		{path: "pki/ca.crt", content: "certificate", expectedContent: "certificate"},
+		{path: "pki/ca.key", content: "key", expectedRemoved: true},
+		{path: "pki/etcd/peer.key", content: "key", expectedRemoved: true},
+	}
+
+	dir := t.TempDir()
+	for _, rt := range tests {
+		p := filepath.Join(dir, rt.path)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(rt.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := redactKubernetesDir(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, rt := range tests {
+		t.Run(rt.path, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, rt.path))
+			if rt.expectedRemoved {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected %s to be removed", rt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != rt.expectedContent {
+				t.Errorf("expected:\n%s\ngot:\n%s", rt.expectedContent, string(data))
+			}
+		})
+	}
+}
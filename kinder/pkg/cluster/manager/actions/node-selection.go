@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+const (
+	// labelSelectorPrefix identifies node selector terms matching the labels of Kubernetes Node objects
+	labelSelectorPrefix = "label:"
+
+	// roleSelectorPrefix identifies node selector terms matching the kinder node role, e.g. role:worker
+	roleSelectorPrefix = "role:"
+)
+
+// nodeSelectorTerm is a term of a node selector, e.g. @cp*, worker1, role:worker or label:foo=bar
+type nodeSelectorTerm struct {
+	// prefix is labelSelectorPrefix or roleSelectorPrefix, or empty for node names and kinder node selectors
+	prefix string
+	value  string
+}
+
+// parseNodeSelector splits a node selector into terms. Terms are separated by commas, except for
+// label: terms that consume the rest of the selector, given that commas are part of the Kubernetes
+// label selector syntax, e.g. label:a=b,c=d; in other words, a label: term must be the last one.
+func parseNodeSelector(nodeSelector string) []nodeSelectorTerm {
+	terms := []nodeSelectorTerm{}
+	for rest := nodeSelector; rest != ""; {
+		term := rest
+		rest = ""
+		if !strings.HasPrefix(strings.TrimSpace(term), labelSelectorPrefix) {
+			term, rest, _ = strings.Cut(term, ",")
+		}
+
+		term = strings.TrimSpace(term)
+		switch {
+		case term == "":
+		case strings.HasPrefix(term, labelSelectorPrefix):
+			terms = append(terms, nodeSelectorTerm{prefix: labelSelectorPrefix, value: strings.TrimSpace(strings.TrimPrefix(term, labelSelectorPrefix))})
+		case strings.HasPrefix(term, roleSelectorPrefix):
+			terms = append(terms, nodeSelectorTerm{prefix: roleSelectorPrefix, value: strings.TrimPrefix(term, roleSelectorPrefix)})
+		default:
+			terms = append(terms, nodeSelectorTerm{value: term})
+		}
+	}
+	return terms
+}
+
+// SelectNodes returns the nodes matching a comma separated list of node selector terms;
+// each term can be a kinder node selector like @all, @cp* or @w*, a node name with or without the cluster
+// name prefix, role:<node role> for selecting nodes by role, e.g. role:external-etcd, or
+// label:<Kubernetes label selector> for selecting nodes by the labels of their Node objects;
+// a label: term must be the last one, because it extends to the end of the selector.
+// Nodes are returned in the order of the terms, without duplicates.
+func SelectNodes(c *status.Cluster, nodeSelector string) (status.NodeList, error) {
+	if strings.TrimSpace(nodeSelector) == "" {
+		nodeSelector = "@all"
+	}
+
+	nodes := status.NodeList{}
+	selected := map[string]bool{}
+	for _, term := range parseNodeSelector(nodeSelector) {
+		var matches status.NodeList
+		switch term.prefix {
+		case labelSelectorPrefix:
+			var err error
+			if matches, err = selectNodesByLabel(c, term.value); err != nil {
+				return nil, err
+			}
+		case roleSelectorPrefix:
+			for _, n := range c.AllNodes() {
+				if n.Role() == term.value {
+					matches = append(matches, n)
+				}
+			}
+			if len(matches) == 0 {
+				return nil, errors.Errorf("no nodes matching selector %q", roleSelectorPrefix+term.value)
+			}
+		default:
+			if n := nodeByName(c, term.value); n != nil {
+				matches = status.NodeList{n}
+				break
+			}
+			var err error
+			if matches, err = c.SelectNodes(term.value); err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, errors.Errorf("no nodes matching selector %q", term.value)
+			}
+		}
+
+		for _, n := range matches {
+			if !selected[n.Name()] {
+				selected[n.Name()] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// selectNodesByLabel returns the nodes whose Node objects match a Kubernetes label selector
+func selectNodesByLabel(c *status.Cluster, labelSelector string) (status.NodeList, error) {
+	lines, err := c.BootstrapControlPlane().Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"get", "nodes", "-l", labelSelector,
+		"-o=jsonpath={.items[*].metadata.name}",
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get nodes matching label selector %q", labelSelector)
+	}
+
+	names := map[string]bool{}
+	for _, l := range lines {
+		for _, name := range strings.Fields(l) {
+			names[name] = true
+		}
+	}
+
+	nodes := status.NodeList{}
+	for _, n := range c.K8sNodes() {
+		if names[n.Name()] {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes, nil
+}
+
+// nodeByName returns the node with the given full name, if any
+func nodeByName(c *status.Cluster, name string) *status.Node {
+	for _, n := range c.AllNodes() {
+		if n.Name() == name {
+			return n
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+func TestParseNodeSelector(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedTerms []nodeSelectorTerm
+	}{
+		{
+			name:          "node name",
+			input:         "control-plane-1",
+			expectedTerms: []nodeSelectorTerm{{value: "control-plane-1"}},
+		},
+		{
+			name:          "kinder node selector",
+			input:         "@cp*",
+			expectedTerms: []nodeSelectorTerm{{value: "@cp*"}},
+		},
+		{
+			name:          "role",
+			input:         "role:external-etcd",
+			expectedTerms: []nodeSelectorTerm{{prefix: roleSelectorPrefix, value: "external-etcd"}},
+		},
+		{
+			name:          "label",
+			input:         "label:a=b",
+			expectedTerms: []nodeSelectorTerm{{prefix: labelSelectorPrefix, value: "a=b"}},
+		},
+		{
+			name:          "label with many requirements",
+			input:         "label:a=b,c!=d,!e",
+			expectedTerms: []nodeSelectorTerm{{prefix: labelSelectorPrefix, value: "a=b,c!=d,!e"}},
+		},
+		{
+			name:  "list of terms ending with a label",
+			input: "@cp1, worker1,role:worker ,label:a=b,c in (d,e)",
+			expectedTerms: []nodeSelectorTerm{
+				{value: "@cp1"},
+				{value: "worker1"},
+				{prefix: roleSelectorPrefix, value: "worker"},
+				{prefix: labelSelectorPrefix, value: "a=b,c in (d,e)"},
+			},
+		},
+		{
+			name:  "only-node with names and a kinder node selector",
+			input: "cp2,worker1,@lb",
+			expectedTerms: []nodeSelectorTerm{
+				{value: "cp2"},
+				{value: "worker1"},
+				{value: "@lb"},
+			},
+		},
+		{
+			name:  "skip-node with a label with set based requirements",
+			input: "@cpn, label: tier notin (db,cache),zone",
+			expectedTerms: []nodeSelectorTerm{
+				{value: "@cpn"},
+				{prefix: labelSelectorPrefix, value: "tier notin (db,cache),zone"},
+			},
+		},
+		{
+			name:          "empty selector",
+			input:         "",
+			expectedTerms: []nodeSelectorTerm{},
+		},
+		{
+			name:          "empty terms are ignored",
+			input:         ",worker1,,",
+			expectedTerms: []nodeSelectorTerm{{value: "worker1"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			terms := parseNodeSelector(test.input)
+			if !reflect.DeepEqual(terms, test.expectedTerms) {
+				t.Fatalf("expected terms: %v, found %v", test.expectedTerms, terms)
+			}
+		})
+	}
+}
+
+func TestCheckNodeSelection(t *testing.T) {
+	cp, w, lb := constants.ControlPlaneNodeRoleValue, constants.WorkerNodeRoleValue, constants.ExternalLoadBalancerNodeRoleValue
+	tests := []struct {
+		name          string
+		roles         []string
+		nodeRoles     []string
+		eligibleRoles []string
+		expectedError bool
+	}{
+		{
+			name:          "cluster without a load balancer",
+			roles:         []string{lb},
+			nodeRoles:     []string{cp},
+			eligibleRoles: []string{cp},
+		},
+		{
+			name:          "load balancer skipped",
+			roles:         []string{lb},
+			nodeRoles:     []string{lb, cp},
+			eligibleRoles: []string{cp},
+			expectedError: true,
+		},
+		{
+			name:          "only workers selected for a control-plane action",
+			roles:         controlPlaneNodeRoles,
+			nodeRoles:     []string{cp, w},
+			eligibleRoles: []string{w},
+			expectedError: true,
+		},
+		{
+			name:          "some targeted nodes selected",
+			roles:         k8sNodeRoles,
+			nodeRoles:     []string{lb, cp, w},
+			eligibleRoles: []string{w},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkNodeSelection("test", test.roles, test.nodeRoles, test.eligibleRoles)
+			if (err != nil) != test.expectedError {
+				t.Errorf("expected error %t, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// RemoveControlPlane removes a control-plane node from the cluster following the documented
+// HA scale-down procedure: drain the node, remove its etcd member, run kubeadm reset, delete the
+// Node object and finally verify that the remaining etcd members have quorum and are healthy.
+//
+// The node to be removed must be selected with --only-node, and it must be the only node selected.
+func RemoveControlPlane(c *status.Cluster, wait time.Duration, vLevel int) error {
+	selected := c.K8sNodes().EligibleForActions()
+	if len(selected) != 1 {
+		return errors.Errorf("remove-control-plane can remove only one control-plane node at a time, %d nodes selected; use --only-node", len(selected))
+	}
+	target := selected[0]
+	if !target.IsControlPlane() {
+		return errors.Errorf("%s is not a control-plane node", target.Name())
+	}
+
+	// commands against the cluster are executed from one of the remaining control-plane nodes
+	remaining := status.NodeList{}
+	for _, n := range c.ControlPlanes() {
+		if n.Name() != target.Name() {
+			remaining = append(remaining, n)
+		}
+	}
+	if len(remaining) == 0 {
+		return errors.Errorf("%s is the only control-plane node in the cluster and it can't be removed", target.Name())
+	}
+	cp := remaining[0]
+
+	cp.Infof("drain node %s", target.Name())
+	if err := cp.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"drain", target.Name(), "--ignore-daemonsets", "--delete-emptydir-data", "--force",
+		fmt.Sprintf("--timeout=%s", wait),
+	).RunWithEcho(); err != nil {
+		return errors.Wrapf(err, "failed to drain node %s", target.Name())
+	}
+
+	// the etcd member is removed explicitly before kubeadm reset, as documented for the HA scale-down procedure
+	var etcdArgs []string
+	if c.ExternalEtcd() == nil {
+		var err error
+		etcdArgs, err = etcdctlArgs(cp)
+		if err != nil {
+			return err
+		}
+
+		cp.Infof("remove etcd member %s", target.Name())
+		if err := removeEtcdMember(cp, etcdArgs, target.Name()); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("using external etcd, skipping etcd member removal")
+	}
+
+	if err := kubeadmResetNode(c, target, nil, vLevel); err != nil {
+		return errors.Wrapf(err, "failed to reset node %s", target.Name())
+	}
+
+	cp.Infof("delete node %s", target.Name())
+	if err := cp.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"delete", "node", target.Name(),
+	).RunWithEcho(); err != nil {
+		return errors.Wrapf(err, "failed to delete node %s", target.Name())
+	}
+
+	if c.ExternalEtcd() == nil {
+		cp.Infof("verify etcd members and health")
+		lines, err := cp.Command(
+			"kubectl", append(append([]string{}, etcdArgs...), "member", "list")...,
+		).RunAndCapture()
+		if err != nil {
+			return errors.Wrap(err, "failed to list etcd members")
+		}
+		members, err := etcdMembers(lines)
+		if err != nil {
+			return err
+		}
+		if _, ok := members[target.Name()]; ok {
+			return errors.Errorf("node %s is still an etcd member", target.Name())
+		}
+		if len(members) != len(remaining) {
+			return errors.Errorf("expected %d etcd members, found %d: %v", len(remaining), len(members), members)
+		}
+
+		if pass := waitFor(c, cp, wait, etcdClusterIsHealthy(etcdArgs)); !pass {
+			return errors.New("timeout: etcd cluster did not become healthy")
+		}
+	}
+
+	fmt.Printf("\nControl-plane node %s removed!\n", target.Name())
+	return nil
+}
+
+// removeEtcdMember removes the etcd member with the given name, using etcdctl on the given node
+func removeEtcdMember(cp *status.Node, etcdArgs []string, name string) error {
+	lines, err := cp.Command(
+		"kubectl", append(append([]string{}, etcdArgs...), "member", "list")...,
+	).RunAndCapture()
+	if err != nil {
+		return errors.Wrap(err, "failed to list etcd members")
+	}
+
+	members, err := etcdMembers(lines)
+	if err != nil {
+		return err
+	}
+
+	id, ok := members[name]
+	if !ok {
+		return errors.Errorf("etcd member %s not found", name)
+	}
+
+	if err := cp.Command(
+		"kubectl", append(append([]string{}, etcdArgs...), "member", "remove", id)...,
+	).RunWithEcho(); err != nil {
+		return errors.Wrapf(err, "failed to remove etcd member %s", name)
+	}
+	return nil
+}
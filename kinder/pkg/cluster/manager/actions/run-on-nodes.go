@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// RunOnNodes executes a shell command on the nodes matching a node selector, either one node
+// at a time in the selection order or on all the nodes in parallel.
+// The command is executed on all the selected nodes also in case of failures, and failures are
+// reported together at the end.
+func RunOnNodes(c *status.Cluster, nodeSelector, command string, parallel bool) error {
+	if command == "" {
+		return errors.New("the run-on-nodes action requires a command; use --command")
+	}
+
+	selected, err := SelectNodes(c, nodeSelector)
+	if err != nil {
+		return err
+	}
+	// nodes excluded with --only-node or --skip-node are not targeted
+	nodes := selected.EligibleForActions()
+	if len(nodes) == 0 {
+		return errors.Errorf("no nodes matching selector %q", nodeSelector)
+	}
+
+	errs := make([]error, len(nodes))
+	if parallel {
+		// output is captured and printed once all the commands are completed, to avoid interleaving
+		outputs := make([][]string, len(nodes))
+		var wg sync.WaitGroup
+		for i, n := range nodes {
+			wg.Add(1)
+			go func(i int, n *status.Node) {
+				defer wg.Done()
+				outputs[i], errs[i] = n.Command("/bin/sh", "-c", command).RunAndCapture()
+			}(i, n)
+		}
+		wg.Wait()
+
+		for i, n := range nodes {
+			n.Infof("%s", command)
+			for _, l := range outputs[i] {
+				fmt.Println(l)
+			}
+		}
+	} else {
+		for i, n := range nodes {
+			n.Infof("%s", command)
+			errs[i] = n.Command("/bin/sh", "-c", command).RunWithEcho()
+		}
+	}
+
+	failures := []string{}
+	for i, n := range nodes {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", n.Name(), errs[i]))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("command failed on %d of %d nodes:\n%s", len(failures), len(nodes), strings.Join(failures, "\n"))
+	}
+
+	fmt.Printf("\nCommand executed on %d nodes!\n", len(nodes))
+	return nil
+}
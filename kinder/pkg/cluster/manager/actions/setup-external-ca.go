@@ -85,7 +85,7 @@ func SetupExternalCA(c *status.Cluster, vLevel int) error {
 	}
 
 	// iterate secondary CP nodes
-	for _, n := range c.SecondaryControlPlanes() {
+	for _, n := range c.SecondaryControlPlanes().EligibleForActions() {
 		// copy the shared kubeconfig files
 		if err := copyKubeconfigFilesToNode(c, n); err != nil {
 			return errors.Wrapf(err, "could not copy kubeconfig files to node %s", n.Name())
@@ -113,7 +113,7 @@ func SetupExternalCA(c *status.Cluster, vLevel int) error {
 	}
 
 	// iterate all workers
-	for _, n := range c.Workers() {
+	for _, n := range c.Workers().EligibleForActions() {
 		// copy the CA cert and key
 		if err := copyCAToNode(c, n); err != nil {
 			return err
@@ -125,8 +125,8 @@ func SetupExternalCA(c *status.Cluster, vLevel int) error {
 		}
 	}
 
-	// delete the ca.key from all nodes
-	for _, n := range c.AllNodes() {
+	// delete the ca.key from all the selected nodes
+	for _, n := range c.AllNodes().EligibleForActions() {
 		if err := n.Command("rm", "-f", "/etc/kubernetes/pki/ca.key").Run(); err != nil {
 			return errors.Wrapf(err, "could not delete ca.key on node: %s", n.Name())
 		}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/cri/host"
+)
+
+// skewComponents are the control-plane components whose versions are read from the static pod manifests
+var skewComponents = []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler", "etcd"}
+
+// SkewReport reports the versions of the components of a cluster, and the violations of the
+// Kubernetes and kubeadm version skew policies
+type SkewReport struct {
+	Name    string         `json:"name"`
+	Nodes   []NodeVersions `json:"nodes"`
+	CoreDNS string         `json:"coreDNS,omitempty"`
+
+	// ExpectedEtcd and ExpectedCoreDNS are the versions kubeadm uses for the newest kube-apiserver
+	ExpectedEtcd    string `json:"expectedEtcd,omitempty"`
+	ExpectedCoreDNS string `json:"expectedCoreDNS,omitempty"`
+
+	// Violations lists the unsupported skews
+	Violations []string `json:"violations,omitempty"`
+	// Warnings lists versions that are supported but unexpected, e.g. an etcd version different from the kubeadm default,
+	// and the versions that could not be read
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NodeVersions are the versions of the components on a node; components not existing on the node are empty
+type NodeVersions struct {
+	Name              string `json:"name"`
+	Role              string `json:"role"`
+	Kubeadm           string `json:"kubeadm,omitempty"`
+	Kubelet           string `json:"kubelet,omitempty"`
+	APIServer         string `json:"apiServer,omitempty"`
+	ControllerManager string `json:"controllerManager,omitempty"`
+	Scheduler         string `json:"scheduler,omitempty"`
+	Etcd              string `json:"etcd,omitempty"`
+}
+
+// GetSkewReport reads the versions of kubeadm, kubelet and of the control-plane components from each node, and
+// the version of CoreDNS from the cluster, and checks them against the version skew policies
+func GetSkewReport(c *status.Cluster) *SkewReport {
+	r := &SkewReport{Name: c.Name()}
+	for _, n := range c.AllNodes() {
+		v := NodeVersions{Name: n.Name(), Role: n.Role()}
+		switch {
+		case n.IsExternalEtcd():
+			if lines, err := host.InspectContainer(n.Name(), "{{.Config.Image}}"); err == nil && len(lines) == 1 {
+				v.Etcd = imageTag(lines[0])
+			}
+		case hasRole(n, k8sNodeRoles):
+			if kv, err := n.KubeadmVersion(); err == nil {
+				v.Kubeadm = "v" + kv.String()
+			} else {
+				r.Warnings = append(r.Warnings, fmt.Sprintf("failed to read the kubeadm version on node %s", n.Name()))
+			}
+			if lines, err := n.Command("kubelet", "--version").Silent().ReadOnly().RunAndCapture(); err == nil && len(lines) == 1 {
+				v.Kubelet = strings.TrimPrefix(lines[0], "Kubernetes ")
+			} else {
+				r.Warnings = append(r.Warnings, fmt.Sprintf("failed to read the kubelet version on node %s", n.Name()))
+			}
+			if n.IsControlPlane() {
+				images := staticPodImages(n)
+				v.APIServer = imageTag(images["kube-apiserver"])
+				v.ControllerManager = imageTag(images["kube-controller-manager"])
+				v.Scheduler = imageTag(images["kube-scheduler"])
+				v.Etcd = imageTag(images["etcd"])
+			}
+		}
+		r.Nodes = append(r.Nodes, v)
+	}
+
+	if cp1 := c.BootstrapControlPlane(); cp1 != nil {
+		lines, err := cp1.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "--request-timeout=10s", "get", "deployment",
+			"-n=kube-system", "coredns", "-o=jsonpath={.spec.template.spec.containers[0].image}",
+		).Silent().ReadOnly().RunAndCapture()
+		if err == nil && len(lines) == 1 {
+			r.CoreDNS = imageTag(lines[0])
+		} else {
+			r.Warnings = append(r.Warnings, "failed to read the CoreDNS version from the cluster")
+		}
+
+		if newest, _ := minorRange(r.Nodes, func(v NodeVersions) string { return v.APIServer }); newest != nil {
+			r.ExpectedEtcd, r.ExpectedCoreDNS = kubeadmDefaultVersions(cp1, newest)
+		}
+	}
+
+	r.evaluate()
+	return r
+}
+
+// staticPodImages returns the images of the static pods on a node, by component
+func staticPodImages(n *status.Node) map[string]string {
+	images := map[string]string{}
+	for _, component := range skewComponents {
+		lines, err := n.Command(
+			"sh", "-c", fmt.Sprintf("grep -m1 'image:' /etc/kubernetes/manifests/%s.yaml", component),
+		).Silent().ReadOnly().RunAndCapture()
+		if err != nil || len(lines) != 1 {
+			continue
+		}
+		images[component] = strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[0]), "image:")), `"'`)
+	}
+	return images
+}
+
+// kubeadmDefaultVersions returns the versions of etcd and CoreDNS used by kubeadm for a Kubernetes version
+func kubeadmDefaultVersions(n *status.Node, kubernetesVersion *K8sVersion.Version) (etcd, coreDNS string) {
+	lines, err := n.Command(
+		"kubeadm", "config", "images", "list", "--kubernetes-version=v"+kubernetesVersion.String(),
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		return "", ""
+	}
+	for _, l := range lines {
+		switch {
+		case strings.Contains(l, "/etcd:"):
+			etcd = imageTag(l)
+		case strings.Contains(l, "coredns:"):
+			coreDNS = imageTag(l)
+		}
+	}
+	return etcd, coreDNS
+}
+
+// imageTag returns the tag of an image, e.g. v1.33.0 for registry.k8s.io/kube-apiserver:v1.33.0
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i >= 0 && !strings.Contains(image[i:], "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// parseSkewVersion parses a Kubernetes version, also from image tags where + is replaced by _
+func parseSkewVersion(v string) (*K8sVersion.Version, error) {
+	return K8sVersion.ParseSemantic(strings.ReplaceAll(v, "_", "+"))
+}
+
+// minorRange returns the newest and the oldest version of a component in the cluster
+func minorRange(nodes []NodeVersions, component func(NodeVersions) string) (newest, oldest *K8sVersion.Version) {
+	for _, n := range nodes {
+		v, err := parseSkewVersion(component(n))
+		if err != nil {
+			continue
+		}
+		if newest == nil || v.GreaterThan(newest) {
+			newest = v
+		}
+		if oldest == nil || v.LessThan(oldest) {
+			oldest = v
+		}
+	}
+	return newest, oldest
+}
+
+// kubeletSkew is the number of minor versions the kubelet can be older than kube-apiserver and kubeadm;
+// it was extended from two to three in v1.28
+func kubeletSkew(apiServer *K8sVersion.Version) int {
+	if apiServer.Minor() >= 28 {
+		return 3
+	}
+	return 2
+}
+
+// evaluate checks the versions against https://kubernetes.io/releases/version-skew-policy/ and the
+// skew policy of kubeadm
+func (r *SkewReport) evaluate() {
+	violation := func(format string, args ...any) {
+		r.Violations = append(r.Violations, fmt.Sprintf(format, args...))
+	}
+	minors := func(a, b *K8sVersion.Version) int {
+		return int(a.Minor()) - int(b.Minor())
+	}
+
+	newestAPI, oldestAPI := minorRange(r.Nodes, func(v NodeVersions) string { return v.APIServer })
+	if newestAPI == nil {
+		r.Warnings = append(r.Warnings, "no kube-apiserver version found, the skew with kube-apiserver is not checked")
+	} else if minors(newestAPI, oldestAPI) > 1 {
+		violation("kube-apiserver versions v%s and v%s differ by more than one minor version", newestAPI, oldestAPI)
+	}
+
+	for _, n := range r.Nodes {
+		versions := map[string]string{
+			"kubeadm": n.Kubeadm, "kubelet": n.Kubelet,
+			"kube-apiserver": n.APIServer, "kube-controller-manager": n.ControllerManager, "kube-scheduler": n.Scheduler,
+		}
+		parsed := map[string]*K8sVersion.Version{}
+		for _, component := range []string{"kubeadm", "kubelet", "kube-apiserver", "kube-controller-manager", "kube-scheduler"} {
+			if versions[component] == "" {
+				continue
+			}
+			v, err := parseSkewVersion(versions[component])
+			if err != nil {
+				r.Warnings = append(r.Warnings, fmt.Sprintf("%s version %q on node %s is not a valid version", component, versions[component], n.Name))
+				continue
+			}
+			parsed[component] = v
+		}
+
+		if newestAPI != nil {
+			// kubeadm configures kube-controller-manager and kube-scheduler to use the local kube-apiserver
+			localAPI := oldestAPI
+			if v, ok := parsed["kube-apiserver"]; ok {
+				localAPI = v
+			}
+			for _, component := range []string{"kube-controller-manager", "kube-scheduler"} {
+				v, ok := parsed[component]
+				if !ok {
+					continue
+				}
+				if minors(v, localAPI) > 0 {
+					violation("%s v%s on node %s is newer than kube-apiserver v%s", component, v, n.Name, localAPI)
+				} else if minors(newestAPI, v) > 1 {
+					violation("%s v%s on node %s is more than one minor version older than kube-apiserver v%s", component, v, n.Name, newestAPI)
+				}
+			}
+			if v, ok := parsed["kubelet"]; ok {
+				if minors(v, oldestAPI) > 0 {
+					violation("kubelet v%s on node %s is newer than kube-apiserver v%s", v, n.Name, oldestAPI)
+				} else if skew := kubeletSkew(newestAPI); minors(newestAPI, v) > skew {
+					violation("kubelet v%s on node %s is more than %d minor versions older than kube-apiserver v%s", v, n.Name, skew, newestAPI)
+				}
+			}
+			if v, ok := parsed["kubeadm"]; ok {
+				if minors(newestAPI, v) > 0 {
+					violation("kubeadm v%s on node %s is older than kube-apiserver v%s", v, n.Name, newestAPI)
+				} else if minors(v, oldestAPI) > 1 {
+					violation("kubeadm v%s on node %s is more than one minor version newer than kube-apiserver v%s", v, n.Name, oldestAPI)
+				}
+			}
+		}
+
+		kubeadm, okKubeadm := parsed["kubeadm"]
+		kubelet, okKubelet := parsed["kubelet"]
+		if okKubeadm && okKubelet {
+			if minors(kubelet, kubeadm) > 0 {
+				violation("kubelet v%s on node %s is newer than kubeadm v%s", kubelet, n.Name, kubeadm)
+			} else if skew := kubeletSkew(kubeadm); minors(kubeadm, kubelet) > skew {
+				violation("kubelet v%s on node %s is more than %d minor versions older than kubeadm v%s", kubelet, n.Name, skew, kubeadm)
+			}
+		}
+
+		if n.Etcd != "" && r.ExpectedEtcd != "" && n.Etcd != r.ExpectedEtcd {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("etcd %s on node %s is not the version used by kubeadm, %s", n.Etcd, n.Name, r.ExpectedEtcd))
+		}
+	}
+
+	if r.CoreDNS != "" && r.ExpectedCoreDNS != "" && r.CoreDNS != r.ExpectedCoreDNS {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("CoreDNS %s is not the version used by kubeadm, %s", r.CoreDNS, r.ExpectedCoreDNS))
+	}
+}
+
+// Print prints the report in a human readable format
+func (r *SkewReport) Print(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tROLE\tKUBEADM\tKUBELET\tAPISERVER\tCONTROLLER-MANAGER\tSCHEDULER\tETCD")
+	for _, n := range r.Nodes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", n.Name, n.Role, orDash(n.Kubeadm), orDash(n.Kubelet),
+			orDash(n.APIServer), orDash(n.ControllerManager), orDash(n.Scheduler), orDash(n.Etcd))
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nCoreDNS: %s\n\n", orDash(r.CoreDNS))
+
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(out, "warning: %s\n", warning)
+	}
+	if len(r.Violations) == 0 {
+		fmt.Fprintf(out, "the versions of cluster %s are within the supported skew\n", r.Name)
+		return
+	}
+	fmt.Fprintf(out, "the versions of cluster %s are not within the supported skew:\n", r.Name)
+	for _, v := range r.Violations {
+		fmt.Fprintf(out, "- %s\n", v)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImageTag(t *testing.T) {
+	var tests = []struct {
+		image    string
+		expected string
+	}{
+		{image: "registry.k8s.io/kube-apiserver:v1.33.0", expected: "v1.33.0"},
+		{image: "localhost:5000/kube-apiserver:v1.34.0-alpha.1.23_0123456789abcd", expected: "v1.34.0-alpha.1.23_0123456789abcd"},
+		{image: "registry.k8s.io/etcd:3.5.21-0@sha256:0123", expected: "3.5.21-0"},
+		{image: "localhost:5000/kube-apiserver", expected: ""},
+	}
+	for _, rt := range tests {
+		t.Run(rt.image, func(t *testing.T) {
+			if tag := imageTag(rt.image); tag != rt.expected {
+				t.Errorf("expected %q, got %q", rt.expected, tag)
+			}
+		})
+	}
+}
+
+func TestSkewReportEvaluate(t *testing.T) {
+	controlPlane := func(name, kubeadm, kubelet, apiServer string) NodeVersions {
+		return NodeVersions{Name: name, Role: "control-plane", Kubeadm: kubeadm, Kubelet: kubelet,
+			APIServer: apiServer, ControllerManager: apiServer, Scheduler: apiServer, Etcd: "3.5.21-0"}
+	}
+	worker := func(name, kubeadm, kubelet string) NodeVersions {
+		return NodeVersions{Name: name, Role: "worker", Kubeadm: kubeadm, Kubelet: kubelet}
+	}
+
+	var tests = []struct {
+		name               string
+		report             SkewReport
+		expectedViolations []string
+		expectedWarnings   []string
+	}{
+		{
+			name: "same versions",
+			report: SkewReport{
+				Nodes:        []NodeVersions{controlPlane("cp1", "v1.33.0", "v1.33.0", "v1.33.0"), worker("w1", "v1.33.0", "v1.33.0")},
+				ExpectedEtcd: "3.5.21-0",
+			},
+		},
+		{
+			name: "upgrade in progress",
+			report: SkewReport{
+				Nodes: []NodeVersions{
+					controlPlane("cp1", "v1.34.0", "v1.33.0", "v1.34.0"),
+					controlPlane("cp2", "v1.34.0", "v1.33.0", "v1.33.0"),
+					worker("w1", "v1.34.0", "v1.31.0"),
+				},
+			},
+		},
+		{
+			name: "unsupported skews",
+			report: SkewReport{
+				Nodes: []NodeVersions{
+					controlPlane("cp1", "v1.33.0", "v1.34.0", "v1.33.0"),
+					worker("w1", "v1.32.0", "v1.29.0"),
+				},
+			},
+			expectedViolations: []string{
+				"kubelet v1.34.0 on node cp1 is newer than kube-apiserver v1.33.0",
+				"kubelet v1.34.0 on node cp1 is newer than kubeadm v1.33.0",
+				"kubelet v1.29.0 on node w1 is more than 3 minor versions older than kube-apiserver v1.33.0",
+				"kubeadm v1.32.0 on node w1 is older than kube-apiserver v1.33.0",
+			},
+		},
+		{
+			name: "skew of kube-apiserver instances",
+			report: SkewReport{
+				Nodes: []NodeVersions{
+					controlPlane("cp1", "v1.34.0", "v1.32.0", "v1.34.0"),
+					controlPlane("cp2", "v1.34.0", "v1.32.0", "v1.32.0"),
+				},
+			},
+			expectedViolations: []string{
+				"kube-apiserver versions v1.34.0 and v1.32.0 differ by more than one minor version",
+				"kubeadm v1.34.0 on node cp1 is more than one minor version newer than kube-apiserver v1.32.0",
+				"kube-controller-manager v1.32.0 on node cp2 is more than one minor version older than kube-apiserver v1.34.0",
+				"kube-scheduler v1.32.0 on node cp2 is more than one minor version older than kube-apiserver v1.34.0",
+				"kubeadm v1.34.0 on node cp2 is more than one minor version newer than kube-apiserver v1.32.0",
+			},
+		},
+		{
+			name: "unexpected etcd and CoreDNS versions",
+			report: SkewReport{
+				Nodes:           []NodeVersions{controlPlane("cp1", "v1.33.0", "v1.33.0", "v1.33.0")},
+				CoreDNS:         "v1.11.3",
+				ExpectedEtcd:    "3.5.16-0",
+				ExpectedCoreDNS: "v1.12.0",
+			},
+			expectedWarnings: []string{
+				"etcd 3.5.21-0 on node cp1 is not the version used by kubeadm, 3.5.16-0",
+				"CoreDNS v1.11.3 is not the version used by kubeadm, v1.12.0",
+			},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			r := rt.report
+			r.evaluate()
+			if !reflect.DeepEqual(r.Violations, rt.expectedViolations) {
+				t.Errorf("expected violations %q, got %q", rt.expectedViolations, r.Violations)
+			}
+			if !reflect.DeepEqual(r.Warnings, rt.expectedWarnings) {
+				t.Errorf("expected warnings %q, got %q", rt.expectedWarnings, r.Warnings)
+			}
+		})
+	}
+}
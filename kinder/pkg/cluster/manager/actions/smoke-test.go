@@ -66,7 +66,7 @@ func SmokeTest(c *status.Cluster, wait time.Duration) error {
 		return err
 	}
 
-	for _, n := range c.K8sNodes() {
+	for _, n := range c.K8sNodes().EligibleForActions() {
 		err = waitForNodePort(c, n, 30*time.Second, nodePort)
 		if err != nil {
 			return err
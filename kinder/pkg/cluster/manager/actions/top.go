@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// oversubscribedThreshold is the fraction of the host CPUs or memory used by the node containers
+// above which the host is reported as oversubscribed
+const oversubscribedThreshold = 0.9
+
+// NodeUsage is the resource usage of a node container
+type NodeUsage struct {
+	Name       string  `json:"name"`
+	Role       string  `json:"role"`
+	CPUPercent float64 `json:"cpuPercent"`
+	// MemoryBytes is the memory used by the node container
+	MemoryBytes int64 `json:"memoryBytes"`
+	// DiskUsedBytes and DiskSizeBytes are the usage of /var in the node, that hosts the container images
+	DiskUsedBytes int64 `json:"diskUsedBytes,omitempty"`
+	DiskSizeBytes int64 `json:"diskSizeBytes,omitempty"`
+	PIDs          int   `json:"pids"`
+}
+
+// HostCapacity is the CPUs and the memory of the host running the node containers
+type HostCapacity struct {
+	CPUs        int   `json:"cpus"`
+	MemoryBytes int64 `json:"memoryBytes"`
+}
+
+// dockerStats is an entry of docker stats --format '{{json .}}'
+type dockerStats struct {
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	PIDs     string `json:"PIDs"`
+}
+
+// GetNodesUsage returns the resource usage of the running node containers, as reported by docker stats
+func GetNodesUsage(c *status.Cluster) ([]NodeUsage, error) {
+	args := []string{"stats", "--no-stream", "--format={{json .}}"}
+	for _, n := range c.AllNodes() {
+		args = append(args, n.Name())
+	}
+	lines, err := exec.NewHostCmd("docker", args...).RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the stats of the node containers: %s", strings.Join(lines, " "))
+	}
+
+	stats := map[string]NodeUsage{}
+	for _, l := range lines {
+		u, err := parseDockerStats(l)
+		if err != nil {
+			return nil, err
+		}
+		stats[u.Name] = u
+	}
+
+	usage := []NodeUsage{}
+	for _, n := range c.AllNodes() {
+		u, ok := stats[n.Name()]
+		if !ok {
+			continue
+		}
+		u.Role = n.Role()
+		// not all the images have a df supporting --output, e.g. the load balancer, so the disk usage is optional
+		if lines, err := n.Command("df", "-B1", "--output=used,size", "/var").Silent().ReadOnly().RunAndCapture(); err == nil && len(lines) == 2 {
+			if fields := strings.Fields(lines[1]); len(fields) == 2 {
+				u.DiskUsedBytes, _ = strconv.ParseInt(fields[0], 10, 64)
+				u.DiskSizeBytes, _ = strconv.ParseInt(fields[1], 10, 64)
+			}
+		}
+		usage = append(usage, u)
+	}
+	return usage, nil
+}
+
+// parseDockerStats parses an entry of docker stats --format '{{json .}}'
+func parseDockerStats(line string) (NodeUsage, error) {
+	s := dockerStats{}
+	if err := json.Unmarshal([]byte(line), &s); err != nil {
+		return NodeUsage{}, errors.Wrapf(err, "unexpected output of docker stats %q", line)
+	}
+	cpu, err := strconv.ParseFloat(strings.TrimSuffix(s.CPUPerc, "%"), 64)
+	if err != nil {
+		return NodeUsage{}, errors.Errorf("unexpected CPU usage %q for %s", s.CPUPerc, s.Name)
+	}
+	// MemUsage is in the format "<usage> / <limit>"
+	memory, err := parseSize(strings.TrimSpace(strings.Split(s.MemUsage, "/")[0]))
+	if err != nil {
+		return NodeUsage{}, errors.Wrapf(err, "unexpected memory usage %q for %s", s.MemUsage, s.Name)
+	}
+	pids, _ := strconv.Atoi(s.PIDs)
+	return NodeUsage{Name: s.Name, CPUPercent: cpu, MemoryBytes: memory, PIDs: pids}, nil
+}
+
+// sizeUnits are the units used by docker for sizes
+var sizeUnits = map[string]float64{
+	"B":   1,
+	"kB":  1e3,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// parseSize parses a size as printed by docker, e.g. 1.5GiB
+func parseSize(s string) (int64, error) {
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i <= 0 {
+		return 0, errors.Errorf("invalid size %q", s)
+	}
+	v, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid size %q", s)
+	}
+	unit, ok := sizeUnits[strings.TrimSpace(s[i:])]
+	if !ok {
+		return 0, errors.Errorf("invalid size %q", s)
+	}
+	return int64(v * unit), nil
+}
+
+// GetHostCapacity returns the CPUs and the memory of the host running the node containers, as reported by docker
+func GetHostCapacity() (HostCapacity, error) {
+	lines, err := exec.NewHostCmd("docker", "info", "--format={{.NCPU}} {{.MemTotal}}").RunAndCapture()
+	if err != nil || len(lines) != 1 {
+		return HostCapacity{}, errors.Wrapf(err, "failed to get the capacity of the host: %s", strings.Join(lines, " "))
+	}
+	h := HostCapacity{}
+	if _, err := fmt.Sscanf(lines[0], "%d %d", &h.CPUs, &h.MemoryBytes); err != nil {
+		return HostCapacity{}, errors.Wrapf(err, "unexpected output of docker info %q", lines[0])
+	}
+	return h, nil
+}
+
+// oversubscription returns warnings if the node containers use most of the CPUs or of the memory of the host
+func oversubscription(usage []NodeUsage, host HostCapacity) []string {
+	var cpu float64
+	var memory int64
+	for _, u := range usage {
+		cpu += u.CPUPercent
+		memory += u.MemoryBytes
+	}
+
+	warnings := []string{}
+	if host.CPUs > 0 && cpu > float64(host.CPUs)*100*oversubscribedThreshold {
+		warnings = append(warnings, fmt.Sprintf("node containers are using %.0f%% of the %d CPUs of the host", cpu/float64(host.CPUs), host.CPUs))
+	}
+	if host.MemoryBytes > 0 && float64(memory) > float64(host.MemoryBytes)*oversubscribedThreshold {
+		warnings = append(warnings, fmt.Sprintf("node containers are using %s of the %s of memory of the host", formatBytes(memory), formatBytes(host.MemoryBytes)))
+	}
+	return warnings
+}
+
+// PrintNodesUsage prints the resource usage of the node containers, with the totals and a warning
+// if the host is oversubscribed
+func PrintNodesUsage(out io.Writer, usage []NodeUsage, host HostCapacity) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tROLE\tCPU\tMEMORY\tDISK (/var)\tPIDS")
+	var total NodeUsage
+	for _, u := range usage {
+		disk := "-"
+		if u.DiskSizeBytes > 0 {
+			disk = fmt.Sprintf("%s / %s", formatBytes(u.DiskUsedBytes), formatBytes(u.DiskSizeBytes))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%s\t%s\t%d\n", u.Name, u.Role, u.CPUPercent, formatBytes(u.MemoryBytes), disk, u.PIDs)
+		total.CPUPercent += u.CPUPercent
+		total.MemoryBytes += u.MemoryBytes
+		total.PIDs += u.PIDs
+	}
+	fmt.Fprintf(w, "TOTAL\t\t%.1f%%\t%s\t\t%d\n", total.CPUPercent, formatBytes(total.MemoryBytes), total.PIDs)
+	if host.CPUs > 0 {
+		fmt.Fprintf(w, "HOST\t\t%d CPUs\t%s\t\t\n", host.CPUs, formatBytes(host.MemoryBytes))
+	}
+	w.Flush()
+
+	for _, warning := range oversubscription(usage, host) {
+		fmt.Fprintf(out, "\nwarning: %s, tests are likely to flake\n", warning)
+	}
+}
+
+func formatBytes(b int64) string {
+	if b >= 1<<30 {
+		return fmt.Sprintf("%.1fGiB", float64(b)/(1<<30))
+	}
+	return fmt.Sprintf("%.0fMiB", float64(b)/(1<<20))
+}
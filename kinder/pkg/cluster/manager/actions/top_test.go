@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDockerStats(t *testing.T) {
+	var tests = []struct {
+		name          string
+		line          string
+		expected      NodeUsage
+		expectedError bool
+	}{
+		{
+			name:     "binary units",
+			line:     `{"BlockIO":"0B / 0B","CPUPerc":"12.50%","MemPerc":"8.12%","MemUsage":"640MiB / 7.7GiB","Name":"kind-control-plane","PIDs":"231"}`,
+			expected: NodeUsage{Name: "kind-control-plane", CPUPercent: 12.5, MemoryBytes: 640 << 20, PIDs: 231},
+		},
+		{
+			name:     "decimal units",
+			line:     `{"CPUPerc":"0.00%","MemUsage":"1.5GB / 16GB","Name":"kind-worker","PIDs":"--"}`,
+			expected: NodeUsage{Name: "kind-worker", MemoryBytes: 1500000000},
+		},
+		{
+			name:          "invalid memory",
+			line:          `{"CPUPerc":"1.00%","MemUsage":"-- / --","Name":"kind-worker"}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid json",
+			line:          `kind-worker 1.00%`,
+			expectedError: true,
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			u, err := parseDockerStats(rt.line)
+			if (err != nil) != rt.expectedError {
+				t.Fatalf("expected error %t, got %v", rt.expectedError, err)
+			}
+			if !rt.expectedError && !reflect.DeepEqual(u, rt.expected) {
+				t.Errorf("expected %+v, got %+v", rt.expected, u)
+			}
+		})
+	}
+}
+
+func TestOversubscription(t *testing.T) {
+	host := HostCapacity{CPUs: 2, MemoryBytes: 4 << 30}
+
+	var tests = []struct {
+		name     string
+		usage    []NodeUsage
+		expected []string
+	}{
+		{
+			name:     "host not oversubscribed",
+			usage:    []NodeUsage{{CPUPercent: 80, MemoryBytes: 1 << 30}, {CPUPercent: 60, MemoryBytes: 1 << 30}},
+			expected: []string{},
+		},
+		{
+			name:  "CPUs and memory oversubscribed",
+			usage: []NodeUsage{{CPUPercent: 120, MemoryBytes: 2 << 30}, {CPUPercent: 70, MemoryBytes: 2 << 30}},
+			expected: []string{
+				"node containers are using 95% of the 2 CPUs of the host",
+				"node containers are using 4.0GiB of the 4.0GiB of memory of the host",
+			},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			if warnings := oversubscription(rt.usage, host); !reflect.DeepEqual(warnings, rt.expected) {
+				t.Errorf("expected %v, got %v", rt.expected, warnings)
+			}
+		})
+	}
+}
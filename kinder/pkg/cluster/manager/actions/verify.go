@@ -0,0 +1,342 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// VerifySpec defines the expected state of a cluster, as checked by the verify action
+type VerifySpec struct {
+	// Nodes defines assertions on Kubernetes Node objects
+	Nodes []NodeAssertion `json:"nodes,omitempty"`
+
+	// Pods defines assertions on the images used by Pods
+	Pods []PodAssertion `json:"pods,omitempty"`
+
+	// ConfigMaps defines assertions on ConfigMap contents
+	ConfigMaps []ConfigMapAssertion `json:"configMaps,omitempty"`
+
+	// Certificates defines assertions on certificate files stored on nodes
+	Certificates []CertificateAssertion `json:"certificates,omitempty"`
+
+	// StaticPods defines assertions on the flags of static pod manifests stored on nodes
+	StaticPods []StaticPodAssertion `json:"staticPods,omitempty"`
+}
+
+// NodeAssertion checks the kubelet version reported by the selected nodes
+type NodeAssertion struct {
+	// Selector is a kinder node selector, e.g. @all, @cp*, worker1; defaults to @all
+	Selector       string `json:"selector,omitempty"`
+	KubeletVersion string `json:"kubeletVersion"`
+}
+
+// PodAssertion checks that all the Pods matching a label selector use the given image
+type PodAssertion struct {
+	Namespace string `json:"namespace,omitempty"`
+	Selector  string `json:"selector"`
+	Image     string `json:"image"`
+}
+
+// ConfigMapAssertion checks that a key of a ConfigMap contains all the given strings
+type ConfigMapAssertion struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Key       string   `json:"key"`
+	Contains  []string `json:"contains"`
+}
+
+// CertificateAssertion checks that a certificate on the selected nodes includes the given SANs
+type CertificateAssertion struct {
+	// Selector is a kinder node selector, e.g. @all, @cp*, worker1; defaults to @cp*
+	Selector string   `json:"selector,omitempty"`
+	Path     string   `json:"path"`
+	SANs     []string `json:"sans"`
+}
+
+// StaticPodAssertion checks that the static pod manifest for a component on the selected nodes
+// includes the given command flags
+type StaticPodAssertion struct {
+	// Selector is a kinder node selector, e.g. @all, @cp*, worker1; defaults to @cp*
+	Selector  string   `json:"selector,omitempty"`
+	Component string   `json:"component"`
+	Flags     []string `json:"flags"`
+}
+
+// NewVerifySpec reads a VerifySpec from a YAML file
+func NewVerifySpec(file string) (*VerifySpec, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read verify spec %s", file)
+	}
+
+	spec := &VerifySpec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse verify spec %s", file)
+	}
+	return spec, nil
+}
+
+// Verify asserts the expected state defined in a VerifySpec file against the live cluster.
+// All the assertions are executed, and failures are reported together at the end.
+func Verify(c *status.Cluster, specFile string) error {
+	if specFile == "" {
+		return errors.New("the verify action requires a spec file; use --verify-spec")
+	}
+
+	spec, err := NewVerifySpec(specFile)
+	if err != nil {
+		return err
+	}
+
+	cp1 := c.BootstrapControlPlane()
+	failures := []string{}
+	report := func(err error) {
+		if err != nil {
+			fmt.Printf("FAIL: %v\n", err)
+			failures = append(failures, err.Error())
+		}
+	}
+
+	for _, a := range spec.Nodes {
+		nodes, err := selectVerifyNodes(c, a.Selector, "@all")
+		if err != nil {
+			report(err)
+			continue
+		}
+		for _, n := range nodes {
+			report(verifyNodeVersion(cp1, n, a))
+		}
+	}
+
+	for _, a := range spec.Pods {
+		report(verifyPodImage(cp1, a))
+	}
+
+	for _, a := range spec.ConfigMaps {
+		report(verifyConfigMap(cp1, a))
+	}
+
+	for _, a := range spec.Certificates {
+		nodes, err := selectVerifyNodes(c, a.Selector, "@cp*")
+		if err != nil {
+			report(err)
+			continue
+		}
+		for _, n := range nodes {
+			report(verifyCertificate(n, a))
+		}
+	}
+
+	for _, a := range spec.StaticPods {
+		nodes, err := selectVerifyNodes(c, a.Selector, "@cp*")
+		if err != nil {
+			report(err)
+			continue
+		}
+		for _, n := range nodes {
+			report(verifyStaticPod(n, a))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("%d assertions failed:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+
+	fmt.Printf("\nAll the assertions passed!\n")
+	return nil
+}
+
+func selectVerifyNodes(c *status.Cluster, selector, defaultSelector string) (status.NodeList, error) {
+	if selector == "" {
+		selector = defaultSelector
+	}
+	nodes, err := c.SelectNodes(selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, errors.Errorf("no nodes matching selector %q", selector)
+	}
+	return nodes, nil
+}
+
+func verifyNodeVersion(cp1 *status.Node, n *status.Node, a NodeAssertion) error {
+	cp1.Infof("verify node %s has kubelet version %s", n.Name(), a.KubeletVersion)
+
+	version := kubectlOutput(cp1,
+		"get", "node", n.Name(),
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"-o=jsonpath='{.status.nodeInfo.kubeletVersion}'",
+	)
+	version = strings.Trim(version, "'")
+	if version != a.KubeletVersion {
+		return errors.Errorf("node %s: expected kubelet version %q, found %q", n.Name(), a.KubeletVersion, version)
+	}
+	return nil
+}
+
+func verifyPodImage(cp1 *status.Node, a PodAssertion) error {
+	namespace := a.Namespace
+	if namespace == "" {
+		namespace = "kube-system"
+	}
+	cp1.Infof("verify pods %s/%s use image %s", namespace, a.Selector, a.Image)
+
+	lines, err := cp1.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"get", "pods", "-n", namespace, "-l", a.Selector,
+		"-o=jsonpath={range .items[*]}{.metadata.name}{\" \"}{range .spec.containers[*]}{.image}{\" \"}{end}{\"\\n\"}{end}",
+	).Silent().RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "pods %s/%s: failed to get pods", namespace, a.Selector)
+	}
+	if len(lines) == 0 {
+		return errors.Errorf("pods %s/%s: no pods found", namespace, a.Selector)
+	}
+
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) == 0 {
+			continue
+		}
+		found := false
+		for _, image := range fields[1:] {
+			if image == a.Image {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("pod %s/%s: expected image %q, found %v", namespace, fields[0], a.Image, fields[1:])
+		}
+	}
+	return nil
+}
+
+func verifyConfigMap(cp1 *status.Node, a ConfigMapAssertion) error {
+	namespace := a.Namespace
+	if namespace == "" {
+		namespace = "kube-system"
+	}
+	cp1.Infof("verify configmap %s/%s key %s", namespace, a.Name, a.Key)
+
+	data, err := getConfigMapData(cp1, namespace, a.Name, a.Key)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range a.Contains {
+		if !strings.Contains(data, s) {
+			return errors.Errorf("configmap %s/%s: key %s does not contain %q", namespace, a.Name, a.Key, s)
+		}
+	}
+	return nil
+}
+
+func verifyCertificate(n *status.Node, a CertificateAssertion) error {
+	n.Infof("verify certificate %s SANs", a.Path)
+
+	lines, err := n.Command("cat", a.Path).Silent().RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "node %s: failed to read certificate %s", n.Name(), a.Path)
+	}
+
+	block, _ := pem.Decode([]byte(strings.Join(lines, "\n")))
+	if block == nil {
+		return errors.Errorf("node %s: failed to decode certificate %s", n.Name(), a.Path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrapf(err, "node %s: failed to parse certificate %s", n.Name(), a.Path)
+	}
+
+	sans := map[string]bool{}
+	for _, dns := range cert.DNSNames {
+		sans[dns] = true
+	}
+	for _, ip := range cert.IPAddresses {
+		sans[ip.String()] = true
+	}
+
+	for _, san := range a.SANs {
+		if !sans[san] {
+			return errors.Errorf("node %s: certificate %s does not include SAN %q", n.Name(), a.Path, san)
+		}
+	}
+	return nil
+}
+
+// staticPodManifest is the subset of a static pod manifest used for verifying flags
+type staticPodManifest struct {
+	Spec struct {
+		Containers []struct {
+			Command []string `json:"command"`
+		} `json:"containers"`
+	} `json:"spec"`
+}
+
+func verifyStaticPod(n *status.Node, a StaticPodAssertion) error {
+	path := fmt.Sprintf("/etc/kubernetes/manifests/%s.yaml", a.Component)
+	n.Infof("verify static pod %s flags", path)
+
+	lines, err := n.Command("cat", path).Silent().RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "node %s: failed to read static pod manifest %s", n.Name(), path)
+	}
+
+	manifest := &staticPodManifest{}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), manifest); err != nil {
+		return errors.Wrapf(err, "node %s: failed to parse static pod manifest %s", n.Name(), path)
+	}
+
+	flags := map[string]bool{}
+	for _, c := range manifest.Spec.Containers {
+		for _, f := range c.Command {
+			flags[f] = true
+		}
+	}
+
+	for _, f := range a.Flags {
+		if !flags[f] {
+			return errors.Errorf("node %s: static pod %s does not include flag %q", n.Name(), a.Component, f)
+		}
+	}
+	return nil
+}
+
+// getConfigMapData returns the value of a key in a ConfigMap
+func getConfigMapData(n *status.Node, namespace, name, key string) (string, error) {
+	lines, err := n.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"get", "configmap", name, "-n", namespace,
+		fmt.Sprintf("-o=jsonpath={.data.%s}", strings.ReplaceAll(key, ".", "\\.")),
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get configmap %s/%s", namespace, name)
+	}
+	return strings.Join(lines, "\n"), nil
+}
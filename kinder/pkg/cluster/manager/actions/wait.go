@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+const (
+	// WaitNodesReady waits for all the Kubernetes nodes to be Ready
+	WaitNodesReady = "nodes-ready"
+	// WaitPodsReady waits for all the pods in all the namespaces to be Ready, or completed
+	WaitPodsReady = "pods-ready"
+
+	waitConditionPrefix = "condition="
+)
+
+// waitCondition is a condition parsed from the --for flag of kinder wait
+type waitCondition struct {
+	// kind is nodes-ready, pods-ready or condition
+	kind string
+	// conditionType and conditionStatus are the condition of the resources to wait for, when kind is condition
+	conditionType   string
+	conditionStatus string
+}
+
+// parseWaitCondition parses nodes-ready, pods-ready, or condition=<type>[=<status>], where status defaults to True
+func parseWaitCondition(s string) (waitCondition, error) {
+	switch {
+	case s == WaitNodesReady || s == WaitPodsReady:
+		return waitCondition{kind: s}, nil
+	case strings.HasPrefix(s, waitConditionPrefix):
+		t := strings.SplitN(strings.TrimPrefix(s, waitConditionPrefix), "=", 2)
+		if t[0] == "" {
+			return waitCondition{}, errors.Errorf("invalid condition %q: the condition type is missing", s)
+		}
+		c := waitCondition{kind: "condition", conditionType: t[0], conditionStatus: "True"}
+		if len(t) == 2 {
+			c.conditionStatus = t[1]
+		}
+		return c, nil
+	default:
+		return waitCondition{}, errors.Errorf("invalid condition %q; use one of [%s, %s, condition=<type>[=<status>]]", s, WaitNodesReady, WaitPodsReady)
+	}
+}
+
+// WaitForConditions blocks until all the conditions hold, or until the timeout expires. Conditions can be nodes-ready,
+// pods-ready, or condition=<type>[=<status>] for waiting on a condition of the given resources, e.g.
+// deployment/coredns, in the given namespace; resources that don't exist yet are waited for
+func WaitForConditions(c *status.Cluster, conditions, resources []string, namespace string, timeout time.Duration) error {
+	if len(conditions) == 0 {
+		return errors.New("at least one condition is required; use --for")
+	}
+	// nb. waitFor skips the wait when the timeout is zero, that would report conditions not checked at all as satisfied
+	if timeout <= 0 {
+		return errors.Errorf("invalid timeout %s; use a --timeout greater than zero", timeout)
+	}
+	cp1 := c.BootstrapControlPlane()
+	if cp1 == nil {
+		return errors.New("the cluster does not have a control-plane node")
+	}
+
+	// names of the conditions not satisfied yet, for reporting them on timeout
+	var mu sync.Mutex
+	pending := map[string]bool{}
+	var tries []try
+	add := func(name string, t try) {
+		pending[name] = true
+		tries = append(tries, func(c *status.Cluster, n *status.Node) bool {
+			if !t(c, n) {
+				return false
+			}
+			mu.Lock()
+			delete(pending, name)
+			mu.Unlock()
+			return true
+		})
+	}
+
+	for _, s := range conditions {
+		wc, err := parseWaitCondition(s)
+		if err != nil {
+			return err
+		}
+		switch wc.kind {
+		case WaitNodesReady:
+			for _, n := range c.K8sNodes() {
+				node := n
+				add(fmt.Sprintf("node %s Ready", node.Name()), func(c *status.Cluster, _ *status.Node) bool {
+					return nodeIsReady(c, node)
+				})
+			}
+		case WaitPodsReady:
+			add("pods Ready", podsAreReady)
+		default:
+			if len(resources) == 0 {
+				return errors.Errorf("%s requires the resources to wait for, e.g. deployment/coredns", s)
+			}
+			for _, r := range resources {
+				add(fmt.Sprintf("%s %s=%s", r, wc.conditionType, wc.conditionStatus), resourceHasCondition(r, namespace, wc.conditionType, wc.conditionStatus))
+			}
+		}
+	}
+
+	cp1.Infof("waiting for %s (timeout %s)", strings.Join(conditions, ", "), timeout)
+	if pass := waitFor(c, cp1, timeout, tries...); !pass {
+		mu.Lock()
+		names := []string{}
+		for name := range pending {
+			names = append(names, name)
+		}
+		mu.Unlock()
+		sort.Strings(names)
+		return errors.Errorf("timeout: conditions not satisfied: %s", strings.Join(names, ", "))
+	}
+	fmt.Println()
+	return nil
+}
+
+// podsAreReady implement a function that test when all the pods in all the namespaces are Ready, or completed
+func podsAreReady(c *status.Cluster, n *status.Node) bool {
+	lines, err := c.BootstrapControlPlane().Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "get", "pods", "-A",
+		`-o=jsonpath={range .items[*]}{.metadata.namespace}/{.metadata.name}{" "}{.status.phase}{" "}{.status.conditions[?(@.type=="Ready")].status}{"\n"}{end}`,
+	).Silent().ReadOnly().RunAndCapture()
+	if err != nil || len(lines) == 0 {
+		return false
+	}
+	if len(podsNotReady(lines)) > 0 {
+		return false
+	}
+	fmt.Printf("%d pods are ready\n", len(lines))
+	return true
+}
+
+// podsNotReady returns the pods that are not Ready nor completed, from lines in the format "<pod> <phase> <ready>"
+func podsNotReady(lines []string) []string {
+	notReady := []string{}
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) >= 2 && fields[1] == "Succeeded" {
+			continue
+		}
+		if len(fields) == 3 && fields[2] == "True" {
+			continue
+		}
+		notReady = append(notReady, fields[0])
+	}
+	return notReady
+}
+
+// resourceHasCondition implement a function that test when a resource has a condition with the given status
+func resourceHasCondition(resource, namespace, conditionType, conditionStatus string) try {
+	return func(c *status.Cluster, n *status.Node) bool {
+		args := []string{"--kubeconfig=/etc/kubernetes/admin.conf", "get", resource,
+			fmt.Sprintf("-o=jsonpath={.status.conditions[?(@.type==%q)].status}", conditionType),
+		}
+		if namespace != "" {
+			args = append(args, "-n", namespace)
+		}
+		if kubectlOutput(c.BootstrapControlPlane(), args...) != conditionStatus {
+			return false
+		}
+		fmt.Printf("%s has condition %s=%s\n", resource, conditionType, conditionStatus)
+		return true
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	var tests = []struct {
+		condition     string
+		expected      waitCondition
+		expectedError bool
+	}{
+		{condition: "nodes-ready", expected: waitCondition{kind: WaitNodesReady}},
+		{condition: "pods-ready", expected: waitCondition{kind: WaitPodsReady}},
+		{condition: "condition=Available", expected: waitCondition{kind: "condition", conditionType: "Available", conditionStatus: "True"}},
+		{condition: "condition=Progressing=False", expected: waitCondition{kind: "condition", conditionType: "Progressing", conditionStatus: "False"}},
+		{condition: "condition=", expectedError: true},
+		{condition: "nodes", expectedError: true},
+	}
+	for _, rt := range tests {
+		t.Run(rt.condition, func(t *testing.T) {
+			c, err := parseWaitCondition(rt.condition)
+			if (err != nil) != rt.expectedError {
+				t.Fatalf("expected error %t, got %v", rt.expectedError, err)
+			}
+			if c != rt.expected {
+				t.Errorf("expected %+v, got %+v", rt.expected, c)
+			}
+		})
+	}
+}
+
+func TestPodsNotReady(t *testing.T) {
+	lines := []string{
+		"kube-system/coredns-1 Running True",
+		"kube-system/coredns-2 Running False",
+		"kube-system/kube-proxy-1 Pending",
+		"default/job-1 Succeeded False",
+	}
+	expected := []string{"kube-system/coredns-2", "kube-system/kube-proxy-1"}
+	if notReady := podsNotReady(lines); !reflect.DeepEqual(notReady, expected) {
+		t.Errorf("expected %v, got %v", expected, notReady)
+	}
+}
+
+func TestWaitForConditionsTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		t.Run(timeout.String(), func(t *testing.T) {
+			if err := WaitForConditions(nil, []string{WaitNodesReady}, nil, "", timeout); err == nil {
+				t.Errorf("expected an error for timeout %s", timeout)
+			}
+		})
+	}
+}
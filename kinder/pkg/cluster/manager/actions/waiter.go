@@ -365,7 +365,7 @@ func kubectlOutput(n *status.Node, args ...string) string {
 	lines, err := n.Command(
 		"kubectl",
 		args...,
-	).Silent().RunAndCapture()
+	).Silent().ReadOnly().RunAndCapture()
 	if err != nil {
 		return ""
 	}
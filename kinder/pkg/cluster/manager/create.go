@@ -43,6 +43,13 @@ type CreateOptions struct {
 	externalEtcd         bool
 	retain               bool
 	volumes              []string
+	labels               []string
+
+	// signature verification of the image
+	verifySignature   bool
+	signatureKey      string
+	signatureIdentity string
+	signatureIssuer   string
 }
 
 // CreateOption is a configuration option supplied to Create
@@ -99,6 +106,23 @@ func Volumes(volumes []string) CreateOption {
 	}
 }
 
+// Labels option instructs create cluster to add labels, in the key=value format, to the node containers,
+// e.g. for selecting the clusters to delete with kinder delete cluster --selector
+func Labels(labels []string) CreateOption {
+	return func(c *CreateOptions) {
+		c.labels = labels
+	}
+}
+
+// VerifyImageSignature option instructs create cluster to verify the cosign signature of the image before
+// using it; key can be a path or a KMS URI, otherwise identity and issuer are used for keyless signatures
+func VerifyImageSignature(enable bool, key, identity, issuer string) CreateOption {
+	return func(c *CreateOptions) {
+		c.verifySignature = enable
+		c.signatureKey, c.signatureIdentity, c.signatureIssuer = key, identity, issuer
+	}
+}
+
 // CreateCluster creates a new kinder cluster
 func CreateCluster(clusterName string, options ...CreateOption) error {
 	flags := &CreateOptions{}
@@ -115,12 +139,26 @@ func CreateCluster(clusterName string, options ...CreateOption) error {
 		return errors.Errorf("a cluster with the name %q already exists", clusterName)
 	}
 
+	for _, l := range flags.labels {
+		key, _, _ := strings.Cut(l, "=")
+		if key == "" || strings.HasPrefix(key, "io.x-k8s.kind.") || strings.HasPrefix(key, "io.k8s.sigs.kind.") {
+			return errors.Errorf("invalid label %q: the key must not be empty nor use the kind label prefixes", l)
+		}
+	}
+
 	fmt.Printf("Creating cluster %q ...\n", clusterName)
 
 	// attempt to explicitly pull the required node image if it doesn't exist locally
 	// we don't care if this errors, we'll still try to run which also pulls
 	ensureNodeImage(flags.image)
 
+	if flags.verifySignature {
+		fmt.Printf("Verifying the signature of the node image (%s) 🔏\n", flags.image)
+		if err := host.VerifyImageSignature(flags.image, flags.signatureKey, flags.signatureIdentity, flags.signatureIssuer); err != nil {
+			return errors.Wrap(err, "the node image can't be used")
+		}
+	}
+
 	handleErr := func(err error) error {
 		// In case of errors nodes are deleted (except if retain is explicitly set)
 		if !flags.retain {
@@ -176,7 +214,7 @@ func createNodes(clusterName string, flags *CreateOptions) error {
 	}
 	log.Infof("Detected %s container runtime for image %s", runtime, flags.image)
 
-	createHelper, err := nodes.NewCreateHelper(runtime)
+	createHelper, err := nodes.NewCreateHelper(runtime, flags.labels)
 	if err != nil {
 		log.Errorf("Error creating NewCreateHelper for CRI %s! %v", flags.image, err)
 		return err
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	kindcluster "sigs.k8s.io/kind/pkg/cluster"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// SelectClusters returns the names of the clusters with nodes matching all the label selectors, e.g. key or
+// key=value, and created more than olderThan ago; olderThan equal to zero selects clusters of any age
+func SelectClusters(selectors []string, olderThan time.Duration) ([]string, error) {
+	clusters, err := status.ListClustersInfo(selectors...)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, c := range clusters {
+		if olderThan > 0 && time.Since(c.Created) < olderThan {
+			log.Debugf("Skipping cluster %s created %s ago", c.Name, time.Since(c.Created).Round(time.Second))
+			continue
+		}
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
+
+// DeleteCluster deletes the node containers of a cluster, its context in the kubeconfig file and the kubeconfig
+// file written by kinder; like rm -f, deleting a cluster that does not exist is not an error
+func DeleteCluster(name, kubeconfig string) error {
+	if err := kindcluster.NewProvider().Delete(name, kubeconfig); err != nil {
+		return errors.Wrapf(err, "failed to delete cluster %q", name)
+	}
+	if err := os.Remove(status.KubeConfigPath(name)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove the kubeconfig file of cluster %q", name)
+	}
+	return nil
+}
@@ -17,7 +17,13 @@ limitations under the License.
 package manager
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -30,6 +36,7 @@ import (
 // ClusterManager manages kind(er) clusters
 type ClusterManager struct {
 	*status.Cluster
+	dryRun bool
 }
 
 // NewClusterManager returns a new cluster manager ready to manage
@@ -67,24 +74,53 @@ func NewClusterManager(clusterName string) (c *ClusterManager, err error) {
 
 // DryRun instruct the cluster manager to dry run commands (without actually running them)
 func (c *ClusterManager) DryRun() {
+	c.dryRun = true
 	for _, n := range c.Cluster.AllNodes() {
 		n.DryRun()
 	}
 }
 
-// OnlyNode instruct the cluster manager to run only commands on one node
-func (c *ClusterManager) OnlyNode(node string) error {
-	found := false
+// RecordCommands instruct the cluster manager to record the result of the commands executed on nodes
+func (c *ClusterManager) RecordCommands(r *exec.Recorder) {
 	for _, n := range c.Cluster.AllNodes() {
-		if n.Name() == node {
-			log.Infof("Found matching node for --only-node: %s", node)
-			found = true
-			continue
+		n.RecordCommands(r)
+	}
+}
+
+// OnlyNode instruct the cluster manager to run only commands on the nodes matching a node selector;
+// see actions.SelectNodes for the selector syntax
+func (c *ClusterManager) OnlyNode(nodeSelector string) error {
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
+	if err != nil {
+		return errors.Wrap(err, "invalid --only-node")
+	}
+	if len(nodes) == 0 {
+		return errors.Errorf("did not find a matching node for --only-node: %s", nodeSelector)
+	}
+
+	selected := map[string]bool{}
+	for _, n := range nodes {
+		log.Infof("Found matching node for --only-node: %s", n.Name())
+		selected[n.Name()] = true
+	}
+	for _, n := range c.Cluster.AllNodes() {
+		if !selected[n.Name()] {
+			n.SkipActions()
 		}
-		n.SkipActions()
 	}
-	if !found {
-		return errors.Errorf("did not find a matching node for --only-node: %s", node)
+	return nil
+}
+
+// SkipNode instruct the cluster manager to not run commands on the nodes matching a node selector;
+// see actions.SelectNodes for the selector syntax
+func (c *ClusterManager) SkipNode(nodeSelector string) error {
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
+	if err != nil {
+		return errors.Wrap(err, "invalid --skip-node")
+	}
+	for _, n := range nodes {
+		log.Infof("Skipping node matching --skip-node: %s", n.Name())
+		n.SkipActions()
 	}
 	return nil
 }
@@ -94,34 +130,123 @@ func (c *ClusterManager) OnlyNode(node string) error {
 // by one or more lower level commands
 func (c *ClusterManager) DoAction(action string, options ...actions.Option) error {
 	log.Infof("Running action %s...", action)
+	if c.dryRun {
+		// prints the node targets, so it is possible to check the effect of e.g. --only-node or --skip-node
+		targets := []string{}
+		for _, n := range c.Cluster.AllNodes().EligibleForActions() {
+			targets = append(targets, fmt.Sprintf("%s (%s)", n.Name(), n.Role()))
+		}
+		log.Infof("Dry running action %s; nodes eligible for the action: %s", action, strings.Join(targets, ", "))
+	}
 	return actions.Run(c.Cluster, action, options...)
 }
 
-// ExecCommand is a topology aware wrapper of docker exec
-func (c *ClusterManager) ExecCommand(nodeSelector string, args []string) error {
-	nodes, err := c.SelectNodes(nodeSelector)
+// ExecCommand is a topology aware wrapper of docker exec; see actions.SelectNodes for the selector syntax.
+// With parallel, the command is executed on all the nodes at the same time, and each line of the output
+// is prefixed with the node name; the command is executed on all the nodes also in case of failures,
+// and failures are reported together at the end
+func (c *ClusterManager) ExecCommand(nodeSelector string, args []string, parallel bool) error {
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
 	if err != nil {
 		return err
 	}
+	if len(nodes) == 0 {
+		return errors.Errorf("no nodes matching selector %q", nodeSelector)
+	}
 
 	log.Infof("%d nodes selected as target for the command", len(nodes))
-	for _, node := range nodes {
-		fmt.Printf("🚀 Executing command on node %s 🚀\n", node.Name())
+	if !parallel {
+		for _, node := range nodes {
+			fmt.Printf("🚀 Executing command on node %s 🚀\n", node.Name())
 
-		cmdArgs := append([]string{"exec",
-			node.Name(),
-		}, args...)
+			cmdArgs := append([]string{"exec",
+				node.Name(),
+			}, args...)
 
-		err := exec.NewHostCmd("docker", cmdArgs...).RunWithEcho()
-		if err != nil {
-			return errors.Wrapf(err, "failed to execute command on node %s", node.Name())
+			err := exec.NewHostCmd("docker", cmdArgs...).RunWithEcho()
+			if err != nil {
+				return errors.Wrapf(err, "failed to execute command on node %s", node.Name())
+			}
 		}
+		return nil
 	}
 
+	width := 0
+	for _, n := range nodes {
+		if len(n.Name()) > width {
+			width = len(n.Name())
+		}
+	}
+
+	// lines from different nodes are written one at a time, so they are never interleaved
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(nodes))
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *status.Node) {
+			defer wg.Done()
+			w := &prefixWriter{out: os.Stdout, mu: &mu, prefix: fmt.Sprintf("[%-*s] ", width, n.Name())}
+			cmdArgs := append([]string{"exec", n.Name()}, args...)
+			errs[i] = exec.NewHostCmd("docker", cmdArgs...).RunWithOutput(w)
+			w.Flush()
+		}(i, n)
+	}
+	wg.Wait()
+
+	failures := []string{}
+	for i, n := range nodes {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", n.Name(), errs[i]))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("command failed on %d of %d nodes:\n%s", len(failures), len(nodes), strings.Join(failures, "\n"))
+	}
 	return nil
 }
 
-// CopyFile is a topology aware wrapper of docker cp
+// prefixWriter writes each line with a prefix; incomplete lines are buffered until completed, or until Flush
+type prefixWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the incomplete line, if any
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		_ = w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
+
+// CopyFile is a topology aware wrapper of docker cp; source and target can be files or folders, and
+// the source can be a glob pattern, e.g. /var/log/*.log. When copying from many nodes, files are copied
+// into a subfolder of the target for each node, e.g. target/kind-control-plane/
 func (c *ClusterManager) CopyFile(source, target string) error {
 	sourceNodes, sourcePath, err := c.ResolveNodesPath(source)
 	if err != nil {
@@ -137,15 +262,8 @@ func (c *ClusterManager) CopyFile(source, target string) error {
 		return errors.Errorf("at least one between source and target must be a node/nodes in the cluster")
 	}
 
-	if sourceNodes != nil {
-		switch len(sourceNodes) {
-		case 1:
-			break // one source node selected: continue
-		case 0:
-			return errors.Errorf("no source node matches given criteria")
-		default:
-			return errors.Errorf("source can't be more than one node")
-		}
+	if sourceNodes != nil && len(sourceNodes) == 0 {
+		return errors.Errorf("no source node matches given criteria")
 	}
 
 	if targetNodes != nil && len(targetNodes) == 0 {
@@ -159,17 +277,92 @@ func (c *ClusterManager) CopyFile(source, target string) error {
 	}
 
 	if targetNodes == nil {
-		fmt.Printf("Copying from %s ...\n", sourceNodes[0].Name())
-		if err := sourceNodes[0].CopyFrom(sourcePath, targetPath); err != nil {
+		return copyFromNodes(sourceNodes, sourcePath, targetPath)
+	}
+	return copyToNodes(sourcePath, targetNodes, targetPath)
+}
+
+// copyFromNodes copies files from nodes to the host; with many nodes, or when the source pattern matches many
+// files, the target is a folder
+func copyFromNodes(nodes status.NodeList, source, target string) error {
+	fanIn := len(nodes) > 1
+	if fanIn && target == "-" {
+		return errors.New("can't copy from many nodes to stdout")
+	}
+
+	for _, n := range nodes {
+		paths, err := nodeGlob(n, source)
+		if err != nil {
 			return err
 		}
+
+		dest := target
+		if fanIn {
+			dest = filepath.Join(target, n.Name())
+		}
+		if fanIn || len(paths) > 1 {
+			if target == "-" {
+				return errors.Errorf("can't copy many files to stdout: %s matches %d files", source, len(paths))
+			}
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				return errors.Wrapf(err, "failed to create %s", dest)
+			}
+		}
+
+		fmt.Printf("Copying from %s ...\n", n.Name())
+		for _, p := range paths {
+			if err := n.CopyFrom(p, dest); err != nil {
+				return errors.Wrapf(err, "failed to copy %s from node %s", p, n.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// copyToNodes copies files from the host to nodes; when the source pattern matches many files,
+// the target is a folder, that is created on nodes if missing
+func copyToNodes(source string, nodes status.NodeList, target string) error {
+	paths := []string{source}
+	if hasGlob(source) {
+		matches, err := filepath.Glob(source)
+		if err != nil {
+			return errors.Wrapf(err, "invalid pattern %s", source)
+		}
+		if len(matches) == 0 {
+			return errors.Errorf("no files match %s", source)
+		}
+		paths = matches
 	}
 
-	for _, n := range targetNodes {
+	for _, n := range nodes {
 		fmt.Printf("Copying to %s ...\n", n.Name())
-		if err := n.CopyTo(sourcePath, targetPath); err != nil {
-			return err
+		if len(paths) > 1 {
+			if err := n.Command("mkdir", "-p", target).Silent().Run(); err != nil {
+				return errors.Wrapf(err, "failed to create %s on node %s", target, n.Name())
+			}
+		}
+		for _, p := range paths {
+			if err := n.CopyTo(p, target); err != nil {
+				return errors.Wrapf(err, "failed to copy %s to node %s", p, n.Name())
+			}
 		}
 	}
 	return nil
 }
+
+// nodeGlob returns the files on a node matching a path, that can be a glob pattern
+func nodeGlob(n *status.Node, path string) ([]string, error) {
+	if !hasGlob(path) {
+		return []string{path}, nil
+	}
+	// the pattern is expanded by the shell on the node; paths are relative to the root, like for docker cp
+	lines, err := n.Command("sh", "-c", "cd / && ls -d "+path).Silent().ReadOnly().RunAndCapture()
+	if err != nil || len(lines) == 0 {
+		return nil, errors.Errorf("no files match %s on node %s", path, n.Name())
+	}
+	return lines, nil
+}
+
+func hasGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var tests = []struct {
+		name     string
+		writes   []string
+		expected string
+	}{
+		{name: "complete lines", writes: []string{"a\nb\n"}, expected: "[n] a\n[n] b\n"},
+		{name: "lines split across writes", writes: []string{"a", "b\nc", "\n"}, expected: "[n] ab\n[n] c\n"},
+		{name: "incomplete line flushed", writes: []string{"a\nb"}, expected: "[n] a\n[n] b\n"},
+		{name: "empty lines", writes: []string{"\n\n"}, expected: "[n] \n[n] \n"},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			w := &prefixWriter{out: out, mu: &sync.Mutex{}, prefix: "[n] "}
+			for _, s := range rt.writes {
+				if n, err := w.Write([]byte(s)); err != nil || n != len(s) {
+					t.Fatalf("unexpected write result %d, %v", n, err)
+				}
+			}
+			w.Flush()
+			if out.String() != rt.expected {
+				t.Errorf("expected %q, got %q", rt.expected, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// patchableBinaries are the binaries that can be replaced on running nodes, with the args
+// for printing their version, that is used for checking the binaries work on the nodes
+var patchableBinaries = map[string][]string{
+	"kubeadm": {"version", "-o", "short"},
+	"kubelet": {"--version"},
+	"kubectl": {"version", "--client"},
+}
+
+// PatchBinaries replaces the kubeadm, kubelet or kubectl binaries on the running nodes matching a node
+// selector with binaries on the host, and restarts the kubelet if replaced; binaries are identified by
+// file name. See actions.SelectNodes for the selector syntax
+func (c *ClusterManager) PatchBinaries(nodeSelector string, binaries []string, wait time.Duration) error {
+	if len(binaries) == 0 {
+		return errors.New("at least one binary must be set")
+	}
+	for _, b := range binaries {
+		name := filepath.Base(b)
+		if _, ok := patchableBinaries[name]; !ok {
+			return errors.Errorf("unsupported binary %s, only kubeadm, kubelet and kubectl binaries can be replaced", b)
+		}
+		info, err := os.Stat(b)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", b)
+		}
+		if info.IsDir() || info.Mode().Perm()&0111 == 0 {
+			return errors.Errorf("%s is not an executable file", b)
+		}
+	}
+
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
+	if err != nil {
+		return err
+	}
+	var targets status.NodeList
+	for _, n := range nodes {
+		if n.IsExternalEtcd() || n.IsExternalLoadBalancer() {
+			log.Warnf("Skipping node %s, that is not a Kubernetes node", n.Name())
+			continue
+		}
+		targets = append(targets, n)
+	}
+	if len(targets) == 0 {
+		return errors.Errorf("no Kubernetes nodes matching %q", nodeSelector)
+	}
+
+	log.Infof("%d nodes selected as target for the binaries", len(targets))
+	for _, n := range targets {
+		fmt.Printf("🔧 Patching binaries on node %s 🔧\n", n.Name())
+		restartKubelet := false
+		for _, b := range binaries {
+			name := filepath.Base(b)
+			if err := patchBinary(n, b, name); err != nil {
+				return errors.Wrapf(err, "failed to replace %s on node %s", name, n.Name())
+			}
+			restartKubelet = restartKubelet || name == "kubelet"
+		}
+		if restartKubelet {
+			if err := restartNodeKubelet(n, wait); err != nil {
+				return errors.Wrapf(err, "failed to restart the kubelet on node %s", n.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// patchBinary copies a binary next to the one on the node, and then moves it in place, so binaries
+// of running processes and binaries symlinked to upgrade artifacts are replaced as well
+func patchBinary(n *status.Node, src, name string) error {
+	lines, err := n.Command("sh", "-c", fmt.Sprintf("command -v %s || echo /usr/bin/%[1]s", name)).Silent().RunAndCapture()
+	if err != nil || len(lines) == 0 {
+		return errors.Errorf("failed to find %s on the node", name)
+	}
+	dst := lines[len(lines)-1]
+
+	tmp := dst + ".kinder-new"
+	if err := n.CopyTo(src, tmp); err != nil {
+		return err
+	}
+	if err := n.Command("chmod", "0755", tmp).Silent().Run(); err != nil {
+		return err
+	}
+	// the binary is checked before replacing the existing one, e.g. for binaries built for another architecture
+	version, err := n.Command(tmp, patchableBinaries[name]...).Silent().RunAndCapture()
+	if err != nil {
+		_ = n.Command("rm", "-f", tmp).Silent().Run()
+		return errors.Wrapf(err, "%s does not work on the node", src)
+	}
+	if err := n.Command("mv", "-f", tmp, dst).Silent().Run(); err != nil {
+		return err
+	}
+	n.Infof("%s replaced with %s (%s)", dst, src, strings.Join(version, " "))
+	return nil
+}
+
+// restartNodeKubelet restarts the kubelet and, if the node is already part of a cluster, waits for the kubelet to be healthy
+func restartNodeKubelet(n *status.Node, wait time.Duration) error {
+	if err := n.Command("systemctl", "restart", "kubelet").Silent().Run(); err != nil {
+		return err
+	}
+	// before kubeadm init/join the kubelet has no config and keeps restarting, so there is nothing to wait for
+	if err := n.Command("test", "-f", "/var/lib/kubelet/config.yaml").Silent().Run(); err != nil {
+		n.Infof("kubelet restarted")
+		return nil
+	}
+
+	n.Infof("waiting for the kubelet to be healthy (timeout %s)", wait)
+	deadline := time.Now().Add(wait)
+	for {
+		if err := n.Command("curl", "-sSf", "http://127.0.0.1:10248/healthz").Silent().Run(); err == nil {
+			n.Infof("kubelet restarted and healthy")
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return errors.New("timeout: the kubelet is not healthy")
+		}
+		time.Sleep(time.Second)
+	}
+}
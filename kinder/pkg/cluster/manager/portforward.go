@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	osexec "os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+)
+
+// PortForward forwards ports on the host to ports of a node container until ctx is done; each mapping is
+// [HOST_PORT:]CONTAINER_PORT, and an empty HOST_PORT, e.g. :2381, picks a free port.
+// Connections are proxied with socat via docker exec, so also the ports listening only on the loopback
+// interface of the node, like etcd metrics, can be reached from the host
+func (c *ClusterManager) PortForward(ctx context.Context, nodeSelector, address string, mappings []string) error {
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
+	if err != nil {
+		return err
+	}
+	if len(nodes) != 1 {
+		return errors.Errorf("selector %q matches %d nodes, exactly one node is required", nodeSelector, len(nodes))
+	}
+	n := nodes[0]
+	if err := n.Command("sh", "-c", "command -v socat").Silent().ReadOnly().Run(); err != nil {
+		return errors.Errorf("socat is required in node %s for forwarding ports", n.Name())
+	}
+
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+	for _, m := range mappings {
+		hostPort, containerPort, err := parsePortMapping(m)
+		if err != nil {
+			return err
+		}
+		l, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(hostPort)))
+		if err != nil {
+			return errors.Wrapf(err, "failed to listen on port %d", hostPort)
+		}
+		listeners = append(listeners, l)
+		fmt.Printf("Forwarding from %s -> %s:%d\n", l.Addr(), n.Name(), containerPort)
+		go acceptConnections(ctx, l, n.Name(), containerPort)
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// parsePortMapping parses a [HOST_PORT:]CONTAINER_PORT mapping; the host port is 0 when empty
+func parsePortMapping(m string) (hostPort, containerPort int, err error) {
+	h, p, found := strings.Cut(m, ":")
+	if !found {
+		h, p = m, m
+	}
+	if containerPort, err = strconv.Atoi(p); err != nil || containerPort < 1 || containerPort > 65535 {
+		return 0, 0, errors.Errorf("invalid port mapping %q, expected [HOST_PORT:]CONTAINER_PORT", m)
+	}
+	if h == "" {
+		return 0, containerPort, nil
+	}
+	if hostPort, err = strconv.Atoi(h); err != nil || hostPort < 0 || hostPort > 65535 {
+		return 0, 0, errors.Errorf("invalid port mapping %q, expected [HOST_PORT:]CONTAINER_PORT", m)
+	}
+	return hostPort, containerPort, nil
+}
+
+func acceptConnections(ctx context.Context, l net.Listener, node string, port int) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			// the listener is closed when forwarding stops
+			return
+		}
+		go forwardConnection(ctx, conn, node, port)
+	}
+}
+
+// forwardConnection pipes a connection into socat running in the node, until either side closes it
+func forwardConnection(ctx context.Context, conn net.Conn, node string, port int) {
+	defer conn.Close()
+	log.Debugf("Handling connection from %s to %s:%d", conn.RemoteAddr(), node, port)
+
+	cmd := osexec.CommandContext(ctx, "docker", "exec", "-i", node, "socat", "-", fmt.Sprintf("TCP:127.0.0.1:%d", port))
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		log.Warnf("failed to forward connection to %s:%d: %v", node, port, err)
+		return
+	}
+	var stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = conn, &stderr
+	if err := cmd.Start(); err != nil {
+		log.Warnf("failed to forward connection to %s:%d: %v", node, port, err)
+		return
+	}
+	go func() {
+		_, _ = io.Copy(stdin, conn)
+		stdin.Close()
+	}()
+	if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+		log.Warnf("failed to forward connection to %s:%d: %v %s", node, port, err, strings.TrimSpace(stderr.String()))
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/alter"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// LeakedResource is a docker resource left by an interrupted kinder run
+type LeakedResource struct {
+	// Type is one of container, volume or image
+	Type    string    `json:"type"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	Reason  string    `json:"reason"`
+}
+
+// FindLeakedResources returns the resources identified by the kind and kinder labels that are left by interrupted
+// runs and created more than olderThan ago, so resources in use by runs in progress are not returned:
+//   - the node containers of clusters without a control-plane node, or never started
+//   - the temporary containers, e.g. for altering images
+//   - the volumes of node containers and of temporary containers no longer existing
+//   - the untagged node images, e.g. replaced by a new build with the same name
+func FindLeakedResources(olderThan time.Duration) ([]LeakedResource, error) {
+	clusters, err := status.ListClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []LeakedResource
+	add := func(typ, reason string, lines []string) {
+		resources = append(resources, parseLeakedResources(typ, reason, lines, olderThan)...)
+	}
+
+	for _, name := range clusters {
+		c, err := status.FromDocker(name)
+		if err != nil {
+			return nil, err
+		}
+		if c.BootstrapControlPlane() != nil {
+			continue
+		}
+		lines, err := dockerList("ps", "-a", "--filter", fmt.Sprintf("label=%s=%s", constants.DeprecatedClusterLabelKey, name),
+			"--format", "{{.Names}} {{.CreatedAt}}")
+		if err != nil {
+			return nil, err
+		}
+		add("container", fmt.Sprintf("node of cluster %s, that has no control-plane node", name), lines)
+	}
+
+	lines, err := dockerList("ps", "-a", "--filter", "label="+constants.DeprecatedClusterLabelKey,
+		"--filter", "status=created", "--filter", "status=dead", "--format", "{{.Names}} {{.CreatedAt}}")
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lines {
+		// the nodes of clusters without a control-plane node are already listed
+		if name, _, _ := strings.Cut(l, " "); !containsResource(resources, "container", name) {
+			add("container", "node never started", []string{l})
+		}
+	}
+
+	lines, err = dockerList("ps", "-a", "--filter", "label="+constants.TemporaryLabelKey, "--format", "{{.Names}} {{.CreatedAt}}")
+	if err != nil {
+		return nil, err
+	}
+	add("container", "temporary container", lines)
+
+	for _, label := range []string{constants.ClusterLabelKey, constants.TemporaryLabelKey} {
+		names, err := dockerList("volume", "ls", "-q", "--filter", "dangling=true", "--filter", "label="+label)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			continue
+		}
+		lines, err := dockerList(append([]string{"volume", "inspect", "--format", "{{.Name}} {{.CreatedAt}}"}, names...)...)
+		if err != nil {
+			return nil, err
+		}
+		add("volume", "volume not used by any container", lines)
+	}
+
+	lines, err = dockerList("images", "--filter", "dangling=true", "--filter", "label="+alter.BaseImageLabel,
+		"--format", "{{.ID}} {{.CreatedAt}}")
+	if err != nil {
+		return nil, err
+	}
+	add("image", "untagged node image", lines)
+
+	return resources, nil
+}
+
+// RemoveLeakedResources removes the resources returned by FindLeakedResources; all the resources are removed
+// also in case of failures, and failures are reported together at the end
+func RemoveLeakedResources(resources []LeakedResource) error {
+	// containers are removed first, because they prevent removing their volumes and images
+	failures := []string{}
+	for _, typ := range []string{"container", "volume", "image"} {
+		for _, r := range resources {
+			if r.Type != typ {
+				continue
+			}
+			var args []string
+			switch r.Type {
+			case "container":
+				args = []string{"rm", "-f", "-v", r.Name}
+			case "volume":
+				args = []string{"volume", "rm", r.Name}
+			case "image":
+				args = []string{"rmi", r.Name}
+			}
+			log.Infof("Removing %s %s", r.Type, r.Name)
+			if lines, err := exec.NewHostCmd("docker", args...).RunAndCapture(); err != nil {
+				failures = append(failures, fmt.Sprintf("%s %s: %s", r.Type, r.Name, strings.Join(lines, " ")))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("failed to remove %d of %d resources:\n%s", len(failures), len(resources), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// PrintLeakedResources prints the resources in a human readable format
+func PrintLeakedResources(out io.Writer, resources []LeakedResource) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME\tAGE\tREASON")
+	for _, r := range resources {
+		name := r.Name
+		if r.Type == "volume" && len(name) > 12 {
+			// anonymous volumes are named by a long digest, like IDs
+			name = name[:12]
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Type, name, time.Since(r.Created).Round(time.Minute), r.Reason)
+	}
+	w.Flush()
+}
+
+// parseLeakedResources parses lines in the format "<name> <creation time>", and returns the resources created
+// more than olderThan ago
+func parseLeakedResources(typ, reason string, lines []string, olderThan time.Duration) []LeakedResource {
+	var resources []LeakedResource
+	for _, l := range lines {
+		name, created, ok := strings.Cut(l, " ")
+		if !ok {
+			continue
+		}
+		t, err := parseDockerTime(created)
+		if err != nil {
+			log.Warnf("ignoring %s %s: %v", typ, name, err)
+			continue
+		}
+		if time.Since(t) < olderThan {
+			log.Debugf("ignoring %s %s created %s ago", typ, name, time.Since(t).Round(time.Second))
+			continue
+		}
+		resources = append(resources, LeakedResource{Type: typ, Name: name, Created: t, Reason: reason})
+	}
+	return resources
+}
+
+func containsResource(resources []LeakedResource, typ, name string) bool {
+	for _, r := range resources {
+		if r.Type == typ && r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerList runs a docker command listing resources, and returns the non empty lines of the output
+func dockerList(args ...string) ([]string, error) {
+	lines, err := exec.NewHostCmd("docker", args...).RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run docker %s: %s", strings.Join(args[:2], " "), strings.Join(lines, " "))
+	}
+	var result []string
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" {
+			result = append(result, l)
+		}
+	}
+	return result, nil
+}
+
+// parseDockerTime parses the creation time printed by docker, e.g. 2026-10-14 10:02:03 +0200 CEST in lists,
+// or 2026-10-14T08:02:03Z when inspecting volumes
+func parseDockerTime(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999 -0700 MST", time.RFC3339Nano} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid creation time %q", s)
+}
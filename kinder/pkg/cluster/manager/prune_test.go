@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDockerTime(t *testing.T) {
+	var tests = []struct {
+		created       string
+		expected      time.Time
+		expectedError bool
+	}{
+		{created: "2026-10-14 10:02:03 +0200 CEST", expected: time.Date(2026, 10, 14, 8, 2, 3, 0, time.UTC)},
+		{created: "2026-10-14 08:02:03.123456789 +0000 UTC", expected: time.Date(2026, 10, 14, 8, 2, 3, 123456789, time.UTC)},
+		{created: "2026-10-14T08:02:03Z", expected: time.Date(2026, 10, 14, 8, 2, 3, 0, time.UTC)},
+		{created: "2 hours ago", expectedError: true},
+	}
+	for _, rt := range tests {
+		t.Run(rt.created, func(t *testing.T) {
+			created, err := parseDockerTime(rt.created)
+			if (err != nil) != rt.expectedError {
+				t.Fatalf("expected error %t, got %v", rt.expectedError, err)
+			}
+			if !created.Equal(rt.expected) {
+				t.Errorf("expected %s, got %s", rt.expected, created)
+			}
+		})
+	}
+}
+
+func TestParseLeakedResources(t *testing.T) {
+	created := func(ago time.Duration) string {
+		return time.Now().Add(-ago).UTC().Format(time.RFC3339Nano)
+	}
+	lines := []string{
+		"old " + created(2*time.Hour),
+		"recent " + created(time.Minute),
+		"invalid yesterday",
+		"unnamed",
+	}
+	resources := parseLeakedResources("volume", "volume not used by any container", lines, time.Hour)
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %v", resources)
+	}
+	if r := resources[0]; r.Type != "volume" || r.Name != "old" || r.Reason != "volume not used by any container" {
+		t.Errorf("unexpected resource %+v", r)
+	}
+	if resources := parseLeakedResources("volume", "", lines, 0); len(resources) != 2 {
+		t.Errorf("expected 2 resources without a minimum age, got %v", resources)
+	}
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+const (
+	// ShellBroadcastMode runs each command typed in the session on all the nodes at the same time
+	ShellBroadcastMode = "broadcast"
+	// ShellPanesMode opens a tmux window with a pane for each node, with the input synchronized across panes
+	ShellPanesMode = "panes"
+	// ShellSequentialMode opens an interactive shell on each node, one after the other
+	ShellSequentialMode = "sequential"
+)
+
+// ShellModes are the modes supported by Shell
+var ShellModes = []string{ShellBroadcastMode, ShellPanesMode, ShellSequentialMode}
+
+// Shell opens an interactive session on the nodes matching a selector; see actions.SelectNodes for the
+// selector syntax, and ShellModes for the supported modes
+func (c *ClusterManager) Shell(nodeSelector, mode string) error {
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return errors.Errorf("no nodes matching selector %q", nodeSelector)
+	}
+
+	switch mode {
+	case ShellBroadcastMode:
+		return c.broadcastShell(nodes, os.Stdin, os.Stdout)
+	case ShellPanesMode:
+		return panesShell(c.Name(), nodes)
+	case ShellSequentialMode:
+		return sequentialShell(nodes)
+	}
+	return errors.Errorf("invalid shell mode %q; use one of [%s]", mode, strings.Join(ShellModes, ", "))
+}
+
+func sequentialShell(nodes status.NodeList) error {
+	for i, n := range nodes {
+		fmt.Printf("🐚 Opening a shell on node %s (%d of %d); exit the shell to continue 🐚\n", n.Name(), i+1, len(nodes))
+		if err := exec.NewHostCmd("docker", "exec", "-it", n.Name(), "bash").Stdin(os.Stdin).RunWithOutput(os.Stdout); err != nil {
+			// the shell exits with the exit code of the last command, that is not a failure of the session
+			log.Debugf("shell on node %s exited: %v", n.Name(), err)
+		}
+	}
+	return nil
+}
+
+func panesShell(cluster string, nodes status.NodeList) error {
+	if _, err := osexec.LookPath("tmux"); err != nil {
+		return errors.Errorf("the %s mode requires tmux on the host", ShellPanesMode)
+	}
+	tmux := func(args ...string) ([]string, error) {
+		lines, err := exec.NewHostCmd("tmux", args...).RunAndCapture()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to run tmux %s: %s", args[0], strings.Join(lines, " "))
+		}
+		return lines, nil
+	}
+
+	// a session left by a previous kinder shell is replaced
+	session := "kinder-" + cluster
+	_ = exec.NewHostCmd("tmux", "kill-session", "-t", "="+session).Run()
+
+	for i, n := range nodes {
+		shell := fmt.Sprintf("docker exec -it %s bash", n.Name())
+		args := []string{"split-window", "-t", session, "-P", "-F", "#{pane_id}", shell}
+		if i == 0 {
+			args = []string{"new-session", "-d", "-s", session, "-x", "200", "-y", "50", "-P", "-F", "#{pane_id}", shell}
+		}
+		lines, err := tmux(args...)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 1 {
+			_, _ = tmux("select-pane", "-t", lines[0], "-T", n.Name())
+		}
+		// the layout is recomputed at each pane for making room for the next one
+		if _, err := tmux("select-layout", "-t", session, "tiled"); err != nil {
+			return err
+		}
+	}
+	for _, option := range [][]string{
+		{"set-option", "-t", session, "pane-border-status", "top"},
+		{"set-option", "-t", session, "pane-border-format", " #{pane_title} "},
+		{"set-window-option", "-t", session, "synchronize-panes", "on"},
+	} {
+		if _, err := tmux(option...); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Input is sent to all the panes; use the tmux command 'setw synchronize-panes off' for typing in one pane only\n")
+	if os.Getenv("TMUX") != "" {
+		_, err := tmux("switch-client", "-t", session)
+		return err
+	}
+	return exec.NewHostCmd("tmux", "attach-session", "-t", session).Stdin(os.Stdin).RunWithOutput(os.Stdout)
+}
+
+// shellSession is a bash process running on a node, that reads the commands broadcasted to all the nodes
+type shellSession struct {
+	node   *status.Node
+	cmd    *osexec.Cmd
+	stdin  io.WriteCloser
+	output *shellOutput
+	exited chan struct{}
+}
+
+func (c *ClusterManager) broadcastShell(nodes status.NodeList, in io.Reader, out io.Writer) error {
+	// the end of each command is detected by a marker printed with its exit code
+	marker := fmt.Sprintf("__kinder_shell_%d__", time.Now().UnixNano())
+
+	width := 0
+	for _, n := range nodes {
+		if len(n.Name()) > width {
+			width = len(n.Name())
+		}
+	}
+
+	var mu sync.Mutex
+	sessions := []*shellSession{}
+	defer func() {
+		for _, s := range sessions {
+			s.stdin.Close()
+			select {
+			case <-s.exited:
+			case <-time.After(5 * time.Second):
+				_ = s.cmd.Process.Kill()
+			}
+		}
+	}()
+	for _, n := range nodes {
+		s := &shellSession{
+			node: n,
+			// docker runs in its own process group, so Ctrl+C is handled by kinder and does not close the shells
+			cmd: osexec.Command("docker", "exec", "-i", n.Name(), "bash"),
+			output: &shellOutput{
+				prefixWriter: prefixWriter{out: out, mu: &mu, prefix: fmt.Sprintf("[%-*s] ", width, n.Name())},
+				marker:       marker,
+				done:         make(chan int, 1),
+			},
+			exited: make(chan struct{}),
+		}
+		s.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		s.cmd.Stdout, s.cmd.Stderr = s.output, s.output
+		stdin, err := s.cmd.StdinPipe()
+		if err != nil {
+			return err
+		}
+		s.stdin = stdin
+		if err := s.cmd.Start(); err != nil {
+			return errors.Wrapf(err, "failed to open a shell on node %s", n.Name())
+		}
+		go func() {
+			_ = s.cmd.Wait()
+			close(s.exited)
+		}()
+		fmt.Fprintf(s.stdin, "exec 2>&1; echo $$ > /tmp/%[1]s.pid; trap 'rm -f /tmp/%[1]s.pid' EXIT\n", marker)
+		sessions = append(sessions, s)
+	}
+
+	// Ctrl+C interrupts the commands running on the nodes, instead of ending the session
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	targets := sessions
+	fmt.Fprintf(out, "Type the commands to run on %d nodes; :nodes lists the nodes, :select <node selector> changes "+
+		"the nodes, and exit or Ctrl+D ends the session\n", len(nodes))
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "kinder [%d nodes]$ ", len(targets))
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case line == "exit":
+			return nil
+		case line == ":nodes":
+			for _, s := range targets {
+				fmt.Fprintln(out, s.node.Name())
+			}
+			continue
+		case strings.HasPrefix(line, ":select"):
+			selected, err := c.selectSessions(sessions, strings.TrimSpace(strings.TrimPrefix(line, ":select")))
+			if err != nil {
+				fmt.Fprintf(out, "error: %v\n", err)
+				continue
+			}
+			targets = selected
+			continue
+		}
+
+		// ignore Ctrl+C pressed at the prompt
+		select {
+		case <-interrupt:
+		default:
+		}
+		running := []*shellSession{}
+		for _, s := range targets {
+			// the marker is printed on a new line, also when the output of the command does not end with a newline
+			if _, err := fmt.Fprintf(s.stdin, "%s\nprintf '\\n%s %%d\\n' $?\n", line, marker); err != nil {
+				fmt.Fprintf(out, "error: the shell on node %s is closed\n", s.node.Name())
+				continue
+			}
+			running = append(running, s)
+		}
+
+		failures := []string{}
+		for _, s := range running {
+			for waiting := true; waiting; {
+				select {
+				case code := <-s.output.done:
+					if code != 0 {
+						failures = append(failures, fmt.Sprintf("%s (%d)", s.node.Name(), code))
+					}
+					waiting = false
+				case <-s.exited:
+					s.output.Flush()
+					failures = append(failures, fmt.Sprintf("%s (shell closed)", s.node.Name()))
+					waiting = false
+				case <-interrupt:
+					for _, r := range running {
+						_ = r.node.Command("sh", "-c", fmt.Sprintf("pkill -INT -P $(cat /tmp/%s.pid)", marker)).Silent().Run()
+					}
+				}
+			}
+		}
+		if len(failures) > 0 {
+			fmt.Fprintf(out, "exit code not zero on %s\n", strings.Join(failures, ", "))
+		}
+	}
+}
+
+// selectSessions returns the sessions on the nodes matching a selector
+func (c *ClusterManager) selectSessions(sessions []*shellSession, nodeSelector string) ([]*shellSession, error) {
+	if nodeSelector == "" {
+		return sessions, nil
+	}
+	nodes, err := actions.SelectNodes(c.Cluster, nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+	selected := []*shellSession{}
+	for _, n := range nodes {
+		for _, s := range sessions {
+			if s.node.Name() == n.Name() {
+				selected = append(selected, s)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.Errorf("no nodes of the session matching selector %q", nodeSelector)
+	}
+	return selected, nil
+}
+
+// shellOutput writes the output of a shellSession prefixed with the node name, and detects the marker
+// printed at the beginning of a line at the end of each command
+type shellOutput struct {
+	prefixWriter
+	marker string
+	done   chan int
+
+	// blank is set when an empty line is held back, because it is the newline printed before the marker
+	// when the output of the command ends with a newline
+	blank bool
+}
+
+func (w *shellOutput) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf[:i+1]
+		w.buf = w.buf[i+1:]
+
+		if code, ok := w.parseMarker(line); ok {
+			w.blank = false
+			// the output is never blocked, e.g. when the marker is printed while no command is waited for
+			select {
+			case w.done <- code:
+			default:
+			}
+			continue
+		}
+		if err := w.flushBlank(); err != nil {
+			return 0, err
+		}
+		if len(line) == 1 {
+			w.blank = true
+			continue
+		}
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes the empty line held back and the incomplete line, if any
+func (w *shellOutput) Flush() {
+	_ = w.flushBlank()
+	w.prefixWriter.Flush()
+}
+
+func (w *shellOutput) flushBlank() error {
+	if !w.blank {
+		return nil
+	}
+	w.blank = false
+	return w.writeLine([]byte("\n"))
+}
+
+// parseMarker returns the exit code of a command if line is the marker, in the form "<marker> <exit code>"
+func (w *shellOutput) parseMarker(line []byte) (int, bool) {
+	rest, ok := bytes.CutPrefix(line, []byte(w.marker+" "))
+	if !ok {
+		return 0, false
+	}
+	code, err := strconv.Atoi(string(bytes.TrimSpace(rest)))
+	if err != nil {
+		return 0, false
+	}
+	return code, true
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestShellOutput(t *testing.T) {
+	const marker = "__kinder_shell_1__"
+	var tests = []struct {
+		name          string
+		writes        []string
+		expected      string
+		expectedCodes []int
+	}{
+		{
+			name:          "output ending with a newline",
+			writes:        []string{"a\n\n" + marker + " 0\n"},
+			expected:      "[n] a\n",
+			expectedCodes: []int{0},
+		},
+		{
+			name:          "output not ending with a newline",
+			writes:        []string{"a", "\n" + marker + " 2\n"},
+			expected:      "[n] a\n",
+			expectedCodes: []int{2},
+		},
+		{
+			name:          "empty lines in the output",
+			writes:        []string{"a\n\n\n", "\n" + marker + " 0\n"},
+			expected:      "[n] a\n[n] \n[n] \n",
+			expectedCodes: []int{0},
+		},
+		{
+			name:          "marker split across writes",
+			writes:        []string{"\n__kinder_", "shell_1__ 1\n"},
+			expectedCodes: []int{1},
+		},
+		{
+			name:     "marker not at the beginning of a line",
+			writes:   []string{"echo " + marker + " 0\n"},
+			expected: "[n] echo " + marker + " 0\n",
+		},
+		{
+			name:     "marker without exit code",
+			writes:   []string{marker + " done\n"},
+			expected: "[n] " + marker + " done\n",
+		},
+		{
+			name:          "markers not waited for do not block",
+			writes:        []string{"\n" + marker + " 0\n\n" + marker + " 1\n"},
+			expectedCodes: []int{0},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			w := &shellOutput{
+				prefixWriter: prefixWriter{out: out, mu: &sync.Mutex{}, prefix: "[n] "},
+				marker:       marker,
+				done:         make(chan int, 1),
+			}
+			var codes []int
+			for _, s := range rt.writes {
+				if _, err := w.Write([]byte(s)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				select {
+				case code := <-w.done:
+					codes = append(codes, code)
+				default:
+				}
+			}
+			w.Flush()
+			if out.String() != rt.expected {
+				t.Errorf("expected %q, got %q", rt.expected, out.String())
+			}
+			if !reflect.DeepEqual(codes, rt.expectedCodes) {
+				t.Errorf("expected exit codes %v, got %v", rt.expectedCodes, codes)
+			}
+		})
+	}
+}
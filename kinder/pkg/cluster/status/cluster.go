@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"path/filepath"
 	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -83,6 +85,56 @@ func ListClusters() ([]string, error) {
 	return sets.NewString(lines...).List(), nil
 }
 
+// ClusterInfo describes an existing cluster, as returned by ListClustersInfo
+type ClusterInfo struct {
+	Name string
+	// Created is the creation time of the oldest node of the cluster
+	Created time.Time
+}
+
+// ListClustersInfo lists the clusters with at least one node matching all the label selectors,
+// where each selector is a label key or a key=value pair
+func ListClustersInfo(selectors ...string) ([]ClusterInfo, error) {
+	args := []string{
+		"ps",
+		"-a",         // show stopped nodes
+		"--no-trunc", // don't truncate
+		"--filter", "label=" + constants.DeprecatedClusterLabelKey,
+	}
+	for _, s := range selectors {
+		args = append(args, "--filter", "label="+s)
+	}
+	args = append(args, "--format", fmt.Sprintf(`{{.Label "%s"}} {{.CreatedAt}}`, constants.DeprecatedClusterLabelKey))
+
+	lines, err := exec.NewHostCmd("docker", args...).RunAndCapture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list clusters: %s", lines)
+	}
+
+	created := map[string]time.Time{}
+	for _, l := range lines {
+		name, createdAt, ok := strings.Cut(l, " ")
+		if !ok {
+			continue
+		}
+		// docker prints the creation time e.g. as 2026-10-14 10:02:03 +0200 CEST
+		t, err := time.Parse("2006-01-02 15:04:05 -0700 MST", createdAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the creation time of the nodes of cluster %s", name)
+		}
+		if c, ok := created[name]; !ok || t.Before(c) {
+			created[name] = t
+		}
+	}
+
+	clusters := make([]ClusterInfo, 0, len(created))
+	for name, t := range created {
+		clusters = append(clusters, ClusterInfo{Name: name, Created: t})
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
+
 // IsKnown returns true if a cluster exists with the given name.
 // If obtaining the list of known clusters fails the function returns an error.
 func IsKnown(name string) (bool, error) {
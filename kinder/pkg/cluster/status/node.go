@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -51,6 +52,7 @@ type Node struct {
 	cri             ContainerRuntime
 	etcdImage       string
 	skip            bool
+	dryRun          bool
 	commandMutators []commandMutator
 }
 
@@ -158,6 +160,7 @@ func (n *Node) SkipActions() {
 // DryRun differs from SkipRun, because in case of DryRun kinder prints all the details for running
 // the command manually.
 func (n *Node) DryRun() {
+	n.dryRun = true
 	if n.commandMutators == nil {
 		n.commandMutators = []commandMutator{}
 	}
@@ -169,6 +172,20 @@ func (n *Node) DryRun() {
 	)
 }
 
+// IsDryRun returns true if the node was instructed to dry run commands.
+func (n *Node) IsDryRun() bool {
+	return n.dryRun
+}
+
+// RecordCommands instruct the node to record the result of all the commands that will be executed on this node.
+func (n *Node) RecordCommands(r *exec.Recorder) {
+	n.commandMutators = append(n.commandMutators,
+		func(c *exec.NodeCmd) *exec.NodeCmd {
+			return c.Record(r)
+		},
+	)
+}
+
 // Infof print an information message in the same format of commands on the node;
 // the message is print after the prompt containing the kind (er) node name.
 func (n *Node) Infof(message string, args ...any) {
@@ -189,7 +206,7 @@ func (n *Node) MustKubeadmVersion() *K8sVersion.Version {
 
 // KubeadmVersion returns the kubeadm version installed on the node
 func (n *Node) KubeadmVersion() (*K8sVersion.Version, error) {
-	lines, err := n.Command("kubeadm", "version", "-o=short").Silent().RunAndCapture()
+	lines, err := n.Command("kubeadm", "version", "-o=short").Silent().ReadOnly().RunAndCapture()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get kubeadm version")
 	}
@@ -219,7 +236,7 @@ func (n *Node) EtcdImage() (string, error) {
 	lines, err := n.Command(
 		"/bin/sh", "-c",
 		fmt.Sprintf("kubeadm config images list --kubernetes-version=%s 2> /dev/null | grep etcd", kubeVersion),
-	).Silent().RunAndCapture()
+	).Silent().ReadOnly().RunAndCapture()
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get the etcd image")
 	}
@@ -308,7 +325,7 @@ func (n *Node) WriteNodeSettings(settings *NodeSettings) error {
 func (n *Node) ReadNodeSettings() (*NodeSettings, error) {
 	lines, err := n.Command(
 		"cat", nodeSettingsPath,
-	).Silent().RunAndCapture()
+	).Silent().ReadOnly().RunAndCapture()
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to read %s", nodeSettingsPath)
 	}
@@ -405,6 +422,10 @@ func (n *Node) CopyFrom(source, dest string) error {
 
 // CopyTo copies the source file on the host to dest on the node
 func (n *Node) CopyTo(source, dest string) error {
+	if n.dryRun {
+		fmt.Printf("\n%s\n", colors.Prompt(fmt.Sprintf("docker cp %s %s:%s (dry run)", source, n.name, dest)))
+		return nil
+	}
 	cmd := exec.NewHostCmd(
 		"docker", "cp",
 		source,          // from the host, at source
@@ -414,7 +435,13 @@ func (n *Node) CopyTo(source, dest string) error {
 }
 
 // WriteFile writes a temporary file with the given contents and copies the file to the node container
+// When dry running, the file contents are printed instead, with credentials redacted.
 func (n *Node) WriteFile(containerPath string, contents []byte) error {
+	if n.dryRun {
+		fmt.Printf("\n%s\n%s\n", colors.Prompt(fmt.Sprintf("%s: write %s, %d bytes (dry run)", n.name, containerPath, len(contents))), redactCredentials(strings.TrimRight(string(contents), "\n")))
+		return nil
+	}
+
 	// Write the contents as a temporary file
 	tmpfile, err := os.CreateTemp("", fmt.Sprintf("%s-*", n.name))
 	if err != nil {
@@ -436,10 +463,23 @@ func (n *Node) WriteFile(containerPath string, contents []byte) error {
 	return nil
 }
 
+// credentialsRE matches kubeconfig and kubeadm config fields holding credentials
+var credentialsRE = regexp.MustCompile(`(?m)^(\s*-?\s*(client-key-data|client-certificate-data|token|certificateKey):\s*).+$`)
+
+// privateKeyRE matches PEM encoded private keys
+var privateKeyRE = regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----.*?-----END ([A-Z ]*)PRIVATE KEY-----`)
+
+// redactCredentials hides the credentials in the contents of a file, e.g. the client key in a kubeconfig file,
+// so they are not leaked into the logs when dry running
+func redactCredentials(contents string) string {
+	contents = credentialsRE.ReplaceAllString(contents, "${1}<redacted>")
+	return privateKeyRE.ReplaceAllString(contents, "-----BEGIN ${1}PRIVATE KEY-----\n<redacted>\n-----END ${2}PRIVATE KEY-----")
+}
+
 // KubeVersion returns the Kubernetes version installed on the node
 func (n *Node) KubeVersion() (version string, err error) {
 	// grab kubernetes version from the node image
-	lines, err := n.Command("cat", "/kind/version").RunAndCapture()
+	lines, err := n.Command("cat", "/kind/version").ReadOnly().RunAndCapture()
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get file")
 	}
@@ -70,6 +70,10 @@ const (
 	// This is the deprecated value of NodeRoleKey, and will be removed in a future release
 	DeprecatedNodeRoleLabelKey = "io.k8s.sigs.kind.role"
 
+	// TemporaryLabelKey is applied to the containers and volumes that kinder creates for a short time, e.g. for
+	// altering images, so kinder prune can remove the ones leaked by interrupted runs
+	TemporaryLabelKey = "io.x-k8s.kinder.temporary"
+
 	// PodSubnet defines the default pod subnet used by kind
 	// TODO: send a PR to define this value in a kind constant (currently it is not)
 	PodSubnet = "10.244.0.0/16"
@@ -110,6 +114,10 @@ const (
 
 	// PatchesDir defines the path to patches stored on node
 	PatchesDir = "/kinder/patches"
+
+	// EtcdImageFile defines the name of the file with the etcd image to use instead of the kubeadm default;
+	// the file is stored in /kind for init and in /kinder/upgrade/{version} for upgrades
+	EtcdImageFile = "etcd-image"
 )
 
 // other constants
@@ -26,18 +26,31 @@ import (
 // retrying up to retries times
 // it returns true if it attempted to pull, and any errors from pulling
 func PullImage(image string, retries int) (bool, error) {
+	return PullImageForArch(image, "", retries)
+}
+
+// PullImageForArch is like PullImage, but it pulls the image also when the local image has a different
+// architecture, e.g. when building arm64 images on an amd64 host; if arch is empty, any architecture is accepted
+func PullImageForArch(image, arch string, retries int) (bool, error) {
 	// once we have configurable log levels
 	// if this did not return an error, then the image exists locally
-	if err := exec.NewHostCmd("docker", "inspect", "--type=image", image).Run(); err == nil {
-		return false, nil
+	if lines, err := exec.NewHostCmd("docker", "inspect", "--type=image", "--format={{.Architecture}}", image).RunAndCapture(); err == nil {
+		if arch == "" || (len(lines) == 1 && lines[0] == arch) {
+			return false, nil
+		}
+	}
+
+	args := []string{"pull", image}
+	if arch != "" {
+		args = []string{"pull", "--platform=linux/" + arch, image}
 	}
 
 	// otherwise try to pull it
 	var err error
-	if err = exec.NewHostCmd("docker", "pull", image).Run(); err != nil {
+	if err = exec.NewHostCmd("docker", args...).Run(); err != nil {
 		for i := range retries {
 			time.Sleep(time.Second * time.Duration(i+1))
-			if err = exec.NewHostCmd("docker", "pull", image).Run(); err == nil {
+			if err = exec.NewHostCmd("docker", args...).Run(); err == nil {
 				break
 			}
 		}
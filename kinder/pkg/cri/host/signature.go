@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package host
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// SignImage signs an image in a registry with cosign, including the images referenced by a manifest list;
+// key can be a path or a KMS URI, while an empty key signs with the cosign keyless flow
+func SignImage(image, key string) error {
+	ref, err := digestReference(image)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Signing %s ...", ref)
+	args := []string{"sign", "--yes", "--recursive"}
+	if key != "" {
+		args = append(args, "--key", key)
+	}
+	if err := exec.NewHostCmd("cosign", append(args, ref)...).RunWithEcho(); err != nil {
+		return errors.Wrapf(err, "failed to sign %s", ref)
+	}
+	return nil
+}
+
+// VerifyImageSignature verifies the cosign signature of an image in a registry, and checks that the local
+// image is the signed one; key can be a path or a KMS URI, otherwise the signature is verified with the
+// identity and the OIDC issuer of the cosign keyless flow
+func VerifyImageSignature(image, key, identity, issuer string) error {
+	args := []string{"verify"}
+	switch {
+	case key != "":
+		args = append(args, "--key", key)
+	case identity != "" && issuer != "":
+		args = append(args, "--certificate-identity", identity, "--certificate-oidc-issuer", issuer)
+	default:
+		return errors.New("verifying signatures requires a key, or an identity and an OIDC issuer")
+	}
+
+	ref, err := digestReference(image)
+	if err != nil {
+		return err
+	}
+	lines, err := exec.NewHostCmd("cosign", append(args, ref)...).RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "signature of %s is not valid: %s", image, strings.Join(lines, " "))
+	}
+
+	// the local image is used to create nodes, so it must be the same image signed in the registry
+	lines, err = exec.NewHostCmd("docker", "image", "inspect", "-f", "{{json .RepoDigests}}", image).RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect %s", image)
+	}
+	var digests []string
+	if err := json.Unmarshal([]byte(strings.Join(lines, "")), &digests); err != nil {
+		return errors.Wrapf(err, "failed to read the digests of %s", image)
+	}
+	// repositories in RepoDigests can be normalized, e.g. docker.io/kindest/node, so only the digests are compared
+	_, digest, _ := strings.Cut(ref, "@")
+	if !slices.ContainsFunc(digests, func(d string) bool { return strings.HasSuffix(d, "@"+digest) }) {
+		return errors.Errorf("the local image %s is not the signed image %s; remove the local image and pull it again", image, ref)
+	}
+	log.Infof("Signature of %s verified", ref)
+	return nil
+}
+
+// digestReference returns the reference by digest of an image in a registry, e.g. registry.example.com/node@sha256:...
+func digestReference(image string) (string, error) {
+	lines, err := exec.NewHostCmd("docker", "buildx", "imagetools", "inspect", "--format", "{{.Manifest.Digest}}", image).RunAndCapture()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the digest of %s in the registry: %s", image, strings.Join(lines, " "))
+	}
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], "sha256:") {
+		return "", errors.Errorf("failed to get the digest of %s in the registry: unexpected output %v", image, lines)
+	}
+	return fmt.Sprintf("%s@%s", imageRepository(image), lines[0]), nil
+}
+
+// imageRepository returns the repository of an image, without the tag or the digest
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
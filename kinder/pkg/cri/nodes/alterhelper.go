@@ -23,6 +23,7 @@ import (
 	"k8s.io/kubeadm/kinder/pkg/build/bits"
 	"k8s.io/kubeadm/kinder/pkg/cluster/status"
 	"k8s.io/kubeadm/kinder/pkg/cri/nodes/containerd"
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes/crio"
 	"k8s.io/kubeadm/kinder/pkg/cri/nodes/docker"
 )
 
@@ -45,6 +46,8 @@ func (h *AlterHelper) GetAlterContainerArgs() ([]string, []string) {
 		return containerd.GetAlterContainerArgs()
 	case status.DockerRuntime:
 		return docker.GetAlterContainerArgs()
+	case status.CRIORuntime:
+		return crio.GetAlterContainerArgs()
 	}
 	return []string{}, []string{}
 }
@@ -57,17 +60,21 @@ func (h *AlterHelper) StartCRI(bc *bits.BuildContext) error {
 		return containerd.StartRuntime(bc)
 	case status.DockerRuntime:
 		return docker.StartRuntime(bc)
+	case status.CRIORuntime:
+		return crio.StartRuntime(bc)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
 
-// SetupCRI setups the container runtime.
-func (h *AlterHelper) SetupCRI(bc *bits.BuildContext) error {
+// SetupCRI setups the container runtime; sandboxImage overrides the sandbox image recommended by kubeadm.
+func (h *AlterHelper) SetupCRI(bc *bits.BuildContext, sandboxImage string) error {
 	switch h.cri {
 	case status.ContainerdRuntime:
-		return containerd.SetupRuntime(bc)
+		return containerd.SetupRuntime(bc, sandboxImage)
 	case status.DockerRuntime:
-		return docker.SetupRuntime(bc)
+		return docker.SetupRuntime(bc, sandboxImage)
+	case status.CRIORuntime:
+		return crio.SetupRuntime(bc, sandboxImage)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
@@ -79,6 +86,8 @@ func (h *AlterHelper) PreLoadInitImages(bc *bits.BuildContext, srcFolder string)
 		return containerd.PreLoadInitImages(bc, srcFolder)
 	case status.DockerRuntime:
 		return docker.PreLoadInitImages(bc, srcFolder)
+	case status.CRIORuntime:
+		return crio.PreLoadInitImages(bc, srcFolder)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
@@ -91,6 +100,8 @@ func (h *AlterHelper) StopCRI(bc *bits.BuildContext) error {
 		return containerd.StopRuntime(bc)
 	case status.DockerRuntime:
 		return docker.StopRuntime(bc)
+	case status.CRIORuntime:
+		return crio.StopRuntime(bc)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
@@ -102,10 +113,25 @@ func (h *AlterHelper) ImportImage(bc *bits.BuildContext, tar string) error {
 		return containerd.ImportImage(bc, tar)
 	case status.DockerRuntime:
 		return docker.ImportImage(bc, tar)
+	case status.CRIORuntime:
+		return crio.ImportImage(bc, tar)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
 
+// ListImages returns the images in the CR
+func (h *AlterHelper) ListImages(bc *bits.BuildContext) ([]string, error) {
+	switch h.cri {
+	case status.ContainerdRuntime:
+		return containerd.ListImages(bc)
+	case status.DockerRuntime:
+		return docker.ListImages(bc)
+	case status.CRIORuntime:
+		return crio.ListImages(bc)
+	}
+	return nil, errors.Errorf("unknown cri: %s", h.cri)
+}
+
 // GetImagesForKubeadmBinary runs a kubeadm binary located at "binaryPath" and gets the images it returns
 func (h *AlterHelper) GetImagesForKubeadmBinary(bc *bits.BuildContext, binaryPath string) ([]string, error) {
 	images, err := bc.CombinedOutputLinesInContainer(
@@ -121,13 +147,16 @@ func (h *AlterHelper) GetImagesForKubeadmBinary(bc *bits.BuildContext, binaryPat
 	return images, nil
 }
 
-// Commit a kind(er) node image that uses the selected container runtime internally
-func (h *AlterHelper) Commit(containerID, targetImage string) error {
+// Commit a kind(er) node image that uses the selected container runtime internally, applying
+// the given changes, e.g. LABEL instructions
+func (h *AlterHelper) Commit(containerID, targetImage string, changes ...string) error {
 	switch h.cri {
 	case status.ContainerdRuntime:
-		return containerd.Commit(containerID, targetImage)
+		return containerd.Commit(containerID, targetImage, changes...)
 	case status.DockerRuntime:
-		return docker.Commit(containerID, targetImage)
+		return docker.Commit(containerID, targetImage, changes...)
+	case status.CRIORuntime:
+		return crio.Commit(containerID, targetImage, changes...)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
@@ -25,12 +25,13 @@ import (
 
 	"github.com/pkg/errors"
 
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
 	"k8s.io/kubeadm/kinder/pkg/constants"
 	"k8s.io/kubeadm/kinder/pkg/exec"
 )
 
-// BaseRunArgs computes docker arguments that apply to all containers
-func BaseRunArgs(cluster, name, role string) ([]string, error) {
+// BaseRunArgs computes docker arguments that apply to all containers, including the user defined labels
+func BaseRunArgs(cluster, name, role string, labels []string) ([]string, error) {
 	// standard arguments all nodes containers need, computed once
 	args := []string{
 		"run",
@@ -45,6 +46,9 @@ func BaseRunArgs(cluster, name, role string) ([]string, error) {
 		"--label", fmt.Sprintf("%s=%s", constants.NodeRoleLabelKey, role),
 		"--label", fmt.Sprintf("%s=%s", constants.DeprecatedNodeRoleLabelKey, role),
 	}
+	for _, l := range labels {
+		args = append(args, "--label", l)
+	}
 
 	// TODO: enable IPv6 if necessary
 	// args = append(args, "--sysctl=net.ipv6.conf.all.disable_ipv6=0", "--sysctl=net.ipv6.conf.all.forwarding=1")
@@ -136,7 +140,7 @@ func getSubnets(networkName string) ([]string, error) {
 }
 
 // RunArgsForNode computes docker run arguments that apply to containers that should host K8s nodes
-func RunArgsForNode(role string, volumes []string, args []string) ([]string, error) {
+func RunArgsForNode(cluster, role string, volumes []string, args []string) ([]string, error) {
 	args = append(args,
 		// running containers in a container requires privileged
 		// NOTE: we could try to replicate this with --cap-add, and use less
@@ -153,7 +157,8 @@ func RunArgsForNode(role string, volumes []string, args []string) ([]string, err
 		// filesystem, which is not only better for performance, but allows
 		// running kind in kind for "party tricks"
 		// (please don't depend on doing this though!)
-		"--volume", "/var",
+		// the volume is labeled with the cluster, so kinder prune can remove it if leaked
+		"--mount", fmt.Sprintf("type=volume,dst=/var,volume-label=%s=%s", constants.ClusterLabelKey, cluster),
 		// some k8s things want to read /lib/modules
 		"--volume", "/lib/modules:/lib/modules:ro",
 	)
@@ -225,3 +230,20 @@ func TryUntil(until time.Time, try func() bool) bool {
 	}
 	return false
 }
+
+// KubeadmSandboxImage returns the sandbox image recommended by the kubeadm binary in the alter container
+func KubeadmSandboxImage(bc *bits.BuildContext) (string, error) {
+	binaryPath := "/kind/bin/kubeadm"
+	cmd := fmt.Sprintf(
+		`%[1]s config images list --kubernetes-version=$(%[1]s version -o short) 2> /dev/null | grep pause`,
+		binaryPath,
+	)
+	images, err := bc.CombinedOutputLinesInContainer("bash", "-c", cmd)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to execute command %q, output %v", cmd, images)
+	}
+	if len(images) != 1 {
+		return "", errors.Errorf("expected the output of command %q to have 1 line, got: %v", cmd, images)
+	}
+	return images[0], nil
+}
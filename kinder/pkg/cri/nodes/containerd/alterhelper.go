@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -49,29 +50,25 @@ func GetAlterContainerArgs() ([]string, []string) {
 	return runArgs, runCommands
 }
 
-// SetupRuntime setups the runtime
-func SetupRuntime(bc *bits.BuildContext) error {
-	if err := setupCRISandboxImage(bc); err != nil {
+// SetupRuntime setups the runtime; sandboxImage overrides the sandbox image recommended by kubeadm
+func SetupRuntime(bc *bits.BuildContext, sandboxImage string) error {
+	if err := setupCRISandboxImage(bc, sandboxImage); err != nil {
 		return err
 	}
 	return nil
 }
 
-// setupCRISandboxImage rewrites the containerd config file to use the sandbox image recommended by kubeadm.
-func setupCRISandboxImage(bc *bits.BuildContext) error {
-	binaryPath := "/kind/bin/kubeadm"
-	cmd := fmt.Sprintf(
-		`%[1]s config images list --kubernetes-version=$(%[1]s version -o short) 2> /dev/null | grep pause`,
-		binaryPath,
-	)
-	images, err := bc.CombinedOutputLinesInContainer("bash", "-c", cmd)
-	if err != nil {
-		return errors.Wrapf(err, "failed to execute command %q, output %v", cmd, images)
-	}
-	if len(images) != 1 {
-		return errors.Errorf("expected the output of command %q to have 1 line, got: %v", cmd, images)
+// setupCRISandboxImage rewrites the containerd config file to use the given sandbox image, or
+// the sandbox image recommended by kubeadm if not set.
+func setupCRISandboxImage(bc *bits.BuildContext, sandboxImage string) error {
+	if sandboxImage == "" {
+		image, err := common.KubeadmSandboxImage(bc)
+		if err != nil {
+			return err
+		}
+		sandboxImage = image
 	}
-	if len(images[0]) > 0 {
+	if len(sandboxImage) > 0 {
 		tmpConfigFileName := "containerd-config.toml"
 		tmpConfigFileInContainer := filepath.Join(bc.ContainerBasePath(), tmpConfigFileName)
 		tmpConfigFileOnHost := filepath.Join(bc.HostBasePath(), tmpConfigFileName)
@@ -89,16 +86,16 @@ func setupCRISandboxImage(bc *bits.BuildContext) error {
 				config.DefaultConfigPath)
 			return nil
 		}
-		if currentSandboxImage != images[0] {
-			log.Infof("updating the config file %s to use the recommended sandbox image %s", tmpConfigFileInContainer, images[0])
-			if err := config.SetCRISandboxImage(tmpConfigFileOnHost, images[0]); err != nil {
-				return errors.Wrapf(err, "failed to setup the sanbox image %s for the containerd runtime", images[0])
+		if currentSandboxImage != sandboxImage {
+			log.Infof("updating the config file %s to use the sandbox image %s", tmpConfigFileInContainer, sandboxImage)
+			if err := config.SetCRISandboxImage(tmpConfigFileOnHost, sandboxImage); err != nil {
+				return errors.Wrapf(err, "failed to setup the sanbox image %s for the containerd runtime", sandboxImage)
 			}
 			if err := bc.RunInContainer("cp", tmpConfigFileInContainer, config.DefaultConfigPath); err != nil {
 				log.Errorf("failed to copy %s into %s, error: %v", tmpConfigFileInContainer, config.DefaultConfigPath, err)
 				return err
 			}
-			log.Infof("configured the containerd runtime to use the sandbox image %s", images[0])
+			log.Infof("configured the containerd runtime to use the sandbox image %s", sandboxImage)
 		}
 	}
 	return nil
@@ -138,6 +135,22 @@ func ImportImage(bc *bits.BuildContext, tar string) error {
 	return nil
 }
 
+// ListImages returns the images in the containerd runtime
+func ListImages(bc *bits.BuildContext) ([]string, error) {
+	lines, err := bc.CombinedOutputLinesInContainer("ctr", "--namespace=k8s.io", "images", "ls", "-q")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the images")
+	}
+	var images []string
+	for _, l := range lines {
+		// images are listed also by digest
+		if l != "" && !strings.HasPrefix(l, "sha256:") {
+			images = append(images, l)
+		}
+	}
+	return images, nil
+}
+
 // PreLoadInitImages preload images required by kubeadm-init into the containerd runtime that exists inside a kind(er) node
 func PreLoadInitImages(bc *bits.BuildContext, srcFolder string) error {
 	// NB. this code is an extract from "sigs.k8s.io/kind/pkg/build/node"
@@ -147,12 +160,13 @@ func PreLoadInitImages(bc *bits.BuildContext, srcFolder string) error {
 	)
 }
 
-// Commit a kind(er) node image that uses the containerd runtime internally
-func Commit(containerID, targetImage string) error {
+// Commit a kind(er) node image that uses the containerd runtime internally;
+// changes are additional Dockerfile instructions to apply to the image, e.g. LABEL
+func Commit(containerID, targetImage string, changes ...string) error {
 	// NB. this code is an extract from "sigs.k8s.io/kind/pkg/build/node"
 
 	// Save the image changes to a new image
-	cmd := exec.Command("docker", "commit",
+	args := []string{"commit",
 		/*
 			The snapshot storage must be a volume to avoid overlay on overlay
 
@@ -164,7 +178,11 @@ func Commit(containerID, targetImage string) error {
 		"--change", `VOLUME [ "/var/lib/containerd" ]`,
 		// we need to put this back after changing it when running the image
 		"--change", `ENTRYPOINT [ "/usr/local/bin/entrypoint", "/sbin/init" ]`,
-		containerID, targetImage)
+	}
+	for _, c := range changes {
+		args = append(args, "--change", c)
+	}
+	cmd := exec.Command("docker", append(args, containerID, targetImage)...)
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
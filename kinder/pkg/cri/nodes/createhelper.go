@@ -23,19 +23,22 @@ import (
 	"k8s.io/kubeadm/kinder/pkg/constants"
 	"k8s.io/kubeadm/kinder/pkg/cri/nodes/common"
 	"k8s.io/kubeadm/kinder/pkg/cri/nodes/containerd"
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes/crio"
 	"k8s.io/kubeadm/kinder/pkg/cri/nodes/docker"
 	"k8s.io/kubeadm/kinder/pkg/exec"
 )
 
 // CreateHelper provides CRI specific methods for node create
 type CreateHelper struct {
-	cri status.ContainerRuntime
+	cri    status.ContainerRuntime
+	labels []string
 }
 
-// NewCreateHelper returns a new CreateHelper
-func NewCreateHelper(cri status.ContainerRuntime) (*CreateHelper, error) {
+// NewCreateHelper returns a new CreateHelper; labels, in the key=value format, are added to all the containers
+func NewCreateHelper(cri status.ContainerRuntime, labels []string) (*CreateHelper, error) {
 	return &CreateHelper{
-		cri: cri,
+		cri:    cri,
+		labels: labels,
 	}, nil
 }
 
@@ -43,16 +46,18 @@ func NewCreateHelper(cri status.ContainerRuntime) (*CreateHelper, error) {
 func (h *CreateHelper) CreateNode(cluster, name, image, role string, volumes []string) error {
 	switch h.cri {
 	case status.ContainerdRuntime:
-		return containerd.CreateNode(cluster, name, image, role, volumes)
+		return containerd.CreateNode(cluster, name, image, role, volumes, h.labels)
 	case status.DockerRuntime:
-		return docker.CreateNode(cluster, name, image, role, volumes)
+		return docker.CreateNode(cluster, name, image, role, volumes, h.labels)
+	case status.CRIORuntime:
+		return crio.CreateNode(cluster, name, image, role, volumes, h.labels)
 	}
 	return errors.Errorf("unknown cri: %s", h.cri)
 }
 
 // CreateExternalEtcd creates a container hosting a single node, insecure, external etcd cluster
 func (h *CreateHelper) CreateExternalEtcd(cluster, name, image string) error {
-	args, err := common.BaseRunArgs(cluster, name, constants.ExternalEtcdNodeRoleValue)
+	args, err := common.BaseRunArgs(cluster, name, constants.ExternalEtcdNodeRoleValue, h.labels)
 	if err != nil {
 		return err
 	}
@@ -72,7 +77,7 @@ func (h *CreateHelper) CreateExternalEtcd(cluster, name, image string) error {
 
 // CreateExternalLoadBalancer creates a container hosting an external load balancer
 func (h *CreateHelper) CreateExternalLoadBalancer(cluster, name string) error {
-	args, err := common.BaseRunArgs(cluster, name, constants.ExternalLoadBalancerNodeRoleValue)
+	args, err := common.BaseRunArgs(cluster, name, constants.ExternalLoadBalancerNodeRoleValue, h.labels)
 	if err != nil {
 		return err
 	}
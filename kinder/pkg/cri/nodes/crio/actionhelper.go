@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crio
+
+import (
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// PreLoadUpgradeImages preload images required by kubeadm-upgrade into the CRI-O runtime that exists inside a kind(er) node
+func PreLoadUpgradeImages(n *status.Node, srcFolder string) error {
+	return n.Command("bash", "-c", loadImagesCmd(srcFolder)).Silent().Run()
+}
+
+// GetImages returns the list of images available in the node
+func GetImages(n *status.Node) ([]string, error) {
+	current, err := n.Command(
+		"bash", "-c", `crictl images | tail -n +2 | awk '{print $1":"$2}'`,
+	).Silent().RunAndCapture()
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read current images from %s", n.Name())
+	}
+
+	return current, nil
+}
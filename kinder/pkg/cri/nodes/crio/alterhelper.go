@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crio
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/build/bits"
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes/common"
+)
+
+// configDropIn is the CRI-O config file with the kinder settings
+const configDropIn = "/etc/crio/crio.conf.d/99-kinder.conf"
+
+// GetAlterContainerArgs returns arguments for the alter container for CRI-O
+func GetAlterContainerArgs() ([]string, []string) {
+	// CRI-O is not started in the alter container, so this is the same as containerd
+	runArgs := []string{
+		"--privileged",
+		"--entrypoint=/bin/sleep",
+	}
+	runCommands := []string{
+		"infinity",
+	}
+	return runArgs, runCommands
+}
+
+// SetupRuntime setups the runtime; sandboxImage overrides the sandbox image recommended by kubeadm
+func SetupRuntime(bc *bits.BuildContext, sandboxImage string) error {
+	if sandboxImage == "" {
+		image, err := common.KubeadmSandboxImage(bc)
+		if err != nil {
+			return err
+		}
+		sandboxImage = image
+	}
+
+	// kind nodes use the systemd cgroup driver, that is the CRI-O default, so only the sandbox image is configured
+	config := fmt.Sprintf("[crio.image]\npause_image = \"%s\"\n", sandboxImage)
+	if err := bc.RunInContainer("bash", "-c", fmt.Sprintf("mkdir -p $(dirname %[1]s) && printf '%[2]s' > %[1]s", configDropIn, config)); err != nil {
+		return errors.Wrapf(err, "could not write %s", configDropIn)
+	}
+	log.Infof("configured the CRI-O runtime to use the sandbox image %s", sandboxImage)
+	return nil
+}
+
+// StartRuntime starts the runtime
+func StartRuntime(bc *bits.BuildContext) error {
+	// CRI-O can't use its overlay storage in the alter container, because /var/lib/containers becomes
+	// a volume only in the committed image; images are loaded at node create time instead
+	return nil
+}
+
+// StopRuntime stops the runtime
+func StopRuntime(bc *bits.BuildContext) error {
+	return nil
+}
+
+// PreLoadInitImages preload images required by kubeadm-init into the CRI-O runtime that exists inside a kind(er) node
+func PreLoadInitImages(bc *bits.BuildContext, srcFolder string) error {
+	// NOP, images tars are loaded at node create time
+	return nil
+}
+
+// ImportImage import a TAR file into the CR and delete it
+func ImportImage(bc *bits.BuildContext, tar string) error {
+	// NOP, images tars are loaded at node create time
+	return nil
+}
+
+// ListImages returns the images in the image tars that are loaded at node create time, or at upgrade time
+func ListImages(bc *bits.BuildContext) ([]string, error) {
+	lines, err := bc.CombinedOutputLinesInContainer("bash", "-c",
+		`for f in $(find /kind/images /kinder/upgrade -name '*.tar' 2> /dev/null); do `+repoTagsCmd+`; done`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the images")
+	}
+	var images []string
+	for _, l := range lines {
+		images = append(images, strings.Fields(l)...)
+	}
+	return images, nil
+}
+
+// Commit a kind(er) node image that uses the CRI-O runtime internally;
+// changes are additional Dockerfile instructions to apply to the image, e.g. LABEL
+func Commit(containerID, targetImage string, changes ...string) error {
+	args := []string{"commit",
+		// the storage of CRI-O must be a volume to avoid overlay on overlay, like for containerd
+		"--change", `VOLUME [ "/var/lib/containers" ]`,
+		"--change", `ENTRYPOINT [ "/usr/local/bin/entrypoint", "/sbin/init" ]`,
+	}
+	for _, c := range changes {
+		args = append(args, "--change", c)
+	}
+	cmd := exec.Command("docker", append(args, containerID, targetImage)...)
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// repoTagsCmd prints the names of the images in the image tar $f, read from the manifest of the tar
+const repoTagsCmd = `tar -xOf $f manifest.json | sed -n 's/.*"RepoTags":\[\([^]]*\)\].*/\1/p' | tr ',' ' ' | tr -d '"'`
+
+// loadImagesCmd returns a command that loads the images tars in srcFolder into the CRI-O storage; image
+// names are read from the manifest of each tar, because skopeo requires the name of the destination image
+func loadImagesCmd(srcFolder string) string {
+	return `for f in $(find ` + srcFolder + ` -name '*.tar'); do ` +
+		`for tag in $(` + repoTagsCmd + `); do ` +
+		`skopeo copy --quiet docker-archive:$f containers-storage:$tag || exit 1; ` +
+		`done; done`
+}
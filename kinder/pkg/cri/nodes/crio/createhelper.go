@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crio
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/cri/nodes/common"
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// CreateNode creates a container that internally hosts the CRI-O cri runtime
+func CreateNode(cluster, name, image, role string, volumes, labels []string) error {
+	args, err := common.BaseRunArgs(cluster, name, role, labels)
+	if err != nil {
+		return err
+	}
+
+	args, err = common.RunArgsForNode(cluster, role, volumes, args)
+	if err != nil {
+		return err
+	}
+
+	// Specify the image to run
+	args = append(args, image)
+
+	// creates the container
+	if err := exec.NewHostCmd("docker", args...).Run(); err != nil {
+		return err
+	}
+
+	// wait for CRI-O to be ready
+	const crioTimeout = time.Second * 60
+	if !waitForCRIO(name, time.Now().Add(crioTimeout)) {
+		return errors.Errorf("timed out waiting for CRI-O to be ready on node %s after %v", name, crioTimeout)
+	}
+
+	// load the image artifacts into CRI-O
+	if err := exec.NewNodeCmd(name, "bash", "-c", loadImagesCmd("/kind/images")).Silent().Run(); err != nil {
+		log.Warningf("Failed to preload CRI-O images from /kind/images: %v", err)
+	}
+
+	return nil
+}
+
+// waitForCRIO waits for CRI-O to be ready on the node
+func waitForCRIO(name string, until time.Time) bool {
+	return common.TryUntil(until, func() bool {
+		out, err := exec.NewNodeCmd(name, "systemctl", "is-active", "crio").Silent().RunAndCapture()
+		if err != nil {
+			return false
+		}
+		return len(out) == 1 && out[0] == "active"
+	})
+}
@@ -19,6 +19,7 @@ package docker
 import (
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -37,8 +38,11 @@ func GetAlterContainerArgs() ([]string, []string) {
 	return runArgs, []string{}
 }
 
-// SetupRuntime setups the runtime
-func SetupRuntime(bc *bits.BuildContext) error {
+// SetupRuntime setups the runtime; the sandbox image of docker is set by the kubelet, so it can't be overridden
+func SetupRuntime(bc *bits.BuildContext, sandboxImage string) error {
+	if sandboxImage != "" {
+		return errors.New("the sandbox image can't be set for images using docker as container runtime")
+	}
 	// Rewrite the Docker daemon config to include:
 	// - the "cri-containerd: true", which is something that already exists in kindest/base:v20190403-1ebf15f
 	// - the cgroup driver setting (systemd)
@@ -96,16 +100,36 @@ func PreLoadInitImages(bc *bits.BuildContext, srcFolder string) error {
 	return nil
 }
 
+// ListImages returns the images in the docker runtime
+func ListImages(bc *bits.BuildContext) ([]string, error) {
+	lines, err := bc.CombinedOutputLinesInContainer("docker", "images", "--format", "{{.Repository}}:{{.Tag}}")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the images")
+	}
+	var images []string
+	for _, l := range lines {
+		if l != "" && !strings.Contains(l, "<none>") {
+			images = append(images, l)
+		}
+	}
+	return images, nil
+}
+
 // ImportImage import a TAR file into the CR and delete it
 func ImportImage(bc *bits.BuildContext, tar string) error {
 	// NO-OP for Docker
 	return nil
 }
 
-// Commit a kind(er) node image that uses the docker runtime internally
-func Commit(containerID, targetImage string) error {
+// Commit a kind(er) node image that uses the docker runtime internally;
+// changes are additional Dockerfile instructions to apply to the image, e.g. LABEL
+func Commit(containerID, targetImage string, changes ...string) error {
 	// Save the image changes to a new image
-	cmd := exec.Command("docker", "commit", containerID, targetImage)
+	args := []string{"commit"}
+	for _, c := range changes {
+		args = append(args, "--change", c)
+	}
+	cmd := exec.Command("docker", append(args, containerID, targetImage)...)
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -27,13 +27,13 @@ import (
 )
 
 // CreateNode creates a container that internally hosts the docker cri runtime
-func CreateNode(cluster, name, image, role string, volumes []string) error {
-	args, err := common.BaseRunArgs(cluster, name, role)
+func CreateNode(cluster, name, image, role string, volumes, labels []string) error {
+	args, err := common.BaseRunArgs(cluster, name, role, labels)
 	if err != nil {
 		return err
 	}
 
-	args, err = common.RunArgsForNode(role, volumes, args)
+	args, err = common.RunArgsForNode(cluster, role, volumes, args)
 	if err != nil {
 		return err
 	}
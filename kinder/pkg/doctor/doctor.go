@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package doctor implements checks of the host for the known requirements of kinder, and for
+// settings that are known to make clusters fail
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+
+	kinderexec "k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+// Status is the result of a check
+type Status string
+
+const (
+	// OK means the host satisfies the check
+	OK Status = "ok"
+	// Warning means the host could have problems running some clusters
+	Warning Status = "warning"
+	// Failure means clusters are likely to fail on the host
+	Failure Status = "failure"
+	// Skipped means the check could not be executed
+	Skipped Status = "skipped"
+)
+
+const (
+	minDockerVersion = "20.10.0"
+	minPodmanVersion = "4.0.0"
+
+	// inotify limits recommended by kind, see https://kind.sigs.k8s.io/docs/user/known-issues/
+	minInotifyWatches   = 524288
+	minInotifyInstances = 512
+
+	// free disk space in the docker root dir, in GiB
+	minFreeDisk     = 2
+	minFreeDiskWarn = 10
+)
+
+// Result is the result of a check, with the fix when the check is not satisfied
+type Result struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// engine is the container engine used by kinder
+type engine struct {
+	// name is docker or podman, that is used through the docker CLI
+	name          string
+	version       string
+	rootless      bool
+	cgroupVersion string
+	rootDir       string
+}
+
+// Run executes all the checks
+func Run() []Result {
+	e, result := checkEngine()
+	results := []Result{result}
+	// checks of the engine settings require a working engine
+	if e != nil {
+		results = append(results, checkCgroups(e))
+		results = append(results, checkDiskSpace(e))
+	}
+	results = append(results, checkInotify()...)
+	results = append(results, checkKernelModules()...)
+	results = append(results, checkIP6Tables())
+	return results
+}
+
+func checkEngine() (*engine, Result) {
+	r := Result{Name: "container engine"}
+	if _, err := exec.LookPath("docker"); err != nil {
+		r.Status, r.Message = Failure, "the docker CLI is not installed"
+		r.Fix = "install docker (https://docs.docker.com/engine/install/); with podman, install the podman-docker package providing the docker CLI"
+		return nil, r
+	}
+
+	lines, err := kinderexec.NewHostCmd("docker", "info", "--format={{.ServerVersion}}|{{.OperatingSystem}}|{{json .SecurityOptions}}|{{.CgroupVersion}}|{{.DockerRootDir}}").RunAndCapture()
+	fields := []string{}
+	if err == nil && len(lines) == 1 {
+		fields = strings.Split(lines[0], "|")
+	}
+	if len(fields) != 5 {
+		r.Status, r.Message = Failure, fmt.Sprintf("the container engine is not reachable: %s", strings.Join(lines, " "))
+		r.Fix = "start the docker daemon, e.g. sudo systemctl start docker, and check that the current user can use it, e.g. with docker ps"
+		return nil, r
+	}
+
+	e := &engine{
+		name:          "docker",
+		version:       fields[0],
+		rootless:      strings.Contains(fields[2], "rootless"),
+		cgroupVersion: fields[3],
+		rootDir:       fields[4],
+	}
+	minVersion := minDockerVersion
+	if strings.Contains(strings.ToLower(fields[1]), "podman") || strings.Contains(strings.ToLower(fields[0]), "podman") {
+		e.name, minVersion = "podman", minPodmanVersion
+		if lines, err := kinderexec.NewHostCmd("podman", "version", "--format={{.Version}}").RunAndCapture(); err == nil && len(lines) == 1 {
+			e.version = lines[0]
+		}
+	}
+
+	mode := ""
+	if e.rootless {
+		mode = ", rootless"
+	}
+	r.Message = fmt.Sprintf("%s %s%s", e.name, e.version, mode)
+	v, err := K8sVersion.ParseGeneric(e.version)
+	switch {
+	case err != nil:
+		r.Status = Warning
+		r.Message += "; the version can't be parsed"
+	case v.LessThan(K8sVersion.MustParseGeneric(minVersion)):
+		r.Status = Warning
+		r.Message += fmt.Sprintf("; %s %s or newer is recommended", e.name, minVersion)
+		r.Fix = fmt.Sprintf("upgrade %s", e.name)
+	default:
+		r.Status = OK
+	}
+	return e, r
+}
+
+func checkCgroups(e *engine) Result {
+	r := Result{Name: "cgroups"}
+	if e.cgroupVersion != "2" {
+		r.Status, r.Message = Warning, fmt.Sprintf("cgroup v%s; recent Kubernetes versions require cgroup v2 by default", e.cgroupVersion)
+		r.Fix = "boot the host with cgroup v2, e.g. adding systemd.unified_cgroup_hierarchy=1 to the kernel command line"
+		return r
+	}
+	if !e.rootless {
+		r.Status, r.Message = OK, "cgroup v2"
+		return r
+	}
+
+	// rootless engines can give to nodes only the controllers delegated by systemd to the user
+	uid := os.Getuid()
+	path := fmt.Sprintf("/sys/fs/cgroup/user.slice/user-%d.slice/user@%d.service/cgroup.controllers", uid, uid)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.Status, r.Message = Skipped, fmt.Sprintf("can't read the delegated controllers from %s", path)
+		return r
+	}
+	delegated := strings.Fields(string(data))
+	missing := []string{}
+	for _, c := range []string{"cpu", "cpuset", "io", "memory", "pids"} {
+		if !contains(delegated, c) {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		r.Status, r.Message = Failure, fmt.Sprintf("cgroup v2, the %s controllers are not delegated to the user", strings.Join(missing, ", "))
+		r.Fix = "delegate the controllers with a drop-in for user@.service with Delegate=cpu cpuset io memory pids, " +
+			"see https://kind.sigs.k8s.io/docs/user/rootless/"
+		return r
+	}
+	r.Status, r.Message = OK, "cgroup v2, with the required controllers delegated to the user"
+	return r
+}
+
+func checkDiskSpace(e *engine) Result {
+	r := Result{Name: "disk space"}
+	lines, err := kinderexec.NewHostCmd("df", "-Pk", e.rootDir).RunAndCapture()
+	if err != nil || len(lines) != 2 {
+		// e.g. the engine runs in a VM, like on macOS
+		r.Status, r.Message = Skipped, fmt.Sprintf("can't read the free space in %s", e.rootDir)
+		return r
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) < 4 {
+		r.Status, r.Message = Skipped, fmt.Sprintf("unexpected output of df: %q", lines[1])
+		return r
+	}
+	free, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		r.Status, r.Message = Skipped, fmt.Sprintf("unexpected output of df: %q", lines[1])
+		return r
+	}
+
+	freeGiB := float64(free) / (1 << 20)
+	r.Message = fmt.Sprintf("%.1f GiB free in %s", freeGiB, e.rootDir)
+	switch {
+	case freeGiB < minFreeDisk:
+		r.Status = Failure
+	case freeGiB < minFreeDiskWarn:
+		r.Status = Warning
+	default:
+		r.Status = OK
+		return r
+	}
+	r.Fix = fmt.Sprintf("free some space, e.g. removing unused images with docker image prune, or kinder cache prune; at least %d GiB are recommended", minFreeDiskWarn)
+	return r
+}
+
+func checkInotify() []Result {
+	var results []Result
+	for _, l := range []struct {
+		name, sysctl string
+		min          int64
+	}{
+		{"inotify watches", "fs.inotify.max_user_watches", minInotifyWatches},
+		{"inotify instances", "fs.inotify.max_user_instances", minInotifyInstances},
+	} {
+		r := Result{Name: l.name}
+		v, err := readSysctl(l.sysctl)
+		switch {
+		case err != nil:
+			r.Status, r.Message = Skipped, err.Error()
+		case v < l.min:
+			r.Status, r.Message = Warning, fmt.Sprintf("%s is %d; clusters with many nodes can fail with \"too many open files\"", l.sysctl, v)
+			r.Fix = fmt.Sprintf("sudo sysctl %s=%d, and add it to /etc/sysctl.d/ for making it persistent", l.sysctl, l.min)
+		default:
+			r.Status, r.Message = OK, fmt.Sprintf("%s is %d", l.sysctl, v)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func checkKernelModules() []Result {
+	var results []Result
+	for _, m := range []struct {
+		name, purpose string
+	}{
+		{"overlay", "the overlayfs snapshotter of containerd"},
+		{"br_netfilter", "the kube-proxy and the CNI plugins"},
+		{"ip_tables", "the kube-proxy in iptables mode"},
+	} {
+		r := Result{Name: "kernel module " + m.name}
+		switch {
+		case runtime.GOOS != "linux":
+			r.Status, r.Message = Skipped, "not running on Linux"
+		case moduleLoaded(m.name):
+			r.Status, r.Message = OK, "loaded"
+		default:
+			r.Status, r.Message = Warning, fmt.Sprintf("not loaded; it is used by %s", m.purpose)
+			r.Fix = fmt.Sprintf("sudo modprobe %s, and add it to /etc/modules-load.d/ for loading it at boot", m.name)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func checkIP6Tables() Result {
+	r := Result{Name: "ip6tables"}
+	switch {
+	case runtime.GOOS != "linux":
+		r.Status, r.Message = Skipped, "not running on Linux"
+	case !fileExists("/proc/net/if_inet6"):
+		r.Status, r.Message = Warning, "IPv6 is disabled; IPv6 and dual-stack clusters can't be created"
+		r.Fix = "enable IPv6, e.g. with sudo sysctl net.ipv6.conf.all.disable_ipv6=0"
+	case !moduleLoaded("ip6_tables"):
+		r.Status, r.Message = Warning, "the ip6_tables kernel module is not loaded; IPv6 and dual-stack clusters can fail"
+		r.Fix = "sudo modprobe ip6_tables"
+	default:
+		r.Status, r.Message = OK, "IPv6 and ip6tables are available"
+	}
+	return r
+}
+
+// readSysctl reads an integer kernel parameter
+func readSysctl(name string) (int64, error) {
+	path := "/proc/sys/" + strings.ReplaceAll(name, ".", "/")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, errors.Errorf("can't read %s", name)
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// moduleLoaded returns true if a kernel module is loaded or built into the kernel
+func moduleLoaded(name string) bool {
+	return fileExists("/sys/module/" + name)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Print prints the results, with the fixes for the checks not satisfied; it returns the number of failures
+func Print(out io.Writer, results []Result) int {
+	symbols := map[Status]string{OK: "✓", Warning: "!", Failure: "✗", Skipped: "-"}
+	failures := 0
+	for _, r := range results {
+		fmt.Fprintf(out, "%s %-28s %s\n", symbols[r.Status], r.Name, r.Message)
+		if r.Fix != "" {
+			fmt.Fprintf(out, "  %-28s fix: %s\n", "", r.Fix)
+		}
+		if r.Status == Failure {
+			failures++
+		}
+	}
+	return failures
+}
@@ -59,6 +59,13 @@ func (c *HostCmd) RunWithEcho() error {
 	return c.runInnnerCommand()
 }
 
+// RunWithOutput execute the inner command on a kind(er) node and writes the command output to w
+func (c *HostCmd) RunWithOutput(w io.Writer) error {
+	c.stdout = w
+	c.stderr = w
+	return c.runInnnerCommand()
+}
+
 // RunAndCapture executes the inner command on a kind(er) node and return the output captured during execution
 func (c *HostCmd) RunAndCapture() (lines []string, err error) {
 	var buff bytes.Buffer
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -36,16 +37,19 @@ import (
 //	command text, that can help in debugging, please set the KINDER_COLORS environment variable to ON.
 //
 // By default, when the command is run it does not print any output generated during execution.
-// See Silent, Stdin, RunWithEcho, RunAndCapture, Skip and DryRun for possible variations to the default behavior.
+// See Silent, Stdin, RunWithEcho, RunAndCapture, Skip, DryRun and ReadOnly for possible variations to the default behavior.
 type NodeCmd struct {
-	node    string
-	command string
-	args    []string
-	silent  bool
-	dryRun  bool
-	stdin   io.Reader
-	stdout  io.Writer
-	stderr  io.Writer
+	node     string
+	command  string
+	args     []string
+	silent   bool
+	dryRun   bool
+	readOnly bool
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+
+	recorder *Recorder
 }
 
 // NewNodeCmd returns a new ProxyCmd to run a command on a kind(er) node
@@ -104,6 +108,19 @@ func (c *NodeCmd) DryRun() *NodeCmd {
 	return c
 }
 
+// ReadOnly marks the command as not changing the node state, so it is executed also when dry running;
+// this allows to resolve e.g. versions or settings while dry running actions.
+func (c *NodeCmd) ReadOnly() *NodeCmd {
+	c.readOnly = true
+	return c
+}
+
+// Record instructs the proxy command to add a CommandResult to the given Recorder after execution.
+func (c *NodeCmd) Record(r *Recorder) *NodeCmd {
+	c.recorder = r
+	return c
+}
+
 func (c *NodeCmd) runInnnerCommand() error {
 	// define the proxy command used to pass the command to the node container
 	command := "docker"
@@ -146,20 +163,52 @@ func (c *NodeCmd) runInnnerCommand() error {
 		cmd.Stderr = c.stderr
 	}
 
-	// if not silent, prints the screen echo for the command to be executed
-	if !c.silent {
+	// if requested to record the command result, keeps track of the stderr tail
+	var tail *tailWriter
+	if c.recorder != nil {
+		tail = newTailWriter(maxStderrTailBytes)
+		if cmd.Stderr != nil {
+			cmd.Stderr = io.MultiWriter(cmd.Stderr, tail)
+		} else {
+			cmd.Stderr = tail
+		}
+	}
+	start := time.Now()
+
+	// read only commands are executed also when dry running
+	dryRun := c.dryRun && !c.readOnly
+
+	// if not silent, prints the screen echo for the command to be executed;
+	// when dry running, all the commands are printed
+	if !c.silent || dryRun {
 		prompt := colors.Prompt(fmt.Sprintf("%s:$ ", c.node))
 		command := colors.Command(fmt.Sprintf("%s %s", c.command, strings.Join(c.args, " ")))
 		fmt.Printf("\n%s%s\n", prompt, command)
 	}
 
-	// if we are dry running, eventually print the proxy command and then exit
-	if c.dryRun {
+	// if we are dry running, eventually print the proxy command and the data piped to the command, and then exit
+	if dryRun {
 		log.Debugf("Running: %s", strings.Join(cmd.Args, " "))
+		if c.stdin != nil {
+			if data, err := io.ReadAll(c.stdin); err == nil && len(data) > 0 {
+				fmt.Printf("%s\n", strings.TrimRight(string(data), "\n"))
+			}
+		}
+		if c.recorder != nil {
+			c.recorder.record(c.node, c.commandText(), start, true, nil, nil)
+		}
 		return nil
 	}
 
 	// eventually print the proxy command, and then run the command to be executed
 	log.Debugf("Running: %s", strings.Join(cmd.Args, " "))
-	return cmd.Run()
+	err := cmd.Run()
+	if c.recorder != nil {
+		c.recorder.record(c.node, c.commandText(), start, false, tail, err)
+	}
+	return err
+}
+
+func (c *NodeCmd) commandText() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s", c.command, strings.Join(c.args, " ")))
 }
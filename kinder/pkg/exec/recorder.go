@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxStderrTailBytes defines how many bytes of stderr are retained for each recorded command
+	maxStderrTailBytes = 4096
+
+	// maxStderrTailLines defines how many lines of stderr are reported for each recorded command
+	maxStderrTailLines = 20
+)
+
+// CommandResult is a machine-readable record of a command executed on a node
+type CommandResult struct {
+	Node       string   `json:"node"`
+	Command    string   `json:"command"`
+	StartTime  string   `json:"startTime"`
+	Duration   float64  `json:"durationSeconds"`
+	ExitCode   int      `json:"exitCode"`
+	DryRun     bool     `json:"dryRun,omitempty"`
+	StderrTail []string `json:"stderrTail,omitempty"`
+}
+
+// Recorder collects the CommandResult of all the NodeCmd it is attached to.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	results []CommandResult
+}
+
+// NewRecorder returns a new, empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Results returns a copy of the CommandResult collected so far, in execution order
+func (r *Recorder) Results() []CommandResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]CommandResult, len(r.results))
+	copy(results, r.results)
+	return results
+}
+
+func (r *Recorder) add(result CommandResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, result)
+}
+
+// record adds a CommandResult for a command completed at the given time
+func (r *Recorder) record(node, command string, start time.Time, dryRun bool, tail *tailWriter, err error) {
+	result := CommandResult{
+		Node:      node,
+		Command:   command,
+		StartTime: start.UTC().Format(time.RFC3339),
+		Duration:  time.Since(start).Seconds(),
+		ExitCode:  exitCode(err),
+		DryRun:    dryRun,
+	}
+	if tail != nil {
+		result.StderrTail = tail.lines(maxStderrTailLines)
+	}
+	r.add(result)
+}
+
+// exitCode returns the exit code of a command given the error returned by its execution;
+// -1 is used when the command did not run or was terminated by a signal
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// tailWriter is an io.Writer retaining only the last max bytes written
+type tailWriter struct {
+	max int
+	buf []byte
+}
+
+func newTailWriter(max int) *tailWriter {
+	return &tailWriter{max: max}
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > w.max {
+		w.buf = w.buf[len(w.buf)-w.max:]
+	}
+	return len(p), nil
+}
+
+// lines returns the last n non-empty lines retained by the writer
+func (w *tailWriter) lines(n int) []string {
+	lines := []string{}
+	for _, l := range strings.Split(string(w.buf), "\n") {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// cacheDirEnv is the env variable with the folder of the local cache of the files downloaded via http
+	cacheDirEnv = "KINDER_CACHE_DIR"
+
+	// cacheEnv is the env variable for enabling the local cache, that is disabled by default because
+	// files are never removed from the cache unless pruned
+	cacheEnv = "KINDER_CACHE"
+
+	// minTmpAge is the age of the last write after which the temporary file of a download is considered left
+	// by an interrupted download
+	minTmpAge = time.Hour
+)
+
+// DefaultCacheDir returns the folder of the local cache of the files downloaded via http, that is
+// KINDER_CACHE_DIR if set, or the kinder/bits folder in the user cache folder, e.g. ~/.cache/kinder/bits
+func DefaultCacheDir() (string, error) {
+	if dir := os.Getenv(cacheDirEnv); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Wrap(err, "error getting the folder for the cache")
+	}
+	return filepath.Join(dir, "kinder", "bits"), nil
+}
+
+// cache is a content-addressed local cache of the files downloaded via http; files are stored by sha256 digest
+// in the blobs folder, while the urls folder maps each URL to the digest of the file downloaded from it
+type cache struct {
+	dir string
+}
+
+// cacheEntry maps an URL to a file in the cache
+type cacheEntry struct {
+	URL    string `json:"url"`
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+	ETag   string `json:"etag,omitempty"`
+}
+
+// defaultCache returns the cache in DefaultCacheDir, or nil if the cache is not enabled
+func defaultCache() *cache {
+	if os.Getenv(cacheEnv) != "true" {
+		return nil
+	}
+	dir, err := DefaultCacheDir()
+	if err != nil {
+		log.Warnf("cache disabled: %v", err)
+		return nil
+	}
+	return &cache{dir: dir}
+}
+
+func (c *cache) entryPath(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(c.dir, "urls", hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *cache) blobPath(digest string) string {
+	return filepath.Join(c.dir, "blobs", "sha256", digest)
+}
+
+// fetch returns the path of the file in the cache for an URL, downloading it if it's not in the cache,
+// if the file in the cache is corrupted, or if the ETag of the URL changed
+func (c *cache) fetch(uri string) (string, error) {
+	if blob, ok := c.lookup(uri); ok {
+		log.Infof("Using %s from the cache", uri)
+		// keeps track of the usage of the file, for pruning the files not used recently
+		now := time.Now()
+		_ = os.Chtimes(blob, now, now)
+		return blob, nil
+	}
+	return c.download(uri)
+}
+
+// lookup returns the path of a valid file in the cache for an URL
+func (c *cache) lookup(uri string) (string, bool) {
+	data, err := os.ReadFile(c.entryPath(uri))
+	if err != nil {
+		return "", false
+	}
+	e := &cacheEntry{}
+	if err := json.Unmarshal(data, e); err != nil || e.URL != uri {
+		return "", false
+	}
+
+	blob := c.blobPath(e.Digest)
+	if digest, err := FileDigest(blob); err != nil || digest != e.Digest {
+		log.Warnf("ignoring corrupted file for %s in the cache", uri)
+		os.Remove(blob)
+		return "", false
+	}
+
+	// when possible, check that the file at the URL did not change; when the URL can't be reached,
+	// e.g. in air-gapped environments, the file in the cache is used
+	if e.ETag != "" {
+		if etag, err := httpHead(uri); err == nil && etag != "" && etag != e.ETag {
+			log.Infof("%s changed, ignoring the file in the cache", uri)
+			return "", false
+		}
+	}
+	return blob, true
+}
+
+// download downloads a file into the cache
+func (c *cache) download(uri string) (string, error) {
+	tmpDir := filepath.Join(c.dir, "tmp")
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return "", errors.Wrapf(err, "error creating the cache folder %s", c.dir)
+	}
+
+	resp, err := httpGetResponse(uri)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// the file is downloaded into a temporary file, and moved to the blobs folder only if complete
+	tmp, err := os.CreateTemp(tmpDir, "download-")
+	if err != nil {
+		return "", errors.Wrap(err, "error creating a file in the cache")
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	tracked, finish := downloads.track(path.Base(uri), resp.ContentLength, resp.Body)
+	size, err := io.Copy(io.MultiWriter(tmp, h), tracked)
+	finish()
+	tmp.Close()
+	if err != nil {
+		return "", errors.Wrapf(err, "error downloading %s", uri)
+	}
+	if resp.ContentLength >= 0 && size != resp.ContentLength {
+		return "", errors.Errorf("error downloading %s: got %d bytes, expected %d", uri, size, resp.ContentLength)
+	}
+
+	e := &cacheEntry{URL: uri, Digest: hex.EncodeToString(h.Sum(nil)), Size: size, ETag: resp.Header.Get("ETag")}
+	blob := c.blobPath(e.Digest)
+	if err := os.MkdirAll(filepath.Dir(blob), 0755); err != nil {
+		return "", errors.Wrapf(err, "error creating the cache folder %s", c.dir)
+	}
+	if err := os.Rename(tmp.Name(), blob); err != nil {
+		return "", errors.Wrap(err, "error adding the file to the cache")
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.entryPath(uri)), 0755); err != nil {
+		return "", errors.Wrapf(err, "error creating the cache folder %s", c.dir)
+	}
+	if err := os.WriteFile(c.entryPath(uri), data, 0644); err != nil {
+		return "", errors.Wrap(err, "error adding the file to the cache")
+	}
+	return blob, nil
+}
+
+// PruneCache removes the files in the cache that were not used in the given duration, or all the files if
+// olderThan is zero, and prints a summary of the removed files
+func PruneCache(out io.Writer, dir string, olderThan time.Duration) error {
+	c := &cache{dir: dir}
+	blobs, err := filepath.Glob(c.blobPath("*"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading the cache folder %s", dir)
+	}
+
+	var removed int
+	var freed int64
+	for _, b := range blobs {
+		info, err := os.Stat(b)
+		if err != nil {
+			continue
+		}
+		if olderThan > 0 && time.Since(info.ModTime()) < olderThan {
+			continue
+		}
+		if err := os.Remove(b); err != nil {
+			return errors.Wrapf(err, "error removing %s", b)
+		}
+		removed++
+		freed += info.Size()
+	}
+
+	// remove the entries pointing to files no longer in the cache
+	entries, err := filepath.Glob(filepath.Join(dir, "urls", "*.json"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading the cache folder %s", dir)
+	}
+	for _, p := range entries {
+		e := &cacheEntry{}
+		if data, err := os.ReadFile(p); err == nil && json.Unmarshal(data, e) == nil && e.Digest != "" {
+			if _, err := os.Stat(c.blobPath(e.Digest)); err == nil {
+				continue
+			}
+		}
+		if err := os.Remove(p); err != nil {
+			return errors.Wrapf(err, "error removing %s", p)
+		}
+	}
+
+	// remove the files left by interrupted downloads, while keeping the ones of downloads in progress, that are
+	// written continuously, also when pruning all the files
+	tmps, err := filepath.Glob(filepath.Join(dir, "tmp", "*"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading the cache folder %s", dir)
+	}
+	for _, p := range tmps {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) < max(olderThan, minTmpAge) {
+			continue
+		}
+		if err := os.Remove(p); err != nil {
+			return errors.Wrapf(err, "error removing %s", p)
+		}
+	}
+
+	fmt.Fprintf(out, "removed %d files from %s, %.1f MB freed\n", removed, dir, float64(freed)/(1<<20))
+	return nil
+}
+
+// httpHead returns the ETag of an URL
+func httpHead(uri string) (string, error) {
+	req, err := newHTTPRequest(http.MethodHead, uri)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{Transport: httpTransport(), Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("HTTP HEAD %s failed: %s", uri, resp.Status)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// FileDigest returns the sha256 digest of a file
+func FileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(cacheDirEnv, dir)
+	t.Setenv(cacheEnv, "true")
+
+	content, etag, gets := "kubeadm-v1", `"v1"`, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Method == http.MethodGet {
+			gets++
+			fmt.Fprint(w, content)
+		}
+	}))
+	defer server.Close()
+
+	uri := server.URL + "/kubeadm"
+	dst := filepath.Join(dir, "kubeadm")
+	copyAndCheck := func(expectedGets int) {
+		t.Helper()
+		if err := copyFromURI(uri, dst); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b, _ := os.ReadFile(dst); string(b) != content {
+			t.Errorf("expected %q, got %q", content, string(b))
+		}
+		if gets != expectedGets {
+			t.Errorf("expected %d downloads, got %d", expectedGets, gets)
+		}
+	}
+
+	// the first copy downloads the file, the second one reads it from the cache
+	copyAndCheck(1)
+	copyAndCheck(1)
+
+	// corrupted files in the cache are downloaded again
+	c := defaultCache()
+	blob, ok := c.lookup(uri)
+	if !ok {
+		t.Fatalf("expected %s in the cache", uri)
+	}
+	if err := os.WriteFile(blob, []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	copyAndCheck(2)
+
+	// files changed at the URL are downloaded again
+	content, etag = "kubeadm-v2", `"v2"`
+	copyAndCheck(3)
+
+	// pruning with a duration keeps the files used recently, while pruning all removes them; downloads in progress are always kept
+	inProgress, interrupted := filepath.Join(dir, "tmp", "download-1"), filepath.Join(dir, "tmp", "download-2")
+	for _, p := range []string{inProgress, interrupted} {
+		if err := os.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(interrupted, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := PruneCache(io.Discard, dir, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := c.lookup(uri); !ok {
+		t.Errorf("expected %s to be kept in the cache", uri)
+	}
+	if _, err := os.Stat(inProgress); err != nil {
+		t.Errorf("expected %s to be kept in the cache", inProgress)
+	}
+	if _, err := os.Stat(interrupted); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed from the cache", interrupted)
+	}
+	if err := PruneCache(io.Discard, dir, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := c.lookup(uri); ok {
+		t.Errorf("expected %s to be removed from the cache", uri)
+	}
+	if entries, _ := filepath.Glob(filepath.Join(dir, "urls", "*")); len(entries) != 0 {
+		t.Errorf("expected no entries in the cache, got %v", entries)
+	}
+	if _, err := os.Stat(inProgress); err != nil {
+		t.Errorf("expected %s to be kept in the cache also when pruning all", inProgress)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+)
+
+const (
+	// commitLabelPrefix is the prefix of the labels that refer to the build of a commit, e.g. ci/sha/8c4e3fa
+	commitLabelPrefix = "sha/"
+
+	// ciCommitLength is the length of the commit sha in the version of ci builds, e.g. v1.33.0-alpha.2.101+8c4e3faed35411
+	ciCommitLength = 14
+)
+
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// resolveVersion gets the Kubernetes version for src, that can be a version, a label, or the sha
+// of a commit prefixed by sha/
+func resolveVersion(repository, src string) (*K8sVersion.Version, error) {
+	if version, err := K8sVersion.ParseSemantic(src); err == nil {
+		return version, nil
+	}
+	if commit, ok := strings.CutPrefix(src, commitLabelPrefix); ok {
+		return resolveCommit(repository, commit)
+	}
+	return resolveLabel(repository, src)
+}
+
+// gcsListResponse is the response of the GCS JSON API for listing objects
+type gcsListResponse struct {
+	Items []struct {
+		Name string `json:"name"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// resolveCommit gets the version of the build of a commit in a repository hosted in a GCS bucket,
+// by listing the builds having the commit sha in the version; abbreviated shas are accepted, but
+// they must match a single build
+func resolveCommit(repository, commit string) (*K8sVersion.Version, error) {
+	commit = strings.ToLower(commit)
+	if !commitRegexp.MatchString(commit) {
+		return nil, errors.Errorf("invalid commit sha %q, expected 7 to 40 hex characters", commit)
+	}
+	if len(commit) > ciCommitLength {
+		commit = commit[:ciCommitLength]
+	}
+
+	if repository == releaseBuildURepository {
+		return nil, errors.Errorf("commits can be resolved only for ci builds, e.g. ci/%s%s", commitLabelPrefix, commit)
+	}
+	u, err := url.Parse(repository)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid repository %s", repository)
+	}
+	bucket, prefix, _ := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	if bucket == "" {
+		return nil, errors.Errorf("commits can be resolved only for repositories in GCS buckets, got %s", repository)
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	query := url.Values{}
+	query.Set("prefix", prefix+"v")
+	query.Set("matchGlob", fmt.Sprintf("%sv*+%s*/bin/linux/*/kubeadm", prefix, commit))
+	query.Set("fields", "items(name),nextPageToken")
+	uri := fmt.Sprintf("%s://%s/storage/v1/b/%s/o", u.Scheme, u.Host, bucket)
+	log.Debugf("Resolving commit %s in %s", commit, repository)
+
+	versions := map[string]*K8sVersion.Version{}
+	for pageToken := ""; ; {
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		list, err := listObjects(uri + "?" + query.Encode())
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing the builds of commit %s in %s", commit, repository)
+		}
+		for _, i := range list.Items {
+			folder, _, _ := strings.Cut(strings.TrimPrefix(i.Name, prefix), "/")
+			v, err := K8sVersion.ParseSemantic(folder)
+			if err != nil || !strings.HasPrefix(v.BuildMetadata(), commit) {
+				continue
+			}
+			versions[v.String()] = v
+		}
+		if pageToken = list.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, errors.Errorf("no build of commit %s found in %s", commit, repository)
+	}
+	var found []string
+	for v := range versions {
+		found = append(found, "v"+v)
+	}
+	if len(found) > 1 {
+		sort.Strings(found)
+		return nil, errors.Errorf("commit %s matches many builds in %s: %s; use a longer sha", commit, repository, strings.Join(found, ", "))
+	}
+	log.Debugf("Commit %s resolves to %s", commit, found[0])
+	return versions[strings.TrimPrefix(found[0], "v")], nil
+}
+
+func listObjects(uri string) (*gcsListResponse, error) {
+	_, r, err := httpGet(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	list := &gcsListResponse{}
+	if err := json.NewDecoder(r).Decode(list); err != nil {
+		return nil, errors.Wrap(err, "error reading the list of objects")
+	}
+	return list, nil
+}
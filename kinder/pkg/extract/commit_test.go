@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestResolveCommit(t *testing.T) {
+	builds := []string{
+		"v1.33.0-alpha.2.101+8c4e3faed35411",
+		"v1.33.0-alpha.2.102+8c4e3fab000000",
+		"v1.33.0-alpha.2.103+a1b2c3d4e5f678",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/b/bucket/o" || r.URL.Query().Get("prefix") != "ci/v" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		list := gcsListResponse{}
+		for _, b := range builds {
+			for _, arch := range []string{"amd64", "arm64"} {
+				name := "ci/" + b + "/bin/linux/" + arch + "/kubeadm"
+				if ok, _ := path.Match(r.URL.Query().Get("matchGlob"), name); ok {
+					list.Items = append(list.Items, struct {
+						Name string `json:"name"`
+					}{Name: name})
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	var tests = []struct {
+		commit          string
+		expectedVersion string
+		expectedError   string
+	}{
+		{commit: "8c4e3fae", expectedVersion: "1.33.0-alpha.2.101+8c4e3faed35411"},
+		{commit: "8C4E3FAED35411", expectedVersion: "1.33.0-alpha.2.101+8c4e3faed35411"},
+		{commit: "a1b2c3d4e5f6789012345678901234567890abcd", expectedVersion: "1.33.0-alpha.2.103+a1b2c3d4e5f678"},
+		{commit: "8c4e3fa", expectedError: "matches many builds"},
+		{commit: "8c4e3f", expectedError: "invalid commit sha"},
+		{commit: "8c4e3f0", expectedError: "no build of commit"},
+		{commit: "8c4e3fX", expectedError: "invalid commit sha"},
+	}
+	for _, rt := range tests {
+		t.Run(rt.commit, func(t *testing.T) {
+			v, err := resolveCommit(server.URL+"/bucket/ci", rt.commit)
+			if rt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), rt.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", rt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v.String() != rt.expectedVersion {
+				t.Errorf("expected %s, got %s", rt.expectedVersion, v)
+			}
+		})
+	}
+}
+
+func TestIsLabel(t *testing.T) {
+	var tests = []struct {
+		src      string
+		expected bool
+	}{
+		{src: "v1.33.0", expected: false},
+		{src: "ci/latest", expected: true},
+		{src: "release/stable-1.33", expected: true},
+		{src: "ci/v1.34.0-alpha.1.120+0123456789abcd", expected: false},
+		{src: "ci/sha/0123456789abcd", expected: false},
+		{src: "gs://my-bucket/ci/latest-1.33", expected: true},
+		{src: "gs://my-bucket/ci/sha/0123456789abcd", expected: false},
+		{src: "https://k8s.mycompany.com/v1.33.0", expected: false},
+		{src: "/tmp/bits", expected: false},
+	}
+	for _, rt := range tests {
+		t.Run(rt.src, func(t *testing.T) {
+			if actual := IsLabel(rt.src); actual != rt.expected {
+				t.Errorf("expected %t, got %t", rt.expected, actual)
+			}
+		})
+	}
+}
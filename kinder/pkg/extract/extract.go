@@ -47,6 +47,13 @@ const (
 	ciBuildRepository       = "https://storage.googleapis.com/k8s-release-dev/ci"
 	releaseBuildURepository = "https://dl.k8s.io/release"
 
+	// gcsURL is the http endpoint for reading from GCS buckets
+	gcsURL = "https://storage.googleapis.com"
+
+	// gcsAccessTokenEnv is the env variable with the access token for reading from private GCS buckets,
+	// e.g. the output of gcloud auth print-access-token
+	gcsAccessTokenEnv = "KINDER_GCS_ACCESS_TOKEN"
+
 	kubeadmBinary = "kubeadm"
 	kubeletBinary = "kubelet"
 	kubectlBinary = "kubectl"
@@ -77,12 +84,29 @@ const (
 
 	// LocalRepositorySource describe a src that is hosted in local repository
 	LocalRepositorySource
+
+	// GCSBucketSource describe a src that is hosted in a GCS bucket with the same layout of ciBuildRepository,
+	// e.g. gs://my-bucket/ci/latest-1.33 or gs://my-bucket/ci/v1.33.0
+	GCSBucketSource
+
+	// OCIRegistrySource describe a src that is an OCI artifact with a layer for each file,
+	// e.g. oci://registry.example.com/kubernetes/bits:v1.33.0
+	OCIRegistrySource
+
+	// ProviderSource describe a src that is handled by a Provider registered for its scheme, e.g. mirror://v1.33.0
+	ProviderSource
 )
 
 // GetSourceType returns the src type descriptor
 func GetSourceType(src string) SourceType {
-	if strings.HasPrefix(src, "file://") {
+	if _, ok := providerFor(src); ok {
+		return ProviderSource
+	} else if strings.HasPrefix(src, "file://") {
 		return LocalRepositorySource
+	} else if strings.HasPrefix(src, "gs://") {
+		return GCSBucketSource
+	} else if strings.HasPrefix(src, "oci://") {
+		return OCIRegistrySource
 	} else if strings.HasPrefix(src, "release/") {
 		return ReleaseLabelOrVersionSource
 	} else if strings.HasPrefix(src, "ci/") {
@@ -166,6 +190,16 @@ func WithVersionFile(enable bool) Option {
 	}
 }
 
+// WithArch option instructs the Extractor to retrieve artifacts for the given architecture from ci/release builds;
+// by default amd64 artifacts are retrieved
+func WithArch(arch string) Option {
+	return func(b *Extractor) {
+		if arch != "" {
+			b.options.arch = arch
+		}
+	}
+}
+
 // WithVersionFolder option instructs the Extractor to save all files in a folder named like the kubernetes version
 func WithVersionFolder(versionFolder bool) Option {
 	return func(b *Extractor) {
@@ -185,6 +219,16 @@ type Extractor struct {
 	dstMutator fileNameMutator
 	// add version file to dst
 	addVersionFileToDst bool
+	// options for the source
+	options sourceOptions
+}
+
+// sourceOptions defines options for reading from a source
+type sourceOptions struct {
+	// arch of the artifacts to retrieve from ci/release builds
+	arch string
+	// verifier of the files downloaded via http, if any
+	verifier *verifier
 }
 
 // NewExtractor returns a new extractor configured with the given options
@@ -195,6 +239,7 @@ func NewExtractor(src, dst string, options ...Option) (extractor *Extractor) {
 		dst:                 dst,
 		dstMutator:          fileNameMutator{},
 		addVersionFileToDst: true,
+		options:             sourceOptions{arch: "amd64"},
 	}
 
 	// apply user options
@@ -219,61 +264,56 @@ func (e *Extractor) Extract() (paths map[string]string, err error) {
 		f = extractFromReleaseBuild
 	case CILabelOrVersionSource:
 		f = extractFromCIBuild
+	case GCSBucketSource:
+		f = extractFromGCSBucket
+	case OCIRegistrySource:
+		f = extractFromOCIRegistry
 	case RemoteRepositorySource:
-		f = extractFromHTTP
+		f = extractFromRemoteRepository
 	case LocalRepositorySource:
 		f = extractFromLocalDir
+	case ProviderSource:
+		f = extractFromProvider
 	default:
 		return nil, errors.Errorf("source %s did not resolve to a valid source type", e.src)
 	}
 
-	return f(e.src, e.files, e.dst, e.dstMutator, e.addVersionFileToDst)
+	return f(e.src, e.files, e.dst, e.options, e.dstMutator, e.addVersionFileToDst)
 }
 
 // extractFunc define a function that implements an extractor method
-type extractFunc func(string, []string, string, fileNameMutator, bool) (map[string]string, error)
+type extractFunc func(string, []string, string, sourceOptions, fileNameMutator, bool) (map[string]string, error)
 
-func extractFromCIBuild(src string, files []string, dst string, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+func extractFromCIBuild(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
 	// cleanup the src from the prefix, if any
 	src = strings.TrimPrefix(src, "ci/")
 
-	// gets the Kubernetes version from the src
-	version, err := K8sVersion.ParseSemantic(src)
-	if err != nil {
-		version, err = resolveLabel(ciBuildRepository, src)
-		if err != nil {
-			return nil, err
-		}
-	}
+	return extractFromBuild(ciBuildRepository, src, files, dst, o, m, addVersionFileToDst)
+}
 
-	// saves the version file (if requested)
-	// nb. version file is created so the target folder can be eventually used as a source
-	if err := saveVersionFile(addVersionFileToDst, dst, version, m); err != nil {
-		return nil, errors.Wrapf(err, "error creating version file in %s", dst)
-	}
+func extractFromReleaseBuild(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+	// cleanup the source src the prefix, if any
+	src = strings.TrimPrefix(src, "release/")
 
-	// pass the version to the file name mutator
-	// nb. this will allow to save extracted files into a version folder
-	m.SetPrependVersionFolder(version)
+	return extractFromBuild(releaseBuildURepository, src, files, dst, o, m, addVersionFileToDst)
+}
 
-	// sets the url for downloading the requested ci version
-	src = fmt.Sprintf("%s/v%s", ciBuildRepository, version)
+func extractFromGCSBucket(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+	repository, label, err := gcsRepository(src)
+	if err != nil {
+		return nil, err
+	}
 
-	// read from the src via http, taking care of setting addVersionFileToDst (because it was already saved above)
-	return extractFromHTTP(src, files, dst, m, false)
+	return extractFromBuild(repository, label, files, dst, o, m, addVersionFileToDst)
 }
 
-func extractFromReleaseBuild(src string, files []string, dst string, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
-	// cleanup the source src the prefix, if any
-	src = strings.TrimPrefix(src, "release/")
-
+// extractFromBuild reads a version or a label from a repository with the layout of the Kubernetes
+// ci/release builds, where labels are .txt files and files are stored in vVERSION/bin/linux/ARCH
+func extractFromBuild(repository, src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
 	// gets the Kubernetes version from the src
-	version, err := K8sVersion.ParseSemantic(src)
+	version, err := resolveVersion(repository, src)
 	if err != nil {
-		version, err = resolveLabel(releaseBuildURepository, src)
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
 	// saves the version file (if requested)
@@ -286,14 +326,39 @@ func extractFromReleaseBuild(src string, files []string, dst string, m fileNameM
 	// nb. this will allow to save extracted files into a version folder
 	m.SetPrependVersionFolder(version)
 
-	// sets the url for downloading the requested release version
-	src = fmt.Sprintf("%s/v%s", releaseBuildURepository, version)
+	// sets the url for downloading the requested version
+	src = fmt.Sprintf("%s/v%s/bin/linux/%s", repository, version, o.arch)
 
 	// read from the src via http, taking care of setting addVersionFileToDst (because it was already saved above)
-	return extractFromHTTP(src, files, dst, m, false)
+	return extractFromHTTP(src, files, dst, o, m, false)
+}
+
+func extractFromRemoteRepository(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+	// in case the source is a Kubernetes build, add bin/OS/ARCH to the src uri
+	if strings.HasPrefix(src, releaseBuildURepository) || strings.HasPrefix(src, ciBuildRepository) {
+		src = fmt.Sprintf("%s/bin/linux/%s", src, o.arch)
+	}
+
+	return extractFromHTTP(src, files, dst, o, m, addVersionFileToDst)
+}
+
+// gcsRepository splits a gs://BUCKET/PATH/LABEL source into the http url of the repository,
+// that is the bucket and the path, and the version or the label to read from the repository
+func gcsRepository(src string) (repository, label string, err error) {
+	bucketPath := strings.TrimSuffix(strings.TrimPrefix(src, "gs://"), "/")
+	i := strings.LastIndex(bucketPath, "/")
+	if i <= 0 || i == len(bucketPath)-1 {
+		return "", "", errors.Errorf("invalid GCS source %s, expected gs://BUCKET/PATH/LABEL or gs://BUCKET/PATH/VERSION", src)
+	}
+	repository, label = bucketPath[:i], bucketPath[i+1:]
+	// labels for commits have two segments, e.g. gs://BUCKET/PATH/sha/COMMIT
+	if r, ok := strings.CutSuffix(repository, "/"+strings.TrimSuffix(commitLabelPrefix, "/")); ok && strings.Contains(r, "/") {
+		repository, label = r, commitLabelPrefix+label
+	}
+	return fmt.Sprintf("%s/%s", gcsURL, repository), label, nil
 }
 
-func extractFromHTTP(src string, files []string, dst string, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+func extractFromHTTP(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
 	dst, _ = filepath.Abs(dst)
 	if _, err := os.Stat(dst); os.IsNotExist(err) {
 		return nil, errors.Errorf("destination path %s does not exists", dst)
@@ -311,31 +376,33 @@ func extractFromHTTP(src string, files []string, dst string, m fileNameMutator,
 		files = append(files, "version")
 	}
 
-	// in case the source is a Kubernetes build, add bin/OS/ARCH to the src uri
-	if strings.HasPrefix(src, releaseBuildURepository) || strings.HasPrefix(src, ciBuildRepository) {
-		src = fmt.Sprintf("%s/bin/linux/amd64", src)
-	}
-
-	// Download the files.
-	paths = map[string]string{}
-	for _, f := range files {
+	// Download the files, concurrently.
+	paths, err = extractAll(files, func(f string) (string, error) {
 		srcFilePath := fmt.Sprintf("%s/%s", src, f)
 		log.Infof("Downloading %s\n", srcFilePath)
 		dstFilePath := path.Join(dst, m.Mutate(f))
 		if err := copyFromURI(srcFilePath, dstFilePath); err != nil {
-			return nil, errors.Wrapf(err, "failed to copy %s to %s", srcFilePath, dstFilePath)
+			return "", errors.Wrapf(err, "failed to copy %s to %s", srcFilePath, dstFilePath)
+		}
+		// verify the file, if required, before it is used; files that can't be verified are removed
+		if err := o.verifier.verify(srcFilePath, f, dstFilePath); err != nil {
+			os.Remove(dstFilePath)
+			return "", err
 		}
 		if f == kubeadmBinary || f == kubeletBinary || f == kubectlBinary {
 			os.Chmod(dstFilePath, 0755)
 		}
-		paths[f] = dstFilePath
+		return dstFilePath, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	log.Infof("Downloaded files saved into %s", dst)
 
 	return paths, nil
 }
 
-func extractFromLocalDir(src string, files []string, dst string, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+func extractFromLocalDir(src string, files []string, dst string, _ sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
 	// checks if source folder exists
 	src, _ = filepath.Abs(src)
 	if _, err := os.Stat(src); os.IsNotExist(err) {
@@ -507,20 +574,34 @@ var httpGetBackoff = wait.Backoff{
 }
 
 func httpGet(uri string) (int64, io.ReadCloser, error) {
+	resp, err := httpGetResponse(uri)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.ContentLength, resp.Body, nil
+}
+
+func httpGetResponse(uri string) (*http.Response, error) {
 	var lastError error
 	var resp *http.Response
 
 	// Create a custom http.Client with redirect behavior
 	client := &http.Client{
+		Transport: httpTransport(),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			// Allow redirects
 			return nil
 		},
 	}
 
-	err := wait.ExponentialBackoff(httpGetBackoff, func() (bool, error) {
+	req, err := newHTTPRequest(http.MethodGet, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wait.ExponentialBackoff(httpGetBackoff, func() (bool, error) {
 		var err error
-		resp, err = client.Get(uri)
+		resp, err = client.Do(req)
 		if err != nil {
 			log.Warnf("HTTP GET %s failed. Retry in few seconds", uri)
 			lastError = errors.Wrapf(err, "HTTP GET %s failed", uri)
@@ -534,13 +615,35 @@ func httpGet(uri string) (int64, io.ReadCloser, error) {
 		return true, nil
 	})
 	if err != nil {
-		return 0, nil, lastError
+		return nil, lastError
 	}
 
-	return resp.ContentLength, resp.Body, nil
+	return resp, nil
+}
+
+func newHTTPRequest(method, uri string) (*http.Request, error) {
+	req, err := http.NewRequest(method, uri, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid URI %s", uri)
+	}
+	// reading from private GCS buckets requires an access token
+	if token := os.Getenv(gcsAccessTokenEnv); token != "" && strings.HasPrefix(uri, gcsURL+"/") {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
 }
 
+// copyFromURI downloads a file, using the local cache if enabled
 func copyFromURI(src, dst string) error {
+	if c := defaultCache(); c != nil {
+		blob, err := c.fetch(src)
+		if err == nil {
+			// files are copied, because they can be changed after extraction, e.g. by fixing image tarballs
+			return kindfs.CopyFile(blob, dst)
+		}
+		log.Warnf("error using the cache for %s, downloading it: %v", src, err)
+	}
+
 	size, r, err := httpGet(src)
 	if err != nil {
 		return errors.Wrapf(err, "error getting reader for %s", src)
@@ -561,7 +664,9 @@ func copyFromURI(src, dst string) error {
 	}
 	defer w.Close()
 
-	if _, err := io.Copy(w, r); err != nil {
+	tracked, finish := downloads.track(path.Base(src), size, r)
+	defer finish()
+	if _, err := io.Copy(w, tracked); err != nil {
 		return errors.Wrapf(err, "error copying %s to %s", src, dst)
 	}
 
@@ -629,6 +734,30 @@ func (m *fileNameMutator) SetPrependVersionFolder(version *K8sVersion.Version) {
 	}
 }
 
+// IsLabel returns true if src is a build label, e.g. ci/latest or release/stable-1.33, that resolves
+// to different versions over time; versions and commit shas are not labels
+func IsLabel(src string) bool {
+	var label string
+	switch GetSourceType(src) {
+	case ReleaseLabelOrVersionSource:
+		label = strings.TrimPrefix(src, "release/")
+	case CILabelOrVersionSource:
+		label = strings.TrimPrefix(src, "ci/")
+	case GCSBucketSource:
+		_, l, err := gcsRepository(src)
+		if err != nil {
+			return false
+		}
+		label = l
+	default:
+		return false
+	}
+	if _, err := K8sVersion.ParseSemantic(label); err == nil {
+		return false
+	}
+	return !strings.HasPrefix(label, commitLabelPrefix)
+}
+
 // ResolveLabel provide a utility func for resolving a label
 func ResolveLabel(src string) (version string, err error) {
 	var repository string
@@ -641,11 +770,19 @@ func ResolveLabel(src string) (version string, err error) {
 		src = strings.TrimPrefix(src, "ci/")
 
 		repository = ciBuildRepository
+	case GCSBucketSource:
+		repository, src, err = gcsRepository(src)
+		if err != nil {
+			return "", err
+		}
+	case ProviderSource:
+		p, _ := providerFor(src)
+		return p.Version(src)
 	default:
 		return "", errors.Errorf("source %s did not resolve to a valid label", src)
 	}
 
-	v, err := resolveLabel(repository, src)
+	v, err := resolveVersion(repository, src)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig is the proxy used for downloading bits; empty fields default to the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY env variables
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// WithEnvDefaults returns the proxy config with the empty fields set from the env variables
+func (p ProxyConfig) WithEnvDefaults() ProxyConfig {
+	env := httpproxy.FromEnvironment()
+	if p.HTTPProxy == "" {
+		p.HTTPProxy = env.HTTPProxy
+	}
+	if p.HTTPSProxy == "" {
+		p.HTTPSProxy = env.HTTPSProxy
+	}
+	if p.NoProxy == "" {
+		p.NoProxy = env.NoProxy
+	}
+	return p
+}
+
+// Env returns the env variables for the proxy config, in upper and lower case because
+// tools read one or the other, e.g. HTTPS_PROXY=http://proxy:3128 and https_proxy=http://proxy:3128
+func (p ProxyConfig) Env() []string {
+	var env []string
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", p.HTTPProxy},
+		{"HTTPS_PROXY", p.HTTPSProxy},
+		{"NO_PROXY", p.NoProxy},
+	} {
+		if v.value != "" {
+			env = append(env, v.name+"="+v.value, strings.ToLower(v.name)+"="+v.value)
+		}
+	}
+	return env
+}
+
+var (
+	transportMu sync.RWMutex
+	transport   http.RoundTripper = http.DefaultTransport
+)
+
+// ConfigureHTTP configures the proxy and the additional CA certificates, in PEM format, trusted when downloading
+// bits, e.g. for networks with TLS interception; certificates are trusted in addition to the system ones
+func ConfigureHTTP(proxy ProxyConfig, caCertificates []string) error {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxy = proxy.WithEnvDefaults()
+	proxyFunc := (&httpproxy.Config{HTTPProxy: proxy.HTTPProxy, HTTPSProxy: proxy.HTTPSProxy, NoProxy: proxy.NoProxy}).ProxyFunc()
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	if len(caCertificates) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, path := range caCertificates {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrapf(err, "error reading the CA certificates in %s", path)
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				return errors.Errorf("%s does not contain CA certificates in PEM format", path)
+			}
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	transport = t
+	return nil
+}
+
+// httpTransport returns the transport for the http clients downloading bits
+func httpTransport() http.RoundTripper {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return transport
+}
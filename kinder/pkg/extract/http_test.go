@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProxyConfigEnv(t *testing.T) {
+	var tests = []struct {
+		name     string
+		proxy    ProxyConfig
+		expected []string
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:     "https proxy only",
+			proxy:    ProxyConfig{HTTPSProxy: "http://proxy:3128"},
+			expected: []string{"HTTPS_PROXY=http://proxy:3128", "https_proxy=http://proxy:3128"},
+		},
+		{
+			name:  "all settings",
+			proxy: ProxyConfig{HTTPProxy: "http://proxy:3128", HTTPSProxy: "http://proxy:3129", NoProxy: "localhost"},
+			expected: []string{
+				"HTTP_PROXY=http://proxy:3128", "http_proxy=http://proxy:3128",
+				"HTTPS_PROXY=http://proxy:3129", "https_proxy=http://proxy:3129",
+				"NO_PROXY=localhost", "no_proxy=localhost",
+			},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			if env := rt.proxy.Env(); !reflect.DeepEqual(env, rt.expected) {
+				t.Errorf("expected %v, got %v", rt.expected, env)
+			}
+		})
+	}
+}
+
+func TestConfigureHTTP(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "kubeadm")
+	}))
+	defer server.Close()
+	defer func(t http.RoundTripper) { transport = t }(transport)
+
+	dir := t.TempDir()
+	caCert := filepath.Join(dir, "ca.crt")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCert, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.crt")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ConfigureHTTP(ProxyConfig{}, []string{invalid}); err == nil {
+		t.Errorf("expected an error for a file without certificates")
+	}
+
+	if err := ConfigureHTTP(ProxyConfig{NoProxy: "*"}, []string{caCert}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, r, err := httpGet(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Close()
+	if b, _ := io.ReadAll(r); string(b) != "kubeadm" {
+		t.Errorf("expected kubeadm, got %q", string(b))
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/oci"
+)
+
+// download saves a blob into dst, verifying its digest
+func download(c *oci.Client, layer oci.Descriptor, dst string) error {
+	algorithm, digest, ok := strings.Cut(layer.Digest, ":")
+	if !ok || algorithm != "sha256" {
+		return errors.Errorf("unsupported digest %s", layer.Digest)
+	}
+	blob, err := c.Blob(layer.Digest)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	w, err := os.Create(dst)
+	if err != nil {
+		return errors.Wrapf(err, "error creating %s", dst)
+	}
+	defer w.Close()
+
+	h := sha256.New()
+	tracked, finish := downloads.track(path.Base(dst), layer.Size, blob)
+	defer finish()
+	if _, err := io.Copy(io.MultiWriter(w, h), tracked); err != nil {
+		return errors.Wrapf(err, "error copying %s to %s", layer.Digest, dst)
+	}
+	if hex.EncodeToString(h.Sum(nil)) != digest {
+		return errors.Errorf("digest of %s does not match %s", dst, layer.Digest)
+	}
+	return nil
+}
+
+// extractFromOCIRegistry reads files from an OCI artifact, where each file is a layer named after
+// the org.opencontainers.image.title annotation, like artifacts pushed with e.g. oras push
+func extractFromOCIRegistry(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+	ref, err := oci.ParseReference(src)
+	if err != nil {
+		return nil, err
+	}
+	dst, _ = filepath.Abs(dst)
+	if _, err := os.Stat(dst); os.IsNotExist(err) {
+		return nil, errors.Errorf("destination path %s does not exists", dst)
+	}
+
+	c := oci.NewClient(ref, &http.Client{Transport: httpTransport()})
+	manifest, err := c.Manifest(o.arch)
+	if err != nil {
+		return nil, err
+	}
+	layers := map[string]oci.Descriptor{}
+	for _, l := range manifest.Layers {
+		if title := l.Annotations[oci.TitleAnnotation]; title != "" {
+			layers[title] = l
+		}
+	}
+
+	// read version file (only if required by the fileNameMutator)
+	// nb. this will allow to save extracted files into a version folder
+	if m.prependVersionFolder {
+		l, ok := layers["version"]
+		if !ok {
+			return nil, errors.Errorf("%s does not provide a version file", src)
+		}
+		blob, err := c.Blob(l.Digest)
+		if err != nil {
+			return nil, err
+		}
+		version, err := readVersion(blob)
+		blob.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading version from %s", src)
+		}
+		m.SetPrependVersionFolder(version)
+	}
+	if err := m.EnsureFolder(dst); err != nil {
+		return nil, err
+	}
+
+	// expand wildcards defined in the list of files, if any, e.g. for getting all the image tarballs
+	var expandedFiles []string
+	for _, f := range files {
+		if !strings.Contains(f, "*") {
+			expandedFiles = append(expandedFiles, f)
+			continue
+		}
+		for _, title := range slices.Sorted(maps.Keys(layers)) {
+			if ok, _ := path.Match(f, title); ok {
+				expandedFiles = append(expandedFiles, title)
+			}
+		}
+	}
+
+	// if required, add the version file to the list of files to be copied to dest
+	// nb. version file is created so the target folder can be eventually used as a source
+	if addVersionFileToDst {
+		expandedFiles = append(expandedFiles, "version")
+	}
+
+	for _, f := range expandedFiles {
+		if _, ok := layers[f]; !ok {
+			return nil, errors.Errorf("%s does not provide %s", src, f)
+		}
+	}
+	paths, err = extractAll(expandedFiles, func(f string) (string, error) {
+		log.Infof("Pulling %s from %s\n", f, src)
+		dstFilePath := path.Join(dst, m.Mutate(f))
+		if err := download(c, layers[f], dstFilePath); err != nil {
+			return "", errors.Wrapf(err, "failed to pull %s from %s", f, src)
+		}
+		if f == kubeadmBinary || f == kubeletBinary || f == kubectlBinary {
+			os.Chmod(dstFilePath, 0755)
+		}
+		return dstFilePath, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Pulled files saved into %s", dst)
+
+	return paths, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kubeadm/kinder/pkg/oci"
+)
+
+func TestExtractFromOCIRegistry(t *testing.T) {
+	blobs := map[string][]byte{}
+	layer := func(title, content string) oci.Descriptor {
+		sum := sha256.Sum256([]byte(content))
+		digest := "sha256:" + hex.EncodeToString(sum[:])
+		blobs[digest] = []byte(content)
+		return oci.Descriptor{Digest: digest, Size: int64(len(content)), Annotations: map[string]string{oci.TitleAnnotation: title}}
+	}
+	manifest, _ := json.Marshal(oci.Manifest{
+		MediaType: oci.ManifestMediaType,
+		Layers: []oci.Descriptor{
+			layer("kubeadm", "kubeadm-arm64"),
+			layer("kube-apiserver.tar", "apiserver-arm64"),
+			layer("version", "v1.33.0"),
+		},
+	})
+	index := fmt.Sprintf(`{"mediaType":%q,"manifests":[{"digest":"sha256:amd64","platform":{"os":"linux","architecture":"amd64"}},{"digest":"sha256:arm64","platform":{"os":"linux","architecture":"arm64"}}]}`, oci.IndexMediaType)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:kube/bits:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token":"secret"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",scope="repository:kube/bits:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v2/kube/bits/manifests/v1.33.0":
+			fmt.Fprint(w, index)
+		case r.URL.Path == "/v2/kube/bits/manifests/sha256:arm64":
+			w.Write(manifest)
+		case strings.HasPrefix(r.URL.Path, "/v2/kube/bits/blobs/"):
+			if b, ok := blobs[strings.TrimPrefix(r.URL.Path, "/v2/kube/bits/blobs/")]; ok {
+				w.Write(b)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dst := t.TempDir()
+	src := fmt.Sprintf("oci://%s/kube/bits:v1.33.0", strings.TrimPrefix(server.URL, "http://"))
+	if GetSourceType(src) != OCIRegistrySource {
+		t.Fatalf("expected %s to be an OCI source", src)
+	}
+	e := NewExtractor(src, dst, WithArch("arm64"))
+	e.SetFiles([]string{"kubeadm", "*.tar"})
+	paths, err := e.Extract()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"kubeadm": "kubeadm-arm64", "kube-apiserver.tar": "apiserver-arm64", "version": "v1.33.0"}
+	if len(paths) != len(expected) {
+		t.Errorf("expected %d files, got %v", len(expected), paths)
+	}
+	for f, content := range expected {
+		b, err := os.ReadFile(filepath.Join(dst, f))
+		if err != nil || string(b) != content {
+			t.Errorf("expected %s to contain %q, got %q, %v", f, content, string(b), err)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dst, "kubeadm")); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("expected kubeadm to be executable")
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxParallelDownloads is the maximum number of files downloaded at the same time, across all the extractors
+	maxParallelDownloads = 4
+
+	// progressInterval is the interval between progress reports
+	progressInterval = 3 * time.Second
+)
+
+// downloadSlots limits the number of files downloaded at the same time
+var downloadSlots = make(chan struct{}, maxParallelDownloads)
+
+// extractAll runs extract for each file concurrently, and returns the paths of the extracted files by file name;
+// the first error is returned, after all the files are processed
+func extractAll(files []string, extract func(f string) (string, error)) (map[string]string, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	paths := map[string]string{}
+	errs := make([]error, len(files))
+	for i, f := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			downloadSlots <- struct{}{}
+			defer func() { <-downloadSlots }()
+
+			p, err := extract(f)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			mu.Lock()
+			paths[f] = p
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// downloads reports the progress of the files downloaded at the same time
+var downloads = &progress{out: os.Stderr, interval: progressInterval}
+
+// progress tracks the progress of a batch of downloads, that starts with the first download and
+// ends when there are no more downloads in progress, and periodically prints a report
+type progress struct {
+	out      io.Writer
+	interval time.Duration
+
+	mu     sync.Mutex
+	items  []*progressItem
+	start  time.Time
+	active int
+	stop   chan struct{}
+}
+
+// progressItem is a file being downloaded; size is -1 when unknown
+type progressItem struct {
+	name string
+	size int64
+	done int64
+}
+
+// track returns a reader that tracks the download of a file with the given name and size from r;
+// finish must be called when the download ends
+func (p *progress) track(name string, size int64, r io.Reader) (tracked io.Reader, finish func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.active == 0 {
+		p.items, p.start, p.stop = nil, time.Now(), make(chan struct{})
+		go p.report(p.stop)
+	}
+	p.active++
+	item := &progressItem{name: name, size: size}
+	p.items = append(p.items, item)
+
+	return &progressReader{p: p, item: item, r: r}, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.active--
+		if p.active == 0 {
+			close(p.stop)
+			if len(p.items) > 1 {
+				fmt.Fprintln(p.out, p.summary(time.Since(p.start)))
+			}
+		}
+	}
+}
+
+// report prints the progress until stop is closed
+func (p *progress) report(stop chan struct{}) {
+	t := time.NewTicker(p.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			p.mu.Lock()
+			fmt.Fprintln(p.out, p.status(time.Since(p.start)))
+			p.mu.Unlock()
+		}
+	}
+}
+
+// status returns the progress of the downloads, with the progress of each file and the ETA
+func (p *progress) status(elapsed time.Duration) string {
+	var files, completed int
+	var size, done int64
+	var unknownSize bool
+	var inProgress []string
+	for _, i := range p.items {
+		files++
+		done += i.done
+		if i.size >= 0 {
+			size += i.size
+		} else {
+			unknownSize = true
+		}
+		switch {
+		case i.size >= 0 && i.done >= i.size:
+			completed++
+		case i.size > 0:
+			inProgress = append(inProgress, fmt.Sprintf("%s %d%%", i.name, i.done*100/i.size))
+		default:
+			inProgress = append(inProgress, fmt.Sprintf("%s %.1f MB", i.name, mb(i.done)))
+		}
+	}
+
+	// without the size of all the files, the total and the ETA are unknown
+	if unknownSize {
+		return fmt.Sprintf("Downloading %d/%d files, %.1f MB: %s",
+			completed, files, mb(done), strings.Join(inProgress, ", "))
+	}
+	eta := "unknown"
+	if done > 0 && size >= done {
+		remaining := time.Duration(float64(elapsed) * float64(size-done) / float64(done))
+		eta = remaining.Round(time.Second).String()
+	}
+	return fmt.Sprintf("Downloading %d/%d files, %.1f/%.1f MB, ETA %s: %s",
+		completed, files, mb(done), mb(size), eta, strings.Join(inProgress, ", "))
+}
+
+// summary returns the summary of the downloads
+func (p *progress) summary(elapsed time.Duration) string {
+	var done int64
+	for _, i := range p.items {
+		done += i.done
+	}
+	return fmt.Sprintf("Downloaded %d files, %.1f MB in %s", len(p.items), mb(done), elapsed.Round(time.Second))
+}
+
+type progressReader struct {
+	p    *progress
+	item *progressItem
+	r    io.Reader
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.p.mu.Lock()
+	r.item.done += int64(n)
+	r.p.mu.Unlock()
+	return n, err
+}
+
+func mb(size int64) float64 {
+	return float64(size) / (1 << 20)
+}
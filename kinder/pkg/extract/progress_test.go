@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressStatus(t *testing.T) {
+	var tests = []struct {
+		name     string
+		items    []*progressItem
+		expected string
+	}{
+		{
+			name: "files in progress",
+			items: []*progressItem{
+				{name: "kubeadm", size: 4 << 20, done: 1 << 20},
+				{name: "kubelet", size: 4 << 20, done: 3 << 20},
+			},
+			expected: "Downloading 0/2 files, 4.0/8.0 MB, ETA 10s: kubeadm 25%, kubelet 75%",
+		},
+		{
+			name: "completed and unknown size",
+			items: []*progressItem{
+				{name: "kubeadm", size: 4 << 20, done: 4 << 20},
+				{name: "kube-apiserver.tar", size: -1, done: 2 << 20},
+			},
+			expected: "Downloading 1/2 files, 6.0 MB: kube-apiserver.tar 2.0 MB",
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			p := &progress{items: rt.items}
+			if actual := p.status(10 * time.Second); actual != rt.expected {
+				t.Errorf("expected %q, got %q", rt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProgressTrack(t *testing.T) {
+	out := &bytes.Buffer{}
+	p := &progress{out: out, interval: time.Hour}
+
+	r1, finish1 := p.track("kubeadm", 3, strings.NewReader("abc"))
+	r2, finish2 := p.track("kubelet", 2, strings.NewReader("de"))
+	for _, r := range []io.Reader{r1, r2} {
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	finish1()
+	if out.Len() != 0 {
+		t.Errorf("expected no summary while downloads are in progress, got %q", out.String())
+	}
+	finish2()
+	if !strings.HasPrefix(out.String(), "Downloaded 2 files") {
+		t.Errorf("expected a summary of the downloads, got %q", out.String())
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// Provider retrieves Kubernetes artifacts from a custom source, e.g. an internal mirror or an artifact store;
+// providers are registered for a scheme with RegisterProvider, and used for the sources starting with it,
+// e.g. mirror://v1.33.0 for the mirror scheme, in all the kinder flags accepting a source.
+//
+// The Extractor takes care of the target folder and of the version file, so providers only
+// have to resolve versions and fetch files; files are fetched concurrently.
+type Provider interface {
+	// Version returns the Kubernetes version of the artifacts at src, e.g. v1.33.0; src is the
+	// whole source, including the scheme, so providers can resolve labels too
+	Version(src string) (string, error)
+
+	// Fetch saves a file for the given version and architecture into path; name is the name of a Kubernetes
+	// binary or image tarball, e.g. kubeadm or kube-apiserver.tar, or any other file requested to the Extractor
+	Fetch(src, version, name, arch, path string) error
+}
+
+// builtinSchemes are the schemes of the sources supported by kinder, that can't be registered
+var builtinSchemes = []string{"file", "gs", "oci", "http", "https"}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{}
+)
+
+// RegisterProvider registers a Provider for the sources with the given scheme, e.g. mirror for mirror://;
+// it is meant to be called by downstream projects, usually in an init function, before kinder commands run
+func RegisterProvider(scheme string, p Provider) error {
+	if scheme == "" || strings.ContainsAny(scheme, ":/") {
+		return errors.Errorf("invalid scheme %q for the bits provider", scheme)
+	}
+	for _, s := range builtinSchemes {
+		if s == scheme {
+			return errors.Errorf("the scheme %s is reserved for a kinder source", scheme)
+		}
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if _, ok := providers[scheme]; ok {
+		return errors.Errorf("a bits provider for the scheme %s is already registered", scheme)
+	}
+	providers[scheme] = p
+	return nil
+}
+
+// providerFor returns the registered Provider for a source, if any
+func providerFor(src string) (Provider, bool) {
+	scheme, _, ok := strings.Cut(src, "://")
+	if !ok {
+		return nil, false
+	}
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[scheme]
+	return p, ok
+}
+
+func extractFromProvider(src string, files []string, dst string, o sourceOptions, m fileNameMutator, addVersionFileToDst bool) (paths map[string]string, err error) {
+	p, ok := providerFor(src)
+	if !ok {
+		return nil, errors.Errorf("no bits provider registered for %s", src)
+	}
+	for _, f := range files {
+		if strings.ContainsAny(f, "*?[") {
+			return nil, errors.Errorf("bits providers don't support patterns, got %s for %s", f, src)
+		}
+	}
+
+	dst, _ = filepath.Abs(dst)
+	if _, err := os.Stat(dst); os.IsNotExist(err) {
+		return nil, errors.Errorf("destination path %s does not exists", dst)
+	}
+
+	v, err := p.Version(src)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the version of %s", src)
+	}
+	version, err := K8sVersion.ParseSemantic(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid version %q for %s", v, src)
+	}
+
+	// saves the version file (if requested), and pass the version to the file name mutator
+	if err := saveVersionFile(addVersionFileToDst, dst, version, m); err != nil {
+		return nil, errors.Wrapf(err, "error creating version file in %s", dst)
+	}
+	m.SetPrependVersionFolder(version)
+	if err := m.EnsureFolder(dst); err != nil {
+		return nil, err
+	}
+
+	paths, err = extractAll(files, func(f string) (string, error) {
+		log.Infof("Fetching %s from %s", f, src)
+		dstFilePath := filepath.Join(dst, m.Mutate(f))
+		if err := p.Fetch(src, "v"+version.String(), f, o.arch, dstFilePath); err != nil {
+			os.Remove(dstFilePath)
+			return "", errors.Wrapf(err, "failed to fetch %s from %s", f, src)
+		}
+		if f == kubeadmBinary || f == kubeletBinary || f == kubectlBinary {
+			os.Chmod(dstFilePath, 0755)
+		}
+		return dstFilePath, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Fetched files saved into %s", dst)
+	return paths, nil
+}
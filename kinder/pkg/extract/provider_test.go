@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type fakeProvider struct{}
+
+func (fakeProvider) Version(src string) (string, error) {
+	return "v1.33.0", nil
+}
+
+func (fakeProvider) Fetch(src, version, name, arch, path string) error {
+	return os.WriteFile(path, []byte(fmt.Sprintf("%s %s %s", name, version, arch)), 0644)
+}
+
+func TestRegisterProvider(t *testing.T) {
+	var tests = []struct {
+		scheme        string
+		expectedError bool
+	}{
+		{scheme: "test-register"},
+		{scheme: "test-register", expectedError: true},
+		{scheme: "https", expectedError: true},
+		{scheme: "mirror://", expectedError: true},
+		{scheme: "", expectedError: true},
+	}
+	for _, rt := range tests {
+		t.Run(rt.scheme, func(t *testing.T) {
+			err := RegisterProvider(rt.scheme, fakeProvider{})
+			if (err != nil) != rt.expectedError {
+				t.Errorf("expected error %t, got %v", rt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestExtractFromProvider(t *testing.T) {
+	if err := RegisterProvider("test-extract", fakeProvider{}); err != nil {
+		t.Fatal(err)
+	}
+	src := "test-extract://v1.33.0"
+	if GetSourceType(src) != ProviderSource {
+		t.Fatalf("expected %s to be a provider source", src)
+	}
+
+	dst := t.TempDir()
+	paths, err := NewExtractor(src, dst, WithArch("arm64"), OnlyKubeadm(true), WithVersionFile(true), WithVersionFolder(true)).Extract()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"version": "v1.33.0", "v1.33.0/kubeadm": "kubeadm v1.33.0 arm64"}
+	for f, content := range expected {
+		b, err := os.ReadFile(filepath.Join(dst, f))
+		if err != nil || string(b) != content {
+			t.Errorf("expected %s to contain %q, got %q, %v", f, content, string(b), err)
+		}
+	}
+	if len(paths) != 1 {
+		t.Errorf("expected 1 file, got %v", paths)
+	}
+	if info, err := os.Stat(filepath.Join(dst, "v1.33.0", "kubeadm")); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("expected kubeadm to be executable")
+	}
+}
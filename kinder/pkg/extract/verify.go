@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/exec"
+)
+
+const (
+	// DefaultSignatureIdentity is the identity that signs the Kubernetes release artifacts
+	DefaultSignatureIdentity = "krel-trust@k8s-releng-prod.iam.gserviceaccount.com"
+
+	// DefaultSignatureIssuer is the OIDC issuer of the identity that signs the Kubernetes release artifacts
+	DefaultSignatureIssuer = "https://accounts.google.com"
+)
+
+// WithChecksums option instructs the Extractor to verify files downloaded via http with the sha256 checksums
+// in a file or at an URL, in the format of sha256sum; each line of the file can refer to a file by name,
+// e.g. kubeadm, or by URL, and an error is returned for downloaded files without a checksum
+func WithChecksums(src string) Option {
+	return func(b *Extractor) {
+		if src != "" {
+			b.verifierOrNew().checksumsSrc = src
+		}
+	}
+}
+
+// WithSignatures option instructs the Extractor to verify files downloaded via http with the sigstore signatures
+// published next to each file, e.g. kubeadm.sig and kubeadm.cert, for the given identity and OIDC issuer;
+// signatures are verified with cosign, that must be installed on the host
+func WithSignatures(enable bool, identity, issuer string) Option {
+	return func(b *Extractor) {
+		if enable {
+			v := b.verifierOrNew()
+			v.signatureIdentity, v.signatureIssuer = identity, issuer
+		}
+	}
+}
+
+func (e *Extractor) verifierOrNew() *verifier {
+	if e.options.verifier == nil {
+		e.options.verifier = &verifier{}
+	}
+	return e.options.verifier
+}
+
+// verifier verifies files downloaded via http before they are used
+type verifier struct {
+	checksumsSrc string
+
+	// checksums are read once, and guarded by mu because files are verified concurrently
+	mu        sync.Mutex
+	checksums map[string]string
+
+	signatureIdentity string
+	signatureIssuer   string
+}
+
+// verify checks a file downloaded from an uri; name is the name of the file in the source, e.g. kubeadm
+func (v *verifier) verify(uri, name, path string) error {
+	if v == nil {
+		return nil
+	}
+	if v.checksumsSrc != "" {
+		if err := v.verifyChecksum(uri, name, path); err != nil {
+			return err
+		}
+	}
+	// the version file is not a Kubernetes artifact, so it is not signed
+	if v.signatureIdentity != "" && name != "version" {
+		if err := v.verifySignature(uri, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *verifier) verifyChecksum(uri, name, path string) error {
+	v.mu.Lock()
+	if v.checksums == nil {
+		checksums, err := readChecksums(v.checksumsSrc)
+		if err != nil {
+			v.mu.Unlock()
+			return err
+		}
+		v.checksums = checksums
+	}
+	expected, ok := v.checksums[uri]
+	if !ok {
+		expected, ok = v.checksums[name]
+	}
+	v.mu.Unlock()
+	if !ok {
+		return errors.Errorf("%s does not define a checksum for %s", v.checksumsSrc, uri)
+	}
+
+	actual, err := FileDigest(path)
+	if err != nil {
+		return errors.Wrapf(err, "error reading %s", path)
+	}
+	if actual != expected {
+		return errors.Errorf("checksum of %s is %s, expected %s", uri, actual, expected)
+	}
+	log.Infof("Checksum of %s verified", uri)
+	return nil
+}
+
+// readChecksums reads checksums in the format of sha256sum from a local file or from an URL
+func readChecksums(src string) (map[string]string, error) {
+	var r io.ReadCloser
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		_, body, err := httpGet(src)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading checksums from %s", src)
+		}
+		r = body
+	} else {
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading checksums from %s", src)
+		}
+		r = f
+	}
+	defer r.Close()
+
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || len(fields[0]) != sha256.Size*2 {
+			return nil, errors.Errorf("invalid checksum in %s: %q", src, line)
+		}
+		// sha256sum marks files read in binary mode with *
+		name := strings.TrimPrefix(strings.TrimPrefix(fields[1], "*"), "./")
+		checksums[name] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "error reading checksums from %s", src)
+	}
+	return checksums, nil
+}
+
+func (v *verifier) verifySignature(uri, path string) error {
+	dir, err := os.MkdirTemp("", "kinder-signature-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	signature, certificate := filepath.Join(dir, "signature"), filepath.Join(dir, "certificate")
+	if err := copyFromURI(uri+".sig", signature); err != nil {
+		return errors.Wrapf(err, "error getting the signature of %s", uri)
+	}
+	if err := copyFromURI(uri+".cert", certificate); err != nil {
+		return errors.Wrapf(err, "error getting the certificate of %s", uri)
+	}
+
+	lines, err := exec.NewHostCmd("cosign", "verify-blob",
+		"--signature", signature,
+		"--certificate", certificate,
+		"--certificate-identity", v.signatureIdentity,
+		"--certificate-oidc-issuer", v.signatureIssuer,
+		path,
+	).RunAndCapture()
+	if err != nil {
+		return errors.Wrapf(err, "signature of %s is not valid: %s", uri, strings.Join(lines, " "))
+	}
+	log.Infof("Signature of %s verified", uri)
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyChecksums(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "content of %s", strings.TrimPrefix(r.URL.Path, "/"))
+	}))
+	defer server.Close()
+
+	checksum := func(name string) string {
+		sum := sha256.Sum256([]byte("content of " + name))
+		return hex.EncodeToString(sum[:])
+	}
+
+	var tests = []struct {
+		name          string
+		checksums     string
+		expectedError string
+	}{
+		{
+			name:      "checksum by name",
+			checksums: fmt.Sprintf("%s  kubeadm\n", checksum("kubeadm")),
+		},
+		{
+			name:      "checksum by URL, read in binary mode",
+			checksums: fmt.Sprintf("# checksums\n%s *%s/kubeadm\n", checksum("kubeadm"), server.URL),
+		},
+		{
+			name:          "wrong checksum",
+			checksums:     fmt.Sprintf("%s  kubeadm\n", checksum("kubelet")),
+			expectedError: "checksum of",
+		},
+		{
+			name:          "missing checksum",
+			checksums:     fmt.Sprintf("%s  kubelet\n", checksum("kubelet")),
+			expectedError: "does not define a checksum",
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			checksums := filepath.Join(dir, "SHA256SUMS")
+			if err := os.WriteFile(checksums, []byte(rt.checksums), 0644); err != nil {
+				t.Fatal(err)
+			}
+			dst := filepath.Join(dir, "dst")
+			if err := os.Mkdir(dst, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := NewExtractor(server.URL, dst, OnlyKubeadm(true), WithChecksums(checksums)).Extract()
+			if rt.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), rt.expectedError) {
+				t.Fatalf("expected error containing %q, got %v", rt.expectedError, err)
+			}
+			if _, err := os.Stat(filepath.Join(dst, "kubeadm")); !os.IsNotExist(err) {
+				t.Errorf("expected kubeadm to be removed")
+			}
+		})
+	}
+}
@@ -60,6 +60,21 @@ func Config(kubeadmConfigVersion string, data ConfigData) (config string, err er
 	return buff.String(), nil
 }
 
+// KnownConfigVersions returns the list of kubeadm config API versions supported by kinder
+func KnownConfigVersions() []string {
+	return []string{"v1beta3", "v1beta4"}
+}
+
+// ValidateConfigVersion validates a kubeadm config API version
+func ValidateConfigVersion(kubeadmConfigVersion string) error {
+	for _, v := range KnownConfigVersions() {
+		if v == kubeadmConfigVersion {
+			return nil
+		}
+	}
+	return errors.Errorf("unknown kubeadm config version: %s. Use one of %s", kubeadmConfigVersion, KnownConfigVersions())
+}
+
 // GetKubeadmConfigVersion returns the kubeadm config version corresponding to a Kubernetes kubeadmVersion
 func GetKubeadmConfigVersion(kubeadmVersion *K8sVersion.Version) string {
 	// v1alpha1 (that is Kubernetes v1.10.0) is out of support
@@ -100,6 +115,8 @@ type ConfigData struct {
 	FeatureGateValue string
 	// The encryption algorithm
 	EncryptionAlgorithm string
+	// CloudProvider is passed to the kubelet --cloud-provider flag, e.g. external
+	CloudProvider string
 	// UpgradeVersion is the version passed to kubeadm upgrade
 	UpgradeVersion string
 	// DerivedConfigData is populated by Derive()
@@ -178,6 +195,10 @@ nodeRegistration:
   kubeletExtraArgs:
   - name: node-ip
     value: "{{ .NodeAddress }}"
+  {{- if .CloudProvider }}
+  - name: cloud-provider
+    value: "{{ .CloudProvider }}"
+  {{- end }}
   ignorePreflightErrors:
   {{range .IgnorePreflightErrors }}  - {{.}}
   {{end}}
@@ -196,6 +217,10 @@ nodeRegistration:
   kubeletExtraArgs:
   - name: node-ip
     value: "{{ .NodeAddress }}"
+  {{- if .CloudProvider }}
+  - name: cloud-provider
+    value: "{{ .CloudProvider }}"
+  {{- end }}
   ignorePreflightErrors:
   {{range .IgnorePreflightErrors }}  - {{.}}
   {{end}}
@@ -313,6 +338,9 @@ nodeRegistration:
   criSocket: "/run/containerd/containerd.sock"
   kubeletExtraArgs:
     node-ip: "{{ .NodeAddress }}"
+    {{- if .CloudProvider }}
+    cloud-provider: "{{ .CloudProvider }}"
+    {{- end }}
   ignorePreflightErrors:
   {{range .IgnorePreflightErrors }}  - {{.}}
   {{end}}
@@ -331,6 +359,9 @@ nodeRegistration:
   criSocket: "/run/containerd/containerd.sock"
   kubeletExtraArgs:
     node-ip: "{{ .NodeAddress }}"
+    {{- if .CloudProvider }}
+    cloud-provider: "{{ .CloudProvider }}"
+    {{- end }}
   ignorePreflightErrors:
   {{range .IgnorePreflightErrors }}  - {{.}}
   {{end}}
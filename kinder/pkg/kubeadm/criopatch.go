@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// CRIOSocket is the socket of the CRI-O container runtime
+const CRIOSocket = "unix:///var/run/crio/crio.sock"
+
+// GetCRIOPatch returns the kubeadm config patch that will instruct kubeadm
+// to use the CRI-O socket, that is then passed to the kubelet too.
+func GetCRIOPatch(kubeadmConfigVersion string, ControlPlane bool) ([]string, error) {
+	log.Debugf("Preparing crioPatch for kubeadm config %s", kubeadmConfigVersion)
+
+	switch kubeadmConfigVersion {
+	case "v1beta3", "v1beta4":
+	default:
+		return nil, errors.Errorf("unknown kubeadm config version: %s", kubeadmConfigVersion)
+	}
+
+	return []string{
+		fmt.Sprintf(crioPatch, kubeadmConfigVersion, "InitConfiguration", CRIOSocket),
+		fmt.Sprintf(crioPatch, kubeadmConfigVersion, "JoinConfiguration", CRIOSocket),
+	}, nil
+}
+
+const crioPatch = `apiVersion: kubeadm.k8s.io/%s
+kind: %s
+nodeRegistration:
+  criSocket: %s`
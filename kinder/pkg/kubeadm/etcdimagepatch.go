@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// SplitImage splits an image reference in repository and tag, e.g. registry.k8s.io/etcd and 3.5.21-0
+func SplitImage(image string) (string, string, error) {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") || strings.Contains(image, "@") {
+		return "", "", errors.Errorf("invalid image %q, the image must have a tag", image)
+	}
+	return image[:i], image[i+1:], nil
+}
+
+// GetEtcdImagePatch returns the kubeadm config patch that will instruct kubeadm
+// to use a specific image for the local etcd; kubeadm appends etcd to the image repository,
+// so the image must be named etcd, e.g. registry.example.com/dev/etcd:3.6.0
+func GetEtcdImagePatch(kubeadmConfigVersion string, image string) (string, error) {
+	log.Debugf("Preparing etcdImage patch for kubeadm config %s", kubeadmConfigVersion)
+
+	switch kubeadmConfigVersion {
+	case "v1beta3", "v1beta4":
+	default:
+		return "", errors.Errorf("unknown kubeadm config version: %s", kubeadmConfigVersion)
+	}
+
+	if err := ValidateEtcdImage(image); err != nil {
+		return "", err
+	}
+	repository, tag, _ := SplitImage(image)
+	return fmt.Sprintf(etcdImagePatch, kubeadmConfigVersion, strings.TrimSuffix(repository, "/etcd"), tag), nil
+}
+
+// ValidateEtcdImage checks that an image can be used for the local etcd
+func ValidateEtcdImage(image string) error {
+	repository, _, err := SplitImage(image)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(repository, "/etcd") {
+		return errors.Errorf("invalid etcd image %q, the image must be named etcd", image)
+	}
+	return nil
+}
+
+// SetEtcdImage sets the image for the local etcd in a ClusterConfiguration, e.g. the one stored in the
+// kubeadm-config ConfigMap, because kubeadm upgrade reads the etcd image from there
+func SetEtcdImage(clusterConfiguration, image string) (string, error) {
+	if err := ValidateEtcdImage(image); err != nil {
+		return "", err
+	}
+	repository, tag, _ := SplitImage(image)
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(clusterConfiguration), &config); err != nil {
+		return "", errors.Wrap(err, "failed to read the ClusterConfiguration")
+	}
+	etcd, _ := config["etcd"].(map[string]interface{})
+	if etcd == nil {
+		etcd = map[string]interface{}{}
+	}
+	if _, ok := etcd["external"]; ok {
+		return "", errors.New("the etcd image can't be set for a cluster using external etcd")
+	}
+	local, _ := etcd["local"].(map[string]interface{})
+	if local == nil {
+		local = map[string]interface{}{}
+	}
+	local["imageRepository"] = strings.TrimSuffix(repository, "/etcd")
+	local["imageTag"] = tag
+	etcd["local"] = local
+	config["etcd"] = etcd
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write the ClusterConfiguration")
+	}
+	return string(data), nil
+}
+
+const etcdImagePatch = `apiVersion: kubeadm.k8s.io/%s
+kind: ClusterConfiguration
+etcd:
+  local:
+    imageRepository: %s
+    imageTag: %s
+`
@@ -18,40 +18,56 @@ package kubeadm
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
+// ExternalEtcd defines the settings for accessing an external etcd cluster
+type ExternalEtcd struct {
+	Endpoints []string
+	CAFile    string
+	CertFile  string
+	KeyFile   string
+}
+
 // GetExternalEtcdPatch returns the kubeadm config patch that will instruct kubeadm
 // to use external etcd.
-func GetExternalEtcdPatch(kubeadmConfigVersion string, etcdIP string) (string, error) {
+func GetExternalEtcdPatch(kubeadmConfigVersion string, etcd ExternalEtcd) (string, error) {
 	// select the patches for the kubeadm config version
 	log.Debugf("Preparing externalEtcdPatch for kubeadm config %s", kubeadmConfigVersion)
 
-	var externalEtcdPatch string
 	switch kubeadmConfigVersion {
-	case "v1beta3":
-		externalEtcdPatch = externalEtcdPatchv1beta3
-	case "v1beta4":
-		externalEtcdPatch = externalEtcdPatchv1beta4
+	case "v1beta3", "v1beta4":
 	default:
 		return "", errors.Errorf("unknown kubeadm config version: %s", kubeadmConfigVersion)
 	}
 
-	return fmt.Sprintf(externalEtcdPatch, etcdIP), nil
-}
+	if len(etcd.Endpoints) == 0 {
+		return "", errors.New("at least one external etcd endpoint is required")
+	}
 
-const externalEtcdPatchv1beta3 = `apiVersion: kubeadm.k8s.io/v1beta3
-kind: ClusterConfiguration
-etcd:
-  external:
-    endpoints:
-    - http://%s:2379`
+	var b strings.Builder
+	fmt.Fprintf(&b, externalEtcdPatch, kubeadmConfigVersion)
+	for _, e := range etcd.Endpoints {
+		fmt.Fprintf(&b, "\n    - %s", e)
+	}
+	if etcd.CAFile != "" {
+		fmt.Fprintf(&b, "\n    caFile: %s", etcd.CAFile)
+	}
+	if etcd.CertFile != "" {
+		fmt.Fprintf(&b, "\n    certFile: %s", etcd.CertFile)
+	}
+	if etcd.KeyFile != "" {
+		fmt.Fprintf(&b, "\n    keyFile: %s", etcd.KeyFile)
+	}
+
+	return b.String(), nil
+}
 
-const externalEtcdPatchv1beta4 = `apiVersion: kubeadm.k8s.io/v1beta4
+const externalEtcdPatch = `apiVersion: kubeadm.k8s.io/%s
 kind: ClusterConfiguration
 etcd:
   external:
-    endpoints:
-    - http://%s:2379`
+    endpoints:`
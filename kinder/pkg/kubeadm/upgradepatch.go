@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetNoForceUpgradePatch returns the kubeadm config patch that will instruct kubeadm upgrade apply
+// to not force the upgrade, so version skew errors are not ignored.
+// NB. UpgradeConfiguration exists only in v1beta4; with older config versions kinder passes flags instead.
+func GetNoForceUpgradePatch(kubeadmConfigVersion string) (PatchJSON6902, error) {
+	log.Debugf("Preparing noForceUpgradePatch for kubeadm config %s", kubeadmConfigVersion)
+
+	if kubeadmConfigVersion != "v1beta4" {
+		return PatchJSON6902{}, errors.Errorf("kubeadm config version %s does not support UpgradeConfiguration", kubeadmConfigVersion)
+	}
+
+	return PatchJSON6902{
+		Group:   "kubeadm.k8s.io",
+		Version: kubeadmConfigVersion,
+		Kind:    "UpgradeConfiguration",
+		Patch:   noForceUpgradePatchv1beta4,
+	}, nil
+}
+
+const noForceUpgradePatchv1beta4 = `
+- op: remove
+  path: "/apply/forceUpgrade"`
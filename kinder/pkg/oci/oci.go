@@ -0,0 +1,352 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oci implements the subset of the OCI distribution API required for pulling manifests and
+// blobs of artifacts, e.g. pushed with oras push, including the token authentication used by most registries
+package oci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Scheme is the prefix of OCI artifact references
+	Scheme = "oci://"
+
+	// env variables with the credentials for reading from private OCI registries
+	UsernameEnv = "KINDER_OCI_USERNAME"
+	PasswordEnv = "KINDER_OCI_PASSWORD"
+
+	// PlainHTTPEnv is the env variable for reading from OCI registries over http instead of https;
+	// registries on localhost are always read over http
+	PlainHTTPEnv = "KINDER_OCI_PLAIN_HTTP"
+
+	// TitleAnnotation is the layer annotation with the file name, as set by e.g. oras push
+	TitleAnnotation = "org.opencontainers.image.title"
+
+	ManifestMediaType           = "application/vnd.oci.image.manifest.v1+json"
+	IndexMediaType              = "application/vnd.oci.image.index.v1+json"
+	DockerManifestMediaType     = "application/vnd.docker.distribution.manifest.v2+json"
+	DockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// maxManifestSize limits the size of manifests read from registries
+	maxManifestSize = 10 << 20
+)
+
+var digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// Reference is a reference to an OCI artifact, e.g. oci://registry.example.com/kubernetes/bits:v1.33.0
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses a reference in the form oci://registry/repository[:tag][@sha256:<hex>]
+func ParseReference(ref string) (*Reference, error) {
+	s := strings.TrimPrefix(ref, Scheme)
+
+	r := &Reference{}
+	if i := strings.Index(s, "@"); i >= 0 {
+		s, r.Digest = s[:i], s[i+1:]
+		if !digestRegexp.MatchString(r.Digest) {
+			return nil, errors.Errorf("invalid OCI reference %s: %q is not a valid sha256 digest", ref, r.Digest)
+		}
+	}
+	i := strings.Index(s, "/")
+	if i <= 0 || i == len(s)-1 {
+		return nil, errors.Errorf("invalid OCI reference %s: registry and repository are required", ref)
+	}
+	r.Registry, r.Repository = s[:i], s[i+1:]
+	if j := strings.LastIndex(r.Repository, ":"); j > strings.LastIndex(r.Repository, "/") {
+		r.Repository, r.Tag = r.Repository[:j], r.Repository[j+1:]
+	}
+	if r.Tag == "" && r.Digest == "" {
+		r.Tag = "latest"
+	}
+	return r, nil
+}
+
+// TagOrDigest returns the tag or the digest to be used for fetching the manifest; digests take precedence
+func (r *Reference) TagOrDigest() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// String returns the reference without the oci:// scheme
+func (r *Reference) String() string {
+	s := fmt.Sprintf("%s/%s", r.Registry, r.Repository)
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// Descriptor describes a manifest or a layer
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform,omitempty"`
+}
+
+// Manifest is an OCI image manifest or an OCI image index, that is a manifest list
+type Manifest struct {
+	MediaType string       `json:"mediaType"`
+	Layers    []Descriptor `json:"layers"`
+	Manifests []Descriptor `json:"manifests"`
+}
+
+// Client reads artifacts from an OCI registry
+type Client struct {
+	client *http.Client
+	base   string
+	ref    *Reference
+
+	// auth is guarded by mu, because blobs can be downloaded concurrently
+	mu   sync.Mutex
+	auth string
+}
+
+// NewClient returns a client for the repository of the given reference, sending requests with client
+func NewClient(ref *Reference, client *http.Client) *Client {
+	scheme := "https"
+	host := strings.Split(ref.Registry, ":")[0]
+	if host == "localhost" || host == "127.0.0.1" || os.Getenv(PlainHTTPEnv) == "true" {
+		scheme = "http"
+	}
+	return &Client{
+		client: client,
+		base:   fmt.Sprintf("%s://%s/v2/%s", scheme, ref.Registry, ref.Repository),
+		ref:    ref,
+	}
+}
+
+// ManifestData returns the manifest with the given tag or digest as returned by the registry, e.g. for verifying its digest
+func (c *Client) ManifestData(reference string) ([]byte, error) {
+	resp, err := c.get(fmt.Sprintf("%s/manifests/%s", c.base, reference), ManifestMediaType, IndexMediaType, DockerManifestMediaType, DockerManifestListMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the manifest of %s", reference)
+	}
+	if len(data) > maxManifestSize {
+		return nil, errors.Errorf("the manifest of %s is bigger than %d bytes", reference, maxManifestSize)
+	}
+	return data, nil
+}
+
+// Manifest returns the manifest of the artifact; if the artifact is a manifest list, the manifest for linux/arch is returned
+func (c *Client) Manifest(arch string) (*Manifest, error) {
+	reference := c.ref.TagOrDigest()
+	for {
+		data, err := c.ManifestData(reference)
+		if err != nil {
+			return nil, err
+		}
+		m := &Manifest{}
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, errors.Wrapf(err, "error reading the manifest of %s", reference)
+		}
+		if len(m.Manifests) == 0 {
+			return m, nil
+		}
+
+		reference = ""
+		for _, d := range m.Manifests {
+			if d.Platform != nil && d.Platform.OS == "linux" && d.Platform.Architecture == arch {
+				reference = d.Digest
+				break
+			}
+		}
+		if reference == "" {
+			return nil, errors.Errorf("%s does not provide an artifact for linux/%s", c.ref, arch)
+		}
+	}
+}
+
+// Blob returns the content of the blob with the given digest; the caller must close it
+func (c *Client) Blob(digest string) (io.ReadCloser, error) {
+	resp, err := c.get(fmt.Sprintf("%s/blobs/%s", c.base, digest))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// get sends a GET request to the registry, authenticating once if the registry requests it
+func (c *Client) get(uri string, accept ...string) (*http.Response, error) {
+	for {
+		req, err := http.NewRequest(http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid URI %s", uri)
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		auth := c.authorization()
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "HTTP GET %s failed", uri)
+		}
+		if resp.StatusCode == http.StatusUnauthorized && auth == "" {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := c.authenticate(challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, errors.Errorf("HTTP GET %s failed: %s", uri, resp.Status)
+		}
+		return resp, nil
+	}
+}
+
+func (c *Client) authorization() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.auth
+}
+
+func (c *Client) setAuthorization(auth string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.auth = auth
+}
+
+// authenticate sets the authorization for the registry, as requested by the challenge of the registry;
+// without credentials, an anonymous token is requested
+func (c *Client) authenticate(challenge string) error {
+	username, password := os.Getenv(UsernameEnv), os.Getenv(PasswordEnv)
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if username == "" {
+			return errors.Errorf("registry %s requires credentials, please set the %s and %s env variables", c.ref.Registry, UsernameEnv, PasswordEnv)
+		}
+		c.setAuthorization("Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+		return nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return errors.Errorf("registry %s requested an invalid authentication: %s", c.ref.Registry, challenge)
+		}
+		q := realm.Query()
+		if s := params["service"]; s != "" {
+			q.Set("service", s)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = fmt.Sprintf("repository:%s:pull", c.ref.Repository)
+		}
+		q.Set("scope", scope)
+		realm.RawQuery = q.Encode()
+
+		req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return errors.Wrapf(err, "invalid token URI %s", realm)
+		}
+		if username != "" {
+			req.SetBasicAuth(username, password)
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "error getting a token for registry %s", c.ref.Registry)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("error getting a token for registry %s: %s", c.ref.Registry, resp.Status)
+		}
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return errors.Wrapf(err, "error reading the token for registry %s", c.ref.Registry)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return errors.Errorf("registry %s did not return a token", c.ref.Registry)
+		}
+		c.setAuthorization("Bearer " + token.Token)
+		return nil
+	}
+	return errors.Errorf("registry %s requested an unsupported authentication: %s", c.ref.Registry, challenge)
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.example.com/token",service="registry"
+func parseChallenge(challenge string) (scheme string, params map[string]string) {
+	params = map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme = strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return scheme, params
+	}
+	// params are split on commas outside quotes, because e.g. the scope can be repository:REPOSITORY:pull,push
+	var param strings.Builder
+	quoted := false
+	add := func() {
+		if k, v, ok := strings.Cut(strings.TrimSpace(param.String()), "="); ok {
+			params[strings.ToLower(k)] = strings.Trim(v, `"`)
+		}
+		param.Reset()
+	}
+	for _, r := range parts[1] {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			add()
+			continue
+		}
+		param.WriteRune(r)
+	}
+	add()
+	return scheme, params
+}
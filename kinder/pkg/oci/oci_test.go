@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	testCases := []struct {
+		ref           string
+		expected      Reference
+		expectedError bool
+	}{
+		{ref: "oci://ghcr.io/org/workflows", expected: Reference{Registry: "ghcr.io", Repository: "org/workflows", Tag: "latest"}},
+		{ref: "oci://localhost:5000/workflows:v1", expected: Reference{Registry: "localhost:5000", Repository: "workflows", Tag: "v1"}},
+		{ref: "oci://ghcr.io/org/workflows:v1@" + digest, expected: Reference{Registry: "ghcr.io", Repository: "org/workflows", Tag: "v1", Digest: digest}},
+		{ref: "oci://ghcr.io/org/workflows@sha256:abc", expectedError: true},
+		{ref: "oci://workflows", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ref, func(t *testing.T) {
+			r, err := ParseReference(tc.ref)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if err == nil && *r != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, *r)
+			}
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	var tests = []struct {
+		challenge      string
+		expectedScheme string
+		expectedParams map[string]string
+	}{
+		{
+			challenge:      `Basic realm="registry"`,
+			expectedScheme: "basic",
+			expectedParams: map[string]string{"realm": "registry"},
+		},
+		{
+			challenge:      `Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:kube/bits:pull,push"`,
+			expectedScheme: "bearer",
+			expectedParams: map[string]string{"realm": "https://auth.example.com/token", "service": "registry.example.com", "scope": "repository:kube/bits:pull,push"},
+		},
+	}
+	for _, rt := range tests {
+		t.Run(rt.challenge, func(t *testing.T) {
+			scheme, params := parseChallenge(rt.challenge)
+			if scheme != rt.expectedScheme || !reflect.DeepEqual(params, rt.expectedParams) {
+				t.Errorf("expected %s %v, got %s %v", rt.expectedScheme, rt.expectedParams, scheme, params)
+			}
+		})
+	}
+}
+
+func TestManifestData(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.URL.Path == "/token":
+			fmt.Fprint(w, `{"token":"abc"}`)
+		case r.URL.Path == "/v2/repo/manifests/private" && r.Header.Get("Authorization") != "Bearer abc":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test"`, r.Host))
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			fmt.Fprint(w, "data")
+		}
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		reference        string
+		expectedRequests int
+	}{
+		{reference: "public", expectedRequests: 1},
+		{reference: "private", expectedRequests: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.reference, func(t *testing.T) {
+			requests = 0
+			c := NewClient(&Reference{Registry: strings.TrimPrefix(srv.URL, "http://"), Repository: "repo"}, srv.Client())
+			data, err := c.ManifestData(tc.reference)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != "data" {
+				t.Errorf("expected data, got %q", data)
+			}
+			if requests != tc.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tc.expectedRequests, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager/actions"
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// Artifact defines a file or a folder to be collected into the artifacts folder when a task completes
+type Artifact struct {
+	// Path of the file or folder to collect; it can be a literal or a template
+	Path string
+
+	// Nodes selects the nodes to collect the path from, e.g. @cp*, using the same node selectors of
+	// kinder do --only-node; if not set, the path is collected from the local filesystem
+	Nodes string
+
+	// Cluster to collect the path from; by default, the cluster targeted by the task, if the task is a kinder command
+	Cluster string
+
+	// When defines if the path is collected always, the default, or onFailure only
+	When string
+}
+
+// possible values of Artifact.When
+const (
+	collectAlways    = "always"
+	collectOnFailure = "onFailure"
+)
+
+// validateArtifacts checks the artifacts of a task, setting defaults
+func validateArtifacts(artifacts []Artifact) error {
+	for i := range artifacts {
+		a := &artifacts[i]
+		if a.Path == "" {
+			return errors.Errorf("artifacts[%d] does not define a path", i)
+		}
+		switch a.When {
+		case "":
+			a.When = collectAlways
+		case collectAlways, collectOnFailure:
+		default:
+			return errors.Errorf("artifacts[%d] defines an invalid when value %q, use %s or %s", i, a.When, collectAlways, collectOnFailure)
+		}
+	}
+	return nil
+}
+
+// collectArtifacts copies the artifacts of a taskCmd into a folder named after the task in the artifacts folder;
+// artifacts from nodes are copied into a sub folder for each node. Errors are reported into the task log,
+// because failing to collect artifacts does not change the result of the task
+func collectArtifacts(t *taskCmd, artifacts string, failed bool, log io.Writer) {
+	dir := filepath.Join(artifacts, fmt.Sprintf("%s-artifacts", t.Name))
+	clusters := map[string]*status.Cluster{}
+
+	for _, a := range t.Artifacts {
+		if a.When == collectOnFailure && !failed {
+			continue
+		}
+
+		if a.Nodes == "" {
+			fmt.Fprintf(log, "\ncollecting %s\n", a.Path)
+			if err := copyLocal(a.Path, dir); err != nil {
+				fmt.Fprintf(log, "error collecting %s: %v\n", a.Path, err)
+			}
+			continue
+		}
+
+		c, ok := clusters[a.Cluster]
+		if !ok {
+			var err error
+			if c, err = status.FromDocker(a.Cluster); err != nil {
+				fmt.Fprintf(log, "error collecting %s: %v\n", a.Path, err)
+				continue
+			}
+			clusters[a.Cluster] = c
+		}
+		nodes, err := actions.SelectNodes(c, a.Nodes)
+		if err != nil {
+			fmt.Fprintf(log, "error collecting %s: %v\n", a.Path, err)
+			continue
+		}
+		for _, n := range nodes {
+			fmt.Fprintf(log, "\ncollecting %s from %s\n", a.Path, n.Name())
+			nodeDir := filepath.Join(dir, n.Name())
+			if err := os.MkdirAll(nodeDir, 0755); err != nil {
+				fmt.Fprintf(log, "error creating %s: %v\n", nodeDir, err)
+				continue
+			}
+			if out, err := exec.Command("docker", "cp", fmt.Sprintf("%s:%s", n.Name(), a.Path), nodeDir).CombinedOutput(); err != nil {
+				fmt.Fprintf(log, "error collecting %s from %s: %v\n%s", a.Path, n.Name(), err, out)
+			}
+		}
+	}
+}
+
+// copyLocal copies a file or a folder on the local filesystem into a folder
+func copyLocal(path, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if out, err := exec.Command("cp", "-R", path, dir).CombinedOutput(); err != nil {
+		return errors.Wrap(err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
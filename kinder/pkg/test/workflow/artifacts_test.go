@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+)
+
+func TestValidateArtifacts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         []Artifact
+		expectedWhen  []string
+		expectedError bool
+	}{
+		{
+			name:         "defaults to always",
+			input:        []Artifact{{Path: "/etc/kubernetes"}, {Path: "/var/log", When: collectOnFailure}},
+			expectedWhen: []string{collectAlways, collectOnFailure},
+		},
+		{
+			name:          "missing path",
+			input:         []Artifact{{Nodes: "@cp*"}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid when",
+			input:         []Artifact{{Path: "/etc/kubernetes", When: "onSuccess"}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateArtifacts(tc.input)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			for i, when := range tc.expectedWhen {
+				if tc.input[i].When != when {
+					t.Errorf("expected artifacts[%d] when %q, got %q", i, when, tc.input[i].When)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ComputedVar defines a var whose value is the trimmed stdout of a command
+type ComputedVar struct {
+	// Name of the var
+	Name string
+
+	// Cmd to execute; it can be a literal or a template
+	Cmd string
+
+	// Args allows to set Cmd arguments; args can be a literal or a template
+	Args []string
+}
+
+// computedVarTimeout is the maximum time for computing a var
+const computedVarTimeout = 5 * time.Minute
+
+// validateComputedVars checks that computed vars have a name and a cmd, and that
+// they are not defined as vars or matrix dimensions too
+func validateComputedVars(computed []ComputedVar, vars map[string]string, matrix map[string][]string) error {
+	names := map[string]bool{}
+	for i, v := range computed {
+		if v.Name == "" {
+			return errors.Errorf("computedVars[%d] does not define a name", i)
+		}
+		if v.Cmd == "" {
+			return errors.Errorf("computed var %q does not define a cmd", v.Name)
+		}
+		if names[v.Name] {
+			return errors.Errorf("computed var %q is defined more than once", v.Name)
+		}
+		names[v.Name] = true
+		if _, ok := vars[v.Name]; ok {
+			return errors.Errorf("computed var %q can't be defined in vars too", v.Name)
+		}
+		if _, ok := matrix[v.Name]; ok {
+			return errors.Errorf("computed var %q can't be defined as a matrix dimension too", v.Name)
+		}
+	}
+	return nil
+}
+
+// compute executes the command of a computed var, after expanding templates, and returns its trimmed stdout
+func (c *taskCmdBuilder) compute(v ComputedVar) (string, error) {
+	cmd, err := c.expand(v.Cmd)
+	if err != nil {
+		return "", errors.Wrapf(err, "error expanding cmd for computed var %q", v.Name)
+	}
+	args := make([]string, len(v.Args))
+	for n, a := range v.Args {
+		if args[n], err = c.expand(a); err != nil {
+			return "", errors.Wrapf(err, "error expanding args[%d] for computed var %q", n, v.Name)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), computedVarTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, cmd, args...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		return "", errors.Wrapf(err, "error computing var %q with %s %s: %s", v.Name, cmd, strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
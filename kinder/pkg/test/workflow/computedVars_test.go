@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputedVars(t *testing.T) {
+	testCases := []struct {
+		name          string
+		computed      []ComputedVar
+		presetVars    map[string]string
+		expected      map[string]string
+		expectedError bool
+	}{
+		{
+			name: "trimmed stdout, using vars and already computed vars",
+			computed: []ComputedVar{
+				{Name: "a", Cmd: "echo", Args: []string{" {{ .vars.base }}-a "}},
+				{Name: "b", Cmd: "echo", Args: []string{"{{ .vars.a }}-b"}},
+			},
+			expected: map[string]string{"base": "x", "a": "x-a", "b": "x-a-b"},
+		},
+		{
+			name:       "preset vars are not computed again",
+			computed:   []ComputedVar{{Name: "a", Cmd: "false"}},
+			presetVars: map[string]string{"a": "recorded"},
+			expected:   map[string]string{"base": "x", "a": "recorded"},
+		},
+		{
+			name:          "command failure",
+			computed:      []ComputedVar{{Name: "a", Cmd: "false"}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &Workflow{Vars: map[string]string{"base": "x"}, ComputedVars: tc.computed}
+			c, err := newTaskCmdBuilder(w, tc.presetVars)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(c.vars, tc.expected) {
+				t.Errorf("expected vars %v, got %v", tc.expected, c.vars)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// historyRun is a workflow run recorded in the history; each matrix combination is recorded as a separated run
+type historyRun struct {
+	ID        string `json:"id"`
+	Workflow  string `json:"workflow"`
+	Artifacts string `json:"artifacts,omitempty"`
+	runSummary
+}
+
+// DefaultHistoryDir returns the folder where workflow runs are recorded, that is KINDER_HISTORY_DIR if set,
+// or the kinder/history folder in XDG_STATE_HOME, defaulting to ~/.local/state
+func DefaultHistoryDir() (string, error) {
+	if dir := os.Getenv("KINDER_HISTORY_DIR"); dir != "" {
+		return dir, nil
+	}
+	state := os.Getenv("XDG_STATE_HOME")
+	if state == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errors.Wrap(err, "error getting the folder for the workflow history")
+		}
+		state = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(state, "kinder", "history"), nil
+}
+
+// recordHistory records a workflow run into the history folder; the run id is derived from the start
+// of the run, the workflow name and the matrix combination, if any
+func recordHistory(dir, workflow, artifacts string, s *runSummary) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", errors.Wrapf(err, "error creating the history folder %s", dir)
+	}
+
+	base := fmt.Sprintf("%s-%s", s.Start.Format("20060102-150405"), workflow)
+	if s.Name != "" {
+		base = fmt.Sprintf("%s-%s", base, s.Name)
+	}
+	id := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, id+".json")); os.IsNotExist(err) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if abs, err := filepath.Abs(artifacts); err == nil {
+		artifacts = abs
+	}
+	data, err := json.MarshalIndent(historyRun{ID: id, Workflow: workflow, Artifacts: artifacts, runSummary: *s}, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling the workflow run")
+	}
+	if err := os.WriteFile(filepath.Join(dir, id+".json"), data, 0644); err != nil {
+		return "", errors.Wrapf(err, "error recording the workflow run into %s", dir)
+	}
+	return id, nil
+}
+
+// loadHistory returns the runs recorded in the history folder, from the oldest to the newest
+func loadHistory(dir string) ([]*historyRun, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the history folder %s", dir)
+	}
+	var runs []*historyRun
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading %s", f)
+		}
+		r := &historyRun{}
+		if err := json.Unmarshal(data, r); err != nil || r.ID == "" {
+			return nil, errors.Errorf("%s is not a valid workflow run", f)
+		}
+		runs = append(runs, r)
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Start.Before(runs[j].Start) })
+	return runs, nil
+}
+
+// PrintHistory prints the newest runs recorded in the history folder, at most limit if greater than zero;
+// if workflow is set, only runs of the workflow with the given name are printed
+func PrintHistory(out io.Writer, dir, workflow string, limit int) error {
+	runs, err := loadHistory(dir)
+	if err != nil {
+		return err
+	}
+	var selected []*historyRun
+	for _, r := range runs {
+		if workflow == "" || r.Workflow == workflow {
+			selected = append(selected, r)
+		}
+	}
+	if limit > 0 && len(selected) > limit {
+		selected = selected[len(selected)-limit:]
+	}
+	if len(selected) == 0 {
+		fmt.Fprintf(out, "no workflow runs recorded in %s\n", dir)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN\tWORKFLOW\tMATRIX\tRESULT\tDURATION\tPASSED\tFAILED\tSKIPPED")
+	for _, r := range selected {
+		matrix := r.Name
+		if matrix == "" {
+			matrix = "-"
+		}
+		counts := r.counts()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n", r.ID, r.Workflow, matrix, r.Result, seconds(r.Duration), counts[taskPassed]+counts[taskFlaky], counts[taskFailed], counts[taskSkipped])
+	}
+	return w.Flush()
+}
+
+// ShowRun prints the tasks of a run recorded in the history folder, or of the newest run if id is latest,
+// comparing the duration of each task with the average duration of the same task in the other runs
+// of the same workflow and matrix combination
+func ShowRun(out io.Writer, dir, id string) error {
+	runs, err := loadHistory(dir)
+	if err != nil {
+		return err
+	}
+	var run *historyRun
+	for _, r := range runs {
+		if r.ID == id || id == "latest" {
+			run = r
+		}
+	}
+	if run == nil {
+		return errors.Errorf("workflow run %s is not recorded in %s", id, dir)
+	}
+
+	stats := taskStats(runs, run)
+
+	fmt.Fprintf(out, "run       : %s\n", run.ID)
+	fmt.Fprintf(out, "workflow  : %s\n", run.Workflow)
+	if run.Name != "" {
+		fmt.Fprintf(out, "matrix    : %s\n", run.Name)
+	}
+	fmt.Fprintf(out, "started   : %s\n", run.Start.Format(time.RFC3339))
+	fmt.Fprintf(out, "duration  : %s\n", seconds(run.Duration))
+	fmt.Fprintf(out, "result    : %s\n", run.Result)
+	if run.Artifacts != "" {
+		fmt.Fprintf(out, "artifacts : %s\n", run.Artifacts)
+	}
+	if len(run.Vars) > 0 {
+		fmt.Fprintf(out, "vars      :\n")
+	}
+	for _, k := range sortedKeys(run.Vars) {
+		fmt.Fprintf(out, "  %s: %s\n", k, run.Vars[k])
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TASK\tRESULT\tDURATION\tAVERAGE\tFAILED IN OTHER RUNS\tMESSAGE")
+	for _, t := range run.Tasks {
+		average, failed := "-", "-"
+		if s, ok := stats[t.Name]; ok {
+			average = seconds(s.duration / float64(s.runs))
+			failed = fmt.Sprintf("%d/%d", s.failed, s.runs)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", t.Name, t.Result, seconds(t.Duration), average, failed, firstLine(t.Message))
+	}
+	return w.Flush()
+}
+
+// taskStat aggregates the results of a task in many runs
+type taskStat struct {
+	runs     int
+	failed   int
+	duration float64
+}
+
+// taskStats returns the results of the tasks executed in the other runs of the same workflow and matrix
+// combination of a run; skipped tasks are not considered
+func taskStats(runs []*historyRun, run *historyRun) map[string]*taskStat {
+	stats := map[string]*taskStat{}
+	for _, r := range runs {
+		if r == run || r.Workflow != run.Workflow || r.Name != run.Name {
+			continue
+		}
+		for _, t := range r.Tasks {
+			if t.Result == taskSkipped {
+				continue
+			}
+			s, ok := stats[t.Name]
+			if !ok {
+				s = &taskStat{}
+				stats[t.Name] = s
+			}
+			s.runs++
+			s.duration += t.Duration
+			if t.Result == taskFailed {
+				s.failed++
+			}
+		}
+	}
+	return stats
+}
+
+// counts returns the number of tasks for each result
+func (r *historyRun) counts() map[string]int {
+	counts := map[string]int{}
+	for _, t := range r.Tasks {
+		counts[t.Result]++
+	}
+	return counts
+}
+
+func seconds(s float64) string {
+	return (time.Duration(s * float64(time.Second))).Round(time.Second).String()
+}
+
+func firstLine(s string) string {
+	return strings.SplitN(strings.TrimSpace(s), "\n", 2)[0]
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	runs := []*runSummary{
+		{Result: taskPassed, Start: start, Tasks: []taskSummary{{Name: "task-00-init", Result: taskPassed, Duration: 10}}},
+		{Result: taskFailed, Start: start, Tasks: []taskSummary{{Name: "task-00-init", Result: taskFailed, Duration: 30}}},
+		{Result: taskPassed, Start: start.Add(time.Hour), Tasks: []taskSummary{{Name: "task-00-init", Result: taskSkipped}}},
+	}
+	var ids []string
+	for _, r := range runs {
+		id, err := recordHistory(dir, "regular", "", r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	expectedIDs := []string{"20261014-100000-regular", "20261014-100000-regular-2", "20261014-110000-regular"}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		t.Errorf("expected ids %v, got %v", expectedIDs, ids)
+	}
+
+	loaded, err := loadHistory(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded) != 3 || loaded[2].ID != expectedIDs[2] {
+		t.Fatalf("expected 3 runs ending with %s, got %v", expectedIDs[2], loaded)
+	}
+	stats := taskStats(loaded, loaded[2])
+	expected := map[string]*taskStat{"task-00-init": {runs: 2, failed: 1, duration: 40}}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected stats %v, got %v", expected["task-00-init"], stats["task-00-init"])
+	}
+}
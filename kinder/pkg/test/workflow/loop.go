@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// loopItems returns the items a task iterates over, or nil if the task does not define a loop;
+// each forEach entry is expanded and then split on spaces and commas, so a single entry
+// can refer to a var holding a list, like e.g. "{{ .vars.nodes }}"
+func (c *taskCmdBuilder) loopItems(t *Task) ([]string, error) {
+	if len(t.ForEach) == 0 {
+		return nil, nil
+	}
+
+	items := []string{}
+	for n, e := range t.ForEach {
+		v, err := c.expand(e)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error expanding forEach[%d] for task %q", n, t.Name)
+		}
+		items = append(items, strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n'
+		})...)
+	}
+	return items, nil
+}
+
+var loopNameRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// loopTaskName returns the name of the task generated by a loop for an item
+func loopTaskName(name, item string) string {
+	return name + "-" + strings.Trim(loopNameRegexp.ReplaceAllString(item, "-"), "-")
+}
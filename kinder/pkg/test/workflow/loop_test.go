@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildAllLoop(t *testing.T) {
+	tasks := Tasks{
+		{Name: "first", Cmd: "echo"},
+		{Name: "loop", Cmd: "echo", Args: []string{"{{ .item }}"}, ForEach: []string{"{{ .vars.items }}", "c"}},
+		{Name: "parallel", Cmd: "echo", ForEach: []string{"x y"}, Parallel: true},
+		{Name: "empty", Cmd: "echo", ForEach: []string{""}},
+		{Name: "last", Cmd: "echo", Needs: []string{"empty"}},
+	}
+	if err := resolveDependencies(tasks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, err := newTaskCmdBuilder(&Workflow{Vars: map[string]string{"items": "a, b"}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tcmds, err := c.buildAll(tasks, false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		name         string
+		text         string
+		dependencies []int
+	}{
+		{name: "first", text: "echo ", dependencies: nil},
+		{name: "loop-a", text: "echo a", dependencies: []int{0}},
+		{name: "loop-b", text: "echo b", dependencies: []int{0, 1}},
+		{name: "loop-c", text: "echo c", dependencies: []int{0, 2}},
+		{name: "parallel-x", text: "echo ", dependencies: []int{0, 1, 2, 3}},
+		{name: "parallel-y", text: "echo ", dependencies: []int{0, 1, 2, 3}},
+		{name: "last", text: "echo ", dependencies: []int{0, 1, 2, 3, 4, 5}},
+	}
+	if len(tcmds) != len(expected) {
+		t.Fatalf("expected %d taskCmds, got %d", len(expected), len(tcmds))
+	}
+	for i, e := range expected {
+		if tcmds[i].Name != e.name || tcmds[i].CmdText != e.text || !reflect.DeepEqual(tcmds[i].dependencies, e.dependencies) {
+			t.Errorf("expected taskCmd %d to be %+v, got %s %q %v", i, e, tcmds[i].Name, tcmds[i].CmdText, tcmds[i].dependencies)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// validateMatrix checks that all the matrix dimensions have at least one value and
+// that dimensions are not defined as vars too
+func validateMatrix(matrix map[string][]string, vars map[string]string) error {
+	for _, k := range sortedDimensions(matrix) {
+		if len(matrix[k]) == 0 {
+			return errors.Errorf("matrix dimension %q does not define any value", k)
+		}
+		if _, ok := vars[k]; ok {
+			return errors.Errorf("matrix dimension %q can't be defined in vars too", k)
+		}
+	}
+	return nil
+}
+
+// combinations returns all the combinations of values of the matrix dimensions, as maps of vars.
+// Dimensions are sorted by name, and values of the last dimension change first
+func combinations(matrix map[string][]string) []map[string]string {
+	if len(matrix) == 0 {
+		return nil
+	}
+
+	result := []map[string]string{{}}
+	for _, k := range sortedDimensions(matrix) {
+		var next []map[string]string
+		for _, c := range result {
+			for _, v := range matrix[k] {
+				n := map[string]string{k: v}
+				for ck, cv := range c {
+					n[ck] = cv
+				}
+				next = append(next, n)
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// combinationName returns a name for a combination of values of the matrix dimensions,
+// that can be used as a folder name e.g. cri-containerd_initVersion-v1.33.0
+func combinationName(combination map[string]string) string {
+	keys := make([]string, 0, len(combination))
+	for k := range combination {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, invalidNameChars.ReplaceAllString(fmt.Sprintf("%s-%s", k, combination[k]), "-"))
+	}
+	return strings.Join(parts, "_")
+}
+
+func sortedDimensions(matrix map[string][]string) []string {
+	keys := make([]string, 0, len(matrix))
+	for k := range matrix {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
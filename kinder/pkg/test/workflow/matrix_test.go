@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCombinations(t *testing.T) {
+	matrix := map[string][]string{
+		"initVersion": {"v1.32.0", "v1.33.0"},
+		"cri":         {"containerd", "cri-o"},
+	}
+	expected := []map[string]string{
+		{"cri": "containerd", "initVersion": "v1.32.0"},
+		{"cri": "containerd", "initVersion": "v1.33.0"},
+		{"cri": "cri-o", "initVersion": "v1.32.0"},
+		{"cri": "cri-o", "initVersion": "v1.33.0"},
+	}
+	if c := combinations(matrix); !reflect.DeepEqual(c, expected) {
+		t.Errorf("expected combinations %v, got %v", expected, c)
+	}
+
+	if c := combinations(nil); c != nil {
+		t.Errorf("expected no combinations for an empty matrix, got %v", c)
+	}
+
+	name := combinationName(map[string]string{"initVersion": "v1.33.0+abc", "cri": "containerd"})
+	if expected := "cri-containerd_initVersion-v1.33.0-abc"; name != expected {
+		t.Errorf("expected name %q, got %q", expected, name)
+	}
+}
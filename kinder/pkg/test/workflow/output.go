@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+)
+
+// RunOption is a configuration option supplied to Workflow.Run
+type RunOption func(*runOptions)
+
+// runOptions defines settings for running a workflow, e.g. how the workflow progress and the task output are printed
+type runOptions struct {
+	quiet   bool
+	noColor bool
+	timeout time.Duration
+	webhook string
+
+	skipPreflight bool
+
+	// step executes tasks one at time, asking the user before each task what to do
+	step bool
+
+	// resumeFrom is the task from which the workflow run recorded in the artifacts folder is resumed
+	resumeFrom string
+
+	// history is the folder where workflow runs are recorded, if any
+	history string
+
+	// interruption handles signals for all the matrix combinations of a workflow run
+	interruption *interruption
+
+	// deadline is computed from timeout when the workflow starts
+	deadline time.Time
+}
+
+// Quiet option instructs Workflow.Run to print only failed tasks and the final summary
+func Quiet(quiet bool) RunOption {
+	return func(o *runOptions) {
+		o.quiet = quiet
+	}
+}
+
+// NoColor option disables colors in the task output streamed when running in verbose mode
+func NoColor(noColor bool) RunOption {
+	return func(o *runOptions) {
+		o.noColor = noColor
+	}
+}
+
+// Timeout option sets the maximum duration of the whole workflow, including all the matrix combinations;
+// once reached, the running task is stopped and only forced tasks, e.g. cleanup tasks, are executed
+func Timeout(timeout time.Duration) RunOption {
+	return func(o *runOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Webhook option sets a URL the progress of the workflow is POSTed to, with an event each time a workflow
+// or a task start and complete
+func Webhook(url string) RunOption {
+	return func(o *runOptions) {
+		o.webhook = url
+	}
+}
+
+// SkipPreflight option disables the checks executed before running any task, e.g. for commands
+// or clusters created by tasks in ways the checks can't detect
+func SkipPreflight(skip bool) RunOption {
+	return func(o *runOptions) {
+		o.skipPreflight = skip
+	}
+}
+
+// Step option instructs Workflow.Run to execute tasks one at time, asking the user before each task
+// whether to run it, skip it or abort the workflow
+func Step(step bool) RunOption {
+	return func(o *runOptions) {
+		o.step = step
+	}
+}
+
+// ResumeFrom option instructs Workflow.Run to resume the workflow run recorded in the artifacts folder
+// from the given task
+func ResumeFrom(task string) RunOption {
+	return func(o *runOptions) {
+		o.resumeFrom = task
+	}
+}
+
+// History option sets the folder where each workflow run is recorded, e.g. for comparing timings and
+// failures across many runs of the same workflow; runs are not recorded if the folder is empty
+func History(dir string) RunOption {
+	return func(o *runOptions) {
+		o.history = dir
+	}
+}
+
+// prefixColors are the ANSI colors used for the prefixes of streamed task output; each task picks
+// a color from its name, so the output of tasks executed at the same time can be told apart
+var prefixColors = []string{"36", "32", "33", "35", "34", "96", "92", "93", "95", "94"}
+
+// prefixWriter writes the task output one line at time, prefixed with the task name and the time
+// elapsed since the task started; writes are serialized, because stdout and stderr of many tasks
+// can be streamed at the same time
+type prefixWriter struct {
+	out    io.Writer
+	name   string
+	start  time.Time
+	color  string
+	buffer []byte
+}
+
+var streamLock sync.Mutex
+
+func newPrefixWriter(out io.Writer, name string, start time.Time, noColor bool) *prefixWriter {
+	w := &prefixWriter{
+		out:   out,
+		name:  name,
+		start: start,
+	}
+	if !noColor {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		w.color = prefixColors[h.Sum32()%uint32(len(prefixColors))]
+	}
+	return w
+}
+
+func (w *prefixWriter) prefix() string {
+	p := fmt.Sprintf("[%s +%s]", w.name, time.Since(w.start).Round(time.Second))
+	if w.color != "" {
+		p = fmt.Sprintf("\033[%sm%s\033[0m", w.color, p)
+	}
+	return p + " "
+}
+
+// Write implements io.Writer; incomplete lines are kept until the next write or Flush
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	streamLock.Lock()
+	defer streamLock.Unlock()
+
+	w.buffer = append(w.buffer, p...)
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(w.out, "%s%s", w.prefix(), w.buffer[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buffer = w.buffer[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the incomplete line, if any
+func (w *prefixWriter) Flush() {
+	streamLock.Lock()
+	defer streamLock.Unlock()
+
+	if len(w.buffer) > 0 {
+		fmt.Fprintf(w.out, "%s%s\n", w.prefix(), w.buffer)
+		w.buffer = nil
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Plan resolves all the vars and prints the ordered list of tasks with their final commands, timeouts and
+// target clusters, without executing them; if a matrix is defined, a plan for each combination is printed
+func (w *Workflow) Plan(out io.Writer, artifacts string) error {
+	if len(w.Matrix) == 0 {
+		return w.plan(out, artifacts, nil)
+	}
+	for _, combination := range combinations(w.Matrix) {
+		fmt.Fprintf(out, "## matrix %s\n\n", combinationName(combination))
+		if err := w.plan(out, artifacts, combination); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Workflow) plan(out io.Writer, artifacts string, combination map[string]string) error {
+	taskCmdBuilder, artifacts, err := w.prepare(true, artifacts, combination)
+	if err != nil {
+		return err
+	}
+	taskCmdBuilder.env["ARTIFACTS"] = artifacts
+
+	tcmds, err := taskCmdBuilder.buildAll(w.Tasks, false, 0)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "artifacts   : %s\n", artifacts)
+	fmt.Fprintf(out, "vars        :\n")
+	names := make([]string, 0, len(taskCmdBuilder.vars))
+	for n := range taskCmdBuilder.vars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(out, "  %s: %s\n", n, taskCmdBuilder.vars[n])
+	}
+	if len(w.HostEnv) > 0 {
+		fmt.Fprintf(out, "hostEnv     :\n")
+		for _, n := range w.HostEnv {
+			fmt.Fprintf(out, "  %s: %s\n", n, taskCmdBuilder.hostEnv[n])
+		}
+	}
+	if len(w.Secrets) > 0 {
+		fmt.Fprintf(out, "secrets     :\n")
+		for _, n := range sortedKeys(w.Secrets) {
+			if s := w.Secrets[n]; s.Env != "" {
+				fmt.Fprintf(out, "  %s: %s (from env %s)\n", n, secretMask, s.Env)
+			} else {
+				fmt.Fprintf(out, "  %s: %s (from file %s)\n", n, secretMask, s.File)
+			}
+		}
+	}
+	fmt.Fprintln(out)
+
+	for _, tcmd := range tcmds {
+		fmt.Fprintf(out, "# %s\n", tcmd.Name)
+		fmt.Fprintf(out, "command     : %s\n", tcmd.CmdText)
+		if cluster := targetCluster(tcmd.Cmd.Args); cluster != "" {
+			fmt.Fprintf(out, "cluster     : %s\n", cluster)
+		}
+		fmt.Fprintf(out, "timeout     : %s\n", tcmd.Timeout.Duration)
+		if tcmd.Disabled {
+			fmt.Fprintf(out, "condition   : false, the task will be skipped\n")
+		}
+		switch {
+		case tcmd.onFailure:
+			fmt.Fprintf(out, "section     : onFailure\n")
+		case tcmd.finally:
+			fmt.Fprintf(out, "section     : finally\n")
+		case tcmd.Force:
+			fmt.Fprintf(out, "force       : true\n")
+		}
+		if tcmd.IgnoreError {
+			fmt.Fprintf(out, "ignoreError : true\n")
+		}
+		if tcmd.IgnoreFailure {
+			fmt.Fprintf(out, "ignoreFailure: true\n")
+		}
+		if tcmd.Retries > 0 {
+			fmt.Fprintf(out, "retries     : %d (backoff %s)\n", tcmd.Retries, tcmd.Backoff.Duration)
+		}
+		if tcmd.Parallel {
+			fmt.Fprintf(out, "parallel    : true\n")
+		}
+		if len(tcmd.Needs) > 0 {
+			fmt.Fprintf(out, "needs       : %s\n", strings.Join(tcmd.Needs, ", "))
+		}
+		for _, a := range tcmd.Artifacts {
+			if a.Nodes == "" {
+				fmt.Fprintf(out, "artifact    : %s (%s)\n", a.Path, a.When)
+				continue
+			}
+			fmt.Fprintf(out, "artifact    : %s:%s on cluster %s (%s)\n", a.Nodes, a.Path, a.Cluster, a.When)
+		}
+		fmt.Fprintln(out)
+	}
+	return nil
+}
+
+// targetCluster returns the name of the cluster targeted by a kinder command, if set
+func targetCluster(args []string) string {
+	return kinderFlag(args, "name")
+}
+
+// kinderFlag returns the value of a flag of a kinder command, if any
+func kinderFlag(args []string, name string) string {
+	if len(args) == 0 || filepath.Base(args[0]) != "kinder" {
+		return ""
+	}
+	for i, a := range args[1:] {
+		if v, ok := strings.CutPrefix(a, "--"+name+"="); ok {
+			return v
+		}
+		if a == "--"+name && i+2 < len(args) {
+			return args[i+2]
+		}
+	}
+	return ""
+}
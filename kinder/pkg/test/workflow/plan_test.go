@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTargetCluster(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"kinder", "do", "kubeadm-init", "--name=kinder-regular"}, expected: "kinder-regular"},
+		{args: []string{"/usr/local/bin/kinder", "delete", "cluster", "--name", "kinder-upgrade"}, expected: "kinder-upgrade"},
+		{args: []string{"kinder", "build", "node-image-variant", "--image=kindest/node:test"}},
+		{args: []string{"kinder", "delete", "cluster", "--name"}},
+		{args: []string{"docker", "run", "--name=kinder-regular"}},
+	}
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			if cluster := targetCluster(tc.args); cluster != tc.expected {
+				t.Errorf("expected cluster %q, got %q", tc.expected, cluster)
+			}
+		})
+	}
+}
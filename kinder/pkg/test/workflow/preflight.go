@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/status"
+)
+
+// preflight checks, before running any task, that the commands, the clusters and the node images used
+// by the workflow are available or are created by a previous task, and that the artifacts folder is writable
+type preflight struct {
+	lookPath      func(file string) (string, error)
+	clusterExists func(name string) (bool, error)
+	imageExists   func(image string) bool
+}
+
+func newPreflight() *preflight {
+	return &preflight{
+		lookPath: exec.LookPath,
+		clusterExists: func(name string) (bool, error) {
+			c, err := status.FromDocker(name)
+			if err != nil {
+				return false, err
+			}
+			return len(c.AllNodes()) > 0, nil
+		},
+		imageExists: func(image string) bool {
+			return exec.Command("docker", "image", "inspect", image).Run() == nil
+		},
+	}
+}
+
+// check returns an error listing all the failed checks; inactive tasks, e.g. tasks with a false condition,
+// are ignored, while commands inside the artifacts folder are assumed to be created by a previous task
+func (p *preflight) check(tcmds []*taskCmd, artifacts string) error {
+	var failures []string
+
+	if err := checkWritable(artifacts); err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	// clusters are checked only once, and missing clusters are reported only once
+	clusters := map[string]bool{}
+	checkCluster := func(t *taskCmd, name string) {
+		if name == "" || clusters[name] {
+			return
+		}
+		clusters[name] = true
+		exists, err := p.clusterExists(name)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("task %s: cluster %q is not created by a previous task, and checking if it exists failed: %v", t.Name, name, err))
+		case !exists:
+			failures = append(failures, fmt.Sprintf("task %s: cluster %q is not created by a previous task and it does not exist", t.Name, name))
+		}
+	}
+
+	images := map[string]bool{}
+	checkImage := func(t *taskCmd, image string) {
+		if image == "" || images[image] {
+			return
+		}
+		images[image] = true
+		if !p.imageExists(image) {
+			failures = append(failures, fmt.Sprintf("task %s: image %q is not built or pulled by a previous task and it does not exist", t.Name, image))
+		}
+	}
+
+	commands := map[string]bool{}
+	for _, t := range tcmds {
+		if t.Disabled || t.Resumed {
+			continue
+		}
+		args := t.Cmd.Args
+
+		if !commands[args[0]] {
+			commands[args[0]] = true
+			if err := p.checkCommand(t, artifacts); err != nil {
+				failures = append(failures, fmt.Sprintf("task %s: %v", t.Name, err))
+			}
+		}
+
+		switch {
+		case isCommand(args, "kinder", "create", "cluster"):
+			checkImage(t, kinderFlag(args, "image"))
+			if name := targetCluster(args); name != "" {
+				clusters[name] = true
+			}
+		case isCommand(args, "kinder", "build"):
+			checkImage(t, kinderFlag(args, "base-image"))
+			if image := kinderFlag(args, "image"); image != "" {
+				images[image] = true
+			}
+		case isCommand(args, "docker", "pull"), isCommand(args, "docker", "tag"):
+			images[args[len(args)-1]] = true
+		default:
+			checkCluster(t, targetCluster(args))
+		}
+
+		for _, a := range t.Artifacts {
+			if a.Nodes != "" {
+				checkCluster(t, a.Cluster)
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("preflight checks failed:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// checkCommand checks that the command of a taskCmd exists
+func (p *preflight) checkCommand(t *taskCmd, artifacts string) error {
+	name := t.Cmd.Args[0]
+	if !strings.Contains(name, string(filepath.Separator)) {
+		if _, err := p.lookPath(name); err != nil {
+			return errors.Errorf("command %q not found in PATH", name)
+		}
+		return nil
+	}
+
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.Cmd.Dir, path)
+	}
+	if rel, err := filepath.Rel(artifacts, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return errors.Errorf("command %q does not exist", name)
+	}
+	return nil
+}
+
+// isCommand returns true if args are a command with the given name and sub commands, e.g. kinder create cluster
+func isCommand(args []string, name string, subcommands ...string) bool {
+	if len(args) < len(subcommands)+1 || filepath.Base(args[0]) != name {
+		return false
+	}
+	for i, s := range subcommands {
+		if args[i+1] != s {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWritable checks that a file can be created into the artifacts folder
+func checkWritable(artifacts string) error {
+	f, err := os.CreateTemp(artifacts, ".preflight-")
+	if err != nil {
+		return errors.Wrapf(err, "artifacts folder %s is not writable", artifacts)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestPreflight(t *testing.T) {
+	p := &preflight{
+		lookPath: func(file string) (string, error) {
+			if file == "kinder" || file == "docker" {
+				return file, nil
+			}
+			return "", errors.New("not found")
+		},
+		clusterExists: func(name string) (bool, error) { return name == "existing", nil },
+		imageExists:   func(image string) bool { return image == "kindest/base:local" },
+	}
+	tcmd := func(name string, args ...string) *taskCmd {
+		return &taskCmd{Task: &Task{Name: name}, Cmd: exec.Command(args[0], args[1:]...)}
+	}
+
+	artifacts := t.TempDir()
+	tcmds := []*taskCmd{
+		tcmd("pull", "docker", "pull", "kindest/base:pulled"),
+		tcmd("build", "kinder", "build", "node-image-variant", "--base-image=kindest/base:pulled", "--image=kindest/node:test"),
+		tcmd("create", "kinder", "create", "cluster", "--name=created", "--image=kindest/node:test"),
+		tcmd("init", "kinder", "do", "kubeadm-init", "--name=created"),
+		tcmd("existing", "kinder", "do", "kubeadm-init", "--name", "existing"),
+		tcmd("built-binary", filepath.Join(artifacts, "bin", "kubeadm"), "version"),
+	}
+	if err := p.check(tcmds, artifacts); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tcmds = []*taskCmd{
+		tcmd("missing-command", "foo"),
+		tcmd("missing-image", "kinder", "create", "cluster", "--name=created", "--image=kindest/node:missing"),
+		tcmd("missing-cluster", "kinder", "do", "kubeadm-init", "--name=missing"),
+		tcmd("disabled", "bar"),
+	}
+	tcmds[3].Disabled = true
+	err := p.check(tcmds, filepath.Join(artifacts, "missing"))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, e := range []string{"artifacts folder", "command \"foo\"", "image \"kindest/node:missing\"", "cluster \"missing\""} {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("expected error to report %s, got %v", e, err)
+		}
+	}
+	if strings.Contains(err.Error(), "bar") {
+		t.Errorf("expected disabled tasks to be ignored, got %v", err)
+	}
+}
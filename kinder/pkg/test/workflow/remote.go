@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/kubeadm/kinder/pkg/oci"
+)
+
+// Workflow files can be loaded from a local path, from an http(s) URL or from an OCI artifact.
+//
+// URLs can be pinned by appending the expected digest of the workflow file as a fragment, e.g.
+// https://example.com/workflow.yaml#sha256:<hex>; imports with relative paths in a workflow file
+// loaded from an URL are resolved against the same URL.
+//
+// OCI references have the form oci://registry/repository[:tag][@sha256:<hex>][#file]; all the files
+// in the artifact are pulled into a temporary folder, and the workflow is loaded from the given file,
+// that can be omitted if the artifact contains only one file. The media types used by oras for
+// pushing files are supported, as well as private registries with the credentials set in the
+// KINDER_OCI_USERNAME and KINDER_OCI_PASSWORD env variables.
+
+const (
+	// maxRemoteFileSize limits the size of workflow files read from the network
+	maxRemoteFileSize = 10 << 20
+)
+
+var (
+	digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+	remoteClient = &http.Client{Timeout: time.Minute}
+)
+
+// isURL returns true if the workflow file is an http(s) URL
+func isURL(file string) bool {
+	return strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://")
+}
+
+// isOCIReference returns true if the workflow file is an OCI artifact reference
+func isOCIReference(file string) bool {
+	return strings.HasPrefix(file, oci.Scheme)
+}
+
+// readWorkflowFile returns the content of a workflow file, from a local path or from an URL
+func readWorkflowFile(file string) ([]byte, error) {
+	if isURL(file) {
+		return fetchURL(file)
+	}
+
+	// Checks if the workflow file exists
+	if _, err := os.Stat(file); err != nil {
+		return nil, errors.Errorf("invalid workflow file: %s does not exist", file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading workflow file %s", file)
+	}
+	return data, nil
+}
+
+// importPath returns the path of a workflow file imported by another workflow file; relative paths
+// are considered relative to the folder, or to the URL, where the importing file is located.
+// The returned path is absolute, thus allowing to compare paths when detecting circular imports.
+func importPath(file, imp string) (string, error) {
+	if isURL(imp) {
+		return imp, nil
+	}
+	if isURL(file) {
+		base, err := url.Parse(file)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid workflow URL %s", file)
+		}
+		ref, err := url.Parse(imp)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid import %s", imp)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+
+	p := imp
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(filepath.Dir(file), p)
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting the absolute path of workflow file %s", p)
+	}
+	return abs, nil
+}
+
+// fetchURL downloads a workflow file, verifying its digest if pinned in the URL fragment
+func fetchURL(file string) ([]byte, error) {
+	u, err := url.Parse(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid workflow URL %s", file)
+	}
+	digest := u.Fragment
+	u.Fragment = ""
+
+	if digest == "" {
+		log.Warnf("workflow file %s is not pinned to a digest", file)
+	}
+
+	data, err := httpGetAll(u.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error fetching workflow file %s", file)
+	}
+	if digest != "" {
+		if err := verifyDigest(data, digest); err != nil {
+			return nil, errors.Wrapf(err, "invalid workflow file %s", file)
+		}
+	}
+	return data, nil
+}
+
+// verifyDigest checks that data matches the given sha256 digest
+func verifyDigest(data []byte, digest string) error {
+	if !digestRegexp.MatchString(digest) {
+		return errors.Errorf("%q is not a valid sha256 digest", digest)
+	}
+	sum := sha256.Sum256(data)
+	if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != digest {
+		return errors.Errorf("digest mismatch, expected %s, got %s", digest, actual)
+	}
+	return nil
+}
+
+// httpGetAll executes an HTTP GET and returns the response body, up to maxRemoteFileSize
+func httpGetAll(uri string) ([]byte, error) {
+	resp, err := remoteClient.Get(uri)
+	if err != nil {
+		return nil, errors.Wrapf(err, "HTTP GET %s failed", uri)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("HTTP GET %s failed: %s", uri, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteFileSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading %s", uri)
+	}
+	if len(data) > maxRemoteFileSize {
+		return nil, errors.Errorf("%s is bigger than %d bytes", uri, maxRemoteFileSize)
+	}
+	return data, nil
+}
+
+// pullOCI pulls all the files in an OCI artifact into dir, and returns the path of the workflow file
+func pullOCI(ref, dir string) (string, error) {
+	s, file, _ := strings.Cut(ref, "#")
+	r, err := oci.ParseReference(s)
+	if err != nil {
+		return "", err
+	}
+	if r.Digest == "" {
+		log.Warnf("workflow artifact %s is not pinned to a digest", ref)
+	}
+
+	registry := oci.NewClient(r, remoteClient)
+	data, err := registry.ManifestData(r.TagOrDigest())
+	if err != nil {
+		return "", errors.Wrapf(err, "error fetching the manifest of %s", ref)
+	}
+	if r.Digest != "" {
+		if err := verifyDigest(data, r.Digest); err != nil {
+			return "", errors.Wrapf(err, "invalid manifest of %s", ref)
+		}
+	}
+	var m oci.Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", errors.Wrapf(err, "error unmarshalling the manifest of %s", ref)
+	}
+
+	var files []string
+	for _, l := range m.Layers {
+		title := l.Annotations[oci.TitleAnnotation]
+		if title == "" {
+			continue
+		}
+		if !filepath.IsLocal(title) {
+			return "", errors.Errorf("invalid artifact %s: file %q is not a local path", ref, title)
+		}
+		blob, err := readBlob(registry, l.Digest)
+		if err != nil {
+			return "", errors.Wrapf(err, "error fetching file %s of %s", title, ref)
+		}
+		if err := verifyDigest(blob, l.Digest); err != nil {
+			return "", errors.Wrapf(err, "invalid file %s of %s", title, ref)
+		}
+		dst := filepath.Join(dir, title)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", errors.Wrapf(err, "error creating folder for file %s of %s", title, ref)
+		}
+		if err := os.WriteFile(dst, blob, 0644); err != nil {
+			return "", errors.Wrapf(err, "error writing file %s of %s", title, ref)
+		}
+		files = append(files, title)
+	}
+
+	switch {
+	case file != "":
+		for _, f := range files {
+			if path.Clean(f) == path.Clean(file) {
+				return filepath.Join(dir, f), nil
+			}
+		}
+		return "", errors.Errorf("invalid artifact %s: file %s not found, artifact files are %v", ref, file, files)
+	case len(files) == 1:
+		return filepath.Join(dir, files[0]), nil
+	case len(files) == 0:
+		return "", errors.Errorf("invalid artifact %s: no files found", ref)
+	default:
+		return "", errors.Errorf("invalid artifact %s: the workflow file must be selected among %v, e.g. %s#%s", ref, files, ref, files[0])
+	}
+}
+
+// readBlob reads a file of an OCI artifact, up to maxRemoteFileSize
+func readBlob(registry *oci.Client, digest string) ([]byte, error) {
+	blob, err := registry.Blob(digest)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+
+	data, err := io.ReadAll(io.LimitReader(blob, maxRemoteFileSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading blob %s", digest)
+	}
+	if len(data) > maxRemoteFileSize {
+		return nil, errors.Errorf("blob %s is bigger than %d bytes", digest, maxRemoteFileSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+)
+
+func TestImportPath(t *testing.T) {
+	testCases := []struct {
+		file     string
+		imp      string
+		expected string
+	}{
+		{file: "/workflows/a.yaml", imp: "b.yaml", expected: "/workflows/b.yaml"},
+		{file: "/workflows/a.yaml", imp: "/other/b.yaml", expected: "/other/b.yaml"},
+		{file: "https://example.com/workflows/a.yaml#sha256:abc", imp: "../common/b.yaml", expected: "https://example.com/common/b.yaml"},
+		{file: "/workflows/a.yaml", imp: "https://example.com/b.yaml", expected: "https://example.com/b.yaml"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.imp, func(t *testing.T) {
+			p, err := importPath(tc.file, tc.imp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, p)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// workflowResult defines the outcome of a workflow executed by RunAll
+type workflowResult struct {
+	name     string
+	duration time.Duration
+	err      error
+}
+
+// RunAll executes many workflows, at most concurrency at the same time, and prints an aggregated summary.
+// Each workflow gets an artifacts subfolder named after its workflow file, and its output is prefixed
+// with the same name; workflows are always executed to completion, even if another workflow fails.
+func RunAll(out io.Writer, workflows []*Workflow, concurrency int, dryRun, verbose, exitOnError bool, artifacts string, options ...RunOption) error {
+	if concurrency < 1 {
+		return errors.Errorf("invalid concurrency %d, at least one workflow must be executed at a time", concurrency)
+	}
+	o := runOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+
+	// if the artifact folder is not provided in any way, generates one shared by all the workflows
+	if artifacts == "" {
+		artifacts = os.Getenv("ARTIFACTS")
+	}
+	if artifacts == "" && !dryRun {
+		dir, err := os.Getwd()
+		if err != nil {
+			return errors.Wrapf(err, "error getting current directory")
+		}
+		if artifacts, err = os.MkdirTemp(dir, "kinder-test-workflows"); err != nil {
+			return errors.Wrapf(err, "error creating artifact folder")
+		}
+	}
+
+	names := workflowNames(workflows)
+	start := time.Now()
+	results := make([]workflowResult, len(workflows))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, w := range workflows {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, w *Workflow) {
+			defer func() { <-sem; wg.Done() }()
+
+			r := workflowResult{name: names[i]}
+			started := time.Now()
+			wOut := newPrefixWriter(out, names[i], started, o.noColor)
+			defer wOut.Flush()
+
+			wArtifacts := filepath.Join(artifacts, names[i])
+			if dryRun {
+				wArtifacts = ""
+			} else if err := os.MkdirAll(wArtifacts, 0755); err != nil {
+				r.err = errors.Wrapf(err, "error creating artifact folder for workflow %s", names[i])
+				results[i] = r
+				return
+			}
+
+			r.err = w.Run(wOut, dryRun, verbose, exitOnError, wArtifacts, options...)
+			r.duration = time.Since(started)
+			results[i] = r
+		}(i, w)
+	}
+	wg.Wait()
+
+	return reportAll(out, results, time.Since(start))
+}
+
+// reportAll prints the aggregated summary of the workflows executed by RunAll
+func reportAll(out io.Writer, results []workflowResult, duration time.Duration) error {
+	var failed []string
+	fmt.Fprintf(out, "\nRan %d workflows in %s\n", len(results), duration.Round(time.Second))
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, r.name)
+			fmt.Fprintf(out, " FAIL %s (%s): %v\n", r.name, r.duration.Round(time.Second), r.err)
+			continue
+		}
+		fmt.Fprintf(out, " PASS %s (%s)\n", r.name, r.duration.Round(time.Second))
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "FAIL! -- %d workflows Passed | %d Failed\n\n", len(results)-len(failed), len(failed))
+		return errors.Errorf("failed executing the workflows %s", strings.Join(failed, ", "))
+	}
+	fmt.Fprintf(out, "SUCCESS! -- %d workflows Passed | 0 Failed\n\n", len(results))
+	return nil
+}
+
+// workflowNames returns unique names for the workflows, derived from the workflow files
+func workflowNames(workflows []*Workflow) []string {
+	names := make([]string, len(workflows))
+	used := map[string]int{}
+	for i, w := range workflows {
+		// fragments and digests, e.g. in URLs and OCI references, are not part of the name
+		base := path.Base(strings.SplitN(strings.SplitN(w.ref, "#", 2)[0], "@", 2)[0])
+		name := strings.TrimSuffix(base, path.Ext(base))
+		if name == "" || name == "." || name == "/" {
+			name = "workflow"
+		}
+		used[name]++
+		if used[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, used[name])
+		}
+		names[i] = name
+	}
+	return names
+}
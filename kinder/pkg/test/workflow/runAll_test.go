@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowNames(t *testing.T) {
+	workflows := []*Workflow{
+		{ref: "ci/workflows/regular-1.33.yaml"},
+		{ref: "other/regular-1.33.yaml"},
+		{ref: "https://example.com/upgrade.yaml#sha256:abc"},
+		{ref: "oci://ghcr.io/org/workflows:v1@sha256:abc#skew.yaml"},
+	}
+	expected := []string{"regular-1.33", "regular-1.33-2", "upgrade", "workflows:v1"}
+	if names := workflowNames(workflows); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected names %v, got %v", expected, names)
+	}
+}
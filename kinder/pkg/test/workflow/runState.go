@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// runStateFile is the name of the file in the artifacts folder where the state of a workflow run is recorded
+const runStateFile = "workflow-state.json"
+
+// possible results of a task in the run state
+const (
+	taskPassed  = "passed"
+	taskFailed  = "failed"
+	taskSkipped = "skipped"
+	taskFlaky   = "flaky"
+)
+
+// runState records the vars and the results of the tasks of a workflow run, for allowing to resume it
+type runState struct {
+	Vars  map[string]string `json:"vars"`
+	Tasks []taskState       `json:"tasks"`
+}
+
+// taskState records the result of a task of a workflow run
+type taskState struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+}
+
+// loadRunState reads the state of a workflow run from the artifacts folder
+func loadRunState(artifacts string) (*runState, error) {
+	file := filepath.Join(artifacts, runStateFile)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the state of the workflow run to resume")
+	}
+	s := &runState{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", file)
+	}
+	return s, nil
+}
+
+// save writes the state of a workflow run into the artifacts folder
+func (s *runState) save(artifacts string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the state of the workflow run")
+	}
+	file := filepath.Join(artifacts, runStateFile)
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return errors.Wrapf(err, "error writing %s", file)
+	}
+	return nil
+}
+
+// result returns the result of a task in the run state, if any
+func (s *runState) result(name string) string {
+	for _, t := range s.Tasks {
+		if t.Name == name {
+			return t.Result
+		}
+	}
+	return ""
+}
+
+var taskPrefix = regexp.MustCompile(`^task\-\d{2}\-?`)
+
+// resumeIndex returns the index of the task to resume a workflow from; the task can be referenced
+// by its full name, e.g. task-03-join, or by the name defined in the workflow file, e.g. join
+func resumeIndex(tasks Tasks, name string) (int, error) {
+	for i, t := range tasks {
+		if t.Name == name || (taskPrefix.MatchString(t.Name) && taskPrefix.ReplaceAllString(t.Name, "") == name) {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("task %q to resume the workflow from does not exist", name)
+}
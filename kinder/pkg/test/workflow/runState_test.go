@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+)
+
+func TestResumeIndex(t *testing.T) {
+	tasks := Tasks{{Name: "task-00"}, {Name: "task-01-create"}, {Name: "task-02-join"}}
+	testCases := []struct {
+		name          string
+		expected      int
+		expectedError bool
+	}{
+		{name: "task-00", expected: 0},
+		{name: "join", expected: 2},
+		{name: "task-01-create", expected: 1},
+		{name: "reset", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := resumeIndex(tasks, tc.name)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if i != tc.expected {
+				t.Errorf("expected index %d, got %d", tc.expected, i)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Secret defines where the value of a secret is read from; exactly one of Env or File must be set
+type Secret struct {
+	// Env is the name of the host environment variable holding the secret
+	Env string
+
+	// File is the path of the file holding the secret; trailing new lines are removed
+	File string
+}
+
+// secretMask replaces secret values in logs, junit output and plans
+const secretMask = "***"
+
+// validateSecrets checks that each secret defines exactly one source
+func validateSecrets(secrets map[string]Secret) error {
+	for _, n := range sortedKeys(secrets) {
+		s := secrets[n]
+		if (s.Env == "") == (s.File == "") {
+			return errors.Errorf("secret %q must define exactly one of env or file", n)
+		}
+	}
+	return nil
+}
+
+// loadSecrets reads the values of the secrets
+func loadSecrets(secrets map[string]Secret) (map[string]string, error) {
+	values := map[string]string{}
+	for n, s := range secrets {
+		if s.Env != "" {
+			v, ok := os.LookupEnv(s.Env)
+			if !ok {
+				return nil, errors.Errorf("secret %q: environment variable %s is not set", n, s.Env)
+			}
+			values[n] = v
+			continue
+		}
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return nil, errors.Wrapf(err, "secret %q: error reading %s", n, s.File)
+		}
+		values[n] = strings.TrimRight(string(data), "\r\n")
+	}
+	return values, nil
+}
+
+// masker replaces secret values with a mask; secrets spanning many lines are masked line by line,
+// so the task output can be masked one line at time
+type masker []string
+
+func newMasker(secrets map[string]string) masker {
+	var m masker
+	for _, v := range secrets {
+		for _, l := range strings.Split(v, "\n") {
+			if l = strings.TrimSpace(l); l != "" {
+				m = append(m, l)
+			}
+		}
+	}
+	// longer values are masked first, in case a secret contains another secret
+	sort.Slice(m, func(i, j int) bool { return len(m[i]) > len(m[j]) })
+	return m
+}
+
+func (m masker) mask(s string) string {
+	for _, v := range m {
+		s = strings.ReplaceAll(s, v, secretMask)
+	}
+	return s
+}
+
+// writer returns a writer masking secrets one line at time, or out itself if there are no secrets to mask;
+// the returned flush func writes the incomplete line, if any
+func (m masker) writer(out io.Writer) (io.Writer, func()) {
+	if len(m) == 0 {
+		return out, func() {}
+	}
+	w := &maskWriter{out: out, masker: m}
+	return w, w.flush
+}
+
+// maskWriter masks secrets in the lines written to out
+type maskWriter struct {
+	sync.Mutex
+	out    io.Writer
+	masker masker
+	buffer []byte
+}
+
+// Write implements io.Writer; incomplete lines are kept until the next write or flush
+func (w *maskWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.buffer = append(w.buffer, p...)
+	i := bytes.LastIndexByte(w.buffer, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	if _, err := io.WriteString(w.out, w.masker.mask(string(w.buffer[:i+1]))); err != nil {
+		return 0, err
+	}
+	w.buffer = w.buffer[i+1:]
+	return len(p), nil
+}
+
+func (w *maskWriter) flush() {
+	w.Lock()
+	defer w.Unlock()
+
+	if len(w.buffer) > 0 {
+		_, _ = io.WriteString(w.out, w.masker.mask(string(w.buffer)))
+		w.buffer = nil
+	}
+}
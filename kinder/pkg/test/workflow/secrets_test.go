@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMaskWriter(t *testing.T) {
+	m := newMasker(map[string]string{
+		"token": "s3cr3t",
+		"long":  "s3cr3t-and-more",
+		"multi": "first line\nsecond line\n",
+	})
+
+	var cases = []struct {
+		writes   []string
+		expected string
+	}{
+		{writes: []string{"no secrets\n"}, expected: "no secrets\n"},
+		{writes: []string{"token=s3cr3t\n"}, expected: "token=***\n"},
+		{writes: []string{"token=s3", "cr3t\n"}, expected: "token=***\n"},
+		{writes: []string{"long=s3cr3t-and-more"}, expected: "long=***"},
+		{writes: []string{"first line\n", "second line\n"}, expected: "***\n***\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.writes, ""), func(t *testing.T) {
+			var out bytes.Buffer
+			w, flush := m.writer(&out)
+			for _, s := range c.writes {
+				if _, err := w.Write([]byte(s)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			flush()
+			if out.String() != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// errCanceled is returned when a workflow run is canceled by a signal
+var errCanceled = errors.New("workflow canceled by the user")
+
+// terminationGracePeriod is how long a canceled command can take for exiting after SIGTERM, before being killed
+const terminationGracePeriod = 10 * time.Second
+
+// interruption handles SIGINT and SIGTERM during a workflow run. The first signal cancels the running tasks
+// and skips the following ones, while cleanup and other forced tasks are still executed; a second signal
+// aborts also the forced tasks. In both cases the workflow summary and the junit file are written
+type interruption struct {
+	canceled chan struct{}
+	aborted  chan struct{}
+}
+
+func newInterruption() *interruption {
+	return &interruption{
+		canceled: make(chan struct{}),
+		aborted:  make(chan struct{}),
+	}
+}
+
+// watch handles signals until the returned func is called
+func (i *interruption) watch() func() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case s := <-signals:
+				switch {
+				case !isClosed(i.canceled):
+					log.Warnf("received %s, canceling the workflow; cleanup tasks are still executed, send the signal again for aborting them", s)
+					close(i.canceled)
+				case !isClosed(i.aborted):
+					log.Warnf("received %s, aborting the cleanup tasks", s)
+					close(i.aborted)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(stop)
+	}
+}
+
+// done returns a channel closed when a task must be stopped; forced tasks are stopped only when aborting
+func (i *interruption) done(t *taskCmd) <-chan struct{} {
+	if i == nil {
+		return nil
+	}
+	if t.Force {
+		return i.aborted
+	}
+	return i.canceled
+}
+
+func (i *interruption) isCanceled() bool {
+	return i != nil && isClosed(i.canceled)
+}
+
+func (i *interruption) isAborted() bool {
+	return i != nil && isClosed(i.aborted)
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// terminate sends SIGTERM to the process group of a command, giving the command and its children, e.g. docker exec
+// commands on the nodes, the time to stop gracefully; processes still running after the grace period are killed
+func terminate(cmd *exec.Cmd, result <-chan error) {
+	pgid, err := syscall.Getpgid(cmd.Process.Pid)
+	if err != nil {
+		cleanup(cmd)
+		return
+	}
+	_ = syscall.Kill(-pgid, syscall.SIGTERM)
+	select {
+	case <-result:
+	case <-time.After(terminationGracePeriod):
+	}
+	// the process group is killed also when the command exits, because its children might still be running
+	_ = syscall.Kill(-pgid, syscall.SIGKILL)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+)
+
+func TestInterruption(t *testing.T) {
+	task := &taskCmd{Task: &Task{Name: "task"}}
+	forced := &taskCmd{Task: &Task{Name: "cleanup", Force: true}}
+
+	r := newTaskCmdRunner("", nil)
+	r.interruption = newInterruption()
+	if r.skipReason(task) != "" || r.skipReason(forced) != "" {
+		t.Fatal("expected tasks not to be skipped before signals")
+	}
+
+	close(r.interruption.canceled)
+	if !isClosed(r.interruption.done(task)) || isClosed(r.interruption.done(forced)) {
+		t.Error("expected only tasks not forced to be stopped when canceling")
+	}
+	if r.skipReason(task) == "" || r.skipReason(forced) != "" {
+		t.Error("expected only tasks not forced to be skipped when canceling")
+	}
+
+	close(r.interruption.aborted)
+	if !isClosed(r.interruption.done(forced)) || r.skipReason(forced) == "" {
+		t.Error("expected forced tasks to be stopped and skipped when aborting")
+	}
+}
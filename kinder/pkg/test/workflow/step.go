@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// stepAction defines what the user asked to do with a task when executing a workflow step by step
+type stepAction int
+
+const (
+	stepRun stepAction = iota
+	stepSkip
+	stepAbort
+)
+
+// stepSkippedReason is the reason for skipping taskCmd the user asked to skip
+const stepSkippedReason = "skipping because the user asked to skip the task"
+
+// stepAbortedReason is the reason for skipping taskCmd after the user asked to abort the workflow
+const stepAbortedReason = "skipping because the user aborted the workflow"
+
+// errStepAborted is returned when the user aborts a workflow executed step by step
+var errStepAborted = errors.New("workflow aborted by the user")
+
+// stepper pauses before each task, showing the resolved command and asking the user whether to run it,
+// skip it or abort the workflow
+type stepper struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newStepper(in io.Reader, out io.Writer) *stepper {
+	return &stepper{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// ask prompts the user until a valid answer is given; an empty answer runs the task,
+// while closing the input aborts the workflow
+func (s *stepper) ask(t *taskCmd) stepAction {
+	if t.Description != "" {
+		fmt.Fprintf(s.out, " %s\n", strings.TrimSpace(t.Description))
+	}
+	if t.Cmd.Dir != "" {
+		fmt.Fprintf(s.out, " dir: %s\n", t.Cmd.Dir)
+	}
+	for {
+		fmt.Fprintf(s.out, " [r]un, [s]kip or [a]bort? ")
+		answer, err := s.in.ReadString('\n')
+		if err != nil && strings.TrimSpace(answer) == "" {
+			fmt.Fprintln(s.out)
+			return stepAbort
+		}
+		if action, ok := parseStepAnswer(answer); ok {
+			fmt.Fprintln(s.out)
+			return action
+		}
+		if err != nil {
+			fmt.Fprintln(s.out)
+			return stepAbort
+		}
+	}
+}
+
+// parseStepAnswer returns the action corresponding to an answer, if valid
+func parseStepAnswer(answer string) (stepAction, bool) {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "", "r", "run":
+		return stepRun, true
+	case "s", "skip":
+		return stepSkip, true
+	case "a", "abort":
+		return stepAbort, true
+	}
+	return stepRun, false
+}
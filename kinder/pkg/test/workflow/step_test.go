@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestStepperAsk(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected stepAction
+	}{
+		{input: "\n", expected: stepRun},
+		{input: "run\n", expected: stepRun},
+		{input: "S\n", expected: stepSkip},
+		{input: "foo\na\n", expected: stepAbort},
+		{input: "", expected: stepAbort},
+		{input: "s", expected: stepSkip},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			s := newStepper(strings.NewReader(tc.input), io.Discard)
+			if action := s.ask(&taskCmd{Task: &Task{}, Cmd: &exec.Cmd{}}); action != tc.expected {
+				t.Errorf("expected action %d, got %d", tc.expected, action)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// runSummaryFile is the name of the file in the artifacts folder where the summary of a workflow run is written
+const runSummaryFile = "workflow-summary.json"
+
+// runSummary is a machine-readable summary of a workflow run, for dashboards and flake-analysis tooling
+type runSummary struct {
+	// Name of the matrix combination, if any
+	Name     string    `json:"name,omitempty"`
+	Result   string    `json:"result"`
+	Start    time.Time `json:"start"`
+	Duration float64   `json:"durationSeconds"`
+
+	// Vars are the vars used by the workflow run, including e.g. the versions resolved from labels
+	Vars  map[string]string `json:"vars"`
+	Tasks []taskSummary     `json:"tasks"`
+}
+
+// taskSummary is the summary of a task in a workflow run; paths are relative to the artifacts folder
+type taskSummary struct {
+	Name      string  `json:"name"`
+	Result    string  `json:"result"`
+	Duration  float64 `json:"durationSeconds"`
+	Attempts  int     `json:"attempts,omitempty"`
+	Message   string  `json:"message,omitempty"`
+	Log       string  `json:"log,omitempty"`
+	Output    string  `json:"output,omitempty"`
+	Artifacts string  `json:"artifacts,omitempty"`
+}
+
+// summary returns the summary of the tasks executed by the taskCmdRunner
+func (c *taskCmdRunner) summary(artifacts string) *runSummary {
+	s := &runSummary{
+		Name:     c.suite.Name,
+		Result:   taskPassed,
+		Start:    c.start,
+		Duration: time.Since(c.start).Seconds(),
+		Vars:     map[string]string{},
+		Tasks:    []taskSummary{},
+	}
+	if c.suite.Failures > 0 {
+		s.Result = taskFailed
+	}
+	for _, p := range c.suite.Properties {
+		s.Vars[p.Name] = p.Value
+	}
+
+	exists := func(name string) string {
+		if _, err := os.Stat(filepath.Join(artifacts, name)); err != nil {
+			return ""
+		}
+		return name
+	}
+	for i := range c.suite.Cases {
+		tc := &c.suite.Cases[i]
+		t := taskSummary{
+			Name:      tc.Name,
+			Result:    testCaseResult(tc),
+			Duration:  tc.Time,
+			Attempts:  tc.Attempts,
+			Message:   tc.Skipped,
+			Log:       exists(fmt.Sprintf("%s-log.txt", tc.Name)),
+			Output:    exists(taskOutputFile(tc.Name)),
+			Artifacts: exists(fmt.Sprintf("%s-artifacts", tc.Name)),
+		}
+		switch {
+		case tc.Failure != nil:
+			t.Message = tc.Failure.Message
+		case tc.Flaky != nil:
+			t.Message = tc.Flaky.Message
+		}
+		s.Tasks = append(s.Tasks, t)
+	}
+	return s
+}
+
+// DumpJSONSummary writes a machine-readable summary of executed tasks as a json file
+func (c *taskCmdRunner) DumpJSONSummary(artifacts string) error {
+	data, err := json.MarshalIndent(c.summary(artifacts), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the workflow run summary")
+	}
+	file := filepath.Join(artifacts, runSummaryFile)
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return errors.Wrapf(err, "error writing %s", file)
+	}
+	return nil
+}
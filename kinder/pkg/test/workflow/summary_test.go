@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	artifacts := t.TempDir()
+	for _, f := range []string{"task-00-log.txt", "tasks/00.log"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(artifacts, f)), 0755); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(artifacts, f), nil, 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	r := newTaskCmdRunner("", map[string]string{"kubernetesVersion": "v1.33.0"})
+	_ = r.registerTestCase("task-00", withFailure("exit status 1"), withAttempts(2))
+	_ = r.registerTestCase("task-01", withSkipped("skipping because a predecessor task failed"))
+
+	s := r.summary(artifacts)
+	expected := []taskSummary{
+		{Name: "task-00", Result: taskFailed, Attempts: 2, Message: "exit status 1", Log: "task-00-log.txt", Output: "tasks/00.log"},
+		{Name: "task-01", Result: taskSkipped, Message: "skipping because a predecessor task failed"},
+	}
+	if s.Result != taskFailed {
+		t.Errorf("expected result %q, got %q", taskFailed, s.Result)
+	}
+	if s.Vars["kubernetesVersion"] != "v1.33.0" {
+		t.Errorf("expected vars to be recorded, got %v", s.Vars)
+	}
+	if !reflect.DeepEqual(s.Tasks, expected) {
+		t.Errorf("expected tasks %+v, got %+v", expected, s.Tasks)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"syscall"
 	"text/template"
@@ -35,22 +36,41 @@ import (
 // in the workflow
 type taskCmd struct {
 	*Task
-	Cmd     *exec.Cmd
-	CmdText string
+	Cmd      *exec.Cmd
+	CmdText  string
+	Disabled bool
+	Resumed  bool
 }
 
 // taskCmdBuilder provide support for creating taskCmd, taking care of the context
 // defined by Vars and Env variables
 type taskCmdBuilder struct {
-	env  map[string]string
-	vars map[string]string
+	env     map[string]string
+	hostEnv map[string]string
+	vars    map[string]string
+
+	// secrets are accessible only in the workflow env and in tasks, and they are masked in the textual
+	// representation of the commands and in the task output
+	secrets map[string]string
+	masker  masker
+
+	// params are the params of the current task, if instantiated from a template, accessible as {{ .params.NAME }}
+	params map[string]string
+
+	// item is the current item when building the tasks generated by a loop, accessible as {{ .item }}
+	item *string
 }
 
-// newTaskCmdBuilder return a new taskCmdBuilder
-func newTaskCmdBuilder(w *Workflow) (c *taskCmdBuilder, err error) {
+// newTaskCmdBuilder return a new taskCmdBuilder; preset vars, e.g. for a combination of values of the
+// matrix dimensions or recorded by the workflow run to resume, are not overridden by the vars defined in the workflow
+func newTaskCmdBuilder(w *Workflow, presetVars map[string]string) (c *taskCmdBuilder, err error) {
 	c = &taskCmdBuilder{
-		env:  map[string]string{},
-		vars: map[string]string{},
+		env:     map[string]string{},
+		hostEnv: map[string]string{},
+		vars:    map[string]string{},
+	}
+	for n, v := range presetVars {
+		c.vars[n] = v
 	}
 
 	// loads OS environment variables into the taskCmdBuilder context
@@ -61,9 +81,17 @@ func newTaskCmdBuilder(w *Workflow) (c *taskCmdBuilder, err error) {
 		c.env[name] = value
 	}
 
+	// loads the allowed host environment variables, keeping them separated from the workflow env
+	for _, name := range w.HostEnv {
+		c.hostEnv[name] = os.Getenv(name)
+	}
+
 	// process vars defined in the workflow
 	if w.Vars != nil {
 		for n, v := range w.Vars {
+			if _, ok := presetVars[n]; ok {
+				continue
+			}
 			c.vars[n], err = c.expand(v)
 			if err != nil {
 				return nil, errors.Wrapf(err, "error expanding the %q var", n)
@@ -71,6 +99,24 @@ func newTaskCmdBuilder(w *Workflow) (c *taskCmdBuilder, err error) {
 		}
 	}
 
+	// process computed vars defined in the workflow
+	for _, v := range w.ComputedVars {
+		if _, ok := presetVars[v.Name]; ok {
+			continue
+		}
+		if c.vars[v.Name], err = c.compute(v); err != nil {
+			return nil, err
+		}
+	}
+
+	// loads secrets after vars and computed vars, so secrets can't be recorded as vars
+	if len(w.Secrets) > 0 {
+		if c.secrets, err = loadSecrets(w.Secrets); err != nil {
+			return nil, err
+		}
+		c.masker = newMasker(c.secrets)
+	}
+
 	// process additional environment variables defined in the workflow
 	if w.Env != nil {
 		for n, v := range w.Env {
@@ -86,7 +132,17 @@ func newTaskCmdBuilder(w *Workflow) (c *taskCmdBuilder, err error) {
 
 // defines a list of custom utility functions that can be used in workflow templates
 var funcMap = template.FuncMap{
-	"resolve": extract.ResolveLabel, // e.g. used in templates >> stable: '{{ resolve "release/stable" }}' or {{ "ci/latest" | resolve }}
+	"resolve":         extract.ResolveLabel, // e.g. used in templates >> stable: '{{ resolve "release/stable" }}' or {{ "ci/latest" | resolve }}
+	"versionAtLeast":  versionAtLeast,       // e.g. used in conditions >> if: '{{ versionAtLeast .vars.upgradeVersion "v1.33" }}'
+	"versionLessThan": versionLessThan,      // e.g. used in conditions >> if: '{{ versionLessThan .vars.kubernetesVersion "v1.30" }}'
+	"versionCompare":  versionCompare,       // e.g. used in conditions >> if: '{{ eq (versionCompare .vars.initVersion .vars.upgradeVersion) -1 }}'
+	"majorOf":         majorOf,              // e.g. used in templates >> '{{ majorOf .vars.kubernetesVersion }}'
+	"minorOf":         minorOf,              // e.g. used in conditions >> if: '{{ gt (minorOf .vars.kubernetesVersion) 32 }}'
+	"patchOf":         patchOf,              // e.g. used in conditions >> if: '{{ eq (patchOf .vars.kubernetesVersion) 0 }}'
+	"majorMinor":      majorMinor,           // e.g. used in templates >> '{{ majorMinor .vars.kubernetesVersion }}' >> v1.33
+	"previousMinor":   previousMinor,        // e.g. used in templates >> '{{ previousMinor .vars.kubernetesVersion | ciLabel | resolve }}'
+	"nextMinor":       nextMinor,            // e.g. used in templates >> '{{ nextMinor .vars.kubernetesVersion }}' >> v1.34
+	"ciLabel":         ciLabel,              // e.g. used in templates >> '{{ ciLabel .vars.kubernetesVersion | resolve }}'
 }
 
 // expand takes a string that might contain a golang template and process it
@@ -97,11 +153,23 @@ func (c *taskCmdBuilder) expand(text string) (string, error) {
 		return "", errors.Wrapf(err, "%q is not a valid expression", text)
 	}
 
+	data := map[string]any{
+		"env":     c.env,
+		"hostEnv": c.hostEnv,
+		"vars":    c.vars,
+	}
+	if c.secrets != nil {
+		data["secrets"] = c.secrets
+	}
+	if c.params != nil {
+		data["params"] = c.params
+	}
+	if c.item != nil {
+		data["item"] = *c.item
+	}
+
 	var b bytes.Buffer
-	if err = templ.Execute(&b, map[string]any{
-		"env":  c.env,
-		"vars": c.vars,
-	}); err != nil {
+	if err = templ.Execute(&b, data); err != nil {
 		return "", errors.Wrapf(err, "expression %q returned an error", text)
 	}
 	return b.String(), nil
@@ -109,6 +177,20 @@ func (c *taskCmdBuilder) expand(text string) (string, error) {
 
 // build creates a taskCmd
 func (c *taskCmdBuilder) build(t *Task, verbose bool) (tcmd *taskCmd, err error) {
+	// evaluate the task condition, if any
+	disabled := false
+	if t.If != "" {
+		condition, err := c.expand(t.If)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error expanding if for task %q", t.Name)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(condition))
+		if err != nil {
+			return nil, errors.Errorf("if for task %q must evaluate to true or false, got %q", t.Name, condition)
+		}
+		disabled = !enabled
+	}
+
 	// expand golang templates that might exists in the cmd and/or into the args
 	t.Cmd, err = c.expand(t.Cmd)
 	if err != nil {
@@ -124,8 +206,24 @@ func (c *taskCmdBuilder) build(t *Task, verbose bool) (tcmd *taskCmd, err error)
 	// creates the command
 	cmd := exec.Command(t.Cmd, t.Args...)
 
+	// expand golang templates that might exists in the artifacts to collect; artifacts on nodes
+	// are collected by default from the cluster targeted by the task
+	for n := range t.Artifacts {
+		a := &t.Artifacts[n]
+		for _, v := range []*string{&a.Path, &a.Nodes, &a.Cluster} {
+			if *v, err = c.expand(*v); err != nil {
+				return nil, errors.Wrapf(err, "error expanding artifacts[%d] for task %q", n, t.Name)
+			}
+		}
+		if a.Nodes != "" && a.Cluster == "" {
+			if a.Cluster = targetCluster(cmd.Args); a.Cluster == "" {
+				return nil, errors.Errorf("artifacts[%d] for task %q must define the cluster to collect %s from", n, t.Name, a.Path)
+			}
+		}
+	}
+
 	// store a textual representation of the command to be used in logs/output
-	cmdText := fmt.Sprintf("%s %s", t.Cmd, strings.Join(t.Args, " "))
+	cmdText := c.masker.mask(fmt.Sprintf("%s %s", t.Cmd, strings.Join(t.Args, " ")))
 
 	// set the working dir if different from the current one
 	if t.Dir != "" {
@@ -142,8 +240,88 @@ func (c *taskCmdBuilder) build(t *Task, verbose bool) (tcmd *taskCmd, err error)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
 	return &taskCmd{
-		Task:    t,
-		Cmd:     cmd,
-		CmdText: cmdText,
+		Task:     t,
+		Cmd:      cmd,
+		CmdText:  cmdText,
+		Disabled: disabled,
 	}, nil
 }
+
+// buildAll creates the taskCmd for all the tasks; tasks before the resumed index are marked to be skipped.
+// Tasks defining a loop generate one taskCmd for each item, executed one after the other or, if the task
+// is parallel, at the same time; dependencies on a loop task become dependencies on all the taskCmd it generates
+func (c *taskCmdBuilder) buildAll(tasks Tasks, verbose bool, resumed int) ([]*taskCmd, error) {
+	var tcmds []*taskCmd
+	built := make([][]int, len(tasks))
+	defer func() { c.params = nil }()
+	for i, t := range tasks {
+		// params of tasks instantiated from templates are expanded before the task, and they can't
+		// refer to other params
+		c.params = nil
+		params := map[string]string{}
+		for _, n := range sortedKeys(t.params) {
+			v, err := c.expand(t.params[n])
+			if err != nil {
+				return nil, errors.Wrapf(err, "error expanding param %s for task %q", n, t.Name)
+			}
+			params[n] = v
+		}
+		if t.params != nil {
+			c.params = params
+		}
+
+		var dependencies []int
+		for _, d := range t.dependencies {
+			dependencies = append(dependencies, built[d]...)
+		}
+
+		items, err := c.loopItems(t)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			tcmd, err := c.buildCopy(t, verbose, t.Name)
+			if err != nil {
+				return nil, err
+			}
+			tcmd.dependencies = dependencies
+			tcmd.Resumed = i < resumed
+			built[i] = []int{len(tcmds)}
+			tcmds = append(tcmds, tcmd)
+			continue
+		}
+
+		// tasks depending on a loop without items depend on the tasks the loop depends on
+		if len(items) == 0 {
+			built[i] = dependencies
+			continue
+		}
+		for n, item := range items {
+			c.item = &item
+			tcmd, err := c.buildCopy(t, verbose, loopTaskName(t.Name, item))
+			c.item = nil
+			if err != nil {
+				return nil, err
+			}
+			tcmd.dependencies = dependencies
+			if n > 0 && !t.Parallel {
+				tcmd.dependencies = append(append([]int{}, dependencies...), len(tcmds)-1)
+			}
+			tcmd.Resumed = i < resumed
+			built[i] = append(built[i], len(tcmds))
+			tcmds = append(tcmds, tcmd)
+		}
+	}
+	return tcmds, nil
+}
+
+// buildCopy creates a taskCmd for a copy of a task with the given name
+func (c *taskCmdBuilder) buildCopy(task *Task, verbose bool, name string) (*taskCmd, error) {
+	// templates are expanded on a copy of the task, because a workflow with a
+	// matrix or a loop builds the same tasks many times
+	t := *task
+	t.Name = name
+	t.Args = append([]string(nil), t.Args...)
+	t.Artifacts = append([]Artifact(nil), t.Artifacts...)
+	return c.build(&t, verbose)
+}
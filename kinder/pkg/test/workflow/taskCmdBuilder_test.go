@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+)
+
+func TestHostEnv(t *testing.T) {
+	t.Setenv("KINDER_TEST_ALLOWED", "allowed")
+	t.Setenv("KINDER_TEST_NOT_ALLOWED", "not-allowed")
+
+	c, err := newTaskCmdBuilder(&Workflow{HostEnv: []string{"KINDER_TEST_ALLOWED", "KINDER_TEST_UNSET"}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		text          string
+		expected      string
+		expectedError bool
+	}{
+		{text: "{{ .hostEnv.KINDER_TEST_ALLOWED }}", expected: "allowed"},
+		{text: `{{ or .hostEnv.KINDER_TEST_UNSET "default" }}`, expected: "default"},
+		{text: "{{ .hostEnv.KINDER_TEST_NOT_ALLOWED }}", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			v, err := c.expand(tc.text)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if v != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, v)
+			}
+		})
+	}
+}
@@ -22,13 +22,16 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 )
 
 // taskCmdRunner defines all the info of a runner responsible for executing as
@@ -40,57 +43,182 @@ type taskCmdRunner struct {
 	failed   bool
 	canceled bool
 	timedOut bool
+
+	// state of the workflow run, recorded into stateDir after each task; when resuming a workflow run,
+	// resumed holds the state of the run to resume
+	state    *runState
+	stateDir string
+	resumed  *runState
+
+	// when set, failed test cases are printed to failures, e.g. when running in quiet mode;
+	// noColor disables colors when printing failures and when streaming the task output
+	failures io.Writer
+	noColor  bool
+
+	// masker masks secrets in the task output
+	masker masker
+
+	// interruption, if any, stops the running tasks on SIGINT and SIGTERM
+	interruption *interruption
+
+	// webhook, if any, gets an event each time a task starts and completes
+	webhook *webhook
+
+	// deadline of the whole workflow, if any; once reached, the running task is stopped like for a timeout,
+	// while forced tasks, e.g. cleanup tasks, are still executed with their own timeout
+	deadline time.Time
 }
 
 // junitTestSuite implements junit TestSuite standard object
 type junitTestSuite struct {
-	XMLName  xml.Name `xml:"testsuite"`
-	Failures int      `xml:"failures,attr"`
-	Tests    int      `xml:"tests,attr"`
-	Time     float64  `xml:"time,attr"`
-	Cases    []junitTestCase
+	XMLName    xml.Name        `xml:"testsuite"`
+	Name       string          `xml:"name,attr,omitempty"`
+	Failures   int             `xml:"failures,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Time       float64         `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase
+}
+
+// junitProperty implements junit Property standard object
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 // junitTestCase implements junit TestCase standard object
 type junitTestCase struct {
-	XMLName   xml.Name `xml:"testcase"`
-	ClassName string   `xml:"classname,attr"`
-	Name      string   `xml:"name,attr"`
-	Time      float64  `xml:"time,attr"`
-	Failure   string   `xml:"failure,omitempty"`
-	Skipped   string   `xml:"skipped,omitempty"`
+	XMLName   xml.Name      `xml:"testcase"`
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Flaky     *junitFailure `xml:"flakyFailure,omitempty"`
+	Skipped   string        `xml:"skipped,omitempty"`
+	Attempts  int           `xml:"attempts,attr,omitempty"`
 }
 
-// newTaskCmdRunner returns a new taskCmdRunner
-func newTaskCmdRunner() *taskCmdRunner {
+// junitFailure implements junit Failure standard object; the failure text includes the
+// last lines of the task output, for allowing triage without opening the task logs
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// taskOutputDir is the folder, inside the artifacts folder, with the output of each task
+const taskOutputDir = "tasks"
+
+// taskOutputFile returns the path, relative to the artifacts folder, of the file with only the output of a task,
+// e.g. tasks/01-create-cluster.log
+func taskOutputFile(name string) string {
+	return filepath.Join(taskOutputDir, fmt.Sprintf("%s.log", strings.TrimPrefix(name, "task-")))
+}
+
+// failureOutputLines is the number of lines of the task output recorded in the junit failure
+const failureOutputLines = 50
+
+// newTaskCmdRunner returns a new taskCmdRunner, eventually with a name for the junit test suite;
+// vars are recorded as properties of the test suite
+func newTaskCmdRunner(name string, vars map[string]string) *taskCmdRunner {
+	suite := junitTestSuite{Name: name}
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		suite.Properties = append(suite.Properties, junitProperty{Name: k, Value: vars[k]})
+	}
+
 	return &taskCmdRunner{
 		start: time.Now(),
-		suite: junitTestSuite{},
+		suite: suite,
 	}
 }
 
+// taskOutcome defines the result of the execution of a taskCmd, before it is recorded by the taskCmdRunner
+type taskOutcome struct {
+	options  []testCaseOption
+	failed   bool
+	canceled bool
+	timedOut bool
+}
+
 // Run a taskCmd
 func (c *taskCmdRunner) Run(t *taskCmd, artifacts string, verbose bool) error {
-	start := time.Now()
-
 	// unless the cmd execution is forced, check if the taskCmd should be skipped because one of
 	// the previous taskCmd failed, timedOut or was canceled.
 	// if this is the case record test case as skipped and exits with error
-	if !t.Force {
-		if c.failed {
-			return c.registerTestCase(t.Name, withSkipped("skipping because a predecessor task failed"))
-		}
-		if c.timedOut {
-			return c.registerTestCase(t.Name, withSkipped("skipping because a predecessor task timed-out"))
-		}
-		if c.canceled {
-			return c.registerTestCase(t.Name, withSkipped("skipping because task workflow was canceled by the user"))
-		}
+	if reason := c.skipReason(t); reason != "" {
+		return c.registerTestCase(t.Name, withSkipped(reason))
+	}
+
+	return c.record(t.Name, c.execute(t, artifacts, verbose))
+}
+
+// disabledReason is the reason for skipping taskCmd with a false condition
+const disabledReason = "skipping because the task condition is false"
+
+// resumedReason is the reason for skipping taskCmd before the task a workflow run is resumed from
+const resumedReason = "skipping because the workflow is resumed from a following task"
+
+// inactiveReason returns the reason why a taskCmd is not required in the current workflow run, if any;
+// differently from tasks skipped after a failure, skipping an inactive taskCmd is not considered an error
+func (c *taskCmdRunner) inactiveReason(t *taskCmd) string {
+	if t.Disabled {
+		return disabledReason
 	}
+	if t.Resumed {
+		return resumedReason
+	}
+	if t.onFailure && !c.failed && !c.timedOut && !c.canceled {
+		return "skipping because no predecessor task failed"
+	}
+	return ""
+}
 
-	// creates a channel for handling command cancellation
-	cancel := make(chan os.Signal, 1)
-	signal.Notify(cancel, syscall.SIGINT, syscall.SIGTERM)
+// skipInactive records an inactive taskCmd as skipped
+func (c *taskCmdRunner) skipInactive(t *taskCmd, reason string) {
+	_ = c.registerTestCase(t.Name, withSkipped(reason))
+}
+
+// skipReason returns the reason why a taskCmd should be skipped, if any
+func (c *taskCmdRunner) skipReason(t *taskCmd) string {
+	if c.interruption.isAborted() {
+		return "skipping because task workflow was aborted by the user"
+	}
+	if t.Force {
+		return ""
+	}
+	if c.failed {
+		return "skipping because a predecessor task failed"
+	}
+	if c.timedOut {
+		return "skipping because a predecessor task timed-out"
+	}
+	if c.canceled || c.interruption.isCanceled() {
+		return "skipping because task workflow was canceled by the user"
+	}
+	return ""
+}
+
+// record keeps track of the outcome of a taskCmd for blocking execution of following taskCmd,
+// and registers the corresponding test case
+func (c *taskCmdRunner) record(name string, o *taskOutcome) error {
+	c.failed = c.failed || o.failed
+	c.canceled = c.canceled || o.canceled
+	c.timedOut = c.timedOut || o.timedOut
+	return c.registerTestCase(name, o.options...)
+}
+
+// execute a taskCmd, handling cancellation, timeouts and retries; execute does not change the taskCmdRunner state,
+// so it is safe to execute many taskCmd at the same time, e.g. when running a dependency graph
+func (c *taskCmdRunner) execute(t *taskCmd, artifacts string, verbose bool) *taskOutcome {
+	start := time.Now()
+	c.webhook.notify(webhookEvent{Event: webhookTaskStarted, Task: t.Name})
+
+	// gets the channel for handling command cancellation
+	cancel := c.interruption.done(t)
 
 	// sets Stdout and Stderr for the command.
 	// please note that the command output will go on files by default,
@@ -98,16 +226,29 @@ func (c *taskCmdRunner) Run(t *taskCmd, artifacts string, verbose bool) error {
 	taskLog := filepath.Join(artifacts, fmt.Sprintf("%s-log.txt", t.Name))
 	writer, err := os.Create(taskLog)
 	if err != nil {
-		return errors.Wrapf(err, "error creating %q log file", taskLog)
+		return &taskOutcome{
+			failed:  true,
+			options: []testCaseOption{withFailure(errors.Wrapf(err, "error creating %q log file", taskLog).Error())},
+		}
 	}
-
-	t.Cmd.Stdout = writer
-	t.Cmd.Stderr = writer
-
-	if verbose {
-		t.Cmd.Stdout = io.MultiWriter(writer, os.Stdout)
-		t.Cmd.Stderr = io.MultiWriter(writer, os.Stderr)
+	defer writer.Close()
+
+	// the task output is also written into a separated file, without the command overview and the
+	// other messages written into the log file, so it is easier to look only at the output of a failed task
+	if err := os.MkdirAll(filepath.Join(artifacts, taskOutputDir), 0755); err != nil {
+		return &taskOutcome{
+			failed:  true,
+			options: []testCaseOption{withFailure(errors.Wrapf(err, "error creating %q folder", taskOutputDir).Error())},
+		}
 	}
+	output, err := os.Create(filepath.Join(artifacts, taskOutputFile(t.Name)))
+	if err != nil {
+		return &taskOutcome{
+			failed:  true,
+			options: []testCaseOption{withFailure(errors.Wrapf(err, "error creating %q output file", taskOutputFile(t.Name)).Error())},
+		}
+	}
+	defer output.Close()
 
 	// outputs a command overview before executing it
 	writer.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 80)))
@@ -118,17 +259,93 @@ func (c *taskCmdRunner) Run(t *taskCmd, artifacts string, verbose bool) error {
 	writer.WriteString(fmt.Sprintf("command : %s\n", t.CmdText))
 	writer.WriteString(fmt.Sprintf("timeout : %s\n", t.Timeout.Duration))
 	writer.WriteString(fmt.Sprintf("force   : %v\n", t.Force))
+	if t.Retries > 0 {
+		writer.WriteString(fmt.Sprintf("retries : %d (backoff %s)\n", t.Retries, t.Backoff.Duration))
+	}
 	writer.WriteString(fmt.Sprintf("%s\n\n", strings.Repeat("-", 80)))
+	outputStart, _ := writer.Seek(0, io.SeekCurrent)
+
+	// executes the command, executing it again after failures or timeouts up to the number of retries;
+	// the wait between attempts starts from backoff and doubles at each retry
+	attempt := 1
+	// when verbose, the task output is streamed also to stdout and stderr, prefixed with the task name
+	stdout, stderr := io.MultiWriter(writer, output), io.MultiWriter(writer, output)
+	if verbose {
+		prefixedStdout := newPrefixWriter(os.Stdout, t.Name, start, c.noColor)
+		prefixedStderr := newPrefixWriter(os.Stderr, t.Name, start, c.noColor)
+		defer prefixedStdout.Flush()
+		defer prefixedStderr.Flush()
+		stdout = io.MultiWriter(writer, output, prefixedStdout)
+		stderr = io.MultiWriter(writer, output, prefixedStderr)
+	}
+
+	// secrets are masked before writing the task output to the log file and to stdout and stderr;
+	// incomplete lines are flushed after each attempt, before writing anything else into the log file
+	stdout, flushStdout := c.masker.writer(stdout)
+	stderr, flushStderr := c.masker.writer(stderr)
+	flush := func() {
+		flushStdout()
+		flushStderr()
+	}
+
+	o := c.attempt(t, stdout, stderr, cancel)
+	flush()
+	for backoff := t.Backoff.Duration; (o.failed || o.timedOut) && attempt <= t.Retries && !c.pastDeadline(t); backoff *= 2 {
+		writer.WriteString(fmt.Sprintf("\n%s\nattempt %d of %d failed, retrying in %s\n%s\n\n", strings.Repeat("-", 80), attempt, t.Retries+1, backoff, strings.Repeat("-", 80)))
+
+		select {
+		case <-cancel:
+			o = &taskOutcome{
+				canceled: true,
+				options:  []testCaseOption{withFailure("task was canceled by the user")},
+			}
+		case <-time.After(backoff):
+			// an exec.Cmd can't be started twice, so the next attempt uses a copy of the command
+			t.Cmd = copyCmd(t.Cmd)
+			attempt++
+			o = c.attempt(t, stdout, stderr, cancel)
+			flush()
+		}
+	}
+
+	o.options = append(o.options, withDuration(time.Since(start)))
+	if t.Retries > 0 {
+		o.options = append(o.options, withAttempts(attempt))
+	}
+
+	failed := o.failed || o.timedOut || o.canceled
+	if failed {
+		o.options = append(o.options, withFailureOutput(tailFile(taskLog, outputStart, failureOutputLines)))
+	}
+
+	// failures of tasks ignoring failures are reported as flaky, without blocking execution of following taskCmd;
+	// cancellation by the user is never ignored
+	if t.IgnoreFailure && (o.failed || o.timedOut) {
+		writer.WriteString(fmt.Sprintf("\n%s\ntask failed, ignoring the failure\n%s\n", strings.Repeat("-", 80), strings.Repeat("-", 80)))
+		o.failed, o.timedOut = false, false
+		o.options = append(o.options, asFlaky())
+	}
+
+	collectArtifacts(t, artifacts, failed, writer)
+	return o
+}
+
+// attempt executes a taskCmd once, waiting for the command to complete, to be canceled or to time out
+func (c *taskCmdRunner) attempt(t *taskCmd, stdout, stderr io.Writer, cancel <-chan struct{}) *taskOutcome {
+	t.Cmd.Stdout = stdout
+	t.Cmd.Stderr = stderr
 
 	// starts the command
 	if err := t.Cmd.Start(); err != nil {
 		// keeps track of this failure type to block execution of following TestCmd
-		c.failed = true
-
-		// record test case timeout and exits with error
-		return c.registerTestCase(t.Name, withFailure(err.Error()), withDuration(time.Since(start)))
+		return &taskOutcome{
+			failed:  true,
+			options: []testCaseOption{withFailure(err.Error())},
+		}
 	}
 
+	timeout, timeoutMessage := c.timeout(t)
+
 	// starts a go routine responsible for waiting the command completes
 	result := make(chan error, 1)
 	go func() {
@@ -144,69 +361,95 @@ func (c *taskCmdRunner) Run(t *taskCmd, artifacts string, verbose bool) error {
 		// if the command completed without an error or if we are ignoring errors, record the test case success and exit
 		if err == nil || t.IgnoreError {
 			// record test case timeout as success
-			return c.registerTestCase(t.Name,
-				withDuration(time.Since(start)),
-			)
+			return &taskOutcome{}
 		}
-		// keeps track of this failure type to block execution of following TestCmd
-		c.failed = true
 
 		// cleanup command process and its child, if any
 		cleanup(t.Cmd)
 
-		// otherwise record test case failure and exits with error
-		return c.registerTestCase(t.Name,
-			withFailure(err.Error()),
-			withDuration(time.Since(start)),
-		)
+		// otherwise record test case failure, blocking execution of following TestCmd
+		return &taskOutcome{
+			failed:  true,
+			options: []testCaseOption{withFailure(err.Error())},
+		}
 
 	case <-cancel:
-		// keeps track of this failure type to block execution of following TestCmd
-		c.canceled = true
+		// stops command process and its child, if any, giving them the time to stop gracefully
+		terminate(t.Cmd, result)
+
+		// record test case cancellation, blocking execution of following TestCmd
+		return &taskOutcome{
+			canceled: true,
+			options:  []testCaseOption{withFailure("task was canceled by the user")},
+		}
 
+	case <-time.After(timeout):
 		// cleanup command process and its child, if any
 		cleanup(t.Cmd)
 
-		// record test case cancellation and exits with error
-		return c.registerTestCase(t.Name,
-			withFailure("task was canceled by the user"),
-			withDuration(time.Since(start)),
-		)
+		// record test case timeout, blocking execution of following TestCmd
+		return &taskOutcome{
+			timedOut: true,
+			options:  []testCaseOption{withFailure(timeoutMessage)},
+		}
+	}
+}
 
-	case <-time.After(t.Timeout.Duration):
-		// keeps track of this failure type to block execution of following TestCmd
-		c.timedOut = true
+// timeout returns how long an attempt of a taskCmd can last, and the failure message when the timeout is reached;
+// tasks not forced can't last after the workflow deadline
+func (c *taskCmdRunner) timeout(t *taskCmd) (time.Duration, string) {
+	if !c.deadline.IsZero() && !t.Force {
+		if remaining := time.Until(c.deadline); remaining < t.Timeout.Duration {
+			return remaining, "timeout. The workflow did not complete before the workflow timeout"
+		}
+	}
+	return t.Timeout.Duration, fmt.Sprintf("timeout. The task did not complete in less than %s as expected", t.Timeout.Duration)
+}
 
-		// cleanup command process and its child, if any
-		cleanup(t.Cmd)
+// pastDeadline returns true if a taskCmd can't be executed anymore, because the workflow deadline is reached
+func (c *taskCmdRunner) pastDeadline(t *taskCmd) bool {
+	return !c.deadline.IsZero() && !t.Force && !time.Now().Before(c.deadline)
+}
 
-		// record test case timeout and exits with error
-		return c.registerTestCase(t.Name,
-			withFailure(fmt.Sprintf("timeout. The task did not complete in less than %s as expected", t.Timeout.Duration)),
-			withDuration(time.Since(start)),
-		)
+// copyCmd returns a new command with the same settings of a command already executed
+func copyCmd(cmd *exec.Cmd) *exec.Cmd {
+	return &exec.Cmd{
+		Path:        cmd.Path,
+		Args:        cmd.Args,
+		Env:         cmd.Env,
+		Dir:         cmd.Dir,
+		SysProcAttr: &syscall.SysProcAttr{Setpgid: true},
 	}
 }
 
 // ReportSummary prints a summary of executed task
-func (c *taskCmdRunner) ReportSummary() {
+func (c *taskCmdRunner) ReportSummary(out io.Writer) {
 	total := c.suite.Tests
-	skipped := 0
+	skipped, flaky := 0, 0
 	for _, t := range c.suite.Cases {
 		if t.Skipped != "" {
 			skipped++
 		}
+		if t.Flaky != nil {
+			flaky++
+		}
 	}
 	run := total - skipped
 	failures := c.suite.Failures
 	passed := run - failures
 
-	fmt.Printf("Ran %d of %d tasks in %.3f seconds\n", run, total, c.suite.Time)
+	// tasks ignoring a failure are counted as passed, and reported also as flaky
+	flakyText := ""
+	if flaky > 0 {
+		flakyText = fmt.Sprintf(" (%d Flaky)", flaky)
+	}
+
+	fmt.Fprintf(out, "Ran %d of %d tasks in %.3f seconds\n", run, total, c.suite.Time)
 	if failures > 0 {
-		fmt.Printf("FAIL! -- %d tasks Passed | %d Failed | %d Skipped\n\n", passed, failures, skipped)
+		fmt.Fprintf(out, "FAIL! -- %d tasks Passed%s | %d Failed | %d Skipped\n\n", passed, flakyText, failures, skipped)
 		return
 	}
-	fmt.Printf("SUCCESS! -- %d tasks Passed | %d Failed | %d Skipped\n\n", passed, failures, skipped)
+	fmt.Fprintf(out, "SUCCESS! -- %d tasks Passed%s | %d Failed | %d Skipped\n\n", passed, flakyText, failures, skipped)
 }
 
 // DumpJUnitRunner writes a report of executed tasks as a junit file
@@ -244,7 +487,28 @@ func withDuration(duration time.Duration) testCaseOption {
 }
 func withFailure(message string) testCaseOption {
 	return func(t *junitTestCase) {
-		t.Failure = message
+		t.Failure = &junitFailure{Message: message, Text: message}
+	}
+}
+
+func withFailureOutput(output string) testCaseOption {
+	return func(t *junitTestCase) {
+		if t.Failure != nil && output != "" {
+			t.Failure.Text = fmt.Sprintf("%s\n\nlast lines of the task output:\n%s", t.Failure.Message, output)
+		}
+	}
+}
+
+func withAttempts(attempts int) testCaseOption {
+	return func(t *junitTestCase) {
+		t.Attempts = attempts
+	}
+}
+
+// asFlaky turns the failure of a test case, if any, into a flaky failure
+func asFlaky() testCaseOption {
+	return func(t *junitTestCase) {
+		t.Flaky, t.Failure = t.Failure, nil
 	}
 }
 
@@ -264,12 +528,33 @@ func (c *taskCmdRunner) registerTestCase(name string, options ...testCaseOption)
 	for _, option := range options {
 		option(tc)
 	}
+	// failure messages might include secrets, e.g. when reporting errors about commands, and the task output
+	// might include colors or other characters not allowed in XML documents
+	for _, f := range []*junitFailure{tc.Failure, tc.Flaky} {
+		if f != nil {
+			f.Message, f.Text = sanitizeXML(c.masker.mask(f.Message)), sanitizeXML(c.masker.mask(f.Text))
+		}
+	}
+
+	event := webhookEvent{Event: webhookTaskFinished, Task: name, Result: testCaseResult(tc), Duration: tc.Time, Message: tc.Skipped}
+	if tc.Failure != nil {
+		event.Event, event.Message = webhookTaskFailed, tc.Failure.Message
+	}
+	c.webhook.notify(event)
 
 	c.suite.Cases = append(c.suite.Cases, *tc)
 	c.suite.Tests++
-	if tc.Failure != "" {
+	c.saveState(tc)
+	if tc.Failure != nil {
 		c.suite.Failures++
-		return errors.New(tc.Failure)
+		if c.failures != nil {
+			header := fmt.Sprintf("# %s failed", name)
+			if !c.noColor {
+				header = fmt.Sprintf("\033[31m%s\033[0m", header)
+			}
+			fmt.Fprintf(c.failures, "%s\n%s\n\n", header, tc.Failure.Text)
+		}
+		return errors.New(tc.Failure.Message)
 	}
 
 	if tc.Skipped != "" {
@@ -279,6 +564,68 @@ func (c *taskCmdRunner) registerTestCase(name string, options ...testCaseOption)
 	return nil
 }
 
+// saveState records the result of a test case into the state of the workflow run, if any;
+// tasks skipped when resuming a workflow run keep the result of the run to resume
+func (c *taskCmdRunner) saveState(tc *junitTestCase) {
+	if c.state == nil {
+		return
+	}
+
+	result := testCaseResult(tc)
+	if tc.Skipped == resumedReason && c.resumed != nil {
+		result = c.resumed.result(tc.Name)
+	}
+	c.state.Tasks = append(c.state.Tasks, taskState{Name: tc.Name, Result: result})
+
+	if err := c.state.save(c.stateDir); err != nil {
+		log.Warnf("%v", err)
+	}
+}
+
+// testCaseResult returns the result of a test case, as recorded in the run state and in the run summary
+func testCaseResult(tc *junitTestCase) string {
+	switch {
+	case tc.Failure != nil:
+		return taskFailed
+	case tc.Flaky != nil:
+		return taskFlaky
+	case tc.Skipped != "":
+		return taskSkipped
+	}
+	return taskPassed
+}
+
+// ansiEscapeRE matches ANSI escape sequences, e.g. the ones used for colors in the task output
+var ansiEscapeRE = regexp.MustCompile(`\x1b(\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[@-Z\\-_])`)
+
+// sanitizeXML removes ANSI escape sequences from a text and replaces the characters that are not valid
+// in XML 1.0 documents, e.g. other control characters or invalid UTF-8, so the text can be written into the junit file
+func sanitizeXML(text string) string {
+	text = ansiEscapeRE.ReplaceAllString(text, "")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			return r
+		case r < 0x20, r == 0x7f, r >= 0xd800 && r <= 0xdfff, r == 0xfffe, r == 0xffff:
+			return utf8.RuneError
+		}
+		return r
+	}, strings.ToValidUTF8(text, string(utf8.RuneError)))
+}
+
+// tailFile returns the last lines of a file after the given offset, if any
+func tailFile(file string, offset int64, lines int) string {
+	data, err := os.ReadFile(file)
+	if err != nil || offset >= int64(len(data)) {
+		return ""
+	}
+	all := strings.Split(strings.TrimRight(string(data[offset:]), "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n")
+}
+
 // cleanup tries to ensure a cmdtask is properly closed
 func cleanup(cmd *exec.Cmd) {
 	defer func() {
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTailFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "task-log.txt")
+	if err := os.WriteFile(file, []byte("header\n\nline 1\nline 2\nline 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		offset   int64
+		lines    int
+		expected string
+	}{
+		{name: "all the lines after the offset", offset: 8, lines: 10, expected: "line 1\nline 2\nline 3"},
+		{name: "last lines", offset: 8, lines: 2, expected: "line 2\nline 3"},
+		{name: "offset at the end of the file", offset: 29, lines: 10, expected: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tail := tailFile(file, tc.offset, tc.lines); tail != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, tail)
+			}
+		})
+	}
+}
+
+func TestAsFlaky(t *testing.T) {
+	r := newTaskCmdRunner("", nil)
+	r.state, r.stateDir = &runState{}, t.TempDir()
+
+	if err := r.record("task-00", &taskOutcome{options: []testCaseOption{withFailure("exit status 1"), asFlaky()}}); err != nil {
+		t.Fatalf("expected flaky task to be recorded as successful, got %v", err)
+	}
+	if r.failed || r.suite.Failures != 0 {
+		t.Errorf("expected no failures, got %d", r.suite.Failures)
+	}
+	if tc := r.suite.Cases[0]; tc.Failure != nil || tc.Flaky == nil || tc.Flaky.Message != "exit status 1" {
+		t.Errorf("expected a flaky failure, got %+v", tc)
+	}
+	if result := r.state.result("task-00"); result != taskFlaky {
+		t.Errorf("expected result %q, got %q", taskFlaky, result)
+	}
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	testCases := []struct {
+		name      string
+		deadline  time.Time
+		force     bool
+		expectMax time.Duration
+		expectMin time.Duration
+	}{
+		{name: "no deadline", expectMin: time.Hour, expectMax: time.Hour},
+		{name: "deadline after the task timeout", deadline: time.Now().Add(2 * time.Hour), expectMin: time.Hour, expectMax: time.Hour},
+		{name: "deadline before the task timeout", deadline: time.Now().Add(time.Minute), expectMin: 59 * time.Second, expectMax: time.Minute},
+		{name: "deadline passed", deadline: time.Now().Add(-time.Minute), expectMin: -2 * time.Minute, expectMax: 0},
+		{name: "forced task ignores the deadline", deadline: time.Now().Add(-time.Minute), force: true, expectMin: time.Hour, expectMax: time.Hour},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &taskCmdRunner{deadline: tc.deadline}
+			timeout, _ := r.timeout(&taskCmd{Task: &Task{Timeout: Duration{time.Hour}, Force: tc.force}})
+			if timeout < tc.expectMin || timeout > tc.expectMax {
+				t.Errorf("expected timeout between %s and %s, got %s", tc.expectMin, tc.expectMax, timeout)
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		retries          int
+		failures         int
+		expectedResult   string
+		expectedAttempts int
+		expectedBackoffs []string
+	}{
+		{
+			name:             "no retries",
+			failures:         1,
+			expectedResult:   taskFailed,
+			expectedAttempts: 0,
+		},
+		{
+			name:             "passes after retries",
+			retries:          3,
+			failures:         2,
+			expectedResult:   taskPassed,
+			expectedAttempts: 3,
+			expectedBackoffs: []string{"attempt 1 of 4 failed, retrying in 10ms", "attempt 2 of 4 failed, retrying in 20ms"},
+		},
+		{
+			name:             "fails after all the retries",
+			retries:          2,
+			failures:         5,
+			expectedResult:   taskFailed,
+			expectedAttempts: 3,
+			expectedBackoffs: []string{"attempt 1 of 3 failed, retrying in 10ms", "attempt 2 of 3 failed, retrying in 20ms"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// the script fails until it is executed more than the given number of times
+			counter := filepath.Join(dir, "counter")
+			script := fmt.Sprintf("n=$(cat %[1]s 2>/dev/null || echo 0); n=$((n+1)); echo $n > %[1]s; echo attempt $n; [ $n -gt %[2]d ]", counter, tc.failures)
+			task := graphTaskCmd(&Task{Name: "task", Retries: tc.retries, Backoff: Duration{Duration: 10 * time.Millisecond}}, script)
+
+			r := newTaskCmdRunner("", nil)
+			err := r.Run(task, dir, false)
+			if (err != nil) != (tc.expectedResult == taskFailed) {
+				t.Fatalf("expected result %s, found error %v", tc.expectedResult, err)
+			}
+			if r.failed != (tc.expectedResult == taskFailed) {
+				t.Errorf("expected the runner to record the failure: %v", tc.expectedResult == taskFailed)
+			}
+
+			c := r.suite.Cases[0]
+			if result := testCaseResult(&c); result != tc.expectedResult {
+				t.Errorf("expected result %s, found %s", tc.expectedResult, result)
+			}
+			if c.Attempts != tc.expectedAttempts {
+				t.Errorf("expected %d attempts, found %d", tc.expectedAttempts, c.Attempts)
+			}
+			// the failure includes the output of the last attempt
+			if tc.expectedResult == taskFailed {
+				last := fmt.Sprintf("attempt %d", tc.retries+1)
+				if c.Failure == nil || !strings.Contains(c.Failure.Text, last) {
+					t.Errorf("expected a failure with the output %q, found %v", last, c.Failure)
+				}
+			}
+
+			log, err := os.ReadFile(filepath.Join(dir, "task-log.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			backoffs := []string{}
+			for _, l := range strings.Split(string(log), "\n") {
+				if strings.Contains(l, "retrying in") {
+					backoffs = append(backoffs, l)
+				}
+			}
+			if len(backoffs) == 0 {
+				backoffs = nil
+			}
+			if !reflect.DeepEqual(backoffs, tc.expectedBackoffs) {
+				t.Errorf("expected backoffs %q, found %q", tc.expectedBackoffs, backoffs)
+			}
+		})
+	}
+}
+
+func TestJUnitFailureOutput(t *testing.T) {
+	dir := t.TempDir()
+	r := newTaskCmdRunner("", nil)
+	output := "\x1b[1;31mI1014 error\x1b[0m applying manifest\x00\x07\x1b]0;title\x07 \xff done"
+	if err := r.registerTestCase("task", withFailure("exit status 1\x1b[0m"), withFailureOutput(output)); err == nil {
+		t.Fatal("expected an error for the failed test case")
+	}
+	if err := r.DumpJUnitRunner(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "junit_runner.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite := struct {
+		Cases []junitTestCase `xml:"testcase"`
+	}{}
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("invalid junit file: %v\n%s", err, data)
+	}
+
+	if len(suite.Cases) != 1 || suite.Cases[0].Failure == nil {
+		t.Fatalf("expected a failure in the junit file, found %v", suite.Cases)
+	}
+	f := suite.Cases[0].Failure
+	if f.Message != "exit status 1" {
+		t.Errorf("unexpected failure message %q", f.Message)
+	}
+	if !strings.HasSuffix(f.Text, "I1014 error applying manifest�� � done") {
+		t.Errorf("unexpected failure text %q", f.Text)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// resolveDependencies computes the tasks each task depends on, as indexes in the list of tasks.
+//
+// A task defining needs depends only on the named tasks, that must be defined before it. A task
+// marked as parallel depends on all the tasks before the group of adjacent parallel tasks it belongs to,
+// while any other task depends on all the tasks before it, like in a strict list of tasks.
+// Tasks are referenced by the name defined in the workflow file, so resolveDependencies must be called
+// before task names are prefixed with the task index.
+func resolveDependencies(tasks Tasks) error {
+	index := map[string]int{}
+	duplicated := map[string]bool{}
+	groupStart := 0
+	for i, t := range tasks {
+		if t.Parallel && len(t.Needs) > 0 {
+			return errors.Errorf("task #%d - parallel setting can't be combined with needs", i+1)
+		}
+
+		t.dependencies = nil
+		switch {
+		case len(t.Needs) > 0:
+			for _, n := range t.Needs {
+				j, ok := index[n]
+				if !ok {
+					return errors.Errorf("task #%d - needs %q, that is not a task defined before it", i+1, n)
+				}
+				if duplicated[n] {
+					return errors.Errorf("task #%d - needs %q, that is the name of more than one task", i+1, n)
+				}
+				t.dependencies = append(t.dependencies, j)
+			}
+		case t.Parallel:
+			if i == 0 || !tasks[i-1].Parallel {
+				groupStart = i
+			}
+			for j := 0; j < groupStart; j++ {
+				t.dependencies = append(t.dependencies, j)
+			}
+		default:
+			for j := 0; j < i; j++ {
+				t.dependencies = append(t.dependencies, j)
+			}
+		}
+
+		if t.Name != "" {
+			if _, ok := index[t.Name]; ok {
+				duplicated[t.Name] = true
+			}
+			index[t.Name] = i
+		}
+	}
+	return nil
+}
+
+// hasDependencyGraph returns true if any task requires to be executed as part of a dependency graph
+// instead of a strict list of tasks
+func hasDependencyGraph(tcmds []*taskCmd) bool {
+	for _, tcmd := range tcmds {
+		if tcmd.Parallel || len(tcmd.Needs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// taskResult defines the outcome of a taskCmd executed as part of a dependency graph
+type taskResult struct {
+	index   int
+	outcome *taskOutcome
+}
+
+// runGraph executes taskCmds as soon as all their dependencies are completed, many at the same time if possible.
+// Like for a list of tasks, once a task fails, times out or is canceled, the tasks not yet started are skipped
+// unless forced; when exiting on error, the tasks already started and the cleanup tasks are completed
+// before returning.
+func runGraph(out io.Writer, tcmds []*taskCmd, runner *taskCmdRunner, artifacts string, verbose, exitOnError bool) (foundError bool, err error) {
+	done := make([]bool, len(tcmds))
+	started := make([]bool, len(tcmds))
+	results := make(chan taskResult)
+	running := 0
+
+	ready := func(i int) bool {
+		for _, d := range tcmds[i].dependencies {
+			if !done[d] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		// starts all the tasks with dependencies completed; skipping a task might unblock other tasks
+		for progress := true; progress; {
+			progress = false
+			for i, tcmd := range tcmds {
+				if started[i] {
+					continue
+				}
+				// when exiting on error, tasks not yet started are abandoned, except cleanup tasks
+				if err != nil && !tcmd.isCleanup() {
+					started[i], done[i] = true, true
+					progress = true
+					continue
+				}
+				if !ready(i) {
+					continue
+				}
+				started[i] = true
+				progress = true
+
+				if reason := runner.inactiveReason(tcmd); reason != "" {
+					done[i] = true
+					runner.skipInactive(tcmd, reason)
+					fmt.Fprintf(out, "# %s\n %s\n\n", tcmd.Name, reason)
+					continue
+				}
+
+				if reason := runner.skipReason(tcmd); reason != "" {
+					done[i] = true
+					fmt.Fprintf(out, "# %s\n %v\n\n", tcmd.Name, runner.registerTestCase(tcmd.Name, withSkipped(reason)))
+					continue
+				}
+
+				fmt.Fprintf(out, "# %s\n", tcmd.Name)
+				fmt.Fprintf(out, "%s\n\n", tcmd.CmdText)
+				running++
+				go func(i int, tcmd *taskCmd) {
+					results <- taskResult{index: i, outcome: runner.execute(tcmd, artifacts, verbose)}
+				}(i, tcmd)
+			}
+		}
+
+		if running == 0 {
+			return foundError, err
+		}
+
+		r := <-results
+		running--
+		done[r.index] = true
+		if rerr := runner.record(tcmds[r.index].Name, r.outcome); rerr != nil {
+			foundError = true
+			fmt.Fprintf(out, "# %s\n %v\n\n", tcmds[r.index].Name, rerr)
+			if exitOnError && err == nil {
+				err = rerr
+			}
+			continue
+		}
+		fmt.Fprintf(out, "# %s\n completed!\n\n", tcmds[r.index].Name)
+	}
+}
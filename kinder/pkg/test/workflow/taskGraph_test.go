@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestResolveDependencies(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		input                Tasks
+		expectedDependencies [][]int
+		expectedError        bool
+	}{
+		{
+			name:                 "strict list of tasks",
+			input:                Tasks{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			expectedDependencies: [][]int{nil, {0}, {0, 1}},
+		},
+		{
+			name: "parallel tasks depend on tasks before the group",
+			input: Tasks{
+				{Name: "a"},
+				{Name: "b", Parallel: true},
+				{Name: "c", Parallel: true},
+				{Name: "d"},
+			},
+			expectedDependencies: [][]int{nil, {0}, {0}, {0, 1, 2}},
+		},
+		{
+			name: "fan-out and fan-in with needs",
+			input: Tasks{
+				{Name: "init"},
+				{Name: "join-w1", Needs: []string{"init"}},
+				{Name: "join-w2", Needs: []string{"init"}},
+				{Name: "upgrade", Needs: []string{"join-w1", "join-w2"}},
+			},
+			expectedDependencies: [][]int{nil, {0}, {0}, {1, 2}},
+		},
+		{
+			name:          "needs a task defined after",
+			input:         Tasks{{Name: "a", Needs: []string{"b"}}, {Name: "b"}},
+			expectedError: true,
+		},
+		{
+			name:          "needs an ambiguous task name",
+			input:         Tasks{{Name: "a"}, {Name: "a"}, {Name: "b", Needs: []string{"a"}}},
+			expectedError: true,
+		},
+		{
+			name:          "needs combined with parallel",
+			input:         Tasks{{Name: "a"}, {Name: "b", Parallel: true, Needs: []string{"a"}}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := resolveDependencies(tc.input)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if err != nil {
+				return
+			}
+			dependencies := [][]int{}
+			for _, task := range tc.input {
+				dependencies = append(dependencies, task.dependencies)
+			}
+			if !reflect.DeepEqual(dependencies, tc.expectedDependencies) {
+				t.Errorf("expected dependencies %v, got %v", tc.expectedDependencies, dependencies)
+			}
+		})
+	}
+}
+
+// graphTaskCmd returns a taskCmd executing a shell script, for testing the execution of tasks
+func graphTaskCmd(task *Task, script string) *taskCmd {
+	if task.Timeout.Duration == 0 {
+		task.Timeout = Duration{Duration: 10 * time.Second}
+	}
+	cmd := exec.Command("sh", "-c", script)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return &taskCmd{Task: task, Cmd: cmd, CmdText: script}
+}
+
+func TestRunGraph(t *testing.T) {
+	// each task of the parallel group waits for a file created by the other one, so they complete
+	// only if executed at the same time
+	waitFor := func(dir, create, wait string) string {
+		return fmt.Sprintf("touch %s/%s; for i in $(seq 50); do [ -f %s/%s ] && exit 0; sleep 0.1; done; exit 1", dir, create, dir, wait)
+	}
+
+	testCases := []struct {
+		name               string
+		tasks              func(dir string) ([]*Task, []string)
+		exitOnError        bool
+		expectedFoundError bool
+		expectedError      bool
+		expectedResults    map[string]string
+	}{
+		{
+			name: "parallel tasks are executed at the same time",
+			tasks: func(dir string) ([]*Task, []string) {
+				return []*Task{
+					{Name: "a", Parallel: true},
+					{Name: "b", Parallel: true},
+					{Name: "c"},
+				}, []string{
+					waitFor(dir, "a", "b"),
+					waitFor(dir, "b", "a"),
+					"true",
+				}
+			},
+			expectedResults: map[string]string{"a": taskPassed, "b": taskPassed, "c": taskPassed},
+		},
+		{
+			name: "failures are propagated to dependents, independent tasks are completed",
+			tasks: func(dir string) ([]*Task, []string) {
+				return []*Task{
+					{Name: "init"},
+					{Name: "fail", Needs: []string{"init"}},
+					{Name: "slow", Needs: []string{"init"}},
+					{Name: "after-fail", Needs: []string{"fail"}},
+					{Name: "forced", Needs: []string{"fail"}, Force: true},
+				}, []string{
+					"true",
+					"exit 1",
+					"sleep 0.5",
+					"true",
+					"true",
+				}
+			},
+			expectedFoundError: true,
+			expectedResults:    map[string]string{"init": taskPassed, "fail": taskFailed, "slow": taskPassed, "after-fail": taskSkipped, "forced": taskPassed},
+		},
+		{
+			name: "exit on error abandons tasks not yet started except cleanup tasks",
+			tasks: func(dir string) ([]*Task, []string) {
+				return []*Task{
+					{Name: "fail", Parallel: true},
+					{Name: "slow", Parallel: true},
+					{Name: "next"},
+					{Name: "cleanup", finally: true, Force: true},
+				}, []string{
+					"exit 1",
+					"sleep 0.5",
+					"true",
+					"true",
+				}
+			},
+			exitOnError:        true,
+			expectedFoundError: true,
+			expectedError:      true,
+			expectedResults:    map[string]string{"fail": taskFailed, "slow": taskPassed, "cleanup": taskPassed},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tasks, scripts := tc.tasks(dir)
+			if err := resolveDependencies(tasks); err != nil {
+				t.Fatal(err)
+			}
+			tcmds := []*taskCmd{}
+			for i, task := range tasks {
+				tcmds = append(tcmds, graphTaskCmd(task, scripts[i]))
+			}
+
+			runner := newTaskCmdRunner("", nil)
+			foundError, err := runGraph(io.Discard, tcmds, runner, dir, false, tc.exitOnError)
+			if foundError != tc.expectedFoundError {
+				t.Errorf("expected foundError %v, found %v", tc.expectedFoundError, foundError)
+			}
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error %v, found %v", tc.expectedError, err)
+			}
+
+			results := map[string]string{}
+			for _, c := range runner.suite.Cases {
+				results[c.Name] = testCaseResult(&c)
+			}
+			if !reflect.DeepEqual(results, tc.expectedResults) {
+				t.Errorf("expected results %v, found %v", tc.expectedResults, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// TaskTemplate defines a list of tasks with parameters, that can be instantiated many times in a workflow,
+// e.g. for upgrading a node to a version and then verifying the node
+type TaskTemplate struct {
+	// Params of the template, accessible in the template tasks as {{ .params.NAME }}
+	Params []TemplateParam
+
+	// Tasks of the template; tasks are named after the task instantiating the template, e.g. a template
+	// task named upgrade instantiated by a task named cp1 becomes cp1-upgrade, and needs between
+	// template tasks are changed accordingly
+	Tasks Tasks
+}
+
+// TemplateParam defines a parameter of a TaskTemplate; parameters without a default are required
+type TemplateParam struct {
+	Name    string
+	Default *string
+}
+
+// validateTemplates checks the templates defined in a workflow
+func validateTemplates(templates map[string]*TaskTemplate) error {
+	for _, n := range sortedKeys(templates) {
+		t := templates[n]
+		if t == nil || len(t.Tasks) == 0 {
+			return errors.Errorf("template %q does not define tasks", n)
+		}
+		params := map[string]bool{}
+		for i, p := range t.Params {
+			if p.Name == "" {
+				return errors.Errorf("template %q: param #%d does not define a name", n, i+1)
+			}
+			if params[p.Name] {
+				return errors.Errorf("template %q: param %q is defined more than once", n, p.Name)
+			}
+			params[p.Name] = true
+		}
+		for i, tt := range t.Tasks {
+			if tt.Import != "" || tt.Use != "" {
+				return errors.Errorf("template %q: task #%d - import and use directives can't be used in templates", n, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// expandTemplates replaces tasks with a use directive with the tasks of the template, with the given params
+func (w *Workflow) expandTemplates(file string, tasks Tasks) (Tasks, error) {
+	var expanded Tasks
+	for i, t := range tasks {
+		if t.Use == "" {
+			if len(t.With) != 0 {
+				return nil, errors.Errorf("invalid workflow file %s: task #%d - with setting requires a use directive", file, i+1)
+			}
+			expanded = append(expanded, t)
+			continue
+		}
+
+		// ensure the task does not have other settings, that would be ambiguous for the template tasks
+		x := *t
+		x.Name, x.Use, x.With = "", "", nil
+		if !reflect.DeepEqual(x, Task{}) {
+			return nil, errors.Errorf("invalid workflow file %s: task #%d - use directive can be combined only with name and with settings", file, i+1)
+		}
+
+		tmpl, ok := w.Templates[t.Use]
+		if !ok {
+			return nil, errors.Errorf("invalid workflow file %s: task #%d - template %q is not defined", file, i+1, t.Use)
+		}
+
+		params := map[string]string{}
+		defined := map[string]bool{}
+		for _, p := range tmpl.Params {
+			defined[p.Name] = true
+			v, ok := t.With[p.Name]
+			if !ok {
+				if p.Default == nil {
+					return nil, errors.Errorf("invalid workflow file %s: task #%d - template %q requires param %q", file, i+1, t.Use, p.Name)
+				}
+				v = *p.Default
+			}
+			params[p.Name] = v
+		}
+		for _, n := range sortedKeys(t.With) {
+			if !defined[n] {
+				return nil, errors.Errorf("invalid workflow file %s: task #%d - template %q does not define param %q", file, i+1, t.Use, n)
+			}
+		}
+
+		prefix := t.Name
+		if prefix == "" {
+			prefix = t.Use
+		}
+		names := map[string]bool{}
+		for _, tt := range tmpl.Tasks {
+			names[tt.Name] = true
+		}
+		instanceName := func(name string) string {
+			if name == "" {
+				return prefix
+			}
+			return prefix + "-" + name
+		}
+
+		// template tasks are copied, because a template can be instantiated many times
+		for _, tt := range tmpl.Tasks {
+			x := *tt
+			x.Name = instanceName(tt.Name)
+			x.Needs = nil
+			for _, n := range tt.Needs {
+				if names[n] {
+					n = instanceName(n)
+				}
+				x.Needs = append(x.Needs, n)
+			}
+			x.params = params
+			expanded = append(expanded, &x)
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandTemplates(t *testing.T) {
+	version := "v1.34.0"
+	w := &Workflow{
+		Templates: map[string]*TaskTemplate{
+			"upgrade": {
+				Params: []TemplateParam{{Name: "node"}, {Name: "version", Default: &version}},
+				Tasks: Tasks{
+					{Name: "upgrade", Cmd: "echo"},
+					{Name: "verify", Cmd: "echo", Needs: []string{"upgrade", "setup"}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		tasks          Tasks
+		expectedNames  []string
+		expectedParams []map[string]string
+		expectedNeeds  [][]string
+		expectedError  bool
+	}{
+		{
+			name:           "instantiates template tasks",
+			tasks:          Tasks{{Name: "setup", Cmd: "echo"}, {Name: "cp1", Use: "upgrade", With: map[string]string{"node": "cp1"}}},
+			expectedNames:  []string{"setup", "cp1-upgrade", "cp1-verify"},
+			expectedParams: []map[string]string{nil, {"node": "cp1", "version": "v1.34.0"}, {"node": "cp1", "version": "v1.34.0"}},
+			expectedNeeds:  [][]string{nil, nil, {"cp1-upgrade", "setup"}},
+		},
+		{
+			name:           "names tasks after the template by default",
+			tasks:          Tasks{{Use: "upgrade", With: map[string]string{"node": "w1", "version": "v1.33.0"}}},
+			expectedNames:  []string{"upgrade-upgrade", "upgrade-verify"},
+			expectedParams: []map[string]string{{"node": "w1", "version": "v1.33.0"}, {"node": "w1", "version": "v1.33.0"}},
+			expectedNeeds:  [][]string{nil, {"upgrade-upgrade", "setup"}},
+		},
+		{
+			name:          "missing required param",
+			tasks:         Tasks{{Use: "upgrade"}},
+			expectedError: true,
+		},
+		{
+			name:          "unknown param",
+			tasks:         Tasks{{Use: "upgrade", With: map[string]string{"node": "w1", "foo": "bar"}}},
+			expectedError: true,
+		},
+		{
+			name:          "unknown template",
+			tasks:         Tasks{{Use: "foo"}},
+			expectedError: true,
+		},
+		{
+			name:          "use combined with other settings",
+			tasks:         Tasks{{Use: "upgrade", With: map[string]string{"node": "w1"}, Cmd: "echo"}},
+			expectedError: true,
+		},
+		{
+			name:          "with without use",
+			tasks:         Tasks{{Cmd: "echo", With: map[string]string{"node": "w1"}}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks, err := w.expandTemplates("workflow.yaml", tc.tasks)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(tasks) != len(tc.expectedNames) {
+				t.Fatalf("expected %d tasks, got %d", len(tc.expectedNames), len(tasks))
+			}
+			for i, task := range tasks {
+				if task.Name != tc.expectedNames[i] || !reflect.DeepEqual(task.params, tc.expectedParams[i]) || !reflect.DeepEqual(task.Needs, tc.expectedNeeds[i]) {
+					t.Errorf("expected task %d to be %s %v %v, got %s %v %v", i, tc.expectedNames[i], tc.expectedParams[i], tc.expectedNeeds[i], task.Name, task.params, task.Needs)
+				}
+			}
+		})
+	}
+}
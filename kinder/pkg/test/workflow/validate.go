@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+)
+
+// Validate checks the workflow without executing any command, thus also without computing vars.
+//
+// In addition to the checks executed when loading a workflow file, including the strict check for
+// unknown fields, Validate reports templates that are not valid or that use vars or host environment
+// variables not defined in the workflow, secrets used in vars or not defined in the workflow, tasks with the same name and negative timeouts or backoffs.
+func (w *Workflow) Validate() error {
+	var failures []string
+
+	vars := map[string]bool{}
+	for n := range w.Vars {
+		vars[n] = true
+	}
+	for _, v := range w.ComputedVars {
+		vars[v.Name] = true
+	}
+	for n := range w.Matrix {
+		vars[n] = true
+	}
+	hostEnv := map[string]bool{}
+	for _, n := range w.HostEnv {
+		hostEnv[n] = true
+	}
+	secrets := map[string]bool{}
+	for n := range w.Secrets {
+		secrets[n] = true
+	}
+
+	check := func(where, text string) {
+		if err := checkTemplate(text, vars, hostEnv, secrets); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", where, err))
+		}
+	}
+	// secrets are not available when evaluating vars and computed vars
+	checkVar := func(where, text string) {
+		if err := checkTemplate(text, vars, hostEnv, nil); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", where, err))
+		}
+	}
+
+	for _, n := range sortedKeys(w.Vars) {
+		checkVar(fmt.Sprintf("vars.%s", n), w.Vars[n])
+	}
+	for _, n := range sortedKeys(w.Env) {
+		check(fmt.Sprintf("env.%s", n), w.Env[n])
+	}
+	for _, v := range w.ComputedVars {
+		checkVar(fmt.Sprintf("computed var %q cmd", v.Name), v.Cmd)
+		for i, a := range v.Args {
+			checkVar(fmt.Sprintf("computed var %q args[%d]", v.Name, i), a)
+		}
+	}
+
+	names := map[string]int{}
+	for _, t := range w.Tasks {
+		check(fmt.Sprintf("task %q cmd", t.Name), t.Cmd)
+		for i, a := range t.Args {
+			check(fmt.Sprintf("task %q args[%d]", t.Name, i), a)
+		}
+		if t.If != "" {
+			check(fmt.Sprintf("task %q if", t.Name), t.If)
+		}
+		for i, e := range t.ForEach {
+			check(fmt.Sprintf("task %q forEach[%d]", t.Name, i), e)
+		}
+		for i, a := range t.Artifacts {
+			for _, v := range []string{a.Path, a.Nodes, a.Cluster} {
+				check(fmt.Sprintf("task %q artifacts[%d]", t.Name, i), v)
+			}
+		}
+
+		if t.Timeout.Duration < 0 {
+			failures = append(failures, fmt.Sprintf("task %q: timeout %s is negative", t.Name, t.Timeout.Duration))
+		}
+		if t.Backoff.Duration < 0 {
+			failures = append(failures, fmt.Sprintf("task %q: backoff %s is negative", t.Name, t.Backoff.Duration))
+		}
+
+		// task names are checked without the prefix with the task index
+		if name := taskPrefix.ReplaceAllString(t.Name, ""); name != "" {
+			names[name]++
+		}
+	}
+	for _, n := range sortedKeys(names) {
+		if names[n] > 1 {
+			failures = append(failures, fmt.Sprintf("task name %q is used by %d tasks", n, names[n]))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("invalid workflow:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// checkTemplate parses a template and checks that it refers only to the given vars, host environment variables
+// and secrets; nil secrets means that secrets can't be used at all
+func checkTemplate(text string, vars, hostEnv, secrets map[string]bool) error {
+	templ, err := template.New("").Funcs(funcMap).Parse(text)
+	if err != nil {
+		return errors.Wrapf(err, "%q is not a valid expression", text)
+	}
+	if templ.Tree == nil {
+		return nil
+	}
+
+	var undefined []string
+	walkFields(templ.Tree.Root, func(ident []string) {
+		if len(ident) < 2 {
+			return
+		}
+		switch {
+		case ident[0] == "vars" && !vars[ident[1]]:
+			undefined = append(undefined, fmt.Sprintf("var %s", ident[1]))
+		case ident[0] == "hostEnv" && !hostEnv[ident[1]]:
+			undefined = append(undefined, fmt.Sprintf("host environment variable %s not in the hostEnv allowlist", ident[1]))
+		case ident[0] == "secrets" && secrets == nil:
+			undefined = append(undefined, fmt.Sprintf("secret %s, secrets can't be used in vars", ident[1]))
+		case ident[0] == "secrets" && !secrets[ident[1]]:
+			undefined = append(undefined, fmt.Sprintf("secret %s", ident[1]))
+		}
+	})
+	if len(undefined) > 0 {
+		return errors.Errorf("%q uses undefined %s", text, strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// walkFields calls fn for each field, e.g. .vars.KEY, used in a template parse tree
+func walkFields(node parse.Node, fn func(ident []string)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkFields(c, fn)
+		}
+	case *parse.ActionNode:
+		walkFields(n.Pipe, fn)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.TemplateNode:
+		walkFields(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			walkFields(c, fn)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			walkFields(a, fn)
+		}
+	case *parse.ChainNode:
+		walkFields(n.Node, fn)
+	case *parse.FieldNode:
+		fn(n.Ident)
+	}
+}
+
+func walkBranch(n *parse.BranchNode, fn func(ident []string)) {
+	walkFields(n.Pipe, fn)
+	walkFields(n.List, fn)
+	walkFields(n.ElseList, fn)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	task := func(name, cmd string, args ...string) *Task {
+		return &Task{Name: name, Cmd: cmd, Args: args, Timeout: Duration{time.Minute}}
+	}
+	testCases := []struct {
+		name          string
+		workflow      Workflow
+		expectedError string
+	}{
+		{
+			name: "valid",
+			workflow: Workflow{
+				Vars:         map[string]string{"a": "x"},
+				ComputedVars: []ComputedVar{{Name: "b", Cmd: "echo", Args: []string{"{{ .vars.a }}"}}},
+				Matrix:       map[string][]string{"c": {"1"}},
+				HostEnv:      []string{"HOME"},
+				Tasks: Tasks{
+					task("task-00-one", "echo", "{{ .vars.a }}", "{{ .vars.b }}", "{{ .vars.c }}", "{{ .hostEnv.HOME }}", "{{ .env.PATH }}"),
+					task("task-01", "echo"),
+					task("task-02", "echo"),
+				},
+			},
+		},
+		{
+			name:          "undefined var",
+			workflow:      Workflow{Tasks: Tasks{task("task-00-one", "echo", `{{ if .vars.missing }}x{{ end }}`)}},
+			expectedError: "uses undefined var missing",
+		},
+		{
+			name:          "host env not allowed",
+			workflow:      Workflow{Tasks: Tasks{task("task-00-one", "{{ .hostEnv.HOME }}")}},
+			expectedError: "host environment variable HOME not in the hostEnv allowlist",
+		},
+		{
+			name:          "invalid template",
+			workflow:      Workflow{Vars: map[string]string{"a": "{{ .vars.a "}, Tasks: Tasks{task("task-00-one", "echo")}},
+			expectedError: "is not a valid expression",
+		},
+		{
+			name:          "duplicated task names",
+			workflow:      Workflow{Tasks: Tasks{task("task-00-one", "echo"), task("task-01-one", "echo")}},
+			expectedError: `task name "one" is used by 2 tasks`,
+		},
+		{
+			name:          "negative timeout",
+			workflow:      Workflow{Tasks: Tasks{task("task-00-one", "echo"), {Name: "task-01-two", Cmd: "echo", Timeout: Duration{-time.Second}}}},
+			expectedError: "timeout -1s is negative",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.workflow.Validate()
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	K8sVersion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// version helpers used in templates; like versionAtLeast, all of them accept versions with or without
+// the v prefix and with or without the patch version, ignoring pre-release and build metadata
+
+func parseVersion(v string) (*K8sVersion.Version, error) {
+	version, err := K8sVersion.ParseGeneric(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%q is not a valid version", v)
+	}
+	return version, nil
+}
+
+// versionAtLeast returns true if a version is greater or equal than a minimum version.
+// Pre-release and build metadata are ignored, so e.g. v1.33.0-alpha.1 is at least v1.33
+func versionAtLeast(v, minVersion string) (bool, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return false, err
+	}
+	minimum, err := parseVersion(minVersion)
+	if err != nil {
+		return false, err
+	}
+	return version.AtLeast(minimum), nil
+}
+
+// versionLessThan returns true if a version is lower than a maximum version, ignoring pre-release and build metadata
+func versionLessThan(v, maxVersion string) (bool, error) {
+	atLeast, err := versionAtLeast(v, maxVersion)
+	return !atLeast, err
+}
+
+// versionCompare returns -1, 0 or 1 if a version is lower, equal or greater than another version
+func versionCompare(v, other string) (int, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return 0, err
+	}
+	o, err := parseVersion(other)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case version.LessThan(o):
+		return -1, nil
+	case o.LessThan(version):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// majorOf returns the major of a version, e.g. 1 for v1.33.2
+func majorOf(v string) (uint, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return 0, err
+	}
+	return version.Major(), nil
+}
+
+// minorOf returns the minor of a version, e.g. 33 for v1.33.2
+func minorOf(v string) (uint, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return 0, err
+	}
+	return version.Minor(), nil
+}
+
+// patchOf returns the patch of a version, e.g. 2 for v1.33.2, or 0 if the version has no patch
+func patchOf(v string) (uint, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return 0, err
+	}
+	return version.Patch(), nil
+}
+
+// majorMinor returns the major and the minor of a version, e.g. v1.33 for v1.33.2
+func majorMinor(v string) (string, error) {
+	return addMinor(v, 0)
+}
+
+// previousMinor returns the minor before the minor of a version, e.g. v1.32 for v1.33.2
+func previousMinor(v string) (string, error) {
+	return addMinor(v, -1)
+}
+
+// nextMinor returns the minor after the minor of a version, e.g. v1.34 for v1.33.2
+func nextMinor(v string) (string, error) {
+	return addMinor(v, 1)
+}
+
+func addMinor(v string, delta int) (string, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return "", err
+	}
+	minor := int(version.Minor()) + delta
+	if minor < 0 {
+		return "", errors.Errorf("%q has no previous minor", v)
+	}
+	return fmt.Sprintf("v%d.%d", version.Major(), minor), nil
+}
+
+// ciLabel returns the CI label for the latest build of the minor of a version, e.g. ci/latest-1.33
+// for v1.33.2; the label can be resolved with resolve, e.g. {{ ciLabel .vars.kubernetesVersion | resolve }}
+func ciLabel(v string) (string, error) {
+	version, err := parseVersion(v)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ci/latest-%d.%d", version.Major(), version.Minor()), nil
+}
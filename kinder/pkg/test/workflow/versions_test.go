@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+)
+
+func TestVersionAtLeast(t *testing.T) {
+	testCases := []struct {
+		version       string
+		minVersion    string
+		expected      bool
+		expectedError bool
+	}{
+		{version: "v1.33.0", minVersion: "v1.33", expected: true},
+		{version: "v1.34.1", minVersion: "v1.33.0", expected: true},
+		{version: "v1.32.9", minVersion: "v1.33", expected: false},
+		{version: "v1.33.0-alpha.1.23+0123456789abcdef", minVersion: "v1.33", expected: true},
+		{version: "1.33.0", minVersion: "v1.33.0", expected: true},
+		{version: "latest", minVersion: "v1.33", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.version+" >= "+tc.minVersion, func(t *testing.T) {
+			atLeast, err := versionAtLeast(tc.version, tc.minVersion)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if atLeast != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, atLeast)
+			}
+		})
+	}
+}
+
+func TestVersionHelpers(t *testing.T) {
+	c := &taskCmdBuilder{vars: map[string]string{"kubernetesVersion": "v1.33.2-rc.0"}}
+
+	testCases := []struct {
+		text          string
+		expected      string
+		expectedError bool
+	}{
+		{text: `{{ versionCompare .vars.kubernetesVersion "v1.33" }}`, expected: "1"},
+		{text: `{{ versionCompare "v1.33" "1.33.0" }}`, expected: "0"},
+		{text: `{{ versionCompare "v1.32.9" .vars.kubernetesVersion }}`, expected: "-1"},
+		{text: `{{ majorOf .vars.kubernetesVersion }}.{{ minorOf .vars.kubernetesVersion }}.{{ patchOf .vars.kubernetesVersion }}`, expected: "1.33.2"},
+		{text: `{{ gt (minorOf .vars.kubernetesVersion) 32 }}`, expected: "true"},
+		{text: `{{ majorMinor .vars.kubernetesVersion }}`, expected: "v1.33"},
+		{text: `{{ previousMinor .vars.kubernetesVersion }} {{ nextMinor .vars.kubernetesVersion }}`, expected: "v1.32 v1.34"},
+		{text: `{{ previousMinor .vars.kubernetesVersion | ciLabel }}`, expected: "ci/latest-1.32"},
+		{text: `{{ previousMinor "v1.0" }}`, expectedError: true},
+		{text: `{{ minorOf "latest" }}`, expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			got, err := c.expand(tc.text)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// events POSTed to the webhook
+const (
+	webhookWorkflowStarted  = "workflow-started"
+	webhookWorkflowFinished = "workflow-finished"
+	webhookWorkflowFailed   = "workflow-failed"
+	webhookTaskStarted      = "task-started"
+	webhookTaskFinished     = "task-finished"
+	webhookTaskFailed       = "task-failed"
+)
+
+// webhookTimeout is the maximum time for delivering an event to the webhook
+const webhookTimeout = 10 * time.Second
+
+// webhookEvent is the JSON payload POSTed to the webhook; task events are sent for skipped tasks too,
+// as task-finished events with the skipped result
+type webhookEvent struct {
+	Event    string    `json:"event"`
+	Workflow string    `json:"workflow"`
+	Matrix   string    `json:"matrix,omitempty"`
+	Task     string    `json:"task,omitempty"`
+	Result   string    `json:"result,omitempty"`
+	Duration float64   `json:"durationSeconds,omitempty"`
+	Message  string    `json:"message,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// webhook reports the progress of a workflow run to an external service, e.g. a dashboard or a chat bot
+type webhook struct {
+	url      string
+	workflow string
+	matrix   string
+	client   *http.Client
+}
+
+func newWebhook(url, workflow, matrix string) *webhook {
+	if url == "" {
+		return nil
+	}
+	return &webhook{
+		url:      url,
+		workflow: workflow,
+		matrix:   matrix,
+		client:   &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// notify POSTs an event to the webhook; failures delivering events are logged, but they never
+// fail the workflow. notify is a no-op on a nil webhook
+func (h *webhook) notify(e webhookEvent) {
+	if h == nil {
+		return
+	}
+	e.Workflow, e.Matrix, e.Time = h.workflow, h.matrix, time.Now()
+	if err := h.post(e); err != nil {
+		log.Warnf("error sending the %s event to the webhook: %v", e.Event, err)
+	}
+}
+
+func (h *webhook) post(e webhookEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "error encoding the event")
+	}
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhook(t *testing.T) {
+	var lock sync.Mutex
+	var events []webhookEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e webhookEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		lock.Lock()
+		events = append(events, e)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	r := newTaskCmdRunner("", nil)
+	r.webhook = newWebhook(server.URL, "regular", "")
+	_ = r.registerTestCase("task-00", withDuration(time.Second))
+	_ = r.registerTestCase("task-01", withFailure("exit status 1"))
+	_ = r.registerTestCase("task-02", withSkipped("skipping because a predecessor task failed"))
+
+	expected := []webhookEvent{
+		{Event: webhookTaskFinished, Workflow: "regular", Task: "task-00", Result: taskPassed, Duration: 1},
+		{Event: webhookTaskFailed, Workflow: "regular", Task: "task-01", Result: taskFailed, Message: "exit status 1"},
+		{Event: webhookTaskFinished, Workflow: "regular", Task: "task-02", Result: taskSkipped, Message: "skipping because a predecessor task failed"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(events))
+	}
+	for i := range events {
+		events[i].Time = time.Time{}
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected events %+v, got %+v", expected, events)
+	}
+}
@@ -22,7 +22,19 @@ can be any command, thus including also any kinder commands invoked via CLI.
 
 Tasks will be executed in order; in case of errors the workflow will stop and the remaining tasks
 will be skipped with the only exception of tasks specifically marked to be executed in any case
-(e.g. cleanup tasks).
+(e.g. cleanup tasks); tasks in the onFailure and finally sections are executed at the end of the workflow,
+respectively only after a failure or always, even when exiting on error. Tasks can also define a condition,
+and they are skipped when it is false, or iterate over a list of items, generating one task for each item.
+Adjacent tasks marked as parallel are executed at the same time, as a group, and tasks listing
+the tasks they need are executed as soon as those tasks complete, thus allowing to define
+a dependency graph instead of a strict list of tasks.
+
+Workflows can import all or some of the tasks defined in other workflow files, e.g. for sharing
+setup and teardown tasks. Workflows can also define a matrix, and in this case the workflow is
+executed once for each combination of values of the matrix dimensions.
+
+Workflow files can be loaded from the local file system, from http(s) URLs or from OCI artifacts,
+optionally pinned to a digest.
 */
 package workflow
 
@@ -32,7 +44,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -56,6 +69,23 @@ type Workflow struct {
 	// Vars will be accessible as {{ .vars.KEY }}
 	Vars map[string]string
 
+	// HostEnv is the allowlist of host environment variables the workflow depends on, e.g. DOCKER_IN_DOCKER;
+	// only allowed variables are accessible as {{ .hostEnv.KEY }}, with an empty value if not set on the host,
+	// like e.g. in {{ or .hostEnv.KEY "default" }}. Allowlists of imported workflows are merged
+	HostEnv []string `yaml:"hostEnv"`
+
+	// Secrets defines values read from host environment variables or from files, e.g. registry credentials
+	// or tokens, that are masked in logs, junit output and plans. Secrets will be accessible as {{ .secrets.KEY }}
+	// in env and in tasks, but not in vars and computed vars, because vars are recorded in the junit output
+	// and in the state of the workflow run
+	Secrets map[string]Secret
+
+	// ComputedVars defines vars whose value is the trimmed stdout of a command executed once at the beginning
+	// of the workflow, e.g. for resolving a version label only once for all the tasks. Computed vars are
+	// processed in order, after Vars, and their cmd and args can use Vars and already computed vars.
+	// Computed vars will be accessible as {{ .vars.KEY }}, and they are not computed again when resuming a workflow run
+	ComputedVars []ComputedVar `yaml:"computedVars"`
+
 	// Env defines a list of env variables to be passed to the workflow CMD (in addition to OS env variables);
 	// Envs are processed in order, and OS environment variables, Vars and already known Vars
 	// can be used in templates for other envs.
@@ -65,8 +95,29 @@ type Workflow struct {
 	// Env variables can be used for golang template expansion using {{ .env.KEY }}
 	Env map[string]string
 
+	// Matrix defines dimensions for executing the workflow many times, once for each combination of values.
+	// Values are accessible as vars, e.g. {{ .vars.cri }}, and can be used in templates for other vars;
+	// each combination gets its own artifacts folder and junit_runner.xml file
+	Matrix map[string][]string
+
+	// Templates defines reusable lists of tasks with parameters, instantiated by tasks with a use directive;
+	// templates of imported workflows are merged, so a workflow file can be used as a library of templates
+	Templates map[string]*TaskTemplate
+
 	// Tasks defines the list of tasks to be executed during test workflow
 	Tasks Tasks
+
+	// OnFailure defines a list of tasks to be executed after Tasks only if a task failed, timed out
+	// or was canceled, e.g. for collecting additional debug info
+	OnFailure Tasks `yaml:"onFailure"`
+
+	// Finally defines a list of tasks to be always executed at the end of the workflow, after OnFailure tasks,
+	// even when exiting on error, e.g. for exporting logs and deleting the cluster. Cleanup tasks of
+	// imported workflows are executed after the cleanup tasks of the importing workflow
+	Finally Tasks
+
+	// ref is the workflow file the workflow was loaded from, as given to NewWorkflow
+	ref string
 }
 
 // Tasks represents a list of tasks to be executed during test workflow.
@@ -91,9 +142,18 @@ type Task struct {
 	// Import defines a path of a workflow file to import into the current workflow
 	Import string
 
+	// ImportTasks selects by name the tasks to import, e.g. for sharing only the setup or teardown tasks
+	// of another workflow file; by default all the tasks are imported
+	ImportTasks []string `yaml:"importTasks"`
+
 	// Args allows to set Cmd arguments; args can be a literal or a template
 	Args []string
 
+	// If defines a condition for executing the task; it must be a template evaluating to true or false,
+	// e.g. '{{ versionAtLeast .vars.upgradeVersion "v1.33" }}'. Tasks with a false condition are skipped
+	// without affecting the following tasks
+	If string `yaml:"if"`
+
 	// Force sets a task to be executed no matter of the result of the previous task.
 	// This allows e.g. to define cleanup tasks to be always executed
 	Force bool
@@ -103,6 +163,56 @@ type Task struct {
 
 	// IgnoreError sets a task to be recorded as successful even if it is actually failed
 	IgnoreError bool `yaml:"ignoreError"`
+
+	// IgnoreFailure sets a task to not abort the workflow when it fails or times out, e.g. for optional
+	// diagnostics or best-effort addon installs; differently from IgnoreError, the failure is reported as flaky
+	IgnoreFailure bool `yaml:"ignoreFailure"`
+
+	// Artifacts defines files or folders, on the local filesystem or on the nodes, to be collected into
+	// the artifacts folder when the task completes, e.g. kubeadm config files or static pod manifests
+	Artifacts []Artifact
+
+	// Retries sets how many times a task is executed again after failing or timing out, none by default.
+	// This allows e.g. to cope with known flaky steps like image pulls; the timeout applies to each attempt
+	Retries int
+
+	// Backoff for the first retry, 10s by default; the wait doubles at each following retry
+	Backoff Duration
+
+	// Parallel sets a task to be executed at the same time of the adjacent tasks with Parallel set.
+	// The group completes when all its tasks complete; the following tasks are skipped if any task
+	// in the group fails, unless forced
+	Parallel bool
+
+	// Needs lists the names of the tasks that must complete before executing this task; tasks must be
+	// defined before the task needing them. If not set, the task is executed after all the tasks before it
+	Needs []string
+
+	// ForEach defines a list of items the task iterates over, generating one task for each item, named
+	// after the task and the item; items can be literals or templates, and each entry is split on spaces
+	// and commas, so an entry can refer to a var holding a list. The current item is accessible as {{ .item }}
+	ForEach []string `yaml:"forEach"`
+
+	// Use instantiates the template with the given name, replacing this task with the template tasks
+	Use string
+
+	// With sets the params of the template to instantiate; params can be literals or templates
+	With map[string]string
+
+	// params holds the params of the template this task was instantiated from, accessible as {{ .params.NAME }}
+	params map[string]string
+
+	// dependencies holds the indexes of the tasks this task depends on, as resolved from Needs and Parallel
+	dependencies []int
+
+	// onFailure and finally are set for tasks defined in the corresponding sections of the workflow
+	onFailure bool
+	finally   bool
+}
+
+// isCleanup returns true for tasks defined in the onFailure or finally sections of a workflow
+func (t *Task) isCleanup() bool {
+	return t.onFailure || t.finally
 }
 
 // Duration is a wrapper around time.Duration to satisfy the encoding/json Marshaller
@@ -142,15 +252,35 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 
 // NewWorkflow creates a new workflow as defined in a workflow file
 func NewWorkflow(file string) (*Workflow, error) {
-	// Checks if the workflow file exists
-	if _, err := os.Stat(file); err != nil {
-		return nil, errors.Errorf("invalid workflow file: %s does not exist", file)
+	ref := file
+
+	// OCI artifacts are pulled into a temporary folder, that can be removed once the workflow is loaded
+	if isOCIReference(file) {
+		dir, err := os.MkdirTemp("", "kinder-workflow-")
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating a temporary folder for the workflow artifact")
+		}
+		defer os.RemoveAll(dir)
+
+		if file, err = pullOCI(file, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := newWorkflow(file, nil)
+	if err != nil {
+		return nil, err
 	}
+	w.ref = ref
+	return w, nil
+}
 
+// newWorkflow creates a new workflow as defined in a workflow file, imported by the given chain of workflow files
+func newWorkflow(file string, importedBy []string) (*Workflow, error) {
 	// Loads and unmarshal it
-	data, err := os.ReadFile(file)
+	data, err := readWorkflowFile(file)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error reading workflow file %s", file)
+		return nil, err
 	}
 	var w Workflow
 	err = yaml.UnmarshalStrict(data, &w)
@@ -166,15 +296,72 @@ func NewWorkflow(file string) (*Workflow, error) {
 		return nil, errors.Errorf("invalid taskfile %s: version does not contain a supported value", file)
 	}
 
-	if len(w.Tasks) == 0 {
+	// imported workflows can define only templates, e.g. for sharing templates among many workflows
+	if len(w.Tasks) == 0 && (len(importedBy) == 0 || len(w.Templates) == 0) {
 		return nil, errors.Errorf("invalid taskfile %s: at least one task should be defined", file)
 	}
+	if err := validateTemplates(w.Templates); err != nil {
+		return nil, errors.Wrapf(err, "invalid workflow file %s", file)
+	}
 
 	// Detect and resolve imports by expanding imported workflows into the top level workflow
-	if err := w.expandImports(file); err != nil {
+	tasks, importedCleanup, err := w.expandImports(file, w.Tasks, importedBy)
+	if err != nil {
+		return nil, err
+	}
+	onFailure, onFailureCleanup, err := w.expandImports(file, w.OnFailure, importedBy)
+	if err != nil {
+		return nil, err
+	}
+	finally, finallyCleanup, err := w.expandImports(file, w.Finally, importedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replaces tasks instantiating templates with the template tasks, after templates of imported workflows are merged
+	if tasks, err = w.expandTemplates(file, tasks); err != nil {
+		return nil, err
+	}
+	if onFailure, err = w.expandTemplates(file, onFailure); err != nil {
+		return nil, err
+	}
+	if finally, err = w.expandTemplates(file, finally); err != nil {
 		return nil, err
 	}
 
+	// Appends cleanup tasks at the end of the workflow, followed by the cleanup tasks of the same type of
+	// imported workflows; cleanup tasks are executed even if a task before them fails, like forced tasks
+	for _, t := range onFailure {
+		t.onFailure, t.Force = true, true
+	}
+	for _, t := range finally {
+		t.finally, t.Force = true, true
+	}
+	for _, t := range append(append(importedCleanup, onFailureCleanup...), finallyCleanup...) {
+		if t.onFailure {
+			onFailure = append(onFailure, t)
+			continue
+		}
+		finally = append(finally, t)
+	}
+	w.Tasks = append(append(tasks, onFailure...), finally...)
+	w.OnFailure, w.Finally = nil, nil
+
+	if err := validateMatrix(w.Matrix, w.Vars); err != nil {
+		return nil, errors.Wrapf(err, "invalid workflow file %s", file)
+	}
+	if err := validateComputedVars(w.ComputedVars, w.Vars, w.Matrix); err != nil {
+		return nil, errors.Wrapf(err, "invalid workflow file %s", file)
+	}
+	if err := validateSecrets(w.Secrets); err != nil {
+		return nil, errors.Wrapf(err, "invalid workflow file %s", file)
+	}
+
+	// Resolve dependencies between tasks, before task names are changed
+	if err := resolveDependencies(w.Tasks); err != nil {
+		return nil, errors.Wrapf(err, "invalid workflow file %s", file)
+	}
+
 	// For each task
 	for i, t := range w.Tasks {
 		// if a task name is not defined, assign a default task name
@@ -191,6 +378,23 @@ func NewWorkflow(file string) (*Workflow, error) {
 			t.Timeout.Duration = time.Duration(5 * time.Minute)
 		}
 
+		// check artifacts to be collected, if any
+		if err := validateArtifacts(t.Artifacts); err != nil {
+			return nil, errors.Wrapf(err, "invalid taskfile %s: task %q", file, t.Name)
+		}
+
+		if t.IgnoreError && t.IgnoreFailure {
+			return nil, errors.Errorf("invalid taskfile %s: task %q - ignoreError setting can't be combined with ignoreFailure", file, t.Name)
+		}
+
+		// check retries and assign a default backoff, if not defined
+		if t.Retries < 0 {
+			return nil, errors.Errorf("invalid taskfile %s: task %q defines a negative number of retries", file, t.Name)
+		}
+		if t.Retries > 0 && t.Backoff.Duration == 0 {
+			t.Backoff.Duration = time.Duration(10 * time.Second)
+		}
+
 		// check if the task defines a cmd
 		if t.Cmd == "" {
 			return nil, errors.Errorf("invalid taskfile %s: task %q does not define a cmd", file, t.Name)
@@ -200,53 +404,97 @@ func NewWorkflow(file string) (*Workflow, error) {
 	return &w, nil
 }
 
-// expandImports imports a secondary workflow into the top level Workflow
-func (w *Workflow) expandImports(file string) error {
-	tasks := w.Tasks
-	w.Tasks = Tasks{}
+// expandImports imports secondary workflows into a list of tasks of the top level Workflow;
+// cleanup tasks of the imported workflows are returned separately, for being executed at the end
+func (w *Workflow) expandImports(file string, tasks Tasks, importedBy []string) (expanded, cleanup Tasks, err error) {
+	abs, err := importPath(file, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	importedBy = append(append([]string{}, importedBy...), abs)
+
 	for i, t := range tasks {
 		// check if the task does not defines an import, preserve it as it is
 		if t.Import == "" {
-			w.Tasks = append(w.Tasks, t)
+			if len(t.ImportTasks) != 0 {
+				return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - importTasks setting requires an import directive", file, i+1)
+			}
+			expanded = append(expanded, t)
 			continue
 		}
 
 		// otherwise it is an import task
 		// ensure the import task does not have other settings
 		if t.Dir != "" {
-			return errors.Errorf("invalid workflow file %s: task #%d - dir setting can't be combined with import directive", file, i+1)
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - dir setting can't be combined with import directive", file, i+1)
 		}
 		if t.Cmd != "" {
-			return errors.Errorf("invalid workflow file %s: task #%d - cmd setting can't be combined with import directive", file, i+1)
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - cmd setting can't be combined with import directive", file, i+1)
 		}
 		if len(t.Args) != 0 {
-			return errors.Errorf("invalid workflow file %s: task #%d - args setting can't be combined with import directive", file, i+1)
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - args setting can't be combined with import directive", file, i+1)
+		}
+		if t.If != "" {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - if setting can't be combined with import directive", file, i+1)
 		}
 		if t.Force {
-			return errors.Errorf("invalid workflow file %s: task #%d - force setting can't be combined with import directive", file, i+1)
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - force setting can't be combined with import directive", file, i+1)
 		}
 		if t.Timeout.Duration != 0 {
-			return errors.Errorf("invalid workflow file %s: task #%d - timeout setting can't be combined with import directive", file, i+1)
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - timeout setting can't be combined with import directive", file, i+1)
 		}
 		if t.IgnoreError {
-			return errors.Errorf("invalid workflow file %s: task #%d - ignoreError setting can't be combined with import directive", file, i+1)
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - ignoreError setting can't be combined with import directive", file, i+1)
+		}
+		if t.IgnoreFailure {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - ignoreFailure setting can't be combined with import directive", file, i+1)
+		}
+		if len(t.Artifacts) != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - artifacts setting can't be combined with import directive", file, i+1)
+		}
+		if t.Retries != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - retries setting can't be combined with import directive", file, i+1)
+		}
+		if t.Backoff.Duration != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - backoff setting can't be combined with import directive", file, i+1)
+		}
+		if t.Parallel {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - parallel setting can't be combined with import directive", file, i+1)
+		}
+		if len(t.Needs) != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - needs setting can't be combined with import directive", file, i+1)
+		}
+		if len(t.ForEach) != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - forEach setting can't be combined with import directive", file, i+1)
+		}
+		if t.Use != "" || len(t.With) != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - use and with settings can't be combined with import directive", file, i+1)
 		}
 
 		// reads the Import file
-		// if path are relative, consider as a base path the folder where the importing file is located.
-		// TODO: implement a check for avoiding circular imports
-		path := t.Import
-		if !filepath.IsAbs(path) {
-			base := filepath.Dir(file)
-			path = filepath.Join(base, path)
-		}
-		wx, err := NewWorkflow(path)
+		// if path are relative, consider as a base path the folder or the URL where the importing file is located.
+		path, err := importPath(file, t.Import)
 		if err != nil {
-			return errors.Wrapf(err, "error importing workflow file %s", path)
+			return nil, nil, err
+		}
+		for _, f := range importedBy {
+			if f == path {
+				return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - circular import of workflow file %s", file, i+1, path)
+			}
+		}
+		wx, err := newWorkflow(path, importedBy)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error importing workflow file %s", path)
+		}
+		if len(wx.Matrix) != 0 {
+			return nil, nil, errors.Errorf("invalid workflow file %s: matrix can be defined only in the top level workflow file", path)
 		}
 
 		// merge the vars from the import file into the parent file
 		// in case of conflicts, vars in the parent file will shadow vars in the import file
+		if w.Vars == nil {
+			w.Vars = map[string]string{}
+		}
 		for k, v := range wx.Vars {
 			if _, ok := w.Vars[k]; !ok {
 				w.Vars[k] = v
@@ -255,8 +503,56 @@ func (w *Workflow) expandImports(file string) error {
 			log.Debugf("var %s in workflow file %s is shadowed by var %[1]s in parent workflow file %[3]s", k, path, file)
 		}
 
+		// merge the secrets from the import file into the parent file
+		// in case of conflicts, secrets in the parent file will shadow secrets in the import file
+		for k, v := range wx.Secrets {
+			if _, ok := w.Secrets[k]; !ok {
+				if w.Secrets == nil {
+					w.Secrets = map[string]Secret{}
+				}
+				w.Secrets[k] = v
+				continue
+			}
+			log.Debugf("secret %s in workflow file %s is shadowed by secret %[1]s in parent workflow file %[3]s", k, path, file)
+		}
+
+		// merge the templates from the import file into the parent file
+		// in case of conflicts, templates in the parent file will shadow templates in the import file
+		for k, v := range wx.Templates {
+			if _, ok := w.Templates[k]; !ok {
+				if w.Templates == nil {
+					w.Templates = map[string]*TaskTemplate{}
+				}
+				w.Templates[k] = v
+				continue
+			}
+			log.Debugf("template %s in workflow file %s is shadowed by template %[1]s in parent workflow file %[3]s", k, path, file)
+		}
+
+		// merge the computed vars from the import file into the parent file, before the ones of the parent file
+		// in case of conflicts, computed vars in the parent file will shadow computed vars in the import file
+		var computed []ComputedVar
+		for _, v := range wx.ComputedVars {
+			if !slices.ContainsFunc(w.ComputedVars, func(p ComputedVar) bool { return p.Name == v.Name }) {
+				computed = append(computed, v)
+				continue
+			}
+			log.Debugf("computed var %s in workflow file %s is shadowed by computed var %[1]s in parent workflow file %[3]s", v.Name, path, file)
+		}
+		w.ComputedVars = append(computed, w.ComputedVars...)
+
+		// merge the host env allowlist from the import file into the parent file
+		for _, k := range wx.HostEnv {
+			if !slices.Contains(w.HostEnv, k) {
+				w.HostEnv = append(w.HostEnv, k)
+			}
+		}
+
 		// merge the env vars from the import file into the parent file
 		// in case of conflicts, env vars in the parent file will shadow env vars in the import file
+		if w.Env == nil {
+			w.Env = map[string]string{}
+		}
 		for k, v := range wx.Env {
 			if _, ok := w.Env[k]; !ok {
 				w.Env[k] = v
@@ -265,57 +561,146 @@ func (w *Workflow) expandImports(file string) error {
 			log.Debugf("env var %s in workflow file %s is shadowed by env var %[1]s in parent workflow file %[3]s", k, path, file)
 		}
 
-		// import all tasks from the import file into the parent file, removing task name prefix
-		re := regexp.MustCompile(`^task\-\d{2}\-?`)
+		// import tasks from the import file into the parent file, removing task name prefix;
+		// if tasks to import are selected, only tasks with the given names are imported
+		selected := map[string]bool{}
+		for _, n := range t.ImportTasks {
+			selected[n] = false
+		}
 		for _, tx := range wx.Tasks {
-			tx.Name = re.ReplaceAllString(tx.Name, "")
-			w.Tasks = append(w.Tasks, tx)
+			tx.Name = taskPrefix.ReplaceAllString(tx.Name, "")
+			if len(selected) > 0 {
+				if _, ok := selected[tx.Name]; !ok {
+					continue
+				}
+				selected[tx.Name] = true
+			}
+			if tx.isCleanup() {
+				cleanup = append(cleanup, tx)
+				continue
+			}
+			expanded = append(expanded, tx)
+		}
+		for _, n := range t.ImportTasks {
+			if !selected[n] {
+				return nil, nil, errors.Errorf("invalid workflow file %s: task #%d - task %q does not exist in workflow file %s", file, i+1, n, path)
+			}
 		}
 	}
 
+	return expanded, cleanup, nil
+}
+
+// ScaleTaskTimeouts multiplies the timeout of all the tasks, including the default timeouts, by the given multiplier,
+// e.g. for running workflows in slow environments without editing the workflow file
+func (w *Workflow) ScaleTaskTimeouts(multiplier float64) error {
+	if multiplier <= 0 {
+		return errors.Errorf("invalid task timeout multiplier %v, it must be greater than zero", multiplier)
+	}
+	for _, t := range w.Tasks {
+		t.Timeout.Duration = time.Duration(float64(t.Timeout.Duration) * multiplier)
+	}
 	return nil
 }
 
-// Run executes a workflow
-func (w *Workflow) Run(out io.Writer, dryRun, verbose, exitOnError bool, artifacts string) (err error) {
+// Run executes a workflow; if a matrix is defined, the workflow is executed once for each combination
+func (w *Workflow) Run(out io.Writer, dryRun, verbose, exitOnError bool, artifacts string, options ...RunOption) (err error) {
+	o := runOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+	if o.timeout > 0 {
+		o.deadline = time.Now().Add(o.timeout)
+	}
+	if !dryRun {
+		o.interruption = newInterruption()
+		defer o.interruption.watch()()
+	}
+
+	if o.resumeFrom != "" && artifacts == "" && w.Env["ARTIFACTS"] == "" && os.Getenv("ARTIFACTS") == "" && !dryRun {
+		return errors.New("resuming a workflow run requires the artifacts folder of the run to resume")
+	}
 
-	// get a new taskCmdBuilder, responsible for creating taskCmd commands
-	taskCmdBuilder, err := newTaskCmdBuilder(w)
-	if err != nil {
-		return err
+	if len(w.Matrix) == 0 {
+		return w.run(out, dryRun, verbose, exitOnError, artifacts, nil, o)
 	}
 
-	// if artifact folder is not provided as input argument check
-	// 1. ARTIFACTS env var from the workflow file
-	// 2. ARTIFACTS OS env var
-	// Otherwise generate an artifact folder (or dummy placeholder in case of dry running)
+	// if the artifact folder is not provided in any way, generates one shared by all the combinations
+	if artifacts == "" && w.Env["ARTIFACTS"] == "" && os.Getenv("ARTIFACTS") == "" && !dryRun {
+		dir, err := os.Getwd()
+		if err != nil {
+			return errors.Wrapf(err, "error getting current directory")
+		}
 
-	if artifacts == "" {
-		artifacts = taskCmdBuilder.env["ARTIFACTS"]
+		artifacts, err = os.MkdirTemp(dir, "kinder-test-workflow")
+		if err != nil {
+			return errors.Wrapf(err, "error creating artifact folder")
+		}
 	}
 
-	if artifacts == "" {
-		artifacts = os.Getenv("ARTIFACTS")
+	var failed []string
+	for _, combination := range combinations(w.Matrix) {
+		name := combinationName(combination)
+		fmt.Fprintf(out, "## matrix %s\n\n", name)
+
+		if err := w.run(out, dryRun, verbose, exitOnError, artifacts, combination, o); err != nil {
+			if exitOnError || errors.Is(err, errStepAborted) || errors.Is(err, errCanceled) {
+				return errors.Wrapf(err, "matrix %s", name)
+			}
+			failed = append(failed, name)
+		}
 	}
 
-	if artifacts == "" {
+	if len(failed) > 0 {
+		return errors.Errorf("failed executing the workflow for matrix %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// run executes a workflow, eventually with the vars for a combination of values of the matrix dimensions
+func (w *Workflow) run(out io.Writer, dryRun, verbose, exitOnError bool, artifacts string, combination map[string]string, o runOptions) (err error) {
+	// in quiet mode, only failed tasks are printed, by the taskCmdRunner
+	console := out
+	if o.quiet {
+		out = io.Discard
+	}
+
+	taskCmdBuilder, artifacts, err := w.prepare(dryRun, artifacts, combination)
+	if err != nil {
+		return err
+	}
+	name := ""
+	if combination != nil {
+		name = combinationName(combination)
+	}
+
+	// when resuming, tasks before the task to resume from are skipped, and vars recorded by the workflow run
+	// to resume are used, e.g. for using the same versions resolved from labels
+	resumed := 0
+	var state *runState
+	if o.resumeFrom != "" {
+		if resumed, err = resumeIndex(w.Tasks, o.resumeFrom); err != nil {
+			return err
+		}
 		if !dryRun {
-			dir, err := os.Getwd()
-			if err != nil {
-				return errors.Wrapf(err, "error getting current directory")
+			if state, err = loadRunState(artifacts); err != nil {
+				return err
 			}
-
-			artifacts, err = os.MkdirTemp(dir, "kinder-test-workflow")
-			if err != nil {
-				return errors.Wrapf(err, "error creating artifact folder")
+			if taskCmdBuilder, err = newTaskCmdBuilder(w, state.Vars); err != nil {
+				return err
+			}
+			for _, t := range w.Tasks[:resumed] {
+				// tasks generated by loops are recorded with the name of each item
+				if len(t.ForEach) > 0 {
+					continue
+				}
+				if r := state.result(t.Name); r != taskPassed && r != taskFlaky {
+					log.Warnf("resuming from task %s, but task %s did not pass in the workflow run to resume", w.Tasks[resumed].Name, t.Name)
+				}
 			}
-		} else {
-			artifacts = "<tmp-folder>"
 		}
 	}
 
-	//TODO: ensure artifact folder exist and can be written
-
 	// adds a new env variable indicating where test artifacts should be stored
 	// to make this value available for cmd and args expansion
 	taskCmdBuilder.env["ARTIFACTS"] = artifacts
@@ -323,59 +708,203 @@ func (w *Workflow) Run(out io.Writer, dryRun, verbose, exitOnError bool, artifac
 	// Gets a taskCmdRunner, responsible for executing taskCmd,
 	// handling failure, cancellation, timeouts and for generating or collecting
 	// all the workflow artifacts (junit_runner.xml, task logs, etc)
-	taskCmdRunner := newTaskCmdRunner()
+	taskCmdRunner := newTaskCmdRunner(name, taskCmdBuilder.vars)
+	taskCmdRunner.noColor = o.noColor
+	taskCmdRunner.deadline = o.deadline
+	taskCmdRunner.masker = taskCmdBuilder.masker
+	taskCmdRunner.interruption = o.interruption
+	if o.quiet {
+		taskCmdRunner.failures = console
+	}
+	if !dryRun {
+		taskCmdRunner.state = &runState{Vars: taskCmdBuilder.vars}
+		taskCmdRunner.stateDir = artifacts
+		taskCmdRunner.resumed = state
+		taskCmdRunner.webhook = newWebhook(o.webhook, workflowNames([]*Workflow{w})[0], name)
+	}
 
 	// Process all tasks, exploding golang templates for cmd and args
 	// and create the corresponding taskCmd
 	// Nb. we are splitting this step from actual execution of task for ensuring
 	// that all the formal error are detected before starting any real activity
-	var tcmds []*taskCmd
-	for _, t := range w.Tasks {
+	tcmds, err := taskCmdBuilder.buildAll(w.Tasks, verbose, resumed)
+	if err != nil {
+		return err
+	}
 
-		tcmd, err := taskCmdBuilder.build(t, verbose)
+	// reports the start and the completion of the workflow run to the webhook, if any
+	taskCmdRunner.webhook.notify(webhookEvent{Event: webhookWorkflowStarted})
+	defer func() {
+		event := webhookEvent{Event: webhookWorkflowFinished, Result: taskPassed, Duration: time.Since(taskCmdRunner.start).Seconds()}
 		if err != nil {
+			event.Event, event.Result, event.Message = webhookWorkflowFailed, taskFailed, taskCmdRunner.masker.mask(err.Error())
+		}
+		taskCmdRunner.webhook.notify(event)
+	}()
+
+	// fails fast if commands, clusters or images used by the workflow are not available, instead of
+	// failing in the middle of the workflow run
+	if !dryRun && !o.skipPreflight {
+		if err := newPreflight().check(tcmds, artifacts); err != nil {
 			return err
 		}
+	}
 
-		tcmds = append(tcmds, tcmd)
+	// when executing step by step, the user is asked what to do before each task
+	var stepper *stepper
+	if o.step && !dryRun {
+		stepper = newStepper(os.Stdin, console)
 	}
 
-	foundError := false
-	// Executes taskCmds
-	for _, tcmd := range tcmds {
-		fmt.Fprintf(out, "# %s\n", tcmd.Name)
-		fmt.Fprintf(out, "%s\n\n", tcmd.CmdText)
+	// when exiting on error, the summary and the junit file are written before returning the error
+	foundError, aborted := false, false
+	var exitErr error
+	if hasDependencyGraph(tcmds) && !dryRun && stepper == nil {
+		// Executes taskCmds as soon as their dependencies are completed
+		foundError, exitErr = runGraph(out, tcmds, taskCmdRunner, artifacts, verbose, exitOnError)
+	} else {
+		// Executes taskCmds; when executing step by step, tasks are always executed one at time
+		for _, tcmd := range tcmds {
+			if exitErr != nil && !tcmd.isCleanup() {
+				continue
+			}
+			if aborted {
+				taskCmdRunner.skipInactive(tcmd, stepAbortedReason)
+				continue
+			}
 
-		if !dryRun {
-			err := taskCmdRunner.Run(tcmd, artifacts, verbose)
-			if err != nil {
-				foundError = true
-				fmt.Fprintf(out, " %v\n\n", err)
+			fmt.Fprintf(out, "# %s\n", tcmd.Name)
+			fmt.Fprintf(out, "%s\n\n", tcmd.CmdText)
 
-				if exitOnError {
-					return err
+			if reason := taskCmdRunner.inactiveReason(tcmd); reason != "" {
+				fmt.Fprintf(out, " %s\n\n", reason)
+				if !dryRun {
+					taskCmdRunner.skipInactive(tcmd, reason)
 				}
-
 				continue
 			}
 
-			fmt.Fprintf(out, " completed!\n\n")
+			if stepper != nil && taskCmdRunner.skipReason(tcmd) == "" {
+				switch stepper.ask(tcmd) {
+				case stepSkip:
+					fmt.Fprintf(out, " %s\n\n", stepSkippedReason)
+					taskCmdRunner.skipInactive(tcmd, stepSkippedReason)
+					continue
+				case stepAbort:
+					fmt.Fprintf(out, " %s\n\n", stepAbortedReason)
+					taskCmdRunner.skipInactive(tcmd, stepAbortedReason)
+					aborted = true
+					continue
+				}
+			}
+
+			if !dryRun {
+				err := taskCmdRunner.Run(tcmd, artifacts, verbose)
+				if err != nil {
+					foundError = true
+					fmt.Fprintf(out, " %v\n\n", err)
+
+					// when exiting on error, only cleanup tasks are executed before exiting
+					if exitOnError && exitErr == nil {
+						exitErr = err
+					}
+
+					continue
+				}
+
+				fmt.Fprintf(out, " completed!\n\n")
+			}
 		}
 	}
 
 	// If not dry running, prints task summary and dumps the junit_runner.xml file
 	if !dryRun {
-		taskCmdRunner.ReportSummary()
+		taskCmdRunner.ReportSummary(console)
 
 		if err := taskCmdRunner.DumpJUnitRunner(artifacts); err != nil {
 			fmt.Fprintf(out, "%v\n", err)
 			return err
 		}
-		fmt.Fprintf(out, "see junit-runner.xml and task logs files for more details\n\n")
+		if err := taskCmdRunner.DumpJSONSummary(artifacts); err != nil {
+			fmt.Fprintf(out, "%v\n", err)
+			return err
+		}
+		fmt.Fprintf(out, "see junit-runner.xml, %s and task logs files for more details\n\n", runSummaryFile)
+
+		// failing to record the workflow run into the history does not change the result of the workflow
+		if o.history != "" {
+			id, err := recordHistory(o.history, workflowNames([]*Workflow{w})[0], artifacts, taskCmdRunner.summary(artifacts))
+			if err != nil {
+				log.Warnf("%v", err)
+			} else {
+				fmt.Fprintf(out, "workflow run recorded as %s, see kinder test show %[1]s\n\n", id)
+			}
+		}
 	}
 
+	if exitErr != nil {
+		return exitErr
+	}
+	if aborted {
+		return errStepAborted
+	}
+	if taskCmdRunner.interruption.isCanceled() {
+		return errCanceled
+	}
 	if foundError {
 		return errors.New("failed executing the workflow")
 	}
 	return nil
 }
+
+// prepare returns the taskCmdBuilder and the artifact folder for a workflow run, eventually for
+// a combination of values of the matrix dimensions
+func (w *Workflow) prepare(dryRun bool, artifacts string, combination map[string]string) (*taskCmdBuilder, string, error) {
+	// get a new taskCmdBuilder, responsible for creating taskCmd commands
+	taskCmdBuilder, err := newTaskCmdBuilder(w, combination)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// if artifact folder is not provided as input argument check
+	// 1. ARTIFACTS env var from the workflow file
+	// 2. ARTIFACTS OS env var
+	// Otherwise generate an artifact folder (or dummy placeholder in case of dry running)
+
+	if artifacts == "" {
+		artifacts = taskCmdBuilder.env["ARTIFACTS"]
+	}
+
+	if artifacts == "" {
+		artifacts = os.Getenv("ARTIFACTS")
+	}
+
+	if artifacts == "" {
+		if !dryRun {
+			dir, err := os.Getwd()
+			if err != nil {
+				return nil, "", errors.Wrapf(err, "error getting current directory")
+			}
+
+			artifacts, err = os.MkdirTemp(dir, "kinder-test-workflow")
+			if err != nil {
+				return nil, "", errors.Wrapf(err, "error creating artifact folder")
+			}
+		} else {
+			artifacts = "<tmp-folder>"
+		}
+	}
+
+	// each combination of the matrix dimensions gets its own artifact folder
+	if combination != nil {
+		name := combinationName(combination)
+		artifacts = filepath.Join(artifacts, name)
+		if !dryRun {
+			if err := os.MkdirAll(artifacts, 0755); err != nil {
+				return nil, "", errors.Wrapf(err, "error creating artifact folder for matrix %s", name)
+			}
+		}
+	}
+
+	return taskCmdBuilder, artifacts, nil
+}
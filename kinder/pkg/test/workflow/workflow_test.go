@@ -19,6 +19,9 @@ package workflow
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -102,3 +105,120 @@ func TestDurationJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestImportTasks(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"shared.yaml": `version: 1
+tasks:
+- name: setup
+  cmd: "true"
+- name: get-logs
+  cmd: "true"
+- name: delete
+  cmd: "true"
+`,
+		"teardown.yaml": `version: 1
+tasks:
+- cmd: "true"
+- import: shared.yaml
+  importTasks: [get-logs, delete]
+`,
+		"missing.yaml": `version: 1
+tasks:
+- import: shared.yaml
+  importTasks: [reset]
+`,
+		"circular.yaml": `version: 1
+tasks:
+- import: circular-2.yaml
+`,
+		"circular-2.yaml": `version: 1
+tasks:
+- import: circular.yaml
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		file          string
+		expectedTasks []string
+		expectedError bool
+	}{
+		{file: "shared.yaml", expectedTasks: []string{"task-00-setup", "task-01-get-logs", "task-02-delete"}},
+		{file: "teardown.yaml", expectedTasks: []string{"task-00", "task-01-get-logs", "task-02-delete"}},
+		{file: "missing.yaml", expectedError: true},
+		{file: "circular.yaml", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.file, func(t *testing.T) {
+			w, err := NewWorkflow(filepath.Join(dir, tc.file))
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, got %v, error: %v", tc.expectedError, err != nil, err)
+			}
+			if err != nil {
+				return
+			}
+			var names []string
+			for _, task := range w.Tasks {
+				names = append(names, task.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expectedTasks) {
+				t.Errorf("expected tasks %v, got %v", tc.expectedTasks, names)
+			}
+		})
+	}
+}
+
+func TestCleanupTasks(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"setup.yaml": `version: 1
+tasks:
+- name: create
+  cmd: "true"
+finally:
+- name: delete
+  cmd: "true"
+`,
+		"workflow.yaml": `version: 1
+tasks:
+- import: setup.yaml
+- name: test
+  cmd: "true"
+onFailure:
+- name: debug
+  cmd: "true"
+finally:
+- name: get-logs
+  cmd: "true"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, err := NewWorkflow(filepath.Join(dir, "workflow.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// cleanup tasks are executed at the end, with the ones from imported workflows last
+	var names []string
+	for _, task := range w.Tasks {
+		names = append(names, task.Name)
+		if task.isCleanup() != task.Force {
+			t.Errorf("expected task %s to be forced %v, got %v", task.Name, task.isCleanup(), task.Force)
+		}
+	}
+	expected := []string{"task-00-create", "task-01-test", "task-02-debug", "task-03-get-logs", "task-04-delete"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected tasks %v, got %v", expected, names)
+	}
+}